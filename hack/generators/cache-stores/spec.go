@@ -23,6 +23,10 @@ var supportedTypes = []cacheStoreSupportedType{
 		Type:    "Secret",
 		Package: "corev1",
 	},
+	{
+		Type:    "ConfigMap",
+		Package: "corev1",
+	},
 	{
 		Type:    "EndpointSlice",
 		Package: "discoveryv1",
@@ -56,6 +60,14 @@ var supportedTypes = []cacheStoreSupportedType{
 		Type:    "Gateway",
 		Package: "gatewayapi",
 	},
+	{
+		Type:    "BackendTLSPolicy",
+		Package: "gatewayapi",
+	},
+	{
+		Type:    "BackendLBPolicy",
+		Package: "gatewayapi",
+	},
 	// Kong types
 	{
 		Type:       "KongPlugin",
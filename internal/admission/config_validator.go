@@ -0,0 +1,294 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	credsvalidation "github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	managerscheme "github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// ConfigValidator validates objects in the context of the whole configuration built from the cluster state.
+type ConfigValidator interface {
+	ValidateWithConfig(ctx context.Context, request admissionv1.AdmissionRequest) (bool, string, error)
+}
+
+// ShadowGatewayLimits limits the resources used by ShadowGatewayConfigValidator. Whole configuration validation is
+// skipped, allowing the object, when a limit is reached, as objects were already validated on their own.
+type ShadowGatewayLimits struct {
+	// Timeout is the time limit of validating a single object, including waiting for other validations to finish.
+	Timeout time.Duration
+	// MaxConcurrentValidations is the maximum number of objects validated at the same time.
+	MaxConcurrentValidations int
+	// MaxCachedObjects is the maximum number of objects in the cache for which the whole configuration is validated.
+	MaxCachedObjects int
+}
+
+// DefaultShadowGatewayLimits returns limits leaving enough time for the admission webhook to respond within
+// the default webhook timeout of 10s.
+func DefaultShadowGatewayLimits() ShadowGatewayLimits {
+	return ShadowGatewayLimits{
+		Timeout:                  5 * time.Second,
+		MaxConcurrentValidations: 2,
+		MaxCachedObjects:         10000,
+	}
+}
+
+// ShadowGatewayConfigValidator implements ConfigValidator. It translates a snapshot of the cache with the validated
+// object applied and sends the resulting configuration to a DB-less Kong Gateway that serves no traffic (a shadow
+// gateway). Errors reported by the shadow gateway are returned as the validation message.
+//
+// This allows catching conflicts that only appear once an object is combined with the rest of the configuration
+// (e.g. duplicate routes or SNIs) and can't be detected by validating the object alone.
+type ShadowGatewayConfigValidator struct {
+	logger             logr.Logger
+	cache              store.CacheStores
+	ingressClassName   string
+	translatorFeatures translator.FeatureFlags
+	credentialTypes    *credsvalidation.Registry
+	shadowClient       *adminapi.Client
+	decoder            runtime.Decoder
+	limits             ShadowGatewayLimits
+	// validationSlots limits the number of concurrent validations to limits.MaxConcurrentValidations.
+	validationSlots chan struct{}
+}
+
+// NewShadowGatewayConfigValidator creates a ShadowGatewayConfigValidator. The shadowClient has to point to a DB-less
+// Kong Gateway dedicated to validation as its configuration is replaced on every validation. Credentials of types
+// not registered in credentialTypes are not translated, when it's nil the types supported out of the box are used.
+func NewShadowGatewayConfigValidator(
+	logger logr.Logger,
+	cache store.CacheStores,
+	ingressClassName string,
+	translatorFeatures translator.FeatureFlags,
+	credentialTypes *credsvalidation.Registry,
+	shadowClient *adminapi.Client,
+	limits ShadowGatewayLimits,
+) (*ShadowGatewayConfigValidator, error) {
+	if limits.Timeout <= 0 || limits.MaxConcurrentValidations < 1 || limits.MaxCachedObjects < 1 {
+		return nil, fmt.Errorf("invalid shadow gateway limits: %+v", limits)
+	}
+	s, err := managerscheme.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheme: %w", err)
+	}
+
+	// Translation results of the validated configuration are never reused.
+	translatorFeatures.IngressIncrementalTranslation = false
+	translatorFeatures.ReportConfiguredKubernetesObjects = false
+
+	return &ShadowGatewayConfigValidator{
+		logger:             logger,
+		cache:              cache,
+		ingressClassName:   ingressClassName,
+		translatorFeatures: translatorFeatures,
+		credentialTypes:    credentialTypes,
+		shadowClient:       shadowClient,
+		decoder:            serializer.NewCodecFactory(s).UniversalDeserializer(),
+		limits:             limits,
+		validationSlots:    make(chan struct{}, limits.MaxConcurrentValidations),
+	}, nil
+}
+
+// ValidateWithConfig validates the object from the admission request in the context of the whole configuration.
+// Objects that are not stored in the cache (e.g. Gateway API objects in versions other than the one the controller
+// uses) are not validated, neither are objects validated when one of the limits is reached.
+func (v *ShadowGatewayConfigValidator) ValidateWithConfig(
+	ctx context.Context, request admissionv1.AdmissionRequest,
+) (bool, string, error) {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return true, "", nil
+	}
+
+	decoded, _, err := v.decoder.Decode(request.Object.Raw, nil, nil)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to decode %s: %w", request.Kind.Kind, err)
+	}
+	obj, ok := decoded.(client.Object)
+	if !ok {
+		return true, "", nil
+	}
+	logger := v.logger.WithValues("kind", request.Kind.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+	if _, _, err := v.cache.Get(obj); err != nil {
+		logger.V(util.DebugLevel).Info("Skipping whole configuration validation of an object not stored in the cache")
+		return true, "", nil
+	}
+	if cachedObjects := countCachedObjects(v.cache); cachedObjects > v.limits.MaxCachedObjects {
+		logger.Info("Skipping whole configuration validation, too many objects in the cache",
+			"objects", cachedObjects, "limit", v.limits.MaxCachedObjects)
+		return true, "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.limits.Timeout)
+	defer cancel()
+	select {
+	case v.validationSlots <- struct{}{}:
+		defer func() { <-v.validationSlots }()
+	case <-ctx.Done():
+		logger.Info("Skipping whole configuration validation, timed out waiting for other validations to finish")
+		return true, "", nil
+	}
+
+	// A single snapshot is used for validating the configuration both with and without the change.
+	snapshot, err := v.cache.TakeSnapshot()
+	if err != nil {
+		return false, "", fmt.Errorf("failed to take cache snapshot: %w", err)
+	}
+	// Keep the version of the object from before the change to restore it if needed.
+	previous, existed, err := snapshot.Get(obj)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get object from cache snapshot: %w", err)
+	}
+	if err := snapshot.Add(obj); err != nil {
+		return false, "", fmt.Errorf("failed to add object to cache snapshot: %w", err)
+	}
+
+	resourceFailures, err := v.validateCache(ctx, snapshot)
+	if err != nil {
+		return v.handleValidationError(ctx, logger, err)
+	}
+	if len(resourceFailures) == 0 {
+		return true, "", nil
+	}
+
+	// Failures not caused by the validated object may have existed before the change. In that case, they're not
+	// the reason to reject the object, as that would block all changes until the broken objects are fixed.
+	if !lo.ContainsBy(resourceFailures, failureCausedBy(request.Kind.Kind, obj)) {
+		if existed {
+			err = snapshot.Add(previous.(runtime.Object))
+		} else {
+			err = snapshot.Delete(obj)
+		}
+		if err != nil {
+			return false, "", fmt.Errorf("failed to restore object in cache snapshot: %w", err)
+		}
+		currentFailures, err := v.validateCache(ctx, snapshot)
+		if err != nil {
+			return v.handleValidationError(ctx, logger, err)
+		}
+		if len(currentFailures) > 0 {
+			logger.V(util.DebugLevel).Info("Ignoring whole configuration validation failures existing before the change")
+			return true, "", nil
+		}
+	}
+
+	return false, fmt.Sprintf(ErrTextConfigurationRejected, formatResourceFailures(resourceFailures)), nil
+}
+
+// handleValidationError allows the object when validation didn't finish in time. Other errors are returned.
+func (v *ShadowGatewayConfigValidator) handleValidationError(
+	ctx context.Context, logger logr.Logger, err error,
+) (bool, string, error) {
+	if ctx.Err() != nil {
+		logger.Info("Skipping whole configuration validation, timed out", "timeout", v.limits.Timeout, "error", err.Error())
+		return true, "", nil
+	}
+	return false, "", err
+}
+
+// countCachedObjects returns the number of objects stored in the cache without copying them.
+func countCachedObjects(cache store.CacheStores) int {
+	var count int
+	for _, s := range cache.ListAllStores() {
+		count += len(s.ListKeys())
+	}
+	return count
+}
+
+// validateCache translates the cache and sends the resulting configuration to the shadow gateway. It returns resource
+// failures reported by the shadow gateway.
+func (v *ShadowGatewayConfigValidator) validateCache(ctx context.Context, cache store.CacheStores) ([]failures.ResourceFailure, error) {
+	t, err := translator.NewTranslator(
+		v.logger,
+		store.New(cache, v.ingressClassName, v.logger),
+		"",
+		v.translatorFeatures,
+		shadowSchemaServiceProvider{v.shadowClient},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translator: %w", err)
+	}
+	if v.credentialTypes != nil {
+		t.InjectCredentialTypes(v.credentialTypes)
+	}
+	kongState := t.BuildKongConfig().KongState
+
+	content := deckgen.ToDeckContent(ctx, v.logger, kongState, deckgen.GenerateDeckContentParams{
+		ExpressionRoutes:                v.translatorFeatures.ExpressionRoutes,
+		PluginSchemas:                   v.shadowClient.PluginSchemaStore(),
+		AppendStubEntityWhenConfigEmpty: true,
+	})
+	customEntities := sendconfig.CustomEntitiesByType(kongState.CustomEntityObjectsByType())
+
+	strategy := sendconfig.NewUpdateStrategyInMemory(
+		v.shadowClient.AdminAPIClient(),
+		sendconfig.DefaultContentToDBLessConfigConverter{},
+		v.logger,
+	)
+	err = strategy.Update(ctx, sendconfig.ContentWithHash{Content: content, CustomEntities: customEntities})
+	if err == nil {
+		return nil, nil
+	}
+	updateErr := sendconfig.UpdateError{}
+	if errors.As(err, &updateErr) && len(updateErr.ResourceFailures()) > 0 {
+		return updateErr.ResourceFailures(), nil
+	}
+	return nil, fmt.Errorf("failed to validate configuration on shadow gateway %s: %w", v.shadowClient.BaseRootURL(), err)
+}
+
+// failureCausedBy returns a predicate telling whether a resource failure was caused by the object of the given kind.
+func failureCausedBy(kind string, obj client.Object) func(failures.ResourceFailure) bool {
+	return func(f failures.ResourceFailure) bool {
+		return lo.ContainsBy(f.CausingObjects(), func(o client.Object) bool {
+			return o.GetObjectKind().GroupVersionKind().Kind == kind &&
+				o.GetNamespace() == obj.GetNamespace() &&
+				o.GetName() == obj.GetName()
+		})
+	}
+}
+
+func formatResourceFailures(resourceFailures []failures.ResourceFailure) string {
+	messages := make([]string, 0, len(resourceFailures))
+	for _, f := range resourceFailures {
+		objects := lo.Map(f.CausingObjects(), func(o client.Object, _ int) string {
+			kind := o.GetObjectKind().GroupVersionKind().Kind
+			if o.GetNamespace() == "" {
+				return fmt.Sprintf("%s %s", kind, o.GetName())
+			}
+			return fmt.Sprintf("%s %s/%s", kind, o.GetNamespace(), o.GetName())
+		})
+		messages = append(messages, fmt.Sprintf("%s: %s", strings.Join(objects, ", "), f.Message()))
+	}
+	// Flattened errors come from a map, sort them to get a stable message.
+	slices.Sort(messages)
+	return strings.Join(slices.Compact(messages), "; ")
+}
+
+// shadowSchemaServiceProvider provides the schema service of the shadow gateway to the translator.
+type shadowSchemaServiceProvider struct {
+	client *adminapi.Client
+}
+
+func (p shadowSchemaServiceProvider) GetSchemaService() kong.AbstractSchemaService {
+	return p.client.AdminAPIClient().Schemas
+}
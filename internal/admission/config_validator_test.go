@@ -0,0 +1,287 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+)
+
+// shadowGateway mocks the `POST /config` endpoint of a DB-less Kong Gateway. It responds with the given responses
+// in order, repeating the last one.
+type shadowGateway struct {
+	responses []shadowGatewayResponse
+	requests  int
+}
+
+type shadowGatewayResponse struct {
+	status int
+	body   string
+	delay  time.Duration
+}
+
+func (s *shadowGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/config" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	response := s.responses[min(s.requests, len(s.responses)-1)]
+	s.requests++
+	time.Sleep(response.delay)
+	w.WriteHeader(response.status)
+	_, _ = w.Write([]byte(response.body))
+}
+
+func shadowGatewayAccepted() shadowGatewayResponse {
+	return shadowGatewayResponse{status: http.StatusCreated, body: `{}`}
+}
+
+func shadowGatewayRejected(kind, namespace, name, uid, field, message string) shadowGatewayResponse {
+	return shadowGatewayResponse{
+		status: http.StatusBadRequest,
+		body: fmt.Sprintf(`{
+  "code": 14,
+  "name": "invalid declarative configuration",
+  "message": "declarative config is invalid",
+  "flattened_errors": [
+    {
+      "entity_type": "route",
+      "entity_name": "route",
+      "entity_tags": ["k8s-name:%s", "k8s-namespace:%s", "k8s-kind:%s", "k8s-uid:%s", "k8s-version:v1"],
+      "errors": [{"type": "field", "field": %q, "message": %q}]
+    }
+  ]
+}`, name, namespace, kind, uid, field, message),
+	}
+}
+
+func TestShadowGatewayConfigValidator(t *testing.T) {
+	newIngress := func(name string) *netv1.Ingress {
+		return &netv1.Ingress{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: netv1.SchemeGroupVersion.String(),
+				Kind:       "Ingress",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      name,
+			},
+			Spec: netv1.IngressSpec{
+				IngressClassName: lo.ToPtr("kong"),
+				Rules: []netv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: netv1.IngressRuleValue{
+						HTTP: &netv1.HTTPIngressRuleValue{
+							Paths: []netv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: lo.ToPtr(netv1.PathTypePrefix),
+								Backend: netv1.IngressBackend{
+									Service: &netv1.IngressServiceBackend{
+										Name: "svc",
+										Port: netv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+	existingIngress := newIngress("existing")
+	validatedIngress := newIngress("validated")
+
+	testCases := []struct {
+		name          string
+		object        runtime.Object
+		operation     admissionv1.Operation
+		responses     []shadowGatewayResponse
+		wantOK        bool
+		wantMessage   string
+		wantErr       bool
+		wantRequests  int
+		cachedObjects []runtime.Object
+		limits        *ShadowGatewayLimits
+	}{
+		{
+			name:          "configuration accepted by shadow gateway",
+			object:        validatedIngress,
+			operation:     admissionv1.Create,
+			responses:     []shadowGatewayResponse{shadowGatewayAccepted()},
+			wantOK:        true,
+			wantRequests:  1,
+			cachedObjects: []runtime.Object{existingIngress},
+		},
+		{
+			name:      "configuration rejected because of the validated object",
+			object:    validatedIngress,
+			operation: admissionv1.Update,
+			responses: []shadowGatewayResponse{
+				shadowGatewayRejected("Ingress", "default", "validated", "b2a0ac4b-5b9c-4bd2-9a1b-7d1c4e0f6a51", "paths", "must be unique"),
+			},
+			wantOK:        false,
+			wantMessage:   "configuration with the object applied was rejected by Kong gateway: Ingress default/validated: invalid paths: must be unique",
+			wantRequests:  1,
+			cachedObjects: []runtime.Object{existingIngress},
+		},
+		{
+			name:      "configuration rejected because of another object after the change",
+			object:    validatedIngress,
+			operation: admissionv1.Create,
+			responses: []shadowGatewayResponse{
+				shadowGatewayRejected("Ingress", "default", "existing", "3f5e9c1a-8d2b-4e6f-a7c0-1b9d8e2f4a63", "snis", "must be unique"),
+				shadowGatewayAccepted(),
+			},
+			wantOK:        false,
+			wantMessage:   "configuration with the object applied was rejected by Kong gateway: Ingress default/existing: invalid snis: must be unique",
+			wantRequests:  2,
+			cachedObjects: []runtime.Object{existingIngress},
+		},
+		{
+			name:      "configuration rejected because of another object already before the change",
+			object:    validatedIngress,
+			operation: admissionv1.Create,
+			responses: []shadowGatewayResponse{
+				shadowGatewayRejected("Ingress", "default", "existing", "3f5e9c1a-8d2b-4e6f-a7c0-1b9d8e2f4a63", "snis", "must be unique"),
+			},
+			wantOK:        true,
+			wantRequests:  2,
+			cachedObjects: []runtime.Object{existingIngress},
+		},
+		{
+			name:      "configuration rejected because of another object with the object existing before the change",
+			object:    validatedIngress,
+			operation: admissionv1.Update,
+			responses: []shadowGatewayResponse{
+				shadowGatewayRejected("Ingress", "default", "existing", "3f5e9c1a-8d2b-4e6f-a7c0-1b9d8e2f4a63", "snis", "must be unique"),
+				shadowGatewayAccepted(),
+			},
+			wantOK:        false,
+			wantMessage:   "configuration with the object applied was rejected by Kong gateway: Ingress default/existing: invalid snis: must be unique",
+			wantRequests:  2,
+			cachedObjects: []runtime.Object{existingIngress, newIngress("validated")},
+		},
+		{
+			name:          "validation timing out is skipped",
+			object:        validatedIngress,
+			operation:     admissionv1.Create,
+			responses:     []shadowGatewayResponse{{status: http.StatusCreated, body: `{}`, delay: 200 * time.Millisecond}},
+			wantOK:        true,
+			wantRequests:  1,
+			cachedObjects: []runtime.Object{existingIngress},
+			limits: &ShadowGatewayLimits{
+				Timeout:                  50 * time.Millisecond,
+				MaxConcurrentValidations: 1,
+				MaxCachedObjects:         10,
+			},
+		},
+		{
+			name:          "validation with too many objects in the cache is skipped",
+			object:        validatedIngress,
+			operation:     admissionv1.Create,
+			responses:     []shadowGatewayResponse{shadowGatewayAccepted()},
+			wantOK:        true,
+			wantRequests:  0,
+			cachedObjects: []runtime.Object{existingIngress, newIngress("another")},
+			limits: &ShadowGatewayLimits{
+				Timeout:                  time.Second,
+				MaxConcurrentValidations: 1,
+				MaxCachedObjects:         1,
+			},
+		},
+		{
+			name:         "shadow gateway failing without flattened errors",
+			object:       validatedIngress,
+			operation:    admissionv1.Create,
+			responses:    []shadowGatewayResponse{{status: http.StatusInternalServerError, body: `{"message": "An unexpected error occurred"}`}},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name: "object not stored in the cache is not validated",
+			object: &gatewayapi.GatewayClass{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "gateway.networking.k8s.io/v1beta1",
+					Kind:       "GatewayClass",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "kong",
+				},
+			},
+			operation:    admissionv1.Create,
+			responses:    []shadowGatewayResponse{shadowGatewayAccepted()},
+			wantOK:       true,
+			wantRequests: 0,
+		},
+		{
+			name:         "delete is not validated",
+			object:       validatedIngress,
+			operation:    admissionv1.Delete,
+			responses:    []shadowGatewayResponse{shadowGatewayAccepted()},
+			wantOK:       true,
+			wantRequests: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gw := &shadowGateway{responses: tc.responses}
+			server := httptest.NewServer(gw)
+			t.Cleanup(server.Close)
+			shadowClient, err := adminapi.NewTestClient(server.URL)
+			require.NoError(t, err)
+
+			cache, err := store.NewCacheStoresFromObjs(tc.cachedObjects...)
+			require.NoError(t, err)
+			limits := DefaultShadowGatewayLimits()
+			if tc.limits != nil {
+				limits = *tc.limits
+			}
+			validator, err := NewShadowGatewayConfigValidator(logr.Discard(), cache, "kong", translator.FeatureFlags{}, nil, shadowClient, limits)
+			require.NoError(t, err)
+
+			raw, err := json.Marshal(tc.object)
+			require.NoError(t, err)
+			gvk := tc.object.GetObjectKind().GroupVersionKind()
+			ok, msg, err := validator.ValidateWithConfig(context.Background(), admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+				Operation: tc.operation,
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantOK, ok)
+				assert.Equal(t, tc.wantMessage, msg)
+			}
+			assert.Equal(t, tc.wantRequests, gw.requests)
+
+			// The validated object must never end up in the cache the controller translates.
+			_, exists, err := cache.Get(validatedIngress)
+			require.NoError(t, err)
+			assert.Equal(t, lo.ContainsBy(tc.cachedObjects, func(o runtime.Object) bool {
+				ing, ok := o.(*netv1.Ingress)
+				return ok && ing.Name == validatedIngress.Name
+			}), exists)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package admission
 
 const (
 	ErrTextAdminAPIUnavailable                = "could not talk to Kong admin API"
+	ErrTextConfigurationRejected              = "configuration with the object applied was rejected by Kong gateway: %s"
 	ErrTextConsumerCredentialSecretNotFound   = "consumer referenced non-existent credentials secret"
 	ErrTextConsumerCredentialValidationFailed = "consumer credential failed validation"
 	ErrTextConsumerExists                     = "consumer already exists"
@@ -16,6 +17,10 @@ const (
 	ErrTextPluginConfigValidationFailed       = "unable to validate plugin schema"
 	ErrTextPluginConfigViolatesSchema         = "plugin failed schema validation: %s"
 	ErrTextPluginSecretConfigUnretrievable    = "could not load secret plugin configuration"
+	ErrTextServiceFacadeUnableToValidate      = "unable to validate KongServiceFacade on Kong gateway"
+	ErrTextServiceFacadeViolatesSchema        = "KongServiceFacade failed schema validation: %s"
+	ErrTextUpstreamPolicyUnableToValidate     = "unable to validate KongUpstreamPolicy on Kong gateway"
+	ErrTextUpstreamPolicyViolatesSchema       = "KongUpstreamPolicy failed schema validation: %s"
 	ErrTextVaultConfigUnmarshalFailed         = "failed to unmarshal vault configuration: %v"
 	ErrTextVaultUnableToValidate              = "unable to validate vault on Kong gateway"
 	ErrTextVaultConfigValidationResultInvalid = "vault configuration in invalid: %s"
@@ -20,6 +20,7 @@ import (
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
 )
 
 const (
@@ -37,6 +38,12 @@ type RequestHandler struct {
 	// referring the validated resource (Secret) to check the changes on
 	// referred Secret will produce invalid configuration of the plugins.
 	ReferenceIndexers ctrlref.CacheIndexers
+	// ConfigValidator (optional) validates objects allowed by Validator in the
+	// context of the whole configuration built from the cluster state.
+	ConfigValidator ConfigValidator
+	// WarningsProvider (optional) provides warnings returned along with
+	// the validation result, e.g. about deprecated or ignored configuration.
+	WarningsProvider WarningsProvider
 
 	Logger logr.Logger
 }
@@ -123,10 +130,62 @@ var (
 		Version:  corev1.SchemeGroupVersion.Version,
 		Resource: "services",
 	}
+	tcpIngressGVResource = metav1.GroupVersionResource{
+		Group:    kongv1beta1.SchemeGroupVersion.Group,
+		Version:  kongv1beta1.SchemeGroupVersion.Version,
+		Resource: "tcpingresses",
+	}
+	udpIngressGVResource = metav1.GroupVersionResource{
+		Group:    kongv1beta1.SchemeGroupVersion.Group,
+		Version:  kongv1beta1.SchemeGroupVersion.Version,
+		Resource: "udpingresses",
+	}
+	kongUpstreamPolicyGVResource = metav1.GroupVersionResource{
+		Group:    kongv1beta1.SchemeGroupVersion.Group,
+		Version:  kongv1beta1.SchemeGroupVersion.Version,
+		Resource: "kongupstreampolicies",
+	}
+	kongServiceFacadeGVResource = metav1.GroupVersionResource{
+		Group:    incubatorv1alpha1.SchemeGroupVersion.Group,
+		Version:  incubatorv1alpha1.SchemeGroupVersion.Version,
+		Resource: "kongservicefacades",
+	}
 )
 
 func (h RequestHandler) handleValidation(ctx context.Context, request admissionv1.AdmissionRequest) (
 	*admissionv1.AdmissionResponse, error,
+) {
+	response, err := h.handleObjectValidation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Allowed && h.ConfigValidator != nil {
+		ok, message, err := h.ConfigValidator.ValidateWithConfig(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			denied := NewResponseBuilder(request.UID).Allowed(false).WithMessage(message).Build()
+			denied.Warnings = response.Warnings
+			response = denied
+		}
+	}
+
+	if h.WarningsProvider != nil {
+		warnings, err := h.WarningsProvider.Warnings(ctx, request)
+		if err != nil {
+			// Warnings are informational only, failing to determine some of them must not block the object.
+			h.Logger.Error(err, "Failed to determine admission warnings",
+				"kind", request.Kind.Kind, "namespace", request.Namespace, "name", request.Name)
+		}
+		response.Warnings = append(response.Warnings, warnings...)
+	}
+	return response, nil
+}
+
+func (h RequestHandler) handleObjectValidation(ctx context.Context, request admissionv1.AdmissionRequest) (
+	*admissionv1.AdmissionResponse, error,
 ) {
 	responseBuilder := NewResponseBuilder(request.UID)
 
@@ -145,6 +204,14 @@ func (h RequestHandler) handleValidation(ctx context.Context, request admissionv
 		return h.handleGateway(ctx, request, responseBuilder)
 	case gatewayapi.V1HTTPRouteGVResource, gatewayapi.V1beta1HTTPRouteGVResource:
 		return h.handleHTTPRoute(ctx, request, responseBuilder)
+	case gatewayapi.V1GRPCRouteGVResource:
+		return h.handleGRPCRoute(ctx, request, responseBuilder)
+	case gatewayapi.V1alpha2TCPRouteGVResource:
+		return h.handleTCPRoute(ctx, request, responseBuilder)
+	case gatewayapi.V1alpha2UDPRouteGVResource:
+		return h.handleUDPRoute(ctx, request, responseBuilder)
+	case gatewayapi.V1alpha2TLSRouteGVResource:
+		return h.handleTLSRoute(ctx, request, responseBuilder)
 	case kongIngressGVResource:
 		return h.handleKongIngress(ctx, request, responseBuilder)
 	case kongVaultGVResource:
@@ -155,6 +222,14 @@ func (h RequestHandler) handleValidation(ctx context.Context, request admissionv
 		return h.handleService(ctx, request, responseBuilder)
 	case ingressGVResource:
 		return h.handleIngress(ctx, request, responseBuilder)
+	case tcpIngressGVResource:
+		return h.handleTCPIngress(ctx, request, responseBuilder)
+	case udpIngressGVResource:
+		return h.handleUDPIngress(ctx, request, responseBuilder)
+	case kongUpstreamPolicyGVResource:
+		return h.handleKongUpstreamPolicy(ctx, request, responseBuilder)
+	case kongServiceFacadeGVResource:
+		return h.handleKongServiceFacade(ctx, request, responseBuilder)
 	default:
 		return nil, fmt.Errorf("unknown resource type to validate: %s/%s %s",
 			request.Resource.Group, request.Resource.Version,
@@ -405,6 +480,82 @@ func (h RequestHandler) handleHTTPRoute(
 	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
 }
 
+// +kubebuilder:webhook:verbs=create;update,groups=gateway.networking.k8s.io,resources=grpcroutes,versions=v1,name=grpcroutes.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleGRPCRoute(
+	ctx context.Context,
+	request admissionv1.AdmissionRequest,
+	responseBuilder *ResponseBuilder,
+) (*admissionv1.AdmissionResponse, error) {
+	grpcroute := gatewayapi.GRPCRoute{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &grpcroute)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateGRPCRoute(ctx, grpcroute)
+	if err != nil {
+		return nil, err
+	}
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,groups=gateway.networking.k8s.io,resources=tcproutes,versions=v1alpha2,name=tcproutes.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleTCPRoute(
+	ctx context.Context,
+	request admissionv1.AdmissionRequest,
+	responseBuilder *ResponseBuilder,
+) (*admissionv1.AdmissionResponse, error) {
+	tcproute := gatewayapi.TCPRoute{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &tcproute)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateTCPRoute(ctx, tcproute)
+	if err != nil {
+		return nil, err
+	}
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,groups=gateway.networking.k8s.io,resources=udproutes,versions=v1alpha2,name=udproutes.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleUDPRoute(
+	ctx context.Context,
+	request admissionv1.AdmissionRequest,
+	responseBuilder *ResponseBuilder,
+) (*admissionv1.AdmissionResponse, error) {
+	udproute := gatewayapi.UDPRoute{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &udproute)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateUDPRoute(ctx, udproute)
+	if err != nil {
+		return nil, err
+	}
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,groups=gateway.networking.k8s.io,resources=tlsroutes,versions=v1alpha2,name=tlsroutes.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleTLSRoute(
+	ctx context.Context,
+	request admissionv1.AdmissionRequest,
+	responseBuilder *ResponseBuilder,
+) (*admissionv1.AdmissionResponse, error) {
+	tlsroute := gatewayapi.TLSRoute{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &tlsroute)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateTLSRoute(ctx, tlsroute)
+	if err != nil {
+		return nil, err
+	}
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
 const (
 	proxyWarning    = "Support for 'proxy' was removed in 3.0. It will have no effect. Use Service's annotations instead."
 	routeWarning    = "Support for 'route' was removed in 3.0. It will have no effect. Use Ingress' annotations instead."
@@ -484,6 +635,70 @@ func (h RequestHandler) handleIngress(ctx context.Context, request admissionv1.A
 	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
 }
 
+// +kubebuilder:webhook:verbs=create;update,groups=configuration.konghq.com,resources=tcpingresses,versions=v1beta1,name=tcpingresses.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleTCPIngress(ctx context.Context, request admissionv1.AdmissionRequest, responseBuilder *ResponseBuilder) (*admissionv1.AdmissionResponse, error) {
+	ingress := kongv1beta1.TCPIngress{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &ingress)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateTCPIngress(ctx, ingress)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,groups=configuration.konghq.com,resources=udpingresses,versions=v1beta1,name=udpingresses.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleUDPIngress(ctx context.Context, request admissionv1.AdmissionRequest, responseBuilder *ResponseBuilder) (*admissionv1.AdmissionResponse, error) {
+	ingress := kongv1beta1.UDPIngress{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &ingress)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateUDPIngress(ctx, ingress)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,groups=configuration.konghq.com,resources=kongupstreampolicies,versions=v1beta1,name=kongupstreampolicies.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleKongUpstreamPolicy(ctx context.Context, request admissionv1.AdmissionRequest, responseBuilder *ResponseBuilder) (*admissionv1.AdmissionResponse, error) {
+	policy := kongv1beta1.KongUpstreamPolicy{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &policy)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateUpstreamPolicy(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,groups=incubator.ingress-controller.konghq.com,resources=kongservicefacades,versions=v1alpha1,name=kongservicefacades.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
+
+func (h RequestHandler) handleKongServiceFacade(ctx context.Context, request admissionv1.AdmissionRequest, responseBuilder *ResponseBuilder) (*admissionv1.AdmissionResponse, error) {
+	facade := incubatorv1alpha1.KongServiceFacade{}
+	_, _, err := codecs.UniversalDeserializer().Decode(request.Object.Raw, nil, &facade)
+	if err != nil {
+		return nil, err
+	}
+	ok, message, err := h.Validator.ValidateServiceFacade(ctx, facade)
+	if err != nil {
+		return nil, err
+	}
+
+	return responseBuilder.Allowed(ok).WithMessage(message).Build(), nil
+}
+
 // +kubebuilder:webhook:verbs=create;update,groups=configuration.konghq.com,resources=kongvaults,versions=v1alpha1,name=kongvaults.validation.ingress-controller.konghq.com,path=/,webhookVersions=v1,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,admissionReviewVersions=v1
 
 func (h RequestHandler) handleKongVault(ctx context.Context, request admissionv1.AdmissionRequest, responseBuilder *ResponseBuilder) (*admissionv1.AdmissionResponse, error) {
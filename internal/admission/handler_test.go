@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/reference"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
@@ -443,3 +445,160 @@ func TestHandleSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleValidationOfRoutesAndKongEntities(t *testing.T) {
+	for _, gvr := range []metav1.GroupVersionResource{
+		gatewayapi.V1GRPCRouteGVResource,
+		gatewayapi.V1alpha2TCPRouteGVResource,
+		gatewayapi.V1alpha2UDPRouteGVResource,
+		gatewayapi.V1alpha2TLSRouteGVResource,
+		tcpIngressGVResource,
+		udpIngressGVResource,
+		kongUpstreamPolicyGVResource,
+		kongServiceFacadeGVResource,
+	} {
+		t.Run(gvr.Resource, func(t *testing.T) {
+			handler := RequestHandler{
+				Validator: KongFakeValidator{Result: false, Message: "invalid"},
+				Logger:    logr.Discard(),
+			}
+			got, err := handler.handleValidation(context.Background(), admissionv1.AdmissionRequest{
+				UID:       k8stypes.UID("uid"),
+				Resource:  gvr,
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"metadata":{"name":"test","namespace":"default"}}`),
+				},
+			})
+			require.NoError(t, err)
+			require.False(t, got.Allowed)
+			require.Equal(t, "invalid", got.Result.Message)
+		})
+	}
+}
+
+type fakeConfigValidator struct {
+	result  bool
+	message string
+	calls   int
+}
+
+func (v *fakeConfigValidator) ValidateWithConfig(_ context.Context, _ admissionv1.AdmissionRequest) (bool, string, error) {
+	v.calls++
+	return v.result, v.message, nil
+}
+
+func TestHandleValidationWithConfigValidator(t *testing.T) {
+	testCases := []struct {
+		name              string
+		validatorResult   bool
+		configValidator   *fakeConfigValidator
+		wantAllowed       bool
+		wantMessage       string
+		wantValidatorCall bool
+	}{
+		{
+			name:              "object allowed by both validators",
+			validatorResult:   true,
+			configValidator:   &fakeConfigValidator{result: true},
+			wantAllowed:       true,
+			wantValidatorCall: true,
+		},
+		{
+			name:              "object rejected by config validator",
+			validatorResult:   true,
+			configValidator:   &fakeConfigValidator{result: false, message: "conflict"},
+			wantAllowed:       false,
+			wantMessage:       "conflict",
+			wantValidatorCall: true,
+		},
+		{
+			name:              "object rejected by validator is not validated with config",
+			validatorResult:   false,
+			configValidator:   &fakeConfigValidator{result: true},
+			wantAllowed:       false,
+			wantMessage:       "invalid",
+			wantValidatorCall: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := RequestHandler{
+				Validator:       KongFakeValidator{Result: tc.validatorResult, Message: lo.Ternary(tc.validatorResult, "", "invalid")},
+				ConfigValidator: tc.configValidator,
+				Logger:          logr.Discard(),
+			}
+			got, err := handler.handleValidation(context.Background(), admissionv1.AdmissionRequest{
+				UID:       k8stypes.UID("uid"),
+				Resource:  tcpIngressGVResource,
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"metadata":{"name":"test","namespace":"default"}}`),
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.wantAllowed, got.Allowed)
+			require.Equal(t, tc.wantMessage, got.Result.Message)
+			require.Equal(t, tc.wantValidatorCall, tc.configValidator.calls > 0)
+		})
+	}
+}
+
+type fakeWarningsProvider struct {
+	warnings []string
+	err      error
+}
+
+func (p fakeWarningsProvider) Warnings(_ context.Context, _ admissionv1.AdmissionRequest) ([]string, error) {
+	return p.warnings, p.err
+}
+
+func TestHandleValidationWithWarningsProvider(t *testing.T) {
+	testCases := []struct {
+		name             string
+		validatorResult  bool
+		warningsProvider fakeWarningsProvider
+		wantWarnings     []string
+	}{
+		{
+			name:             "warnings are added to allowed objects",
+			validatorResult:  true,
+			warningsProvider: fakeWarningsProvider{warnings: []string{"warning"}},
+			wantWarnings:     []string{"warning"},
+		},
+		{
+			name:             "warnings are added to denied objects",
+			validatorResult:  false,
+			warningsProvider: fakeWarningsProvider{warnings: []string{"warning"}},
+			wantWarnings:     []string{"warning"},
+		},
+		{
+			name:             "failure to determine some warnings doesn't block the object",
+			validatorResult:  true,
+			warningsProvider: fakeWarningsProvider{warnings: []string{"warning"}, err: fmt.Errorf("failed")},
+			wantWarnings:     []string{"warning"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := RequestHandler{
+				Validator:        KongFakeValidator{Result: tc.validatorResult},
+				WarningsProvider: tc.warningsProvider,
+				Logger:           logr.Discard(),
+			}
+			got, err := handler.handleValidation(context.Background(), admissionv1.AdmissionRequest{
+				UID:       k8stypes.UID("uid"),
+				Resource:  tcpIngressGVResource,
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"metadata":{"name":"test","namespace":"default"}}`),
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.validatorResult, got.Allowed)
+			require.Equal(t, tc.wantWarnings, got.Warnings)
+		})
+	}
+}
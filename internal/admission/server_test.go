@@ -22,6 +22,7 @@ import (
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
 )
 
 var decoder = codecs.UniversalDeserializer()
@@ -74,10 +75,42 @@ func (v KongFakeValidator) ValidateHTTPRoute(_ context.Context, _ gatewayapi.HTT
 	return v.Result, v.Message, v.Error
 }
 
+func (v KongFakeValidator) ValidateGRPCRoute(_ context.Context, _ gatewayapi.GRPCRoute) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateTCPRoute(_ context.Context, _ gatewayapi.TCPRoute) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateUDPRoute(_ context.Context, _ gatewayapi.UDPRoute) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateTLSRoute(_ context.Context, _ gatewayapi.TLSRoute) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
 func (v KongFakeValidator) ValidateIngress(_ context.Context, _ netv1.Ingress) (bool, string, error) {
 	return v.Result, v.Message, v.Error
 }
 
+func (v KongFakeValidator) ValidateTCPIngress(_ context.Context, _ kongv1beta1.TCPIngress) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateUDPIngress(_ context.Context, _ kongv1beta1.UDPIngress) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateUpstreamPolicy(_ context.Context, _ kongv1beta1.KongUpstreamPolicy) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
+func (v KongFakeValidator) ValidateServiceFacade(_ context.Context, _ incubatorv1alpha1.KongServiceFacade) (bool, string, error) {
+	return v.Result, v.Message, v.Error
+}
+
 func (v KongFakeValidator) ValidateVault(_ context.Context, _ kongv1alpha1.KongVault) (bool, string, error) {
 	return v.Result, v.Message, v.Error
 }
@@ -0,0 +1,112 @@
+package credentials
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Registry - Types
+// -----------------------------------------------------------------------------
+
+// Type describes a type of credentials that can be provided for KongConsumers in Secrets
+// labeled with the konghq.com/credential label.
+type Type struct {
+	// Name is the value of the konghq.com/credential label selecting the type.
+	Name string
+
+	// EntityType is the type of the Kong entity credentials of this type are translated to,
+	// e.g. keyauth_credentials.
+	EntityType string
+
+	// SchemaBased tells whether fields of the credentials are unknown to the controller. Such
+	// credentials are validated and translated according to the schema of their EntityType fetched
+	// from Kong and are sent to Kong as generic entities referring to their consumer, which only
+	// DB-less Kong Gateways accept.
+	SchemaBased bool
+}
+
+// Registry holds the credential types known to the controller.
+type Registry struct {
+	lock  sync.RWMutex
+	types map[string]Type
+}
+
+// NewRegistry creates a Registry holding the given types.
+func NewRegistry(types ...Type) *Registry {
+	r := &Registry{types: make(map[string]Type, len(types))}
+	for _, t := range types {
+		r.Register(t)
+	}
+	return r
+}
+
+// NewDefaultRegistry creates a Registry holding the credential types supported by the controller out of
+// the box. Of Kong Gateway Enterprise credential types, only mtls-auth and key-auth-enc are included, other
+// ones have to be registered as schema-based types.
+func NewDefaultRegistry() *Registry {
+	return NewRegistry(
+		Type{Name: "basic-auth", EntityType: "basicauth_credentials"},
+		Type{Name: "hmac-auth", EntityType: "hmacauth_credentials"},
+		Type{Name: "jwt", EntityType: "jwt_secrets"},
+		Type{Name: "key-auth", EntityType: "keyauth_credentials"},
+		Type{Name: "oauth2", EntityType: "oauth2_credentials"},
+		Type{Name: "acl", EntityType: "acls"},
+		Type{Name: "mtls-auth", EntityType: "mtls_auth_credentials"},
+		// Kong Gateway Enterprise credential types.
+		Type{Name: "key-auth-enc", EntityType: "keyauth_enc_credentials", SchemaBased: true},
+	)
+}
+
+// -----------------------------------------------------------------------------
+// Registry - Public Methods
+// -----------------------------------------------------------------------------
+
+// Register adds the type to the registry, replacing a type of the same name.
+func (r *Registry) Register(t Type) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.types[t.Name] = t
+}
+
+// Get returns the registered type of the given name.
+func (r *Registry) Get(name string) (Type, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Lookup returns the registered type of the given name. Types of credential plugins unknown to the
+// controller have to be registered as schema-based types, e.g. with ParseSchemaBasedType, to be used.
+//
+// Entity types of registered types are rejected with a hint, credentials of these types have to use
+// the registered name.
+func (r *Registry) Lookup(name string) (Type, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if t, ok := r.types[name]; ok {
+		return t, nil
+	}
+	for _, t := range r.types {
+		if t.EntityType == name {
+			return Type{}, fmt.Errorf("credential type %s is not supported, use %s instead", name, t.Name)
+		}
+	}
+	return Type{}, fmt.Errorf("invalid credential type %s", name)
+}
+
+// -----------------------------------------------------------------------------
+// Registry - Public Functions
+// -----------------------------------------------------------------------------
+
+// ParseSchemaBasedType parses a schema-based type in the name:entity_type format, e.g.
+// custom-auth:custom_auth_credentials.
+func ParseSchemaBasedType(value string) (Type, error) {
+	name, entityType, ok := strings.Cut(value, ":")
+	if !ok || name == "" || entityType == "" {
+		return Type{}, fmt.Errorf("credential type %q is not in the name:entity_type format", value)
+	}
+	return Type{Name: name, EntityType: entityType, SchemaBased: true}, nil
+}
@@ -0,0 +1,39 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	registry := NewRegistry(
+		Type{Name: "key-auth", EntityType: "keyauth_credentials"},
+		Type{Name: "custom-auth", EntityType: "custom_auth_credentials", SchemaBased: true},
+	)
+
+	credentialType, err := registry.Lookup("custom-auth")
+	require.NoError(t, err)
+	assert.Equal(t, Type{Name: "custom-auth", EntityType: "custom_auth_credentials", SchemaBased: true}, credentialType)
+
+	_, err = registry.Lookup("keyauth_credentials")
+	assert.EqualError(t, err, "credential type keyauth_credentials is not supported, use key-auth instead")
+
+	_, err = registry.Lookup("custom_auth_credentials")
+	assert.EqualError(t, err, "credential type custom_auth_credentials is not supported, use custom-auth instead")
+
+	_, err = registry.Lookup("bee-auth")
+	assert.EqualError(t, err, "invalid credential type bee-auth")
+}
+
+func TestParseSchemaBasedType(t *testing.T) {
+	credentialType, err := ParseSchemaBasedType("custom-auth:custom_auth_credentials")
+	require.NoError(t, err)
+	assert.Equal(t, Type{Name: "custom-auth", EntityType: "custom_auth_credentials", SchemaBased: true}, credentialType)
+
+	for _, invalid := range []string{"custom-auth", "custom-auth:", ":custom_auth_credentials"} {
+		_, err := ParseSchemaBasedType(invalid)
+		assert.EqualError(t, err, `credential type "`+invalid+`" is not in the name:entity_type format`)
+	}
+}
@@ -0,0 +1,109 @@
+package credentials
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Rotation - Public Types
+// -----------------------------------------------------------------------------
+
+// Rotation describes a rotation of a credential Secret to its successor declared with the
+// konghq.com/credential-successor and konghq.com/credential-grace-period annotations.
+//
+// During the grace period, both credentials are attached to consumers referencing the rotated
+// Secret, so clients using the old credential keep working. Once it expires, only the successor
+// is attached. The grace period starts when the controller first observes the rotation with an
+// existing successor, which it records in the status of the consumers referencing the Secret, so
+// that the configuration only depends on the state of the cluster.
+type Rotation struct {
+	// Secret is the name of the rotated credential Secret.
+	Secret string
+
+	// Successor is the name of the Secret in the same namespace the credential is rotated to.
+	Successor string
+
+	// GracePeriod is how long both credentials are attached after the rotation starts.
+	GracePeriod time.Duration
+
+	// StartedAt is when the rotation started. It's zero if the controller hasn't observed it yet.
+	StartedAt time.Time
+}
+
+// -----------------------------------------------------------------------------
+// Rotation - Public Functions
+// -----------------------------------------------------------------------------
+
+// ExtractRotation returns the rotation declared by the credential Secret. It returns false if
+// the Secret doesn't declare a successor.
+func ExtractRotation(secret *corev1.Secret) (Rotation, bool, error) {
+	successor, ok := annotations.ExtractCredentialSuccessor(secret.Annotations)
+	if !ok {
+		return Rotation{}, false, nil
+	}
+	if successor == "" {
+		return Rotation{}, false, fmt.Errorf("%s annotation must not be empty",
+			annotations.AnnotationPrefix+annotations.CredentialSuccessorKey)
+	}
+	if successor == secret.Name {
+		return Rotation{}, false, fmt.Errorf("credential Secret can't be its own successor")
+	}
+
+	rawGracePeriod, ok := annotations.ExtractCredentialGracePeriod(secret.Annotations)
+	if !ok {
+		return Rotation{}, false, fmt.Errorf("%s annotation is required when %s annotation is set",
+			annotations.AnnotationPrefix+annotations.CredentialGracePeriodKey,
+			annotations.AnnotationPrefix+annotations.CredentialSuccessorKey)
+	}
+	gracePeriod, err := time.ParseDuration(rawGracePeriod)
+	if err != nil {
+		return Rotation{}, false, fmt.Errorf("invalid %s annotation: %w",
+			annotations.AnnotationPrefix+annotations.CredentialGracePeriodKey, err)
+	}
+	if gracePeriod < 0 {
+		return Rotation{}, false, fmt.Errorf("%s annotation must not be negative",
+			annotations.AnnotationPrefix+annotations.CredentialGracePeriodKey)
+	}
+
+	return Rotation{Secret: secret.Name, Successor: successor, GracePeriod: gracePeriod}, true, nil
+}
+
+// WithRecordedStart returns the rotation with its start recorded in the status of a consumer referencing
+// the rotated Secret. A start recorded for a different successor is ignored, as the rotation has been
+// declared again since.
+func (r Rotation) WithRecordedStart(status kongv1.KongConsumerStatus) Rotation {
+	for _, recorded := range status.CredentialRotations {
+		if recorded.Secret == r.Secret && recorded.Successor == r.Successor {
+			r.StartedAt = recorded.StartedAt.Time
+			break
+		}
+	}
+	return r
+}
+
+// Started tells whether the controller observed the rotation to start.
+func (r Rotation) Started() bool {
+	return !r.StartedAt.IsZero()
+}
+
+// Expired tells whether the grace period of the rotation has expired at the given time. Only the
+// successor is attached to consumers once it has.
+func (r Rotation) Expired(now time.Time) bool {
+	deadline, ok := r.Deadline()
+	return ok && !now.Before(deadline)
+}
+
+// Deadline returns the time the grace period of the rotation expires at. It returns false if
+// the rotation hasn't started yet.
+func (r Rotation) Deadline() (time.Time, bool) {
+	if !r.Started() {
+		return time.Time{}, false
+	}
+	return r.StartedAt.Add(r.GracePeriod), true
+}
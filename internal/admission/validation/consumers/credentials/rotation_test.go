@@ -0,0 +1,125 @@
+package credentials
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+)
+
+func TestExtractRotation(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantRotation Rotation
+		wantOK       bool
+		wantErr      string
+	}{
+		{
+			name: "no rotation declared",
+		},
+		{
+			name: "rotation declared",
+			annotations: map[string]string{
+				"konghq.com/credential-successor":    "new-secret",
+				"konghq.com/credential-grace-period": "1h30m",
+			},
+			wantRotation: Rotation{Secret: "secret", Successor: "new-secret", GracePeriod: 90 * time.Minute},
+			wantOK:       true,
+		},
+		{
+			name: "grace period missing",
+			annotations: map[string]string{
+				"konghq.com/credential-successor": "new-secret",
+			},
+			wantErr: "konghq.com/credential-grace-period annotation is required when konghq.com/credential-successor annotation is set",
+		},
+		{
+			name: "invalid grace period",
+			annotations: map[string]string{
+				"konghq.com/credential-successor":    "new-secret",
+				"konghq.com/credential-grace-period": "1 day",
+			},
+			wantErr: `invalid konghq.com/credential-grace-period annotation: time: unknown unit " day" in duration "1 day"`,
+		},
+		{
+			name: "negative grace period",
+			annotations: map[string]string{
+				"konghq.com/credential-successor":    "new-secret",
+				"konghq.com/credential-grace-period": "-1h",
+			},
+			wantErr: "konghq.com/credential-grace-period annotation must not be negative",
+		},
+		{
+			name: "secret being its own successor",
+			annotations: map[string]string{
+				"konghq.com/credential-successor":    "secret",
+				"konghq.com/credential-grace-period": "1h",
+			},
+			wantErr: "credential Secret can't be its own successor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "secret",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+			}
+			rotation, ok, err := ExtractRotation(secret)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantRotation, rotation)
+		})
+	}
+}
+
+func TestRotationDeadline(t *testing.T) {
+	rotation := Rotation{Successor: "new-secret", GracePeriod: 24 * time.Hour}
+	_, ok := rotation.Deadline()
+	assert.False(t, ok, "rotation that hasn't started has no deadline")
+
+	rotation.StartedAt = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	deadline, ok := rotation.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC), deadline)
+}
+
+func TestRotationWithRecordedStart(t *testing.T) {
+	startedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	status := kongv1.KongConsumerStatus{
+		CredentialRotations: []kongv1.CredentialRotationStatus{
+			{Secret: "other-secret", Successor: "new-secret", StartedAt: metav1.NewTime(startedAt.Add(-time.Hour))},
+			{Secret: "secret", Successor: "new-secret", StartedAt: metav1.NewTime(startedAt)},
+		},
+	}
+
+	rotation := Rotation{Secret: "secret", Successor: "new-secret", GracePeriod: time.Hour}.WithRecordedStart(status)
+	assert.Equal(t, startedAt, rotation.StartedAt)
+
+	rotation = Rotation{Secret: "secret", Successor: "newer-secret", GracePeriod: time.Hour}.WithRecordedStart(status)
+	assert.False(t, rotation.Started(), "start recorded for a different successor must be ignored")
+}
+
+func TestRotationExpired(t *testing.T) {
+	rotation := Rotation{Secret: "secret", Successor: "new-secret", GracePeriod: time.Hour}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.False(t, rotation.Expired(now), "rotation that hasn't started doesn't expire")
+
+	rotation.StartedAt = now.Add(-59 * time.Minute)
+	assert.False(t, rotation.Expired(now))
+
+	rotation.StartedAt = now.Add(-time.Hour)
+	assert.True(t, rotation.Expired(now))
+}
@@ -2,10 +2,12 @@
 package credentials
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
 
+	"github.com/kong/go-kong/kong"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
@@ -16,9 +18,18 @@ import (
 //  Validation - Public Functions
 // -----------------------------------------------------------------------------
 
+// SchemaGetter fetches schemas of Kong entities.
+type SchemaGetter interface {
+	Get(ctx context.Context, entityType string) (kong.Schema, error)
+}
+
 // ValidateCredentials performs basic validation on a credential secret given
 // the Kubernetes secret which contains credentials data.
-func ValidateCredentials(secret *corev1.Secret) error {
+//
+// Only types registered in the registry are accepted. Credentials of schema-based
+// types are validated against the schema of their entity type fetched using
+// schemaGetter. If it's nil, their fields aren't validated.
+func ValidateCredentials(ctx context.Context, secret *corev1.Secret, registry *Registry, schemaGetter SchemaGetter) error {
 	credentialType, err := util.ExtractKongCredentialType(secret)
 	if err != nil {
 		// this shouldn't occur, since we check this earlier in the admission controller's handleSecret function, but
@@ -26,9 +37,18 @@ func ValidateCredentials(secret *corev1.Secret) error {
 		return fmt.Errorf("secret has no credential type, add a %s label", labels.CredentialTypeLabel)
 	}
 
+	// verify that the rotation to a successor, if declared, is valid
+	if _, _, err := ExtractRotation(secret); err != nil {
+		return err
+	}
+
 	// verify that the credential type provided is valid
-	if !SupportedTypes.Has(credentialType) {
-		return fmt.Errorf("invalid credential type %s", credentialType)
+	t, err := registry.Lookup(credentialType)
+	if err != nil {
+		return err
+	}
+	if t.SchemaBased {
+		return validateSchemaBasedCredentials(ctx, secret, t, schemaGetter)
 	}
 
 	// Check if we're dealing with a JWT credential with an HMAC algorithm.
@@ -36,39 +56,11 @@ func ValidateCredentials(secret *corev1.Secret) error {
 	algo, hasAlgo := secret.Data["algorithm"]
 	ignoreMissingRSAPublicKey := credentialType == "jwt" && hasAlgo && algoIsHMAC(string(algo))
 
-	// verify that all required fields are present
-	var missingFields []string
-	var missingDataFields []string
-	for _, field := range CredTypeToFields[credentialType] {
+	requiredFields := slices.DeleteFunc(slices.Clone(CredTypeToFields[credentialType]), func(field string) bool {
 		// Ignore missing rsa_public_key for jwt credentials with HMAC algorithm
-		if field == "rsa_public_key" && ignoreMissingRSAPublicKey {
-			continue
-		}
-
-		// verify whether the required field is missing
-		requiredData, ok := secret.Data[field]
-		if !ok {
-			missingFields = append(missingFields, field)
-			continue
-		}
-
-		// verify whether the required field is present, but missing data
-		if len(requiredData) < 1 {
-			missingDataFields = append(missingDataFields, field)
-		}
-	}
-
-	// report on any required fields that were missing
-	if len(missingFields) > 0 {
-		return fmt.Errorf("missing required field(s): %s", strings.Join(missingFields, ", "))
-	}
-
-	// report on any required fields that were present, but were missing actual data
-	if len(missingDataFields) > 0 {
-		return fmt.Errorf("some fields were invalid due to missing data: %s", strings.Join(missingDataFields, ", "))
-	}
-
-	return nil
+		return field == "rsa_public_key" && ignoreMissingRSAPublicKey
+	})
+	return validateRequiredFields(secret, requiredFields)
 }
 
 // IsKeyUniqueConstrained indicates whether or not a given key and its type there
@@ -97,7 +89,7 @@ func IsKeyUniqueConstrained(keyType, key string) (constrained bool) {
 // consumer credentials, particularly unique constraints on the underlying data.
 type Credential struct {
 	// Type indicates the credential type, which will reference one of the types
-	// in the SupportedTypes set.
+	// in the Registry.
 	Type string
 
 	// Key is the key for the credentials data
@@ -179,6 +171,86 @@ func (cs Index) add(newCred Credential) error {
 	return nil
 }
 
+// validateSchemaBasedCredentials validates credentials of a schema-based type against the schema of its entity type.
+func validateSchemaBasedCredentials(ctx context.Context, secret *corev1.Secret, t Type, schemaGetter SchemaGetter) error {
+	if schemaGetter == nil {
+		return nil
+	}
+
+	schema, err := schemaGetter.Get(ctx, t.EntityType)
+	if err != nil {
+		if kong.IsNotFoundErr(err) {
+			return fmt.Errorf("invalid credential type %s", t.Name)
+		}
+		return fmt.Errorf("failed to fetch schema of credential type %s: %w", t.Name, err)
+	}
+	requiredFields, belongsToConsumer := credentialSchemaFields(schema)
+	if !belongsToConsumer {
+		return fmt.Errorf("invalid credential type %s: %s entities don't belong to consumers", t.Name, t.EntityType)
+	}
+	return validateRequiredFields(secret, requiredFields)
+}
+
+// credentialSchemaFields returns fields of an entity schema that have to be provided in credentials and whether
+// entities of the schema belong to consumers. Foreign fields and fields that are generated or have a default value
+// don't have to be provided.
+func credentialSchemaFields(schema kong.Schema) (requiredFields []string, belongsToConsumer bool) {
+	fields, _ := schema["fields"].([]interface{})
+	for _, item := range fields {
+		fieldDef, _ := item.(map[string]interface{})
+		for fieldName, fieldAttributes := range fieldDef {
+			fieldAttributesMap, ok := fieldAttributes.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fieldType, _ := fieldAttributesMap["type"].(string)
+			fieldRequired, _ := fieldAttributesMap["required"].(bool)
+			if fieldType == "foreign" {
+				fieldReference, _ := fieldAttributesMap["reference"].(string)
+				belongsToConsumer = belongsToConsumer || (fieldRequired && fieldReference == "consumers")
+				continue
+			}
+			fieldAuto, _ := fieldAttributesMap["auto"].(bool)
+			_, fieldHasDefault := fieldAttributesMap["default"]
+			if fieldRequired && !fieldAuto && !fieldHasDefault {
+				requiredFields = append(requiredFields, fieldName)
+			}
+		}
+	}
+	return requiredFields, belongsToConsumer
+}
+
+// validateRequiredFields verifies that all required fields are present in the secret and have data.
+func validateRequiredFields(secret *corev1.Secret, requiredFields []string) error {
+	var missingFields []string
+	var missingDataFields []string
+	for _, field := range requiredFields {
+		// verify whether the required field is missing
+		requiredData, ok := secret.Data[field]
+		if !ok {
+			missingFields = append(missingFields, field)
+			continue
+		}
+
+		// verify whether the required field is present, but missing data
+		if len(requiredData) < 1 {
+			missingDataFields = append(missingDataFields, field)
+		}
+	}
+
+	// report on any required fields that were missing
+	if len(missingFields) > 0 {
+		return fmt.Errorf("missing required field(s): %s", strings.Join(missingFields, ", "))
+	}
+
+	// report on any required fields that were present, but were missing actual data
+	if len(missingDataFields) > 0 {
+		return fmt.Errorf("some fields were invalid due to missing data: %s", strings.Join(missingDataFields, ", "))
+	}
+
+	return nil
+}
+
 func algoIsHMAC(algo string) bool {
 	return slices.Contains([]string{"HS256", "HS384", "HS512"}, algo)
 }
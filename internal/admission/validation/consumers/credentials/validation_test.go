@@ -1,9 +1,12 @@
 package credentials
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/kong/go-kong/kong"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -240,7 +243,7 @@ func TestValidateCredentials(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateCredentials(tt.secret)
+			err := ValidateCredentials(context.Background(), tt.secret, NewDefaultRegistry(), nil)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.wantErr, err)
@@ -250,3 +253,123 @@ func TestValidateCredentials(t *testing.T) {
 		})
 	}
 }
+
+type fakeSchemaGetter struct {
+	schemas map[string]kong.Schema
+}
+
+func (s fakeSchemaGetter) Get(_ context.Context, entityType string) (kong.Schema, error) {
+	schema, ok := s.schemas[entityType]
+	if !ok {
+		return nil, kong.NewAPIError(http.StatusNotFound, "Not found")
+	}
+	return schema, nil
+}
+
+func TestValidateSchemaBasedCredentials(t *testing.T) {
+	schemaGetter := fakeSchemaGetter{
+		schemas: map[string]kong.Schema{
+			"keyauth_enc_credentials": {
+				"fields": []interface{}{
+					map[string]interface{}{"id": map[string]interface{}{"type": "string", "auto": true, "required": true}},
+					map[string]interface{}{"consumer": map[string]interface{}{"type": "foreign", "reference": "consumers", "required": true}},
+					map[string]interface{}{"key": map[string]interface{}{"type": "string", "auto": true, "required": false}},
+				},
+			},
+			"custom_credentials": {
+				"fields": []interface{}{
+					map[string]interface{}{"consumer": map[string]interface{}{"type": "foreign", "reference": "consumers", "required": true}},
+					map[string]interface{}{"token": map[string]interface{}{"type": "string", "required": true}},
+					map[string]interface{}{"scope": map[string]interface{}{"type": "string", "required": true, "default": "all"}},
+				},
+			},
+			"sessions": {
+				"fields": []interface{}{
+					map[string]interface{}{"name": map[string]interface{}{"type": "string", "required": true}},
+				},
+			},
+		},
+	}
+	secretOfType := func(credentialType string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: credentialType,
+				},
+			},
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		secret       *corev1.Secret
+		schemaGetter SchemaGetter
+		wantErr      string
+	}{
+		{
+			name:         "registered schema-based type",
+			secret:       secretOfType("key-auth-enc", map[string][]byte{"key": []byte("key")}),
+			schemaGetter: schemaGetter,
+		},
+		{
+			name:   "registered schema-based type without schema getter",
+			secret: secretOfType("key-auth-enc", map[string][]byte{"key": []byte("key")}),
+		},
+		{
+			name:         "registered type provided by a plugin",
+			secret:       secretOfType("custom-credentials", map[string][]byte{"token": []byte("token")}),
+			schemaGetter: schemaGetter,
+		},
+		{
+			name:         "registered type provided by a plugin with missing required field",
+			secret:       secretOfType("custom-credentials", map[string][]byte{"scope": []byte("read")}),
+			schemaGetter: schemaGetter,
+			wantErr:      "missing required field(s): token",
+		},
+		{
+			name:         "unregistered type",
+			secret:       secretOfType("other_credentials", map[string][]byte{"token": []byte("token")}),
+			schemaGetter: schemaGetter,
+			wantErr:      "invalid credential type other_credentials",
+		},
+		{
+			name:         "registered type not existing in Kong",
+			secret:       secretOfType("bee-auth", map[string][]byte{"foo": []byte("bar")}),
+			schemaGetter: schemaGetter,
+			wantErr:      "invalid credential type bee-auth",
+		},
+		{
+			name:         "registered type not belonging to consumers",
+			secret:       secretOfType("session", map[string][]byte{"name": []byte("session")}),
+			schemaGetter: schemaGetter,
+			wantErr:      "invalid credential type session: sessions entities don't belong to consumers",
+		},
+		{
+			name:         "entity type of a registered type",
+			secret:       secretOfType("keyauth_credentials", map[string][]byte{"key": []byte("key")}),
+			schemaGetter: schemaGetter,
+			wantErr:      "credential type keyauth_credentials is not supported, use key-auth instead",
+		},
+	}
+	registry := NewRegistry(
+		Type{Name: "key-auth", EntityType: "keyauth_credentials"},
+		Type{Name: "key-auth-enc", EntityType: "keyauth_enc_credentials", SchemaBased: true},
+		Type{Name: "custom-credentials", EntityType: "custom_credentials", SchemaBased: true},
+		Type{Name: "bee-auth", EntityType: "bee_credentials", SchemaBased: true},
+		Type{Name: "session", EntityType: "sessions", SchemaBased: true},
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCredentials(context.Background(), tt.secret, registry, tt.schemaGetter)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
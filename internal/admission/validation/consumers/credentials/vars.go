@@ -1,22 +1,9 @@
 package credentials
 
-import "k8s.io/apimachinery/pkg/util/sets"
-
 // -----------------------------------------------------------------------------
 // Validation - Vars
 // -----------------------------------------------------------------------------
 
-// SupportedTypes indicates all the Kong credential types which are supported for KongConsumer credentials.
-var SupportedTypes = sets.NewString(
-	"basic-auth",
-	"hmac-auth",
-	"jwt",
-	"key-auth",
-	"oauth2",
-	"acl",
-	"mtls-auth",
-)
-
 var (
 	KeyAuthFields    = []string{"key"}
 	BasicAuthFields  = []string{"username", "password"}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/kongplugin"
+	gatewaycontroller "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+)
+
+// -----------------------------------------------------------------------------
+// Validation - GRPCRoute - Public Functions
+// -----------------------------------------------------------------------------
+
+// ValidateGRPCRoute provides a suite of validation for a given GRPCRoute. It checks
+// whether the route is managed by this controller, linked objects, and uses provided
+// routesValidator to validate the route against Kong Gateway validation endpoint.
+// Storer is used to resolve hostnames of Gateway listeners when the route doesn't specify any.
+func ValidateGRPCRoute(
+	ctx context.Context,
+	routesValidator routeValidator,
+	translatorFeatures translator.FeatureFlags,
+	grpcroute *gatewayapi.GRPCRoute,
+	managerClient client.Client,
+	storer store.Storer,
+) (bool, string, error) {
+	// Check if route is managed by this controller. If not, we don't need to validate it.
+	routeIsManaged, err := ensureRouteIsManagedByController(ctx, grpcroute.Namespace, grpcroute.Spec.ParentRefs, managerClient)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to determine whether GRPCRoute is managed by %q controller: %w",
+			gatewaycontroller.GetControllerName(), err)
+	}
+	if !routeIsManaged {
+		return true, "", nil
+	}
+
+	if err := kongplugin.ValidatePluginUniquenessPerObject(ctx, managerClient, grpcroute); err != nil {
+		return false, fmt.Sprintf("GRPCRoute has invalid KongPlugin annotation: %s", err), nil
+	}
+
+	// Validate that the route uses only supported annotations.
+	if err := validation.ValidateRouteSourceAnnotations(grpcroute); err != nil {
+		return false, fmt.Sprintf("GRPCRoute has invalid Kong annotations: %s", err), nil
+	}
+
+	// Validate that the route is valid against Kong Gateway.
+	var kongRoutes []kongstate.Route
+	for ruleNumber := range grpcroute.Spec.Rules {
+		if translatorFeatures.ExpressionRoutes {
+			kongRoutes = append(kongRoutes, subtranslator.GenerateKongExpressionRoutesFromGRPCRouteRule(grpcroute, ruleNumber)...)
+		} else {
+			kongRoutes = append(kongRoutes, subtranslator.GenerateKongRoutesFromGRPCRouteRule(grpcroute, ruleNumber, storer)...)
+		}
+	}
+	ok, msg := validateRoutesWithKongGateway(ctx, routesValidator, "GRPCRoute", kongRoutes)
+	return ok, msg, nil
+}
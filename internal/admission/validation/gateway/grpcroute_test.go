@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gatewaycontroller "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+)
+
+func TestValidateGRPCRoute(t *testing.T) {
+	gateway := &gatewayapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "gateway",
+		},
+		Spec: gatewayapi.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayapi.Listener{{
+				Name:     "http",
+				Port:     80,
+				Protocol: gatewayapi.HTTPProtocolType,
+				Hostname: lo.ToPtr(gatewayapi.Hostname("grpc.example.com")),
+			}},
+		},
+	}
+	fakeClient := fakeclient.
+		NewClientBuilder().
+		WithScheme(lo.Must(scheme.Get())).
+		WithObjects(
+			&gatewayapi.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "kong",
+				},
+				Spec: gatewayapi.GatewayClassSpec{
+					ControllerName: gatewaycontroller.GetControllerName(),
+				},
+			},
+			gateway,
+		).
+		Build()
+	storer := lo.Must(store.NewFakeStore(store.FakeObjects{Gateways: []*gatewayapi.Gateway{gateway}}))
+	grpcroute := &gatewayapi.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "grpcroute",
+		},
+		Spec: gatewayapi.GRPCRouteSpec{
+			CommonRouteSpec: gatewayapi.CommonRouteSpec{
+				ParentRefs: []gatewayapi.ParentReference{{Name: "gateway"}},
+			},
+			Rules: []gatewayapi.GRPCRouteRule{{
+				Matches: []gatewayapi.GRPCRouteMatch{{
+					Method: &gatewayapi.GRPCMethodMatch{
+						Service: lo.ToPtr("grpcbin.GRPCBin"),
+						Method:  lo.ToPtr("DummyUnary"),
+					},
+				}},
+			}},
+		},
+	}
+
+	t.Run("routes are translated with hostnames of Gateway listeners", func(t *testing.T) {
+		rv := &capturingRoutesValidator{}
+		valid, msg, err := ValidateGRPCRoute(context.Background(), rv, translator.FeatureFlags{}, grpcroute, fakeClient, storer)
+		require.NoError(t, err)
+		assert.True(t, valid)
+		assert.Empty(t, msg)
+		require.Len(t, rv.routes, 1)
+		assert.Equal(t, kong.StringSlice("grpc.example.com"), rv.routes[0].Hosts)
+		assert.Equal(t, kong.StringSlice("~/grpcbin.GRPCBin/DummyUnary"), rv.routes[0].Paths)
+	})
+
+	t.Run("routes rejected by Kong fail validation", func(t *testing.T) {
+		valid, msg, err := ValidateGRPCRoute(context.Background(), rejectingRoutesValidator{}, translator.FeatureFlags{}, grpcroute, fakeClient, storer)
+		require.NoError(t, err)
+		assert.False(t, valid)
+		assert.Equal(t, "GRPCRoute failed schema validation: invalid route", msg)
+	})
+}
+
+type rejectingRoutesValidator struct{}
+
+func (rejectingRoutesValidator) Validate(_ context.Context, _ *kong.Route) (bool, string, error) {
+	return false, "invalid route", nil
+}
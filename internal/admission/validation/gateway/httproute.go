@@ -12,6 +12,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/kongplugin"
 	gatewaycontroller "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
@@ -38,7 +39,7 @@ func ValidateHTTPRoute(
 	managerClient client.Client,
 ) (bool, string, error) {
 	// Check if route is managed by this controller. If not, we don't need to validate it.
-	routeIsManaged, err := ensureHTTPRouteIsManagedByController(ctx, httproute, managerClient)
+	routeIsManaged, err := ensureRouteIsManagedByController(ctx, httproute.Namespace, httproute.Spec.ParentRefs, managerClient)
 	if err != nil {
 		return false, "", fmt.Errorf("failed to determine whether HTTPRoute is managed by %q controller: %w",
 			gatewaycontroller.GetControllerName(), err)
@@ -51,10 +52,6 @@ func ValidateHTTPRoute(
 		return false, fmt.Sprintf("HTTPRoute has invalid KongPlugin annotation: %s", err), nil
 	}
 
-	if err := validateHTTPRouteTimeoutBackendRequest(httproute); err != nil {
-		return false, fmt.Sprintf("HTTPRoute spec did not pass validation: %s", err), nil
-	}
-
 	// Validate that no unsupported features are in use.
 	if err := validateHTTPRouteFeatures(httproute, translatorFeatures); err != nil {
 		return false, fmt.Sprintf("HTTPRoute spec did not pass validation: %s", err), nil
@@ -66,7 +63,7 @@ func ValidateHTTPRoute(
 	}
 
 	// Validate that the route is valid against Kong Gateway.
-	ok, msg := validateWithKongGateway(ctx, routesValidator, translatorFeatures, httproute)
+	ok, msg := validateHTTPRouteWithKongGateway(ctx, routesValidator, translatorFeatures, httproute)
 	return ok, msg, nil
 }
 
@@ -82,11 +79,14 @@ func parentRefIsGateway(parentRef gatewayapi.ParentReference) bool {
 		(parentRef.Kind == nil || (*parentRef.Kind == "" || *parentRef.Kind == KindGateway))
 }
 
-// ensureHTTPRouteIsManagedByController checks whether the provided HTTPRoute is managed by this controller implementation.
-func ensureHTTPRouteIsManagedByController(ctx context.Context, httproute *gatewayapi.HTTPRoute, managerClient client.Client) (bool, error) {
-	// In order to be sure whether an HTTPRoute resource is managed by this
+// ensureRouteIsManagedByController checks whether a route with the provided namespace and parentRefs is managed
+// by this controller implementation.
+func ensureRouteIsManagedByController(
+	ctx context.Context, routeNamespace string, parentRefs []gatewayapi.ParentReference, managerClient client.Client,
+) (bool, error) {
+	// In order to be sure whether a route resource is managed by this
 	// controller we ignore references to Gateway resources that do not exist.
-	for _, parentRef := range httproute.Spec.ParentRefs {
+	for _, parentRef := range parentRefs {
 		// Skip the parentRefs that are not Gateways because they cannot refer to the controller.
 		// https://github.com/Kong/kubernetes-ingress-controller/issues/5912
 		if !parentRefIsGateway(parentRef) {
@@ -95,7 +95,7 @@ func ensureHTTPRouteIsManagedByController(ctx context.Context, httproute *gatewa
 
 		// Determine the namespace of the gateway referenced via parentRef. If no
 		// explicit namespace is provided, assume the namespace of the route.
-		namespace := httproute.Namespace
+		namespace := routeNamespace
 		if parentRef.Namespace != nil {
 			namespace = string(*parentRef.Namespace)
 		}
@@ -128,7 +128,7 @@ func ensureHTTPRouteIsManagedByController(ctx context.Context, httproute *gatewa
 		}
 	}
 
-	// If we get here, the HTTPRoute is not managed by this controller.
+	// If we get here, the route is not managed by this controller.
 	return false, nil
 }
 
@@ -136,20 +136,25 @@ func ensureHTTPRouteIsManagedByController(ctx context.Context, httproute *gatewa
 // HTTPRoute implementation and validates that the provided object is not using
 // any of those unsupported features.
 func validateHTTPRouteFeatures(httproute *gatewayapi.HTTPRoute, translatorFeatures translator.FeatureFlags) error {
-	unsupportedFilterMap := map[gatewayapi.HTTPRouteFilterType]struct{}{
-		gatewayapi.HTTPRouteFilterRequestMirror: {},
-	}
 	const (
 		KindService = gatewayapi.Kind("Service")
 	)
 
 	for ruleIndex, rule := range httproute.Spec.Rules {
-		// Filter RequestMirror is not supported.
-
 		for filterIndex, filter := range rule.Filters {
-			if _, unsupported := unsupportedFilterMap[filter.Type]; unsupported {
-				return fmt.Errorf("rules[%d].filters[%d]: filter type %s is unsupported",
-					ruleIndex, filterIndex, filter.Type)
+			if filter.Type == gatewayapi.HTTPRouteFilterRequestMirror && !translatorFeatures.HTTPRouteRequestMirror {
+				return fmt.Errorf("rules[%d].filters[%d]: %w", ruleIndex, filterIndex, subtranslator.ErrRouteValidationRequestMirrorDisabled)
+			}
+			if filter.Type == gatewayapi.HTTPRouteFilterRequestMirror && !translatorFeatures.UntrustedLua {
+				return fmt.Errorf("rules[%d].filters[%d]: %w", ruleIndex, filterIndex, subtranslator.ErrRouteValidationRequestMirrorUntrustedLua)
+			}
+			// We don't support mirroring requests to anything but Kubernetes Services.
+			if filter.Type == gatewayapi.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil {
+				ref := filter.RequestMirror.BackendRef
+				if ref.Kind != nil && *ref.Kind != KindService {
+					return fmt.Errorf("rules[%d].filters[%d]: %s is not a supported kind for RequestMirror backendRef, only %s is supported",
+						ruleIndex, filterIndex, *ref.Kind, KindService)
+				}
 			}
 		}
 
@@ -188,12 +193,12 @@ func validateHTTPRouteFeatures(httproute *gatewayapi.HTTPRoute, translatorFeatur
 // Validation - HTTPRoute - Private Utility Functions
 // -----------------------------------------------------------------------------
 
-func validateWithKongGateway(
+func validateHTTPRouteWithKongGateway(
 	ctx context.Context, routesValidator routeValidator, translatorFeatures translator.FeatureFlags, httproute *gatewayapi.HTTPRoute,
 ) (bool, string) {
 	// Translate HTTPRoute to Kong Route object(s) that can be sent directly to the Admin API for validation.
 	// Use KIC translator that works both for traditional and expressions based routes.
-	var kongRoutes []kong.Route
+	var kongRoutes []kongstate.Route
 	var errMsgs []string
 	for _, rule := range httproute.Spec.Rules {
 		translation := subtranslator.KongRouteTranslation{
@@ -208,52 +213,35 @@ func validateWithKongGateway(
 			errMsgs = append(errMsgs, err.Error())
 			continue
 		}
-		for _, r := range routes {
-			kongRoutes = append(kongRoutes, r.Route)
-		}
+		kongRoutes = append(kongRoutes, routes...)
 	}
 	if len(errMsgs) > 0 {
-		return false, validationMsg(errMsgs)
+		return false, validationMsg("HTTPRoute", errMsgs)
 	}
-	// Validate by using feature of Kong Gateway.
-	for _, kg := range kongRoutes {
-		kg := kg
-		ok, msg, err := routesValidator.Validate(ctx, &kg)
+	return validateRoutesWithKongGateway(ctx, routesValidator, "HTTPRoute", kongRoutes)
+}
+
+// validateRoutesWithKongGateway validates Kong routes translated from a route of the given kind
+// against Kong Gateway.
+func validateRoutesWithKongGateway(
+	ctx context.Context, routesValidator routeValidator, kind string, kongRoutes []kongstate.Route,
+) (bool, string) {
+	var errMsgs []string
+	for _, kr := range kongRoutes {
+		ok, msg, err := routesValidator.Validate(ctx, &kr.Route)
 		if err != nil {
-			return false, fmt.Sprintf("Unable to validate HTTPRoute schema: %s", err.Error())
+			return false, fmt.Sprintf("Unable to validate %s schema: %s", kind, err.Error())
 		}
 		if !ok {
 			errMsgs = append(errMsgs, msg)
 		}
 	}
 	if len(errMsgs) > 0 {
-		return false, validationMsg(errMsgs)
+		return false, validationMsg(kind, errMsgs)
 	}
 	return true, ""
 }
 
-func validationMsg(errMsgs []string) string {
-	return fmt.Sprintf("HTTPRoute failed schema validation: %s", strings.Join(errMsgs, ", "))
-}
-
-func validateHTTPRouteTimeoutBackendRequest(httproute *gatewayapi.HTTPRoute) error {
-	// TODO: remove the validate after we figure out how to handle granular timeout settings
-	// (allowing setting timeouts per rule and not enforcing the same timeout for every HTTPRoute's rule).
-	// https://github.com/Kong/kubernetes-ingress-controller/issues/5451
-
-	var firstTimeoutFound *gatewayapi.Duration
-	for _, rule := range httproute.Spec.Rules {
-		if firstTimeoutFound != nil {
-			if rule.Timeouts == nil {
-				return fmt.Errorf("timeout is set for one of the rules, but not set for another")
-			}
-			if rule.Timeouts != nil && *rule.Timeouts.BackendRequest != *firstTimeoutFound {
-				return fmt.Errorf("timeout is set for one of the rules, but a different value is set in another rule")
-			}
-		} else if rule.Timeouts != nil && rule.Timeouts.BackendRequest != nil {
-			firstTimeoutFound = rule.Timeouts.BackendRequest
-		}
-	}
-
-	return nil
+func validationMsg(kind string, errMsgs []string) string {
+	return fmt.Sprintf("%s failed schema validation: %s", kind, strings.Join(errMsgs, ", "))
 }
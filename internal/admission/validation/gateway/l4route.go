@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/kongplugin"
+	gatewaycontroller "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+)
+
+// -----------------------------------------------------------------------------
+// Validation - TCPRoute, UDPRoute and TLSRoute - Public Functions
+// -----------------------------------------------------------------------------
+
+// ValidateTCPRoute provides a suite of validation for a given TCPRoute. It checks whether
+// the route is managed by this controller, linked objects, and uses provided routesValidator
+// to validate the route against Kong Gateway validation endpoint.
+func ValidateTCPRoute(
+	ctx context.Context,
+	routesValidator routeValidator,
+	translatorFeatures translator.FeatureFlags,
+	tcproute *gatewayapi.TCPRoute,
+	managerClient client.Client,
+) (bool, string, error) {
+	return validateL4Route(ctx, routesValidator, managerClient, l4Route{
+		kind:       "TCPRoute",
+		object:     tcproute,
+		parentRefs: tcproute.Spec.ParentRefs,
+		protocol:   gatewayapi.TCPProtocolType,
+		translate: func(gwPorts []gatewayapi.PortNumber) ([]kongstate.Route, error) {
+			return translator.GenerateKongRoutesFromTCPRoute(tcproute, gwPorts, translatorFeatures.ExpressionRoutes)
+		},
+	})
+}
+
+// ValidateUDPRoute provides a suite of validation for a given UDPRoute. It checks whether
+// the route is managed by this controller, linked objects, and uses provided routesValidator
+// to validate the route against Kong Gateway validation endpoint.
+func ValidateUDPRoute(
+	ctx context.Context,
+	routesValidator routeValidator,
+	translatorFeatures translator.FeatureFlags,
+	udproute *gatewayapi.UDPRoute,
+	managerClient client.Client,
+) (bool, string, error) {
+	return validateL4Route(ctx, routesValidator, managerClient, l4Route{
+		kind:       "UDPRoute",
+		object:     udproute,
+		parentRefs: udproute.Spec.ParentRefs,
+		protocol:   gatewayapi.UDPProtocolType,
+		translate: func(gwPorts []gatewayapi.PortNumber) ([]kongstate.Route, error) {
+			return translator.GenerateKongRoutesFromUDPRoute(udproute, gwPorts, translatorFeatures.ExpressionRoutes)
+		},
+	})
+}
+
+// ValidateTLSRoute provides a suite of validation for a given TLSRoute. It checks whether
+// the route is managed by this controller, linked objects, and uses provided routesValidator
+// to validate the route against Kong Gateway validation endpoint.
+func ValidateTLSRoute(
+	ctx context.Context,
+	routesValidator routeValidator,
+	translatorFeatures translator.FeatureFlags,
+	tlsroute *gatewayapi.TLSRoute,
+	managerClient client.Client,
+) (bool, string, error) {
+	return validateL4Route(ctx, routesValidator, managerClient, l4Route{
+		kind:       "TLSRoute",
+		object:     tlsroute,
+		parentRefs: tlsroute.Spec.ParentRefs,
+		protocol:   gatewayapi.TLSProtocolType,
+		translate: func(gwPorts []gatewayapi.PortNumber) ([]kongstate.Route, error) {
+			return translator.GenerateKongRoutesFromTLSRoute(tlsroute, gwPorts, translatorFeatures.ExpressionRoutes)
+		},
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Validation - TCPRoute, UDPRoute and TLSRoute - Private Functions
+// -----------------------------------------------------------------------------
+
+// l4Route describes a TCPRoute, UDPRoute or TLSRoute to validate.
+type l4Route struct {
+	kind       string
+	object     client.Object
+	parentRefs []gatewayapi.ParentReference
+	// protocol is the protocol of Gateway listeners the route can be attached to.
+	protocol gatewayapi.ProtocolType
+	// translate translates the route to Kong routes matching the provided Gateway listening ports.
+	translate func(gwPorts []gatewayapi.PortNumber) ([]kongstate.Route, error)
+}
+
+func validateL4Route(
+	ctx context.Context,
+	routesValidator routeValidator,
+	managerClient client.Client,
+	route l4Route,
+) (bool, string, error) {
+	// Check if route is managed by this controller. If not, we don't need to validate it.
+	routeIsManaged, err := ensureRouteIsManagedByController(ctx, route.object.GetNamespace(), route.parentRefs, managerClient)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to determine whether %s is managed by %q controller: %w",
+			route.kind, gatewaycontroller.GetControllerName(), err)
+	}
+	if !routeIsManaged {
+		return true, "", nil
+	}
+
+	if err := kongplugin.ValidatePluginUniquenessPerObject(ctx, managerClient, route.object); err != nil {
+		return false, fmt.Sprintf("%s has invalid KongPlugin annotation: %s", route.kind, err), nil
+	}
+
+	gwPorts, err := getGatewayListeningPorts(ctx, managerClient, route.object.GetNamespace(), route.protocol, route.parentRefs)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get listening ports of Gateways %s is attached to: %w", route.kind, err)
+	}
+
+	// Validate that the route is valid against Kong Gateway.
+	kongRoutes, err := route.translate(gwPorts)
+	if err != nil {
+		return false, validationMsg(route.kind, []string{err.Error()}), nil
+	}
+	ok, msg := validateRoutesWithKongGateway(ctx, routesValidator, route.kind, kongRoutes)
+	return ok, msg, nil
+}
+
+// getGatewayListeningPorts returns ports of listeners with the given protocol of Gateways referenced by parentRefs.
+// It mirrors the way the translator determines the ports, skipping Gateways that do not exist.
+func getGatewayListeningPorts(
+	ctx context.Context,
+	managerClient client.Client,
+	routeNamespace string,
+	protocol gatewayapi.ProtocolType,
+	parentRefs []gatewayapi.ParentReference,
+) ([]gatewayapi.PortNumber, error) {
+	var gwPorts []gatewayapi.PortNumber
+	for _, parentRef := range parentRefs {
+		if !parentRefIsGateway(parentRef) {
+			continue
+		}
+		namespace := routeNamespace
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+
+		gateway := gatewayapi.Gateway{}
+		if err := managerClient.Get(ctx, client.ObjectKey{
+			Namespace: namespace,
+			Name:      string(parentRef.Name),
+		}, &gateway); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Gateway: %w", err)
+		}
+
+		for _, listener := range gateway.Spec.Listeners {
+			if (parentRef.SectionName == nil || *parentRef.SectionName == listener.Name) &&
+				(parentRef.Port == nil || *parentRef.Port == listener.Port) &&
+				protocol == listener.Protocol {
+				gwPorts = append(gwPorts, listener.Port)
+			}
+		}
+	}
+	return gwPorts, nil
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gatewaycontroller "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+)
+
+func TestValidateL4Routes(t *testing.T) {
+	gatewayClass := &gatewayapi.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kong",
+		},
+		Spec: gatewayapi.GatewayClassSpec{
+			ControllerName: gatewaycontroller.GetControllerName(),
+		},
+	}
+	gateway := &gatewayapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "gateway",
+		},
+		Spec: gatewayapi.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayapi.Listener{
+				{
+					Name:     "tcp",
+					Port:     9000,
+					Protocol: gatewayapi.TCPProtocolType,
+				},
+				{
+					Name:     "udp",
+					Port:     9001,
+					Protocol: gatewayapi.UDPProtocolType,
+				},
+				{
+					Name:     "tls",
+					Port:     9443,
+					Protocol: gatewayapi.TLSProtocolType,
+				},
+			},
+		},
+	}
+	parentRefs := []gatewayapi.ParentReference{{Name: "gateway"}}
+	backendRefs := []gatewayapi.BackendRef{{
+		BackendObjectReference: gatewayapi.BackendObjectReference{
+			Name: "svc",
+			Port: lo.ToPtr(gatewayapi.PortNumber(80)),
+		},
+	}}
+	routeMeta := metav1.ObjectMeta{
+		Namespace: corev1.NamespaceDefault,
+		Name:      "route",
+	}
+
+	testCases := []struct {
+		name             string
+		validate         func(context.Context, routeValidator, client.Client) (bool, string, error)
+		cachedObjects    []client.Object
+		wantValid        bool
+		wantMsg          string
+		wantDestinations []int
+	}{
+		{
+			name: "TCPRoute is translated with ports of TCP listeners",
+			validate: func(ctx context.Context, rv routeValidator, c client.Client) (bool, string, error) {
+				return ValidateTCPRoute(ctx, rv, translator.FeatureFlags{}, &gatewayapi.TCPRoute{
+					ObjectMeta: routeMeta,
+					Spec: gatewayapi.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi.CommonRouteSpec{ParentRefs: parentRefs},
+						Rules:           []gatewayapi.TCPRouteRule{{BackendRefs: backendRefs}},
+					},
+				}, c)
+			},
+			cachedObjects:    []client.Object{gatewayClass, gateway},
+			wantValid:        true,
+			wantDestinations: []int{9000},
+		},
+		{
+			name: "UDPRoute is translated with ports of UDP listeners",
+			validate: func(ctx context.Context, rv routeValidator, c client.Client) (bool, string, error) {
+				return ValidateUDPRoute(ctx, rv, translator.FeatureFlags{}, &gatewayapi.UDPRoute{
+					ObjectMeta: routeMeta,
+					Spec: gatewayapi.UDPRouteSpec{
+						CommonRouteSpec: gatewayapi.CommonRouteSpec{ParentRefs: parentRefs},
+						Rules:           []gatewayapi.UDPRouteRule{{BackendRefs: backendRefs}},
+					},
+				}, c)
+			},
+			cachedObjects:    []client.Object{gatewayClass, gateway},
+			wantValid:        true,
+			wantDestinations: []int{9001},
+		},
+		{
+			name: "TCPRoute without backendRefs is rejected",
+			validate: func(ctx context.Context, rv routeValidator, c client.Client) (bool, string, error) {
+				return ValidateTCPRoute(ctx, rv, translator.FeatureFlags{}, &gatewayapi.TCPRoute{
+					ObjectMeta: routeMeta,
+					Spec: gatewayapi.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi.CommonRouteSpec{ParentRefs: parentRefs},
+						Rules:           []gatewayapi.TCPRouteRule{{}},
+					},
+				}, c)
+			},
+			cachedObjects: []client.Object{gatewayClass, gateway},
+			wantValid:     false,
+			wantMsg:       "TCPRoute failed schema validation: TCPRoute rules must include at least one backendRef",
+		},
+		{
+			name: "TLSRoute without hostnames is rejected",
+			validate: func(ctx context.Context, rv routeValidator, c client.Client) (bool, string, error) {
+				return ValidateTLSRoute(ctx, rv, translator.FeatureFlags{}, &gatewayapi.TLSRoute{
+					ObjectMeta: routeMeta,
+					Spec: gatewayapi.TLSRouteSpec{
+						CommonRouteSpec: gatewayapi.CommonRouteSpec{ParentRefs: parentRefs},
+						Rules:           []gatewayapi.TLSRouteRule{{BackendRefs: backendRefs}},
+					},
+				}, c)
+			},
+			cachedObjects: []client.Object{gatewayClass, gateway},
+			wantValid:     false,
+			wantMsg:       "TLSRoute failed schema validation: no hostnames provided",
+		},
+		{
+			name: "route attached to a Gateway that does not exist is not validated",
+			validate: func(ctx context.Context, rv routeValidator, c client.Client) (bool, string, error) {
+				return ValidateTCPRoute(ctx, rv, translator.FeatureFlags{}, &gatewayapi.TCPRoute{
+					ObjectMeta: routeMeta,
+					Spec: gatewayapi.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi.CommonRouteSpec{ParentRefs: parentRefs},
+						Rules:           []gatewayapi.TCPRouteRule{{}},
+					},
+				}, c)
+			},
+			cachedObjects: []client.Object{gatewayClass},
+			wantValid:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakeclient.
+				NewClientBuilder().
+				WithScheme(lo.Must(scheme.Get())).
+				WithObjects(tc.cachedObjects...).
+				Build()
+
+			rv := &capturingRoutesValidator{}
+			valid, msg, err := tc.validate(context.Background(), rv, fakeClient)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantValid, valid)
+			assert.Equal(t, tc.wantMsg, msg)
+			if tc.wantDestinations != nil {
+				require.Len(t, rv.routes, 1)
+				assert.Equal(t, tc.wantDestinations, lo.Map(rv.routes[0].Destinations, func(d *kong.CIDRPort, _ int) int {
+					return *d.Port
+				}))
+			}
+		})
+	}
+}
+
+type capturingRoutesValidator struct {
+	routes []kong.Route
+}
+
+func (v *capturingRoutesValidator) Validate(_ context.Context, route *kong.Route) (bool, string, error) {
+	v.routes = append(v.routes, *route)
+	return true, "", nil
+}
@@ -0,0 +1,68 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/kongplugin"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+)
+
+// ValidateTCPIngress validates Kong routes translated from the TCPIngress against Kong Gateway.
+func ValidateTCPIngress(
+	ctx context.Context,
+	routesValidator routeValidator,
+	translatorFeatures translator.FeatureFlags,
+	ingress *kongv1beta1.TCPIngress,
+	managerClient client.Client,
+) (bool, string, error) {
+	if err := kongplugin.ValidatePluginUniquenessPerObject(ctx, managerClient, ingress); err != nil {
+		return false, fmt.Sprintf("TCPIngress has invalid KongPlugin annotation: %s", err), nil
+	}
+
+	kongRoutes := translator.GenerateKongRoutesFromTCPIngress(ingress, translatorFeatures.ExpressionRoutes)
+	ok, msg := validateL4RoutesWithKongGateway(ctx, routesValidator, "TCPIngress", kongRoutes)
+	return ok, msg, nil
+}
+
+// ValidateUDPIngress validates Kong routes translated from the UDPIngress against Kong Gateway.
+func ValidateUDPIngress(
+	ctx context.Context,
+	routesValidator routeValidator,
+	translatorFeatures translator.FeatureFlags,
+	ingress *kongv1beta1.UDPIngress,
+	managerClient client.Client,
+) (bool, string, error) {
+	if err := kongplugin.ValidatePluginUniquenessPerObject(ctx, managerClient, ingress); err != nil {
+		return false, fmt.Sprintf("UDPIngress has invalid KongPlugin annotation: %s", err), nil
+	}
+
+	kongRoutes := translator.GenerateKongRoutesFromUDPIngress(ingress, translatorFeatures.ExpressionRoutes)
+	ok, msg := validateL4RoutesWithKongGateway(ctx, routesValidator, "UDPIngress", kongRoutes)
+	return ok, msg, nil
+}
+
+func validateL4RoutesWithKongGateway(
+	ctx context.Context, routesValidator routeValidator, kind string, kongRoutes []kongstate.Route,
+) (bool, string) {
+	var errMsgs []string
+	for _, kr := range kongRoutes {
+		// Validate by using feature of Kong Gateway.
+		ok, msg, err := routesValidator.Validate(ctx, &kr.Route)
+		if err != nil {
+			return false, fmt.Sprintf("Unable to validate %s schema: %s", kind, err.Error())
+		}
+		if !ok {
+			errMsgs = append(errMsgs, msg)
+		}
+	}
+	if len(errMsgs) > 0 {
+		return false, fmt.Sprintf("%s failed schema validation: %s", kind, strings.Join(errMsgs, ", "))
+	}
+	return true, ""
+}
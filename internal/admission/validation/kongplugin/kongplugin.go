@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/samber/lo"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
@@ -57,3 +58,48 @@ func ValidatePluginUniquenessPerObject(ctx context.Context, objectsLister cluste
 	}
 	return nil
 }
+
+// clusterObjectsGetter is an interface controller-runtime client.Client.
+type clusterObjectsGetter interface {
+	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+}
+
+// FindMissingReferencedPlugins returns references from the konghq.com/plugins annotation of the object to plugins
+// that don't exist. A reference without a namespace can be satisfied either by a KongPlugin in the object's namespace
+// or by a KongClusterPlugin. References are returned in the "namespace:name" form if they have a namespace.
+func FindMissingReferencedPlugins(ctx context.Context, objectsGetter clusterObjectsGetter, obj k8sObjectWithAnnotations) ([]string, error) {
+	var missing []string
+	for _, plugin := range annotations.ExtractNamespacedKongPluginsFromAnnotations(obj.GetAnnotations()) {
+		namespace, reference := obj.GetNamespace(), plugin.Name
+		if plugin.Namespace != "" {
+			namespace, reference = plugin.Namespace, plugin.Namespace+":"+plugin.Name
+		}
+
+		exists, err := objectExists(ctx, objectsGetter, client.ObjectKey{Namespace: namespace, Name: plugin.Name}, &kongv1.KongPlugin{}, "KongPlugin")
+		if err != nil {
+			return nil, err
+		}
+		if !exists && plugin.Namespace == "" {
+			exists, err = objectExists(ctx, objectsGetter, client.ObjectKey{Name: plugin.Name}, &kongv1.KongClusterPlugin{}, "KongClusterPlugin")
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !exists {
+			missing = append(missing, reference)
+		}
+	}
+	return missing, nil
+}
+
+func objectExists(
+	ctx context.Context, objectsGetter clusterObjectsGetter, key client.ObjectKey, obj client.Object, kind string,
+) (bool, error) {
+	if err := objectsGetter.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s %s: %w", kind, key, err)
+	}
+	return true, nil
+}
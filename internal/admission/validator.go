@@ -29,6 +29,7 @@ import (
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
 )
 
 // KongValidator validates Kong entities.
@@ -42,8 +43,16 @@ type KongValidator interface {
 	ValidateCredential(ctx context.Context, secret corev1.Secret) (bool, string)
 	ValidateGateway(ctx context.Context, gateway gatewayapi.Gateway) (bool, string, error)
 	ValidateHTTPRoute(ctx context.Context, httproute gatewayapi.HTTPRoute) (bool, string, error)
+	ValidateGRPCRoute(ctx context.Context, grpcroute gatewayapi.GRPCRoute) (bool, string, error)
+	ValidateTCPRoute(ctx context.Context, tcproute gatewayapi.TCPRoute) (bool, string, error)
+	ValidateUDPRoute(ctx context.Context, udproute gatewayapi.UDPRoute) (bool, string, error)
+	ValidateTLSRoute(ctx context.Context, tlsroute gatewayapi.TLSRoute) (bool, string, error)
 	ValidateIngress(ctx context.Context, ingress netv1.Ingress) (bool, string, error)
+	ValidateTCPIngress(ctx context.Context, ingress kongv1beta1.TCPIngress) (bool, string, error)
+	ValidateUDPIngress(ctx context.Context, ingress kongv1beta1.UDPIngress) (bool, string, error)
 	ValidateService(ctx context.Context, ingress corev1.Service) (bool, string, error)
+	ValidateUpstreamPolicy(ctx context.Context, policy kongv1beta1.KongUpstreamPolicy) (bool, string, error)
+	ValidateServiceFacade(ctx context.Context, facade incubatorv1alpha1.KongServiceFacade) (bool, string, error)
 }
 
 // AdminAPIServicesProvider provides KongHTTPValidator with Kong Admin API services that are needed to perform
@@ -110,6 +119,9 @@ type KongHTTPValidator struct {
 	ManagerClient            client.Client
 	AdminAPIServicesProvider AdminAPIServicesProvider
 	TranslatorFeatures       translator.FeatureFlags
+	// CredentialTypes holds the credential types accepted in credential Secrets. When nil, the types
+	// supported by the controller out of the box are accepted.
+	CredentialTypes *credsvalidation.Registry
 
 	ingressClassMatcher   func(*metav1.ObjectMeta, string, annotations.ClassMatching) bool
 	ingressV1ClassMatcher func(*netv1.Ingress, annotations.ClassMatching) bool
@@ -125,6 +137,7 @@ func NewKongHTTPValidator(
 	ingressClass string,
 	servicesProvider AdminAPIServicesProvider,
 	translatorFeatures translator.FeatureFlags,
+	credentialTypes *credsvalidation.Registry,
 	storer store.Storer,
 ) KongHTTPValidator {
 	return KongHTTPValidator{
@@ -135,6 +148,7 @@ func NewKongHTTPValidator(
 		ManagerClient:            managerClient,
 		AdminAPIServicesProvider: servicesProvider,
 		TranslatorFeatures:       translatorFeatures,
+		CredentialTypes:          credentialTypes,
 
 		ingressClassMatcher:   annotations.IngressClassValidatorFuncFromObjectMeta(ingressClass),
 		ingressV1ClassMatcher: annotations.IngressClassValidatorFuncFromV1Ingress(ingressClass),
@@ -190,7 +204,7 @@ func (validator KongHTTPValidator) ValidateConsumer(
 		}
 
 		// do the basic credentials validation
-		if err := credsvalidation.ValidateCredentials(secret); err != nil {
+		if err := credsvalidation.ValidateCredentials(ctx, secret, validator.credentialTypes(), validator.credentialsSchemaGetter()); err != nil {
 			return false, fmt.Sprintf("%s: %s", ErrTextConsumerCredentialValidationFailed, err), nil
 		}
 
@@ -292,7 +306,7 @@ func (validator KongHTTPValidator) ValidateCredential(ctx context.Context, secre
 	}
 
 	// If we know it's a credentials secret, we can ensure its base-level validity.
-	if err := credsvalidation.ValidateCredentials(&secret); err != nil {
+	if err := credsvalidation.ValidateCredentials(ctx, &secret, validator.credentialTypes(), validator.credentialsSchemaGetter()); err != nil {
 		return false, fmt.Sprintf("%s: %s", ErrTextConsumerCredentialValidationFailed, err)
 	}
 
@@ -451,12 +465,40 @@ func (validator KongHTTPValidator) ValidateGateway(
 func (validator KongHTTPValidator) ValidateHTTPRoute(
 	ctx context.Context, httproute gatewayapi.HTTPRoute,
 ) (bool, string, error) {
-	var routeValidator routeValidator = noOpRoutesValidator{}
-	if routesSvc, ok := validator.AdminAPIServicesProvider.GetRoutesService(); ok {
-		routeValidator = routesSvc
-	}
 	return gatewayvalidation.ValidateHTTPRoute(
-		ctx, routeValidator, validator.TranslatorFeatures, &httproute, validator.ManagerClient,
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &httproute, validator.ManagerClient,
+	)
+}
+
+func (validator KongHTTPValidator) ValidateGRPCRoute(
+	ctx context.Context, grpcroute gatewayapi.GRPCRoute,
+) (bool, string, error) {
+	return gatewayvalidation.ValidateGRPCRoute(
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &grpcroute, validator.ManagerClient, validator.Storer,
+	)
+}
+
+func (validator KongHTTPValidator) ValidateTCPRoute(
+	ctx context.Context, tcproute gatewayapi.TCPRoute,
+) (bool, string, error) {
+	return gatewayvalidation.ValidateTCPRoute(
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &tcproute, validator.ManagerClient,
+	)
+}
+
+func (validator KongHTTPValidator) ValidateUDPRoute(
+	ctx context.Context, udproute gatewayapi.UDPRoute,
+) (bool, string, error) {
+	return gatewayvalidation.ValidateUDPRoute(
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &udproute, validator.ManagerClient,
+	)
+}
+
+func (validator KongHTTPValidator) ValidateTLSRoute(
+	ctx context.Context, tlsroute gatewayapi.TLSRoute,
+) (bool, string, error) {
+	return gatewayvalidation.ValidateTLSRoute(
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &tlsroute, validator.ManagerClient,
 	)
 }
 
@@ -469,11 +511,7 @@ func (validator KongHTTPValidator) ValidateIngress(
 		return true, "", nil
 	}
 
-	var routeValidator routeValidator = noOpRoutesValidator{}
-	if routesSvc, ok := validator.AdminAPIServicesProvider.GetRoutesService(); ok {
-		routeValidator = routesSvc
-	}
-	return ingressvalidation.ValidateIngress(ctx, routeValidator, validator.TranslatorFeatures, &ingress, validator.Logger, validator.Storer, validator.ManagerClient)
+	return ingressvalidation.ValidateIngress(ctx, validator.routeValidator(), validator.TranslatorFeatures, &ingress, validator.Logger, validator.Storer, validator.ManagerClient)
 }
 
 func (validator KongHTTPValidator) ValidateService(
@@ -485,10 +523,106 @@ func (validator KongHTTPValidator) ValidateService(
 	return true, "", nil
 }
 
+func (validator KongHTTPValidator) ValidateTCPIngress(
+	ctx context.Context, ingress kongv1beta1.TCPIngress,
+) (bool, string, error) {
+	// Ignore TCPIngresses that are being managed by another controller.
+	if !validator.ingressClassMatcher(&ingress.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+		return true, "", nil
+	}
+	return ingressvalidation.ValidateTCPIngress(
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &ingress, validator.ManagerClient,
+	)
+}
+
+func (validator KongHTTPValidator) ValidateUDPIngress(
+	ctx context.Context, ingress kongv1beta1.UDPIngress,
+) (bool, string, error) {
+	// Ignore UDPIngresses that are being managed by another controller.
+	if !validator.ingressClassMatcher(&ingress.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+		return true, "", nil
+	}
+	return ingressvalidation.ValidateUDPIngress(
+		ctx, validator.routeValidator(), validator.TranslatorFeatures, &ingress, validator.ManagerClient,
+	)
+}
+
+// ValidateUpstreamPolicy translates the KongUpstreamPolicy into a Kong Upstream the same way it is done
+// for Services referring to it and validates the Upstream against Kong Gateway.
+func (validator KongHTTPValidator) ValidateUpstreamPolicy(
+	ctx context.Context, policy kongv1beta1.KongUpstreamPolicy,
+) (bool, string, error) {
+	upstream := kongstate.TranslateKongUpstreamPolicy(policy.Spec)
+	// The name is irrelevant for validation, it's required by the schema though.
+	upstream.Name = kong.String(validationEntityName)
+
+	errText, err := validator.validateEntityAgainstGatewaySchema(
+		ctx, kong.EntityTypeUpstreams, upstream, ErrTextUpstreamPolicyUnableToValidate, ErrTextUpstreamPolicyViolatesSchema,
+	)
+	if err != nil || errText != "" {
+		return false, errText, err
+	}
+	return true, "", nil
+}
+
+// ValidateServiceFacade translates the KongServiceFacade into a Kong Service the same way it is done
+// for Ingresses referring to it and validates the Service against Kong Gateway.
+func (validator KongHTTPValidator) ValidateServiceFacade(
+	ctx context.Context, facade incubatorv1alpha1.KongServiceFacade,
+) (bool, string, error) {
+	// KongServiceFacades are not translated unless the feature is enabled.
+	if !validator.TranslatorFeatures.KongServiceFacade {
+		return true, "", nil
+	}
+	// Ignore KongServiceFacades that are being managed by another controller.
+	if !validator.ingressClassMatcher(&facade.ObjectMeta, annotations.IngressClassKey, annotations.ExactClassMatch) {
+		return true, "", nil
+	}
+	if err := kongplugin.ValidatePluginUniquenessPerObject(ctx, validator.ManagerClient, &facade); err != nil {
+		return false, fmt.Sprintf("KongServiceFacade has invalid KongPlugin annotation: %s", err), nil
+	}
+
+	service := kongstate.Service{
+		Service: kong.Service{
+			Name:           kong.String(validationEntityName),
+			Host:           kong.String(fmt.Sprintf("%s.%s.svc.facade", facade.Namespace, facade.Name)),
+			Port:           kong.Int(translator.DefaultHTTPPort),
+			Protocol:       kong.String("http"),
+			Path:           kong.String("/"),
+			ConnectTimeout: kong.Int(translator.DefaultServiceTimeout),
+			ReadTimeout:    kong.Int(translator.DefaultServiceTimeout),
+			WriteTimeout:   kong.Int(translator.DefaultServiceTimeout),
+			Retries:        kong.Int(translator.DefaultRetries),
+		},
+	}
+	if err := service.OverrideByAnnotations(facade.Annotations); err != nil {
+		return false, fmt.Sprintf("KongServiceFacade has invalid Kong annotations: %s", err), nil
+	}
+
+	errText, err := validator.validateEntityAgainstGatewaySchema(
+		ctx, kong.EntityTypeServices, &service.Service, ErrTextServiceFacadeUnableToValidate, ErrTextServiceFacadeViolatesSchema,
+	)
+	if err != nil || errText != "" {
+		return false, errText, err
+	}
+	return true, "", nil
+}
+
+// validationEntityName is the name given to Kong entities that are translated only to be validated.
+const validationEntityName = "validation-attempt"
+
 type routeValidator interface {
 	Validate(context.Context, *kong.Route) (bool, string, error)
 }
 
+// routeValidator returns the Kong routes service if available, otherwise a validator accepting all routes.
+func (validator KongHTTPValidator) routeValidator() routeValidator {
+	if routesSvc, ok := validator.AdminAPIServicesProvider.GetRoutesService(); ok {
+		return routesSvc
+	}
+	return noOpRoutesValidator{}
+}
+
 type noOpRoutesValidator struct{}
 
 func (noOpRoutesValidator) Validate(_ context.Context, _ *kong.Route) (bool, string, error) {
@@ -607,6 +741,43 @@ func (validator KongHTTPValidator) validateVaultAgainstGatewaySchema(ctx context
 	return "", nil
 }
 
+// credentialTypes returns the registry of credential types accepted in credential Secrets.
+func (validator KongHTTPValidator) credentialTypes() *credsvalidation.Registry {
+	if validator.CredentialTypes == nil {
+		return credsvalidation.NewDefaultRegistry()
+	}
+	return validator.CredentialTypes
+}
+
+// credentialsSchemaGetter returns the schema service used to validate credentials of schema-based types
+// or nil if there's no Kong Gateway to fetch the schemas from.
+func (validator KongHTTPValidator) credentialsSchemaGetter() credsvalidation.SchemaGetter {
+	schemaService, hasClient := validator.AdminAPIServicesProvider.GetSchemasService()
+	if !hasClient {
+		return nil
+	}
+	return schemaService
+}
+
+// validateEntityAgainstGatewaySchema validates the entity using the schema validation endpoint of Kong Gateway.
+// It returns a message formatted with violatesSchemaFmt if the entity is invalid.
+func (validator KongHTTPValidator) validateEntityAgainstGatewaySchema(
+	ctx context.Context, entityType kong.EntityType, entity any, unableToValidateText, violatesSchemaFmt string,
+) (string, error) {
+	schemaService, hasClient := validator.AdminAPIServicesProvider.GetSchemasService()
+	if !hasClient {
+		return "", nil
+	}
+	isValid, msg, err := schemaService.Validate(ctx, entityType, entity)
+	if err != nil {
+		return unableToValidateText, err
+	}
+	if !isValid {
+		return fmt.Sprintf(violatesSchemaFmt, msg), nil
+	}
+	return "", nil
+}
+
 type managerClientSecretGetter struct {
 	managerClient client.Client
 }
@@ -1579,3 +1579,183 @@ func (s fakeSchemaSvc) Validate(_ context.Context, _ kong.EntityType, _ interfac
 	}
 	return true, "", nil
 }
+
+func TestValidator_ValidateTCPIngress(t *testing.T) {
+	testCases := []struct {
+		name                          string
+		ingressClassMatches           bool
+		kongRouteValidationShouldFail bool
+		wantOK                        bool
+		wantMessage                   string
+	}{
+		{
+			name:                "valid",
+			ingressClassMatches: true,
+			wantOK:              true,
+		},
+		{
+			name:                          "route rejected by Kong",
+			ingressClassMatches:           true,
+			kongRouteValidationShouldFail: true,
+			wantOK:                        false,
+			wantMessage:                   "TCPIngress failed schema validation: something is wrong with the route",
+		},
+		{
+			name:                          "not matching ingress class is always ok",
+			kongRouteValidationShouldFail: true,
+			wantOK:                        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := KongHTTPValidator{
+				ManagerClient: fake.NewClientBuilder().WithScheme(lo.Must(managerscheme.Get())).Build(),
+				AdminAPIServicesProvider: fakeServicesProvider{
+					routeSvc: &fakeRouteSvc{
+						shouldFail: tc.kongRouteValidationShouldFail,
+					},
+				},
+				ingressClassMatcher: func(*metav1.ObjectMeta, string, annotations.ClassMatching) bool {
+					return tc.ingressClassMatches
+				},
+				Logger: logr.Discard(),
+			}
+			ok, msg, err := validator.ValidateTCPIngress(context.Background(), kongv1beta1.TCPIngress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tcpingress",
+					Namespace: "default",
+				},
+				Spec: kongv1beta1.TCPIngressSpec{
+					Rules: []kongv1beta1.IngressRule{
+						{
+							Port: 9000,
+							Backend: kongv1beta1.IngressBackend{
+								ServiceName: "svc",
+								ServicePort: 80,
+							},
+						},
+					},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantMessage, msg)
+		})
+	}
+}
+
+func TestValidator_ValidateUpstreamPolicy(t *testing.T) {
+	testCases := []struct {
+		name            string
+		validateSvcFail bool
+		expectedOK      bool
+		expectedMessage string
+	}{
+		{
+			name:       "valid",
+			expectedOK: true,
+		},
+		{
+			name:            "rejected by Kong",
+			validateSvcFail: true,
+			expectedOK:      false,
+			expectedMessage: "KongUpstreamPolicy failed schema validation: something is wrong in the entity",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := KongHTTPValidator{
+				AdminAPIServicesProvider: fakeServicesProvider{
+					schemaSvc: fakeSchemaSvc{
+						shouldFail: tc.validateSvcFail,
+					},
+				},
+			}
+			ok, msg, err := validator.ValidateUpstreamPolicy(context.Background(), kongv1beta1.KongUpstreamPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "policy",
+					Namespace: "default",
+				},
+				Spec: kongv1beta1.KongUpstreamPolicySpec{
+					Algorithm: lo.ToPtr("consistent-hashing"),
+					HashOn: &kongv1beta1.KongUpstreamHash{
+						Header: lo.ToPtr("x-user"),
+					},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedMessage, msg)
+		})
+	}
+}
+
+func TestValidator_ValidateServiceFacade(t *testing.T) {
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		translatorFeatures translator.FeatureFlags
+		validateSvcFail    bool
+		expectedOK         bool
+		expectedMessage    string
+	}{
+		{
+			name:               "valid",
+			annotations:        map[string]string{annotations.AnnotationPrefix + annotations.ProtocolKey: "https"},
+			translatorFeatures: translator.FeatureFlags{KongServiceFacade: true},
+			expectedOK:         true,
+		},
+		{
+			name:               "invalid protocol annotation",
+			annotations:        map[string]string{annotations.AnnotationPrefix + annotations.ProtocolKey: "ohno"},
+			translatorFeatures: translator.FeatureFlags{KongServiceFacade: true},
+			expectedOK:         false,
+			expectedMessage:    "KongServiceFacade has invalid Kong annotations: konghq.com/protocol annotation has invalid value: ohno",
+		},
+		{
+			name:               "rejected by Kong",
+			translatorFeatures: translator.FeatureFlags{KongServiceFacade: true},
+			validateSvcFail:    true,
+			expectedOK:         false,
+			expectedMessage:    "KongServiceFacade failed schema validation: something is wrong in the entity",
+		},
+		{
+			name:            "not validated when the feature is disabled",
+			validateSvcFail: true,
+			expectedOK:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := KongHTTPValidator{
+				ManagerClient: fake.NewClientBuilder().WithScheme(lo.Must(managerscheme.Get())).Build(),
+				AdminAPIServicesProvider: fakeServicesProvider{
+					schemaSvc: fakeSchemaSvc{
+						shouldFail: tc.validateSvcFail,
+					},
+				},
+				TranslatorFeatures:  tc.translatorFeatures,
+				ingressClassMatcher: fakeClassMatcher,
+			}
+			ok, msg, err := validator.ValidateServiceFacade(context.Background(), incubatorv1alpha1.KongServiceFacade{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "facade",
+					Namespace:   "default",
+					Annotations: tc.annotations,
+				},
+				Spec: incubatorv1alpha1.KongServiceFacadeSpec{
+					Backend: incubatorv1alpha1.KongServiceFacadeBackend{
+						Name: "svc",
+						Port: 80,
+					},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedMessage, msg)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/kongplugin"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/featuregates"
+	managerscheme "github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
+)
+
+// WarningsProvider returns warnings to include in admission responses. Warnings don't affect whether an object
+// is allowed, they inform users about parts of its configuration that are deprecated or have no effect.
+type WarningsProvider interface {
+	Warnings(ctx context.Context, request admissionv1.AdmissionRequest) ([]string, error)
+}
+
+const (
+	ignoredAnnotationWarning          = "%s annotation has no effect on %s, it's only supported on %s."
+	expressionRouterAnnotationWarning = "%s annotation has no effect with the expressions router."
+	overrideAnnotationWarning         = "%s annotation has no effect on %s. KongIngress is deprecated and only applied " +
+		"to Services, use annotations and a KongUpstreamPolicy resource instead."
+	overrideAndUpstreamPolicyWarning = "Service uses both %s and %s annotations, settings from %s take precedence. " +
+		"Remove the deprecated %s annotation."
+	missingPluginWarning       = "Plugin %q referenced by %s annotation does not exist."
+	featureGateDisabledWarning = "%s requires the %s feature gate to be enabled, it has no effect until then."
+)
+
+const (
+	servicesDescription       = "Services"
+	routeSourcesDescription   = "Ingresses, TCPIngresses, UDPIngresses and Gateway API routes"
+	ingressesDescription      = "Ingresses"
+	pluginsAnnotationFullName = annotations.AnnotationPrefix + annotations.PluginsKey
+)
+
+var (
+	// serviceAnnotations are annotations configuring Kong services and upstreams, read only from Kubernetes Services.
+	serviceAnnotations = []string{
+		annotations.AnnotationPrefix + annotations.PathKey,
+		annotations.AnnotationPrefix + annotations.ProtocolKey,
+		annotations.AnnotationPrefix + annotations.ConnectTimeoutKey,
+		annotations.AnnotationPrefix + annotations.WriteTimeoutKey,
+		annotations.AnnotationPrefix + annotations.ReadTimeoutKey,
+		annotations.AnnotationPrefix + annotations.RetriesKey,
+		annotations.AnnotationPrefix + annotations.HostHeaderKey,
+		annotations.AnnotationPrefix + annotations.ClientCertKey,
+		kongv1beta1.KongUpstreamPolicyAnnotationKey,
+	}
+	// routeAnnotations are annotations configuring Kong routes, read only from objects translated to routes.
+	routeAnnotations = []string{
+		annotations.AnnotationPrefix + annotations.StripPathKey,
+		annotations.AnnotationPrefix + annotations.HTTPSRedirectCodeKey,
+		annotations.AnnotationPrefix + annotations.PreserveHostKey,
+		annotations.AnnotationPrefix + annotations.RequestBuffering,
+		annotations.AnnotationPrefix + annotations.ResponseBuffering,
+		annotations.AnnotationPrefix + annotations.ProtocolsKey,
+		annotations.AnnotationPrefix + annotations.MethodsKey,
+		annotations.AnnotationPrefix + annotations.SNIsKey,
+		annotations.AnnotationPrefix + annotations.HostAliasesKey,
+	}
+	// traditionalRouterAnnotations are route annotations ignored when the expressions router is used.
+	traditionalRouterAnnotations = []string{
+		annotations.AnnotationPrefix + annotations.RegexPriorityKey,
+		annotations.AnnotationPrefix + annotations.PathHandlingKey,
+	}
+	// ingressAnnotations are annotations read only from Ingresses.
+	ingressAnnotations = []string{
+		annotations.AnnotationPrefix + annotations.CanaryKey,
+		annotations.AnnotationPrefix + annotations.CanaryWeightKey,
+		annotations.AnnotationPrefix + annotations.CanaryByHeaderKey,
+		annotations.AnnotationPrefix + annotations.CanaryByHeaderValueKey,
+		annotations.AnnotationPrefix + annotations.CanaryByCookieKey,
+	}
+	headersAnnotationPrefix = annotations.AnnotationPrefix + annotations.HeadersKey + "."
+)
+
+// KongWarningsProvider implements WarningsProvider. It warns about:
+// - deprecated annotations,
+// - annotations that the object's kind ignores,
+// - plugins referenced by the konghq.com/plugins annotation that don't exist,
+// - features used while their feature gate is disabled.
+type KongWarningsProvider struct {
+	managerClient      client.Client
+	translatorFeatures translator.FeatureFlags
+	decoder            runtime.Decoder
+}
+
+// NewKongWarningsProvider creates a KongWarningsProvider.
+func NewKongWarningsProvider(managerClient client.Client, translatorFeatures translator.FeatureFlags) (*KongWarningsProvider, error) {
+	s, err := managerscheme.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheme: %w", err)
+	}
+	return &KongWarningsProvider{
+		managerClient:      managerClient,
+		translatorFeatures: translatorFeatures,
+		decoder:            serializer.NewCodecFactory(s).UniversalDeserializer(),
+	}, nil
+}
+
+// Warnings returns warnings about the object from the admission request.
+func (p *KongWarningsProvider) Warnings(ctx context.Context, request admissionv1.AdmissionRequest) ([]string, error) {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return nil, nil
+	}
+
+	decoded, _, err := p.decoder.Decode(request.Object.Raw, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", request.Kind.Kind, err)
+	}
+	obj, ok := decoded.(client.Object)
+	if !ok {
+		return nil, nil
+	}
+	kind := request.Kind.Kind
+
+	var warnings []string
+	switch obj.(type) {
+	case *corev1.Service:
+		warnings = append(warnings, serviceAnnotationsWarnings(obj)...)
+	case *netv1.Ingress, *kongv1beta1.TCPIngress, *kongv1beta1.UDPIngress,
+		*gatewayapi.HTTPRoute, *gatewayapi.GRPCRoute, *gatewayapi.TCPRoute, *gatewayapi.UDPRoute, *gatewayapi.TLSRoute:
+		warnings = append(warnings, p.routeSourceAnnotationsWarnings(kind, obj)...)
+	}
+	warnings = append(warnings, p.featureGatesWarnings(obj)...)
+
+	missingPlugins, err := kongplugin.FindMissingReferencedPlugins(ctx, p.managerClient, obj)
+	if err != nil {
+		return warnings, err
+	}
+	for _, plugin := range missingPlugins {
+		warnings = append(warnings, fmt.Sprintf(missingPluginWarning, plugin, pluginsAnnotationFullName))
+	}
+
+	return warnings, nil
+}
+
+func serviceAnnotationsWarnings(service client.Object) []string {
+	anns := service.GetAnnotations()
+	var warnings []string
+	for _, key := range sortedAnnotationKeys(anns) {
+		switch {
+		case slices.Contains(routeAnnotations, key) || slices.Contains(traditionalRouterAnnotations, key) ||
+			strings.HasPrefix(key, headersAnnotationPrefix):
+			warnings = append(warnings, fmt.Sprintf(ignoredAnnotationWarning, key, "Service", routeSourcesDescription))
+		case slices.Contains(ingressAnnotations, key):
+			warnings = append(warnings, fmt.Sprintf(ignoredAnnotationWarning, key, "Service", ingressesDescription))
+		}
+	}
+
+	// The use of konghq.com/override alone is already reported by the Service handler.
+	overrideKey := annotations.AnnotationPrefix + annotations.ConfigurationKey
+	if _, ok := annotations.ExtractUpstreamPolicy(anns); ok && annotations.ExtractConfigurationName(anns) != "" {
+		warnings = append(warnings, fmt.Sprintf(overrideAndUpstreamPolicyWarning,
+			overrideKey, kongv1beta1.KongUpstreamPolicyAnnotationKey, kongv1beta1.KongUpstreamPolicyAnnotationKey, overrideKey,
+		))
+	}
+	return warnings
+}
+
+func (p *KongWarningsProvider) routeSourceAnnotationsWarnings(kind string, obj client.Object) []string {
+	_, isIngress := obj.(*netv1.Ingress)
+	var warnings []string
+	for _, key := range sortedAnnotationKeys(obj.GetAnnotations()) {
+		switch {
+		case key == annotations.AnnotationPrefix+annotations.ConfigurationKey:
+			warnings = append(warnings, fmt.Sprintf(overrideAnnotationWarning, key, kind))
+		case slices.Contains(serviceAnnotations, key):
+			warnings = append(warnings, fmt.Sprintf(ignoredAnnotationWarning, key, kind, servicesDescription))
+		case slices.Contains(ingressAnnotations, key) && !isIngress:
+			warnings = append(warnings, fmt.Sprintf(ignoredAnnotationWarning, key, kind, ingressesDescription))
+		case p.translatorFeatures.ExpressionRoutes && slices.Contains(traditionalRouterAnnotations, key):
+			warnings = append(warnings, fmt.Sprintf(expressionRouterAnnotationWarning, key))
+		}
+	}
+	return warnings
+}
+
+func (p *KongWarningsProvider) featureGatesWarnings(obj client.Object) []string {
+	var warnings []string
+	switch o := obj.(type) {
+	case *netv1.Ingress:
+		if _, ok := annotations.ExtractRewriteURI(o.Annotations); ok && !p.translatorFeatures.RewriteURIs {
+			warnings = append(warnings, fmt.Sprintf(featureGateDisabledWarning,
+				annotations.AnnotationPrefix+annotations.RewriteURIKey+" annotation", featuregates.RewriteURIsFeature))
+		}
+		if ingressUsesKongServiceFacade(o) && !p.translatorFeatures.KongServiceFacade {
+			warnings = append(warnings, fmt.Sprintf(featureGateDisabledWarning,
+				"Using KongServiceFacade as an Ingress backend", featuregates.KongServiceFacade))
+		}
+	case *incubatorv1alpha1.KongServiceFacade:
+		if !p.translatorFeatures.KongServiceFacade {
+			warnings = append(warnings, fmt.Sprintf(featureGateDisabledWarning, "KongServiceFacade", featuregates.KongServiceFacade))
+		}
+	case *kongv1alpha1.KongCustomEntity:
+		if !p.translatorFeatures.KongCustomEntity {
+			warnings = append(warnings, fmt.Sprintf(featureGateDisabledWarning, "KongCustomEntity", featuregates.KongCustomEntity))
+		}
+	}
+	return warnings
+}
+
+func ingressUsesKongServiceFacade(ingress *netv1.Ingress) bool {
+	backends := []*netv1.IngressBackend{ingress.Spec.DefaultBackend}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			backends = append(backends, &rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return slices.ContainsFunc(backends, func(b *netv1.IngressBackend) bool {
+		return b != nil && b.Resource != nil && subtranslator.IsKongServiceFacade(b.Resource)
+	})
+}
+
+// sortedAnnotationKeys returns annotation keys in a stable order so that warnings are always reported the same way.
+func sortedAnnotationKeys(anns map[string]string) []string {
+	keys := lo.Keys(anns)
+	slices.Sort(keys)
+	return keys
+}
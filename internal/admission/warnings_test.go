@@ -0,0 +1,230 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	managerscheme "github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
+	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
+)
+
+func TestKongWarningsProvider(t *testing.T) {
+	objectMeta := func(anns map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:   corev1.NamespaceDefault,
+			Name:        "test",
+			Annotations: anns,
+		}
+	}
+	serviceTypeMeta := metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+	ingressTypeMeta := metav1.TypeMeta{APIVersion: netv1.SchemeGroupVersion.String(), Kind: "Ingress"}
+	httpRouteTypeMeta := metav1.TypeMeta{APIVersion: gatewayapi.GroupVersion.String(), Kind: "HTTPRoute"}
+
+	testCases := []struct {
+		name               string
+		object             client.Object
+		operation          admissionv1.Operation
+		translatorFeatures translator.FeatureFlags
+		cachedObjects      []client.Object
+		wantWarnings       []string
+	}{
+		{
+			name: "route annotations on Service are ignored",
+			object: &corev1.Service{
+				TypeMeta: serviceTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{
+					"konghq.com/strip-path":      "true",
+					"konghq.com/headers.x-test":  "a",
+					"konghq.com/canary":          "true",
+					"konghq.com/connect-timeout": "1000",
+				}),
+			},
+			wantWarnings: []string{
+				"konghq.com/canary annotation has no effect on Service, it's only supported on Ingresses.",
+				"konghq.com/headers.x-test annotation has no effect on Service, it's only supported on Ingresses, TCPIngresses, UDPIngresses and Gateway API routes.",
+				"konghq.com/strip-path annotation has no effect on Service, it's only supported on Ingresses, TCPIngresses, UDPIngresses and Gateway API routes.",
+			},
+		},
+		{
+			name: "Service using both override and upstream-policy annotations",
+			object: &corev1.Service{
+				TypeMeta: serviceTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{
+					"konghq.com/override":        "kongingress",
+					"konghq.com/upstream-policy": "policy",
+				}),
+			},
+			wantWarnings: []string{
+				"Service uses both konghq.com/override and konghq.com/upstream-policy annotations, settings from " +
+					"konghq.com/upstream-policy take precedence. Remove the deprecated konghq.com/override annotation.",
+			},
+		},
+		{
+			name: "service and deprecated annotations on Ingress are ignored",
+			object: &netv1.Ingress{
+				TypeMeta: ingressTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{
+					"konghq.com/override":   "kongingress",
+					"konghq.com/path":       "/foo",
+					"konghq.com/strip-path": "true",
+					"konghq.com/canary":     "true",
+				}),
+			},
+			wantWarnings: []string{
+				"konghq.com/override annotation has no effect on Ingress. KongIngress is deprecated and only applied " +
+					"to Services, use annotations and a KongUpstreamPolicy resource instead.",
+				"konghq.com/path annotation has no effect on Ingress, it's only supported on Services.",
+			},
+		},
+		{
+			name: "Ingress annotations on HTTPRoute are ignored",
+			object: &gatewayapi.HTTPRoute{
+				TypeMeta:   httpRouteTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{"konghq.com/canary-weight": "10"}),
+			},
+			wantWarnings: []string{
+				"konghq.com/canary-weight annotation has no effect on HTTPRoute, it's only supported on Ingresses.",
+			},
+		},
+		{
+			name: "traditional router annotations with expressions router are ignored",
+			object: &gatewayapi.HTTPRoute{
+				TypeMeta: httpRouteTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{
+					"konghq.com/regex-priority": "10",
+					"konghq.com/path-handling":  "v1",
+					"konghq.com/methods":        "GET",
+					"konghq.com/snis":           "example.com",
+					"konghq.com/headers.x-test": "a",
+				}),
+			},
+			translatorFeatures: translator.FeatureFlags{ExpressionRoutes: true},
+			wantWarnings: []string{
+				"konghq.com/path-handling annotation has no effect with the expressions router.",
+				"konghq.com/regex-priority annotation has no effect with the expressions router.",
+			},
+		},
+		{
+			name: "traditional router annotations with traditional router are not reported",
+			object: &gatewayapi.HTTPRoute{
+				TypeMeta:   httpRouteTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{"konghq.com/methods": "GET"}),
+			},
+		},
+		{
+			name: "referenced plugins that don't exist",
+			object: &netv1.Ingress{
+				TypeMeta: ingressTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{
+					"konghq.com/plugins": "existing-plugin, existing-cluster-plugin, missing-plugin, other:missing-plugin, other:existing-plugin",
+				}),
+			},
+			cachedObjects: []client.Object{
+				&kongv1.KongPlugin{
+					ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "existing-plugin"},
+					PluginName: "key-auth",
+				},
+				&kongv1.KongPlugin{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "existing-plugin"},
+					PluginName: "key-auth",
+				},
+				&kongv1.KongClusterPlugin{
+					ObjectMeta: metav1.ObjectMeta{Name: "existing-cluster-plugin"},
+					PluginName: "key-auth",
+				},
+			},
+			wantWarnings: []string{
+				`Plugin "missing-plugin" referenced by konghq.com/plugins annotation does not exist.`,
+				`Plugin "other:missing-plugin" referenced by konghq.com/plugins annotation does not exist.`,
+			},
+		},
+		{
+			name: "Ingress using feature gated features",
+			object: &netv1.Ingress{
+				TypeMeta:   ingressTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{"konghq.com/rewrite": "/foo"}),
+				Spec: netv1.IngressSpec{
+					DefaultBackend: &netv1.IngressBackend{
+						Resource: &corev1.TypedLocalObjectReference{
+							APIGroup: lo.ToPtr(incubatorv1alpha1.GroupVersion.Group),
+							Kind:     incubatorv1alpha1.KongServiceFacadeKind,
+							Name:     "facade",
+						},
+					},
+				},
+			},
+			wantWarnings: []string{
+				"konghq.com/rewrite annotation requires the RewriteURIs feature gate to be enabled, it has no effect until then.",
+				"Using KongServiceFacade as an Ingress backend requires the KongServiceFacade feature gate to be enabled, it has no effect until then.",
+			},
+		},
+		{
+			name: "Ingress using feature gated features with the gates enabled",
+			object: &netv1.Ingress{
+				TypeMeta:   ingressTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{"konghq.com/rewrite": "/foo"}),
+			},
+			translatorFeatures: translator.FeatureFlags{RewriteURIs: true},
+		},
+		{
+			name: "feature gated kinds",
+			object: &kongv1alpha1.KongCustomEntity{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: kongv1alpha1.GroupVersion.String(),
+					Kind:       kongv1alpha1.KongCustomEntityKind,
+				},
+				ObjectMeta: objectMeta(nil),
+			},
+			wantWarnings: []string{
+				"KongCustomEntity requires the KongCustomEntity feature gate to be enabled, it has no effect until then.",
+			},
+		},
+		{
+			name: "delete is not reported",
+			object: &netv1.Ingress{
+				TypeMeta:   ingressTypeMeta,
+				ObjectMeta: objectMeta(map[string]string{"konghq.com/path": "/foo"}),
+			},
+			operation: admissionv1.Delete,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakeclient.
+				NewClientBuilder().
+				WithScheme(lo.Must(managerscheme.Get())).
+				WithObjects(tc.cachedObjects...).
+				Build()
+			provider, err := NewKongWarningsProvider(fakeClient, tc.translatorFeatures)
+			require.NoError(t, err)
+
+			raw, err := json.Marshal(tc.object)
+			require.NoError(t, err)
+			gvk := tc.object.GetObjectKind().GroupVersionKind()
+			warnings, err := provider.Warnings(context.Background(), admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+				Operation: lo.Ternary(tc.operation == "", admissionv1.Create, tc.operation),
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWarnings, warnings)
+		})
+	}
+}
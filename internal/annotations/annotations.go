@@ -68,6 +68,20 @@ const (
 	UserTagKey           = "/tags"
 	RewriteURIKey        = "/rewrite"
 
+	// CanaryKey is an annotation suffix used to mark an Ingress as a canary of the Ingress
+	// with the same host and path.
+	CanaryKey              = "/canary"
+	CanaryWeightKey        = "/canary-weight"
+	CanaryByHeaderKey      = "/canary-by-header"
+	CanaryByHeaderValueKey = "/canary-by-header-value"
+	CanaryByCookieKey      = "/canary-by-cookie"
+
+	// CredentialSuccessorKey is an annotation suffix used on a KongConsumer credential Secret to declare
+	// the Secret it's being rotated to. Both credentials are attached to consumers referencing the Secret
+	// until the grace period set with CredentialGracePeriodKey expires.
+	CredentialSuccessorKey   = "/credential-successor"
+	CredentialGracePeriodKey = "/credential-grace-period"
+
 	// GatewayClassUnmanagedKey is an annotation used on a Gateway resource to
 	// indicate that the GatewayClass should be reconciled according to unmanaged
 	// mode.
@@ -80,6 +94,11 @@ const (
 	// published to.
 	GatewayPublishServiceKey = "/publish-service"
 
+	// GatewayAdminServiceKey is an annotation suffix used on an unmanaged Gateway to indicate the Service
+	// (in the namespace/name format) exposing Admin APIs of the Kong Gateways dedicated to it. These Kong Gateways
+	// are configured only with the routes accepted by the Gateways annotated with the Service.
+	GatewayAdminServiceKey = "/admin-service"
+
 	// DefaultIngressClass defines the default class used
 	// by Kong's ingress controller.
 	DefaultIngressClass = "kong"
@@ -404,6 +423,12 @@ func UpdateGatewayPublishService(anns map[string]string, services []string) {
 	anns[AnnotationPrefix+GatewayPublishServiceKey] = strings.Join(services, ",")
 }
 
+// ExtractGatewayAdminService extracts the value of the konghq.com/admin-service annotation.
+func ExtractGatewayAdminService(anns map[string]string) (string, bool) {
+	service, ok := anns[AnnotationPrefix+GatewayAdminServiceKey]
+	return service, ok && service != ""
+}
+
 // ExtractUserTags extracts a set of tags from a comma-separated string.
 func ExtractUserTags(anns map[string]string) []string {
 	val := anns[AnnotationPrefix+UserTagKey]
@@ -421,8 +446,46 @@ func ExtractRewriteURI(anns map[string]string) (string, bool) {
 	return s, ok
 }
 
+// ExtractCanary returns true if the canary annotation is set to "true".
+func ExtractCanary(anns map[string]string) bool {
+	return anns[AnnotationPrefix+CanaryKey] == "true"
+}
+
+// ExtractCanaryWeight extracts the canary-weight annotation value.
+func ExtractCanaryWeight(anns map[string]string) (string, bool) {
+	s, ok := anns[AnnotationPrefix+CanaryWeightKey]
+	return s, ok
+}
+
+// ExtractCanaryByHeader extracts the canary-by-header annotation value.
+func ExtractCanaryByHeader(anns map[string]string) string {
+	return anns[AnnotationPrefix+CanaryByHeaderKey]
+}
+
+// ExtractCanaryByHeaderValue extracts the canary-by-header-value annotation value.
+func ExtractCanaryByHeaderValue(anns map[string]string) string {
+	return anns[AnnotationPrefix+CanaryByHeaderValueKey]
+}
+
+// ExtractCanaryByCookie extracts the canary-by-cookie annotation value.
+func ExtractCanaryByCookie(anns map[string]string) string {
+	return anns[AnnotationPrefix+CanaryByCookieKey]
+}
+
 // ExtractUpstreamPolicy extracts the upstream policy annotation value.
 func ExtractUpstreamPolicy(anns map[string]string) (string, bool) {
 	s, ok := anns[kongv1beta1.KongUpstreamPolicyAnnotationKey]
 	return s, ok
 }
+
+// ExtractCredentialSuccessor extracts the credential-successor annotation value.
+func ExtractCredentialSuccessor(anns map[string]string) (string, bool) {
+	s, ok := anns[AnnotationPrefix+CredentialSuccessorKey]
+	return s, ok
+}
+
+// ExtractCredentialGracePeriod extracts the credential-grace-period annotation value.
+func ExtractCredentialGracePeriod(anns map[string]string) (string, bool) {
+	s, ok := anns[AnnotationPrefix+CredentialGracePeriodKey]
+	return s, ok
+}
@@ -1066,3 +1066,53 @@ func TestExtractRewriteURI(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractCanary(t *testing.T) {
+	tests := []struct {
+		name              string
+		anns              map[string]string
+		wantCanary        bool
+		wantWeight        string
+		wantWeightExists  bool
+		wantByHeader      string
+		wantByHeaderValue string
+		wantByCookie      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "canary not set to true",
+			anns: map[string]string{
+				"konghq.com/canary": "yes",
+			},
+		},
+		{
+			name: "all annotations set",
+			anns: map[string]string{
+				"konghq.com/canary":                 "true",
+				"konghq.com/canary-weight":          "10",
+				"konghq.com/canary-by-header":       "X-Canary",
+				"konghq.com/canary-by-header-value": "v2",
+				"konghq.com/canary-by-cookie":       "canary",
+			},
+			wantCanary:        true,
+			wantWeight:        "10",
+			wantWeightExists:  true,
+			wantByHeader:      "X-Canary",
+			wantByHeaderValue: "v2",
+			wantByCookie:      "canary",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantCanary, ExtractCanary(tt.anns))
+			weight, exists := ExtractCanaryWeight(tt.anns)
+			require.Equal(t, tt.wantWeight, weight)
+			require.Equal(t, tt.wantWeightExists, exists)
+			require.Equal(t, tt.wantByHeader, ExtractCanaryByHeader(tt.anns))
+			require.Equal(t, tt.wantByHeaderValue, ExtractCanaryByHeaderValue(tt.anns))
+			require.Equal(t, tt.wantByCookie, ExtractCanaryByCookie(tt.anns))
+		})
+	}
+}
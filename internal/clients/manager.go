@@ -3,11 +3,14 @@ package clients
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/samber/lo"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
 	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
@@ -30,6 +33,7 @@ type AdminAPIClientsProvider interface {
 	KonnectClient() *adminapi.KonnectClient
 	GatewayClients() []*adminapi.Client
 	GatewayClientsToConfigure() []*adminapi.Client
+	GatewaysForClient(client *adminapi.Client) []k8stypes.NamespacedName
 }
 
 // Ticker is an interface that allows to control a ticker.
@@ -49,6 +53,18 @@ type AdminAPIClientsManager struct {
 	discoveredAdminAPIsNotifyChan    chan []adminapi.DiscoveredAdminAPI
 	gatewayClientsChangesSubscribers []chan struct{}
 
+	// discoveredAdminAPIsBySource holds the most recent Admin APIs reported by each notification source.
+	// Admin APIs passed to Notify are stored under an empty key, Admin APIs of data-planes dedicated to Gateways
+	// passed to NotifyGatewayAdminAPIs are stored under the Gateway's NamespacedName.
+	// A union of all of them is what gets sent to discoveredAdminAPIsNotifyChan.
+	discoveredAdminAPIsBySource map[string][]adminapi.DiscoveredAdminAPI
+	// notifyLock serializes notifications so that unions are sent in the order they were computed.
+	notifyLock sync.Mutex
+
+	// gatewaysByAdminAPIAddress maps Admin API addresses of data-planes dedicated to Gateways to these Gateways.
+	// A data-plane can be dedicated to multiple Gateways when they share it.
+	gatewaysByAdminAPIAddress map[string][]k8stypes.NamespacedName
+
 	dbMode dpconf.DBMode
 
 	ctx                   context.Context
@@ -108,12 +124,19 @@ func NewAdminAPIClientsManager(
 	readyClients := lo.SliceToMap(initialClients, func(c *adminapi.Client) (string, *adminapi.Client) {
 		return c.BaseRootURL(), c
 	})
+	// Initial clients are treated as if they were notified with Notify, so that they are not dropped
+	// when the first NotifyGatewayAdminAPIs notification arrives in a setup without Gateway Discovery.
+	initialAdminAPIs := lo.Map(initialClients, func(c *adminapi.Client, _ int) adminapi.DiscoveredAdminAPI {
+		podRef, _ := c.PodReference()
+		return adminapi.DiscoveredAdminAPI{Address: c.BaseRootURL(), PodRef: podRef}
+	})
 	c := &AdminAPIClientsManager{
 		readyGatewayClients:           readyClients,
 		pendingGatewayClients:         make(map[string]adminapi.DiscoveredAdminAPI),
 		readinessChecker:              readinessChecker,
 		readinessReconciliationTicker: clock.NewTicker(),
 		discoveredAdminAPIsNotifyChan: make(chan []adminapi.DiscoveredAdminAPI),
+		discoveredAdminAPIsBySource:   map[string][]adminapi.DiscoveredAdminAPI{"": initialAdminAPIs},
 		ctx:                           ctx,
 		runningChan:                   make(chan struct{}),
 		logger:                        logger,
@@ -146,6 +169,19 @@ func (c *AdminAPIClientsManager) Run() {
 // Notify receives a list of addresses that KongClient should use from now on as
 // a list of Kong Admin API endpoints.
 func (c *AdminAPIClientsManager) Notify(discoveredAPIs []adminapi.DiscoveredAdminAPI) {
+	c.notifySource("", discoveredAPIs)
+}
+
+// NotifyGatewayAdminAPIs receives a list of Admin API endpoints of the data-plane dedicated to the given Gateway.
+// They are used alongside the Admin API endpoints received with Notify. Passing an empty list removes
+// all Admin API endpoints previously registered for the Gateway.
+func (c *AdminAPIClientsManager) NotifyGatewayAdminAPIs(gateway k8stypes.NamespacedName, discoveredAPIs []adminapi.DiscoveredAdminAPI) {
+	c.notifySource(gateway.String(), discoveredAPIs)
+}
+
+// notifySource stores the Admin APIs reported by the given source and sends a union of Admin APIs
+// from all sources to the notifications loop.
+func (c *AdminAPIClientsManager) notifySource(source string, discoveredAPIs []adminapi.DiscoveredAdminAPI) {
 	// Ensure here that we're not done.
 	select {
 	case <-c.ctx.Done():
@@ -153,13 +189,64 @@ func (c *AdminAPIClientsManager) Notify(discoveredAPIs []adminapi.DiscoveredAdmi
 	default:
 	}
 
+	c.notifyLock.Lock()
+	defer c.notifyLock.Unlock()
+
+	if source != "" && len(discoveredAPIs) == 0 {
+		delete(c.discoveredAdminAPIsBySource, source)
+	} else {
+		c.discoveredAdminAPIsBySource[source] = discoveredAPIs
+	}
+
+	var union []adminapi.DiscoveredAdminAPI
+	for _, apis := range c.discoveredAdminAPIsBySource {
+		union = append(union, apis...)
+	}
+	union = lo.UniqBy(union, func(d adminapi.DiscoveredAdminAPI) string { return d.Address })
+	c.updateGatewaysByAdminAPIAddress()
+
 	// And here also listen on c.ctx.Done() to allow the notification to be interrupted.
 	select {
 	case <-c.ctx.Done():
-	case c.discoveredAdminAPIsNotifyChan <- discoveredAPIs:
+	case c.discoveredAdminAPIsNotifyChan <- union:
 	}
 }
 
+// updateGatewaysByAdminAPIAddress rebuilds the mapping of dedicated data-planes' Admin API addresses to their Gateways.
+// Addresses that were passed to Notify are not associated with any Gateway as they serve all of them.
+func (c *AdminAPIClientsManager) updateGatewaysByAdminAPIAddress() {
+	gateways := make(map[string][]k8stypes.NamespacedName)
+	for source, apis := range c.discoveredAdminAPIsBySource {
+		if source == "" {
+			continue
+		}
+		namespace, name, _ := strings.Cut(source, string(k8stypes.Separator))
+		for _, api := range apis {
+			gateways[api.Address] = append(gateways[api.Address], k8stypes.NamespacedName{Namespace: namespace, Name: name})
+		}
+	}
+	for _, addressGateways := range gateways {
+		slices.SortFunc(addressGateways, func(a, b k8stypes.NamespacedName) int {
+			return strings.Compare(a.String(), b.String())
+		})
+	}
+	for _, api := range c.discoveredAdminAPIsBySource[""] {
+		delete(gateways, api.Address)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.gatewaysByAdminAPIAddress = gateways
+}
+
+// GatewaysForClient returns the Gateways the given client's data-plane is dedicated to, sorted by their names.
+// It returns an empty slice if the client's data-plane serves all Gateways.
+func (c *AdminAPIClientsManager) GatewaysForClient(client *adminapi.Client) []k8stypes.NamespacedName {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return slices.Clone(c.gatewaysByAdminAPIAddress[client.BaseRootURL()])
+}
+
 // SetKonnectClient sets a client that will be used to communicate with Konnect Control Plane Admin API.
 // If called multiple times, it will override the client.
 func (c *AdminAPIClientsManager) SetKonnectClient(client *adminapi.KonnectClient) {
@@ -157,6 +157,75 @@ func TestAdminAPIClientsManager_OnNotifyClientsAreUpdatedAccordingly(t *testing.
 	require.NotPanics(t, func() { manager.Notify([]adminapi.DiscoveredAdminAPI{}) }, "notifying about new clients after manager has been shut down shouldn't panic")
 }
 
+func TestAdminAPIClientsManager_NotifyGatewayAdminAPIs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	readinessChecker := &mockReadinessChecker{}
+	initialClient, err := adminapi.NewTestClient("https://localhost:8083")
+	require.NoError(t, err)
+	manager, err := clients.NewAdminAPIClientsManager(
+		ctx,
+		zapr.NewLogger(zap.NewNop()),
+		[]*adminapi.Client{initialClient},
+		readinessChecker,
+	)
+	require.NoError(t, err)
+	manager.Run()
+	<-manager.Running()
+
+	requireClientsMatchEventually := func(t *testing.T, addresses []string, args ...any) {
+		require.Eventually(t, func() bool {
+			clientAddresses := lo.Map(manager.GatewayClients(), func(cl *adminapi.Client, _ int) string {
+				return cl.BaseRootURL()
+			})
+			slices.Sort(addresses)
+			slices.Sort(clientAddresses)
+			return slices.Equal(addresses, clientAddresses)
+		}, time.Second, time.Millisecond, args...)
+	}
+
+	gateway1 := k8stypes.NamespacedName{Namespace: "default", Name: "gateway-1"}
+	gateway2 := k8stypes.NamespacedName{Namespace: "default", Name: "gateway-2"}
+
+	readinessChecker.LetChecksReturn(clients.ReadinessCheckResult{ClientsTurnedReady: intoTurnedReady(testURL1)})
+	manager.NotifyGatewayAdminAPIs(gateway1, []adminapi.DiscoveredAdminAPI{testDiscoveredAdminAPI(testURL1)})
+	requireClientsMatchEventually(t, []string{initialClient.BaseRootURL(), testURL1},
+		"initial client should be kept alongside the managed gateway's client")
+
+	readinessChecker.LetChecksReturn(clients.ReadinessCheckResult{ClientsTurnedReady: intoTurnedReady(testURL2)})
+	manager.NotifyGatewayAdminAPIs(gateway2, []adminapi.DiscoveredAdminAPI{testDiscoveredAdminAPI(testURL2)})
+	requireClientsMatchEventually(t, []string{initialClient.BaseRootURL(), testURL1, testURL2},
+		"clients of both managed gateways should be configured")
+	gateway3 := k8stypes.NamespacedName{Namespace: "default", Name: "gateway-3"}
+	manager.NotifyGatewayAdminAPIs(gateway3, []adminapi.DiscoveredAdminAPI{testDiscoveredAdminAPI(testURL2)})
+	require.Eventually(t, func() bool {
+		cl, ok := lo.Find(manager.GatewayClients(), func(cl *adminapi.Client) bool { return cl.BaseRootURL() == testURL2 })
+		return ok && len(manager.GatewaysForClient(cl)) == 2
+	}, time.Second, time.Millisecond, "gateways sharing a data-plane should be associated with its client")
+	for _, cl := range manager.GatewayClients() {
+		gateways := manager.GatewaysForClient(cl)
+		switch cl.BaseRootURL() {
+		case testURL1:
+			require.Equal(t, []k8stypes.NamespacedName{gateway1}, gateways)
+		case testURL2:
+			require.Equal(t, []k8stypes.NamespacedName{gateway2, gateway3}, gateways)
+		default:
+			require.Empty(t, gateways, "initial client should not be associated with any gateway")
+		}
+	}
+	manager.NotifyGatewayAdminAPIs(gateway3, nil)
+
+	readinessChecker.LetChecksReturn(clients.ReadinessCheckResult{})
+	manager.NotifyGatewayAdminAPIs(gateway1, nil)
+	requireClientsMatchEventually(t, []string{initialClient.BaseRootURL(), testURL2},
+		"clients of a removed managed gateway should be dropped")
+
+	manager.Notify(nil)
+	requireClientsMatchEventually(t, []string{testURL2},
+		"notifying about discovered admin APIs should not affect managed gateways' clients")
+}
+
 func TestNewAdminAPIClientsManager_NoInitialClientsDisallowed(t *testing.T) {
 	_, err := clients.NewAdminAPIClientsManager(
 		context.Background(),
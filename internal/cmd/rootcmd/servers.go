@@ -2,6 +2,9 @@ package rootcmd
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 
@@ -22,10 +25,26 @@ func StartDiagnosticsServer(
 	}
 	logger.Info("Starting diagnostics server")
 
+	var configPinToken string
+	if c.ConfigPinTokenFile != "" {
+		token, err := os.ReadFile(c.ConfigPinTokenFile)
+		if err != nil {
+			return diagnostics.Server{}, fmt.Errorf("failed to read config pin token file: %w", err)
+		}
+		configPinToken = strings.TrimSpace(string(token))
+		if configPinToken == "" {
+			return diagnostics.Server{}, fmt.Errorf("config pin token file %s is empty", c.ConfigPinTokenFile)
+		}
+	}
+
 	s := diagnostics.NewServer(logger, diagnostics.ServerConfig{
 		ProfilingEnabled:    c.EnableProfiling,
 		ConfigDumpsEnabled:  c.EnableConfigDumps,
 		DumpSensitiveConfig: c.DumpSensitiveConfig,
+		ConfigHistorySize:   c.ConfigHistorySize,
+		ConfigPinToken:      configPinToken,
+		TLSCertFile:         c.DiagnosticServerTLSCertFile,
+		TLSKeyFile:          c.DiagnosticServerTLSKeyFile,
 	})
 	go func() {
 		if err := s.Listen(ctx, port); err != nil {
@@ -0,0 +1,283 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers"
+	ctrlutils "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/utils"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+)
+
+// -----------------------------------------------------------------------------
+// KongConsumer Credential Rotation Controller - Reconciler
+// -----------------------------------------------------------------------------
+
+// credentialRotationRefreshInterval is how often the remaining time of rotations in progress is refreshed.
+const credentialRotationRefreshInterval = time.Minute
+
+// KongConsumerCredentialRotationReconciler drives rotations of KongConsumer credential Secrets to their successors.
+// It records when rotations start in the status of KongConsumers, which the translator relies on to decide which
+// credentials to attach until their grace periods expire. With UpdateStatus enabled, it also reports progress of
+// rotations in the CredentialRotation condition of KongConsumers.
+type KongConsumerCredentialRotationReconciler struct {
+	client.Client
+
+	Log              logr.Logger
+	Scheme           *runtime.Scheme
+	CacheSyncTimeout time.Duration
+
+	IngressClassName           string
+	DisableIngressClassLookups bool
+	UpdateStatus               bool
+}
+
+var _ controllers.Reconciler = &KongConsumerCredentialRotationReconciler{}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KongConsumerCredentialRotationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("KongConsumerCredentialRotation").
+		WithOptions(controller.Options{
+			LogConstructor: func(_ *reconcile.Request) logr.Logger {
+				return r.Log
+			},
+			CacheSyncTimeout: r.CacheSyncTimeout,
+		}).
+		// Status updates of KongConsumers made by this and other controllers don't affect rotations.
+		For(&kongv1.KongConsumer{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.listConsumersAffectedBySecret),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isCredentialSecret)),
+		).
+		Complete(r)
+}
+
+// SetLogger sets the logger.
+func (r *KongConsumerCredentialRotationReconciler) SetLogger(l logr.Logger) {
+	r.Log = l
+}
+
+// +kubebuilder:rbac:groups=configuration.konghq.com,resources=kongconsumers/status,verbs=get;update;patch
+
+// Reconcile processes the watched objects.
+func (r *KongConsumerCredentialRotationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("KongConsumer", req.NamespacedName)
+
+	consumer := new(kongv1.KongConsumer)
+	if err := r.Get(ctx, req.NamespacedName, consumer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !consumer.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	class := new(netv1.IngressClass)
+	if !r.DisableIngressClassLookups {
+		if err := r.Get(ctx, k8stypes.NamespacedName{Name: r.IngressClassName}, class); err != nil {
+			log.V(util.DebugLevel).Info("Could not retrieve IngressClass", "ingressclass", r.IngressClassName)
+		}
+	}
+	if !ctrlutils.MatchesIngressClass(consumer, r.IngressClassName, ctrlutils.IsDefaultIngressClass(class)) {
+		return ctrl.Result{}, nil
+	}
+
+	rotations, condition, requeueAfter, err := r.reconcileCredentialRotations(ctx, consumer, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Starts of rotations are recorded regardless of UpdateStatus, as the translator relies on them.
+	updateNeeded := !equality.Semantic.DeepEqual(consumer.Status.CredentialRotations, rotations)
+	consumer.Status.CredentialRotations = rotations
+	if r.UpdateStatus {
+		var conditionChanged bool
+		if condition == nil {
+			conditionChanged = meta.RemoveStatusCondition(&consumer.Status.Conditions, string(kongv1.ConditionCredentialRotation))
+		} else {
+			conditionChanged = meta.SetStatusCondition(&consumer.Status.Conditions, *condition)
+		}
+		updateNeeded = updateNeeded || conditionChanged
+	}
+	if updateNeeded {
+		log.V(util.DebugLevel).Info("Updating credential rotation status", "namespace", req.Namespace, "name", req.Name)
+		if err := r.Status().Update(ctx, consumer); err != nil {
+			// The status may be updated concurrently by other controllers.
+			if apierrors.IsConflict(err) {
+				log.V(util.DebugLevel).Info("Conflict when recording credential rotation progress, requeueing")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+	}
+	if !r.UpdateStatus {
+		return ctrl.Result{}, nil
+	}
+
+	// Remaining time of rotations in progress reported in the condition is refreshed periodically.
+	if requeueAfter > 0 {
+		requeueAfter = min(requeueAfter, credentialRotationRefreshInterval)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileCredentialRotations determines progress of rotations of the consumer's credential Secrets at the given
+// time. It returns the rotations to record in the consumer's status, with starts of rotations observed for the first
+// time set to the given time, the CredentialRotation condition of the consumer and after how long the next rotation
+// in progress completes. The condition is nil if none of the consumer's credential Secrets declares a successor.
+func (r *KongConsumerCredentialRotationReconciler) reconcileCredentialRotations(
+	ctx context.Context, consumer *kongv1.KongConsumer, now time.Time,
+) ([]kongv1.CredentialRotationStatus, *metav1.Condition, time.Duration, error) {
+	var (
+		rotations                      []kongv1.CredentialRotationStatus
+		invalid, inProgress, completed []string
+		requeueAfter                   time.Duration
+	)
+	for _, secretName := range consumer.Credentials {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, k8stypes.NamespacedName{Namespace: consumer.Namespace, Name: secretName}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, 0, err
+		}
+		rotation, ok, err := credentials.ExtractRotation(secret)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("Secret %q: %s", secretName, err))
+			continue
+		}
+		if !ok {
+			// Progress of a rotation that was cancelled is dropped, so it doesn't affect a rotation declared later.
+			continue
+		}
+		successor := &corev1.Secret{}
+		if err := r.Get(ctx, k8stypes.NamespacedName{Namespace: consumer.Namespace, Name: rotation.Successor}, successor); err != nil {
+			if apierrors.IsNotFound(err) {
+				invalid = append(invalid, fmt.Sprintf("Secret %q: successor Secret %q doesn't exist", secretName, rotation.Successor))
+				continue
+			}
+			return nil, nil, 0, err
+		}
+
+		rotation = rotation.WithRecordedStart(consumer.Status)
+		if !rotation.Started() {
+			// The start is truncated to the precision it's serialized with.
+			rotation.StartedAt = now.UTC().Truncate(time.Second)
+		}
+		rotations = append(rotations, kongv1.CredentialRotationStatus{
+			Secret:    rotation.Secret,
+			Successor: rotation.Successor,
+			StartedAt: metav1.NewTime(rotation.StartedAt),
+		})
+		if rotation.Expired(now) {
+			completed = append(completed, fmt.Sprintf("Secret %q was rotated to %q, reference %q in credentials instead",
+				secretName, rotation.Successor, rotation.Successor))
+			continue
+		}
+		deadline, _ := rotation.Deadline()
+		remaining := deadline.Sub(now)
+		inProgress = append(inProgress, fmt.Sprintf("Secret %q is being rotated to %q, both credentials are attached until %s (%s remaining)",
+			secretName, rotation.Successor, deadline.UTC().Format(time.RFC3339), formatRemainingRotationTime(remaining)))
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:               string(kongv1.ConditionCredentialRotation),
+		ObservedGeneration: consumer.Generation,
+		Message:            strings.Join(append(append(invalid, inProgress...), completed...), "; "),
+	}
+	switch {
+	case len(invalid) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(kongv1.ReasonRotationInvalid)
+	case len(inProgress) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(kongv1.ReasonRotationInProgress)
+	case len(completed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(kongv1.ReasonRotationCompleted)
+	default:
+		return rotations, nil, 0, nil
+	}
+	return rotations, condition, requeueAfter, nil
+}
+
+// formatRemainingRotationTime formats the remaining time of a rotation with a precision of minutes.
+func formatRemainingRotationTime(d time.Duration) string {
+	if d < time.Minute {
+		return "less than a minute"
+	}
+	return strings.TrimSuffix(d.Truncate(time.Minute).String(), "0s")
+}
+
+// -----------------------------------------------------------------------------
+// KongConsumer Credential Rotation Controller - Watch Predicates
+// -----------------------------------------------------------------------------
+
+// isCredentialSecret returns true for Secrets labeled as KongConsumer credentials.
+func isCredentialSecret(obj client.Object) bool {
+	_, ok := obj.GetLabels()[labels.CredentialTypeLabel]
+	return ok
+}
+
+// listConsumersAffectedBySecret enqueues reconcile requests for KongConsumers referencing the credential Secret
+// and for KongConsumers referencing Secrets that declare it as their successor.
+func (r *KongConsumerCredentialRotationReconciler) listConsumersAffectedBySecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	secretNames := map[string]struct{}{obj.GetName(): {}}
+
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, client.InNamespace(obj.GetNamespace()), client.HasLabels{labels.CredentialTypeLabel}); err != nil {
+		r.Log.Error(err, "Failed to list credential Secrets in watch predicates", "namespace", obj.GetNamespace())
+		return nil
+	}
+	for _, secret := range secrets.Items {
+		if successor, ok := annotations.ExtractCredentialSuccessor(secret.Annotations); ok && successor == obj.GetName() {
+			secretNames[secret.Name] = struct{}{}
+		}
+	}
+
+	consumers := &kongv1.KongConsumerList{}
+	if err := r.List(ctx, consumers, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list KongConsumers in watch predicates", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, consumer := range consumers.Items {
+		for _, credential := range consumer.Credentials {
+			if _, ok := secretNames[credential]; ok {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: k8stypes.NamespacedName{Namespace: consumer.Namespace, Name: consumer.Name},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
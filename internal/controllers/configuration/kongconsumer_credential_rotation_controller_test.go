@@ -0,0 +1,259 @@
+package configuration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
+)
+
+func TestKongConsumerCredentialRotationReconciler(t *testing.T) {
+	// Starts of rotations are recorded with a precision of seconds.
+	now := time.Now().Truncate(time.Second)
+	credentialSecret := func(name string, anns map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   corev1.NamespaceDefault,
+				Name:        name,
+				Labels:      map[string]string{labels.CredentialTypeLabel: "key-auth"},
+				Annotations: anns,
+			},
+			Data: map[string][]byte{"key": []byte(name)},
+		}
+	}
+	rotatedTo := func(successor, gracePeriod string) map[string]string {
+		return map[string]string{
+			"konghq.com/credential-successor":    successor,
+			"konghq.com/credential-grace-period": gracePeriod,
+		}
+	}
+	recorded := func(secret, successor string, startedAt time.Time) kongv1.CredentialRotationStatus {
+		return kongv1.CredentialRotationStatus{Secret: secret, Successor: successor, StartedAt: metav1.NewTime(startedAt)}
+	}
+	secrets := []*corev1.Secret{
+		credentialSecret("successor", nil),
+		credentialSecret("rotated", rotatedTo("successor", "24h")),
+		credentialSecret("expiring", rotatedTo("successor", "30m")),
+		credentialSecret("missing-successor", rotatedTo("missing", "24h")),
+		credentialSecret("cancelled", nil),
+	}
+
+	testCases := []struct {
+		name               string
+		credentials        []string
+		updateStatus       bool
+		existingConditions []metav1.Condition
+		existingRotations  []kongv1.CredentialRotationStatus
+		wantCondition      *metav1.Condition
+		wantRequeueAfter   time.Duration
+		// wantRotations maps names of rotated Secrets to expected starts of their rotations recorded in the status.
+		wantRotations map[string]time.Time
+	}{
+		{
+			name:         "rotation start is recorded",
+			credentials:  []string{"rotated"},
+			updateStatus: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(kongv1.ConditionCredentialRotation),
+				Status: metav1.ConditionTrue,
+				Reason: string(kongv1.ReasonRotationInProgress),
+			},
+			wantRequeueAfter: credentialRotationRefreshInterval,
+			wantRotations:    map[string]time.Time{"rotated": now},
+		},
+		{
+			name:              "rotation in progress",
+			credentials:       []string{"rotated"},
+			updateStatus:      true,
+			existingRotations: []kongv1.CredentialRotationStatus{recorded("rotated", "successor", now.Add(-time.Hour))},
+			wantCondition: &metav1.Condition{
+				Type:   string(kongv1.ConditionCredentialRotation),
+				Status: metav1.ConditionTrue,
+				Reason: string(kongv1.ReasonRotationInProgress),
+			},
+			wantRequeueAfter: credentialRotationRefreshInterval,
+			wantRotations:    map[string]time.Time{"rotated": now.Add(-time.Hour)},
+		},
+		{
+			name:              "rotation completes once its grace period elapses",
+			credentials:       []string{"expiring"},
+			updateStatus:      true,
+			existingRotations: []kongv1.CredentialRotationStatus{recorded("expiring", "successor", now.Add(-time.Hour))},
+			wantCondition: &metav1.Condition{
+				Type:    string(kongv1.ConditionCredentialRotation),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(kongv1.ReasonRotationCompleted),
+				Message: `Secret "expiring" was rotated to "successor", reference "successor" in credentials instead`,
+			},
+			wantRotations: map[string]time.Time{"expiring": now.Add(-time.Hour)},
+		},
+		{
+			name:              "rotation start recorded for a different successor is replaced",
+			credentials:       []string{"rotated"},
+			updateStatus:      true,
+			existingRotations: []kongv1.CredentialRotationStatus{recorded("rotated", "previous-successor", now.Add(-48*time.Hour))},
+			wantCondition: &metav1.Condition{
+				Type:   string(kongv1.ConditionCredentialRotation),
+				Status: metav1.ConditionTrue,
+				Reason: string(kongv1.ReasonRotationInProgress),
+			},
+			wantRequeueAfter: credentialRotationRefreshInterval,
+			wantRotations:    map[string]time.Time{"rotated": now},
+		},
+		{
+			name:         "rotation to a successor that doesn't exist is not started",
+			credentials:  []string{"rotated", "missing-successor"},
+			updateStatus: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(kongv1.ConditionCredentialRotation),
+				Status: metav1.ConditionFalse,
+				Reason: string(kongv1.ReasonRotationInvalid),
+			},
+			wantRequeueAfter: credentialRotationRefreshInterval,
+			wantRotations:    map[string]time.Time{"rotated": now},
+		},
+		{
+			name:         "condition and progress of a cancelled rotation are removed",
+			credentials:  []string{"cancelled"},
+			updateStatus: true,
+			existingConditions: []metav1.Condition{{
+				Type:               string(kongv1.ConditionCredentialRotation),
+				Status:             metav1.ConditionFalse,
+				Reason:             string(kongv1.ReasonRotationCompleted),
+				LastTransitionTime: metav1.Now(),
+			}},
+			existingRotations: []kongv1.CredentialRotationStatus{recorded("cancelled", "successor", now.Add(-time.Hour))},
+		},
+		{
+			name:          "progress is recorded without updating status conditions",
+			credentials:   []string{"rotated"},
+			wantRotations: map[string]time.Time{"rotated": now},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			consumer := &kongv1.KongConsumer{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   corev1.NamespaceDefault,
+					Name:        "consumer",
+					Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+				},
+				Username:    "consumer",
+				Credentials: tc.credentials,
+				Status: kongv1.KongConsumerStatus{
+					Conditions:          tc.existingConditions,
+					CredentialRotations: tc.existingRotations,
+				},
+			}
+			objects := []client.Object{consumer}
+			for _, secret := range secrets {
+				objects = append(objects, secret.DeepCopy())
+			}
+			fakeClient := fakeclient.
+				NewClientBuilder().
+				WithScheme(lo.Must(scheme.Get())).
+				WithObjects(objects...).
+				WithStatusSubresource(consumer).
+				Build()
+			reconciler := &KongConsumerCredentialRotationReconciler{
+				Client:                     fakeClient,
+				Log:                        logr.Discard(),
+				IngressClassName:           annotations.DefaultIngressClass,
+				DisableIngressClassLookups: true,
+				UpdateStatus:               tc.updateStatus,
+			}
+
+			nn := k8stypes.NamespacedName{Namespace: consumer.Namespace, Name: consumer.Name}
+			result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: nn})
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantRequeueAfter, result.RequeueAfter, float64(time.Second))
+
+			updated := &kongv1.KongConsumer{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(consumer), updated))
+			require.Len(t, updated.Status.CredentialRotations, len(tc.wantRotations))
+			for _, rotation := range updated.Status.CredentialRotations {
+				wantStartedAt, ok := tc.wantRotations[rotation.Secret]
+				require.True(t, ok, "unexpected rotation of Secret %s recorded", rotation.Secret)
+				assert.Equal(t, "successor", rotation.Successor)
+				assert.WithinDuration(t, wantStartedAt, rotation.StartedAt.Time, 2*time.Second, "start of rotation of Secret %s", rotation.Secret)
+			}
+
+			condition := meta.FindStatusCondition(updated.Status.Conditions, string(kongv1.ConditionCredentialRotation))
+			if tc.wantCondition == nil {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			assert.Equal(t, tc.wantCondition.Status, condition.Status)
+			assert.Equal(t, tc.wantCondition.Reason, condition.Reason)
+			if tc.wantCondition.Message != "" {
+				assert.Equal(t, tc.wantCondition.Message, condition.Message)
+			}
+		})
+	}
+}
+
+func TestKongConsumerCredentialRotationConditionMessage(t *testing.T) {
+	fakeClient := fakeclient.
+		NewClientBuilder().
+		WithScheme(lo.Must(scheme.Get())).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "rotated",
+					Annotations: map[string]string{
+						"konghq.com/credential-successor":    "successor",
+						"konghq.com/credential-grace-period": "24h",
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "successor",
+				},
+			},
+		).
+		Build()
+	reconciler := &KongConsumerCredentialRotationReconciler{Client: fakeClient}
+	started := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	consumer := &kongv1.KongConsumer{
+		ObjectMeta:  metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "consumer"},
+		Credentials: []string{"rotated"},
+		Status: kongv1.KongConsumerStatus{
+			CredentialRotations: []kongv1.CredentialRotationStatus{
+				{Secret: "rotated", Successor: "successor", StartedAt: metav1.NewTime(started)},
+			},
+		},
+	}
+
+	_, condition, requeueAfter, err := reconciler.reconcileCredentialRotations(context.Background(), consumer, started.Add(23*time.Hour+59*time.Minute+30*time.Second))
+	require.NoError(t, err)
+	require.NotNil(t, condition)
+	assert.Equal(t, `Secret "rotated" is being rotated to "successor", both credentials are attached until 2024-06-02T12:00:00Z (less than a minute remaining)`, condition.Message)
+	assert.Equal(t, 30*time.Second, requeueAfter)
+
+	_, condition, requeueAfter, err = reconciler.reconcileCredentialRotations(context.Background(), consumer, started.Add(90*time.Minute+30*time.Second))
+	require.NoError(t, err)
+	require.NotNil(t, condition)
+	assert.Equal(t, `Secret "rotated" is being rotated to "successor", both credentials are attached until 2024-06-02T12:00:00Z (22h29m remaining)`, condition.Message)
+	assert.Equal(t, 22*time.Hour+29*time.Minute+30*time.Second, requeueAfter)
+}
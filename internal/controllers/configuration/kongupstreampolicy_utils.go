@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	gatewaycontroller "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
 	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
@@ -69,7 +70,7 @@ func (r *KongUpstreamPolicyReconciler) enforceKongUpstreamPolicyStatus(
 	}
 
 	// Build the status for each ancestor.
-	ancestorsStatus, err := r.buildAncestorsStatus(ctx, services, serviceFacades)
+	ancestorsStatus, err := r.buildAncestorsStatus(ctx, oldPolicy, services, serviceFacades)
 	if err != nil {
 		return false, err
 	}
@@ -132,12 +133,13 @@ func (r *KongUpstreamPolicyReconciler) maybeGetServiceFacadesReferencingUpstream
 // buildAncestorsStatus creates a list of services with their conditions associated.
 func (r *KongUpstreamPolicyReconciler) buildAncestorsStatus(
 	ctx context.Context,
+	policy *kongv1beta1.KongUpstreamPolicy,
 	services []corev1.Service,
 	serviceFacades []incubatorv1alpha1.KongServiceFacade,
 ) ([]ancestorStatus, error) {
 	// Check if any Services have conflicts. We do not verify conflicts for KongServiceFacades as there's
 	// no scenario in which they would have one.
-	conflictedServices, err := r.getConflictedServices(ctx, services)
+	conflictedServices, err := r.getConflictedServices(ctx, policy, services)
 	if err != nil {
 		return nil, err
 	}
@@ -210,8 +212,14 @@ func (r *KongUpstreamPolicyReconciler) buildAncestorsStatus(
 	return ancestorsStatus, nil
 }
 
-// getConflictedServices returns a set of services that have conflicts.
-func (r *KongUpstreamPolicyReconciler) getConflictedServices(ctx context.Context, services []corev1.Service) (servicesSet, error) {
+// getConflictedServices returns a set of services that have conflicts. A Service is conflicted when it's used in
+// an HTTPRoute rule along with Services using other KongUpstreamPolicies or when the rule configures session
+// persistence, which overrides the policy's load balancing settings.
+func (r *KongUpstreamPolicyReconciler) getConflictedServices(
+	ctx context.Context,
+	policy *kongv1beta1.KongUpstreamPolicy,
+	services []corev1.Service,
+) (servicesSet, error) {
 	// return directly when HTTPRoute is not enabled, as it only check conflicted services in HTTPRoute backends only.
 	if !r.HTTPRouteEnabled {
 		return make(servicesSet), nil
@@ -234,8 +242,10 @@ func (r *KongUpstreamPolicyReconciler) getConflictedServices(ctx context.Context
 		if err != nil {
 			return nil, err
 		}
+		configuresHashing := kongstate.KongUpstreamPolicyConfiguresHashing(policy)
 		hasConflict := lo.ContainsBy(httpRoutes.Items, func(httpRoute gatewayapi.HTTPRoute) bool {
-			return httpRouteHasUpstreamPolicyConflictedBackendRefsWithService(httpRoute, upstreamPolicyServices, serviceKey)
+			return httpRouteHasUpstreamPolicyConflictedBackendRefsWithService(httpRoute, upstreamPolicyServices, serviceKey) ||
+				(configuresHashing && httpRouteHasSessionPersistenceWithService(httpRoute, serviceKey))
 		})
 		if hasConflict {
 			conflictedServices[serviceKey] = struct{}{}
@@ -244,6 +254,16 @@ func (r *KongUpstreamPolicyReconciler) getConflictedServices(ctx context.Context
 	return conflictedServices, nil
 }
 
+// httpRouteHasSessionPersistenceWithService checks if there's any HTTPRoute's rule configuring session persistence
+// that uses the given Service as a backend.
+func httpRouteHasSessionPersistenceWithService(httpRoute gatewayapi.HTTPRoute, serviceKey serviceKey) bool {
+	return lo.ContainsBy(httpRoute.Spec.Rules, func(rule gatewayapi.HTTPRouteRule) bool {
+		return rule.SessionPersistence != nil && lo.ContainsBy(rule.BackendRefs, func(br gatewayapi.HTTPBackendRef) bool {
+			return backendRefToServiceRef(httpRoute.Namespace, br.BackendRef) == serviceKey
+		})
+	})
+}
+
 // httpRouteHasUpstreamPolicyConflictedBackendRefsWithService checks if there's any HTTPRoute's rule that uses multiple backendRefs
 // AND they're not all using the same KongUpstreamPolicy.
 // If so, that means that we have a conflict because we cannot apply multiple KongUpstreamPolicy to the same Kong Service.
@@ -394,6 +394,76 @@ func TestEnforceKongUpstreamPolicyStatus(t *testing.T) {
 			},
 			updated: true,
 		},
+		{
+			name: "service used in httproute rule with session persistence, policy configuring hashing, conflict",
+			kongUpstreamPolicy: kongv1beta1.KongUpstreamPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: testNamespace,
+				},
+				Spec: kongv1beta1.KongUpstreamPolicySpec{
+					Algorithm: lo.ToPtr("consistent-hashing"),
+					HashOn:    &kongv1beta1.KongUpstreamHash{Header: lo.ToPtr("x-user")},
+				},
+			},
+			inputObjects: []client.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "svc-1",
+						Namespace: testNamespace,
+						Annotations: map[string]string{
+							kongv1beta1.KongUpstreamPolicyAnnotationKey: policyName,
+						},
+						CreationTimestamp: metav1.Now(),
+					},
+				},
+				&gatewayapi.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "httpRoute",
+						Namespace: testNamespace,
+					},
+					Spec: gatewayapi.HTTPRouteSpec{
+						Rules: []gatewayapi.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayapi.HTTPBackendRef{
+									builder.NewHTTPBackendRef("svc-1").Build(),
+								},
+								SessionPersistence: &gatewayapi.SessionPersistence{
+									SessionName: lo.ToPtr("session"),
+								},
+							},
+						},
+					},
+				},
+			},
+			objectsConfiguredInDataPlane: true,
+			expectedKongUpstreamPolicyStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{
+					{
+						AncestorRef: gatewayapi.ParentReference{
+							Group:     lo.ToPtr(gatewayapi.Group("core")),
+							Kind:      lo.ToPtr(gatewayapi.Kind("Service")),
+							Namespace: lo.ToPtr(gatewayapi.Namespace(testNamespace)),
+							Name:      gatewayapi.ObjectName("svc-1"),
+						},
+						ControllerName: gatewaycontroller.GetControllerName(),
+						Conditions: []metav1.Condition{
+							{
+								Type:   string(gatewayapi.PolicyConditionAccepted),
+								Status: metav1.ConditionFalse,
+								Reason: string(gatewayapi.PolicyReasonConflicted),
+							},
+							{
+								Type:   string(gatewayapi.GatewayConditionProgrammed),
+								Status: metav1.ConditionFalse,
+								Reason: string(gatewayapi.GatewayReasonPending),
+							},
+						},
+					},
+				},
+			},
+			updated: true,
+		},
 		{
 			name: "2 services referencing different policies in different http route rules, accepted",
 			kongUpstreamPolicy: kongv1beta1.KongUpstreamPolicy{
@@ -21,6 +21,7 @@ type DataPlane interface {
 type DataPlaneStatusClient interface {
 	AreKubernetesObjectReportsEnabled() bool
 	KubernetesObjectConfigurationStatus(obj client.Object) k8sobj.ConfigurationStatus
+	KubernetesObjectConfigurationWarnings(obj client.Object) []string
 	KubernetesObjectIsConfigured(obj client.Object) bool
 }
 
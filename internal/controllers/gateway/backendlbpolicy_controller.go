@@ -0,0 +1,333 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/kubernetes/object/status"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+)
+
+const (
+	// backendLBPolicyTargetServiceIndexKey is the index of BackendLBPolicies by "namespace/name" of targeted Services.
+	backendLBPolicyTargetServiceIndexKey = "backendLBPolicyTargetService"
+
+	// backendLBPolicyMaxAncestors is the maximum number of ancestors that can be stored in the BackendLBPolicy
+	// status. This is a limitation of the Gateway API.
+	backendLBPolicyMaxAncestors = 16
+)
+
+// BackendLBPolicyReconciler reconciles BackendLBPolicy resources.
+type BackendLBPolicyReconciler struct {
+	client.Client
+
+	Log              logr.Logger
+	Scheme           *runtime.Scheme
+	DataplaneClient  controllers.DataPlane
+	CacheSyncTimeout time.Duration
+	StatusQueue      *status.Queue
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackendLBPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetCache().IndexField(
+		context.Background(),
+		&gatewayapi.BackendLBPolicy{},
+		backendLBPolicyTargetServiceIndexKey,
+		indexBackendLBPolicyOnTargetServices,
+	); err != nil {
+		return fmt.Errorf("failed to index BackendLBPolicies on target Services: %w", err)
+	}
+
+	blder := ctrl.NewControllerManagedBy(mgr).
+		Named("backendlbpolicy-controller").
+		WithOptions(controller.Options{
+			LogConstructor: func(_ *reconcile.Request) logr.Logger {
+				return r.Log
+			},
+			CacheSyncTimeout: r.CacheSyncTimeout,
+		}).
+		Watches(&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.listBackendLBPoliciesForService),
+		)
+
+	if r.StatusQueue != nil {
+		// Services' configuration status changes have to be propagated to the policies' ancestor Programmed status.
+		// As Services are re-published after every configuration sync, this also picks up changes of the
+		// KongUpstreamPolicies attached to them.
+		blder.WatchesRawSource(
+			source.Channel(
+				r.StatusQueue.Subscribe(schema.GroupVersionKind{
+					Version: "v1",
+					Kind:    "Service",
+				}),
+				handler.EnqueueRequestsFromMapFunc(r.listBackendLBPoliciesForService),
+			),
+		)
+	}
+
+	return blder.For(&gatewayapi.BackendLBPolicy{}).
+		Complete(r)
+}
+
+// -----------------------------------------------------------------------------
+// BackendLBPolicy Controller - Indexers
+// -----------------------------------------------------------------------------
+
+// indexBackendLBPolicyOnTargetServices indexes BackendLBPolicies on "namespace/name" of the Services they target.
+func indexBackendLBPolicyOnTargetServices(o client.Object) []string {
+	policy, ok := o.(*gatewayapi.BackendLBPolicy)
+	if !ok {
+		return []string{}
+	}
+	return lo.Uniq(lo.FilterMap(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReference, _ int) (string, bool) {
+		return policy.Namespace + "/" + string(ref.Name), kongstate.BackendLBPolicyTargetsService(policy, policy.Namespace, string(ref.Name))
+	}))
+}
+
+// -----------------------------------------------------------------------------
+// BackendLBPolicy Controller - Watch Predicates
+// -----------------------------------------------------------------------------
+
+// listBackendLBPoliciesForService enqueues reconcile requests for all the BackendLBPolicies targeting a Service.
+func (r *BackendLBPolicyReconciler) listBackendLBPoliciesForService(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &gatewayapi.BackendLBPolicyList{}
+	if err := r.List(ctx, policies,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{backendLBPolicyTargetServiceIndexKey: obj.GetNamespace() + "/" + obj.GetName()},
+	); err != nil {
+		r.Log.Error(err, "Failed to list BackendLBPolicies in watch predicates", "service", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	return lo.Map(policies.Items, func(p gatewayapi.BackendLBPolicy, _ int) reconcile.Request {
+		return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)}
+	})
+}
+
+// -----------------------------------------------------------------------------
+// BackendLBPolicy Controller - Reconciliation
+// -----------------------------------------------------------------------------
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendlbpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendlbpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=configuration.konghq.com,resources=kongupstreampolicies,verbs=get;list;watch
+
+// Reconcile processes the watched objects.
+func (r *BackendLBPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("GatewayV1Alpha2BackendLBPolicy", req.NamespacedName)
+
+	policy := new(gatewayapi.BackendLBPolicy)
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			debug(log, policy, "Object does not exist, ensuring it is not present in the proxy cache")
+			policy.Namespace = req.Namespace
+			policy.Name = req.Name
+			return ctrl.Result{}, r.DataplaneClient.DeleteObject(policy)
+		}
+		return ctrl.Result{}, err
+	}
+	debug(log, policy, "Processing BackendLBPolicy")
+
+	// clean the object up if it's being deleted
+	if !policy.DeletionTimestamp.IsZero() && time.Now().After(policy.DeletionTimestamp.Time) {
+		debug(log, policy, "Resource is being deleted, its configuration will be removed")
+		objectExistsInCache, err := r.DataplaneClient.ObjectExists(policy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if objectExistsInCache {
+			if err := r.DataplaneClient.DeleteObject(policy); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil // wait until the object is no longer present in the cache
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// enforce the desired BackendLBPolicy status
+	updated, err := r.enforceBackendLBPolicyStatus(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if updated {
+		// status update will re-trigger reconciliation
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.DataplaneClient.UpdateObject(policy); err != nil {
+		debug(log, policy, "Failed to update object in data-plane, requeueing")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// enforceBackendLBPolicyStatus builds the desired status of the BackendLBPolicy with an ancestor for each
+// targeted Service and patches the policy if it differs from the current one.
+func (r *BackendLBPolicyReconciler) enforceBackendLBPolicyStatus(
+	ctx context.Context, oldPolicy *gatewayapi.BackendLBPolicy,
+) (bool, error) {
+	newStatus, err := r.buildBackendLBPolicyStatus(ctx, oldPolicy)
+	if err != nil {
+		return false, err
+	}
+	if isPolicyStatusEqual(oldPolicy.Status, newStatus) {
+		return false, nil
+	}
+	newPolicy := oldPolicy.DeepCopy()
+	newPolicy.Status = newStatus
+	return true, r.Client.Status().Patch(ctx, newPolicy, client.MergeFrom(oldPolicy))
+}
+
+func (r *BackendLBPolicyReconciler) buildBackendLBPolicyStatus(
+	ctx context.Context, policy *gatewayapi.BackendLBPolicy,
+) (gatewayapi.PolicyStatus, error) {
+	// Policies in the same namespace are needed to detect conflicts.
+	policies := &gatewayapi.BackendLBPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(policy.Namespace)); err != nil {
+		return gatewayapi.PolicyStatus{}, fmt.Errorf("failed listing BackendLBPolicies: %w", err)
+	}
+
+	var services []corev1.Service
+	for _, ref := range policy.Spec.TargetRefs {
+		if !kongstate.BackendLBPolicyTargetsService(policy, policy.Namespace, string(ref.Name)) ||
+			lo.ContainsBy(services, func(s corev1.Service) bool { return s.Name == string(ref.Name) }) {
+			continue
+		}
+		service := corev1.Service{}
+		if err := r.Get(ctx, k8stypes.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)}, &service); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return gatewayapi.PolicyStatus{}, err
+		}
+		services = append(services, service)
+	}
+	// Keep the oldest Services if there are more of them than the Gateway API permits.
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].CreationTimestamp.Before(&services[j].CreationTimestamp)
+	})
+	if len(services) > backendLBPolicyMaxAncestors {
+		info(r.Log, policy, "Status has more ancestors than the Gateway API permits, the newest ones will be ignored",
+			"ancestorsCount", len(services),
+			"maxAllowedAncestors", backendLBPolicyMaxAncestors,
+		)
+		services = services[:backendLBPolicyMaxAncestors]
+	}
+
+	policyStatus := gatewayapi.PolicyStatus{}
+	for _, service := range services {
+		service := service
+		acceptedCondition := metav1.Condition{
+			Type:               string(gatewayapi.PolicyConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayapi.PolicyReasonAccepted),
+			ObservedGeneration: policy.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
+		programmedCondition := metav1.Condition{
+			Type:               string(gatewayapi.GatewayConditionProgrammed),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayapi.GatewayReasonProgrammed),
+			ObservedGeneration: policy.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
+
+		upstreamPolicyName, err := r.getConflictingKongUpstreamPolicy(ctx, &service)
+		if err != nil {
+			return gatewayapi.PolicyStatus{}, err
+		}
+		conflicting, overridden := lo.Find(policies.Items, func(p gatewayapi.BackendLBPolicy) bool {
+			return isBackendLBPolicyOlder(&p, policy) &&
+				kongstate.BackendLBPolicyTargetsService(&p, service.Namespace, service.Name)
+		})
+		if overridden {
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayapi.PolicyReasonConflicted)
+			acceptedCondition.Message = fmt.Sprintf("Service is already targeted by BackendLBPolicy %s", conflicting.Name)
+		} else if upstreamPolicyName != "" {
+			// Session persistence takes precedence over the KongUpstreamPolicy, the conflict is reported
+			// on both policies nonetheless as the KongUpstreamPolicy settings are not fully applied.
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayapi.PolicyReasonConflicted)
+			acceptedCondition.Message = fmt.Sprintf(
+				"Service load balancing is also configured by KongUpstreamPolicy %s, session persistence takes precedence",
+				upstreamPolicyName,
+			)
+		}
+		// Unlike the KongUpstreamPolicy conflict, a conflict with an older BackendLBPolicy means this policy's
+		// settings are not applied at all.
+		if overridden || !r.DataplaneClient.KubernetesObjectIsConfigured(&service) {
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = string(gatewayapi.GatewayReasonPending)
+		}
+
+		policyStatus.Ancestors = append(policyStatus.Ancestors, gatewayapi.PolicyAncestorStatus{
+			AncestorRef: gatewayapi.ParentReference{
+				Group:     lo.ToPtr(gatewayapi.Group("core")),
+				Kind:      lo.ToPtr(gatewayapi.Kind("Service")),
+				Namespace: lo.ToPtr(gatewayapi.Namespace(service.Namespace)),
+				Name:      gatewayapi.ObjectName(service.Name),
+			},
+			ControllerName: GetControllerName(),
+			Conditions:     []metav1.Condition{acceptedCondition, programmedCondition},
+		})
+	}
+	return policyStatus, nil
+}
+
+// getConflictingKongUpstreamPolicy returns the name of the KongUpstreamPolicy attached to the Service if it
+// configures load balancing settings conflicting with session persistence, or an empty string otherwise.
+func (r *BackendLBPolicyReconciler) getConflictingKongUpstreamPolicy(ctx context.Context, service *corev1.Service) (string, error) {
+	policyName, ok := service.Annotations[kongv1beta1.KongUpstreamPolicyAnnotationKey]
+	if !ok {
+		return "", nil
+	}
+	upstreamPolicy := &kongv1beta1.KongUpstreamPolicy{}
+	if err := r.Get(ctx, k8stypes.NamespacedName{Namespace: service.Namespace, Name: policyName}, upstreamPolicy); err != nil {
+		// The KongUpstreamPolicy CRD may not be installed, in which case there's nothing to conflict with.
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if !kongstate.KongUpstreamPolicyConfiguresHashing(upstreamPolicy) {
+		return "", nil
+	}
+	return policyName, nil
+}
+
+// isBackendLBPolicyOlder returns true if policy a takes precedence over policy b, i.e. it was created earlier
+// or, when created at the same time, its name is alphabetically first.
+func isBackendLBPolicyOlder(a, b *gatewayapi.BackendLBPolicy) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// SetLogger sets the logger.
+func (r *BackendLBPolicyReconciler) SetLogger(l logr.Logger) {
+	r.Log = l
+}
@@ -0,0 +1,184 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+)
+
+func TestBackendLBPolicyReconciler_EnforceStatus(t *testing.T) {
+	const namespace = "default"
+	now := metav1.Now()
+	newPolicy := func(name string, created metav1.Time, serviceNames ...string) *gatewayapi.BackendLBPolicy {
+		return &gatewayapi.BackendLBPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: created},
+			Spec: gatewayapi.BackendLBPolicySpec{
+				TargetRefs: lo.Map(serviceNames, func(n string, _ int) gatewayapi.LocalPolicyTargetReference {
+					return gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: gatewayapi.ObjectName(n)}
+				}),
+				SessionPersistence: &gatewayapi.SessionPersistence{
+					SessionName: lo.ToPtr("session"),
+				},
+			},
+		}
+	}
+	serviceAncestor := func(name string, accepted, programmed metav1.Condition) gatewayapi.PolicyAncestorStatus {
+		return gatewayapi.PolicyAncestorStatus{
+			AncestorRef: gatewayapi.ParentReference{
+				Group:     lo.ToPtr(gatewayapi.Group("core")),
+				Kind:      lo.ToPtr(gatewayapi.Kind("Service")),
+				Namespace: lo.ToPtr(gatewayapi.Namespace(namespace)),
+				Name:      gatewayapi.ObjectName(name),
+			},
+			ControllerName: GetControllerName(),
+			Conditions:     []metav1.Condition{accepted, programmed},
+		}
+	}
+	accepted := metav1.Condition{
+		Type:   string(gatewayapi.PolicyConditionAccepted),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayapi.PolicyReasonAccepted),
+	}
+	programmed := metav1.Condition{
+		Type:   string(gatewayapi.GatewayConditionProgrammed),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayapi.GatewayReasonProgrammed),
+	}
+	pending := metav1.Condition{
+		Type:   string(gatewayapi.GatewayConditionProgrammed),
+		Status: metav1.ConditionFalse,
+		Reason: string(gatewayapi.GatewayReasonPending),
+	}
+	objects := []client.Object{
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "svc-1"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "svc-2"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        "svc-hashing",
+			Annotations: map[string]string{kongv1beta1.KongUpstreamPolicyAnnotationKey: "hashing"},
+		}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        "svc-round-robin",
+			Annotations: map[string]string{kongv1beta1.KongUpstreamPolicyAnnotationKey: "round-robin"},
+		}},
+		&kongv1beta1.KongUpstreamPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "hashing"},
+			Spec: kongv1beta1.KongUpstreamPolicySpec{
+				HashOn: &kongv1beta1.KongUpstreamHash{Header: lo.ToPtr("x-user")},
+			},
+		},
+		&kongv1beta1.KongUpstreamPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "round-robin"},
+			Spec: kongv1beta1.KongUpstreamPolicySpec{
+				Slots: lo.ToPtr(100),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		policy            *gatewayapi.BackendLBPolicy
+		otherPolicies     []client.Object
+		objectsConfigured bool
+		expectedStatus    gatewayapi.PolicyStatus
+	}{
+		{
+			name:              "accepted and programmed, not existing Service is skipped",
+			policy:            newPolicy("policy", now, "svc-1", "not-existing"),
+			objectsConfigured: true,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{serviceAncestor("svc-1", accepted, programmed)},
+			},
+		},
+		{
+			name:              "accepted, Service not configured yet",
+			policy:            newPolicy("policy", now, "svc-1"),
+			objectsConfigured: false,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{serviceAncestor("svc-1", accepted, pending)},
+			},
+		},
+		{
+			name:              "conflicting with a KongUpstreamPolicy configuring hashing",
+			policy:            newPolicy("policy", now, "svc-hashing", "svc-round-robin"),
+			objectsConfigured: true,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{
+					serviceAncestor("svc-hashing", metav1.Condition{
+						Type:    string(gatewayapi.PolicyConditionAccepted),
+						Status:  metav1.ConditionFalse,
+						Reason:  string(gatewayapi.PolicyReasonConflicted),
+						Message: "Service load balancing is also configured by KongUpstreamPolicy hashing, session persistence takes precedence",
+					}, programmed),
+					serviceAncestor("svc-round-robin", accepted, programmed),
+				},
+			},
+		},
+		{
+			name:   "conflicting with an older policy on one of the Services",
+			policy: newPolicy("policy", now, "svc-1", "svc-2"),
+			otherPolicies: []client.Object{
+				newPolicy("older", metav1.NewTime(now.Add(-time.Hour)), "svc-1"),
+				newPolicy("newer", metav1.NewTime(now.Add(time.Hour)), "svc-2"),
+			},
+			objectsConfigured: true,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{
+					serviceAncestor("svc-1", metav1.Condition{
+						Type:    string(gatewayapi.PolicyConditionAccepted),
+						Status:  metav1.ConditionFalse,
+						Reason:  string(gatewayapi.PolicyReasonConflicted),
+						Message: "Service is already targeted by BackendLBPolicy older",
+					}, pending),
+					serviceAncestor("svc-2", accepted, programmed),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := append([]client.Object{tc.policy}, objects...)
+			objs = append(objs, tc.otherPolicies...)
+			fakeClient := fakeclient.NewClientBuilder().
+				WithScheme(lo.Must(scheme.Get())).
+				WithObjects(objs...).
+				WithStatusSubresource(tc.policy).
+				Build()
+			reconciler := BackendLBPolicyReconciler{
+				Client:          fakeClient,
+				DataplaneClient: policyDataPlaneMock{objectsConfigured: tc.objectsConfigured},
+			}
+
+			policy := &gatewayapi.BackendLBPolicy{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.policy), policy))
+			updated, err := reconciler.enforceBackendLBPolicyStatus(context.Background(), policy)
+			require.NoError(t, err)
+			assert.True(t, updated)
+
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.policy), policy))
+			ignoreConditionTimeAndGeneration := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration")
+			assert.Empty(t, cmp.Diff(tc.expectedStatus, policy.Status, ignoreConditionTimeAndGeneration))
+
+			t.Log("Verifying the status is not updated again when nothing changed")
+			updated, err = reconciler.enforceBackendLBPolicyStatus(context.Background(), policy)
+			require.NoError(t, err)
+			assert.False(t, updated)
+		})
+	}
+}
@@ -0,0 +1,487 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers"
+	ctrlref "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/reference"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/kubernetes/object/status"
+)
+
+const (
+	// backendTLSPolicyTargetServiceIndexKey is the index of BackendTLSPolicies by "namespace/name" of targeted Services.
+	backendTLSPolicyTargetServiceIndexKey = "backendTLSPolicyTargetService"
+	// backendTLSPolicyCACertificateRefIndexKey is the index of BackendTLSPolicies by "kind/namespace/name" of
+	// referenced CA certificates' ConfigMaps and Secrets.
+	backendTLSPolicyCACertificateRefIndexKey = "backendTLSPolicyCACertificateRef"
+
+	// backendTLSPolicyCACertificateKey is the key of a ConfigMap's or Secret's data holding a CA certificate.
+	backendTLSPolicyCACertificateKey = "ca.crt"
+
+	// backendTLSPolicyMaxAncestors is the maximum number of ancestors that can be stored in the BackendTLSPolicy
+	// status. This is a limitation of the Gateway API.
+	backendTLSPolicyMaxAncestors = 16
+
+	// backendTLSPolicyHostnameMismatchMessage is the message of the Accepted condition of policies whose hostname
+	// is not the Host header Kong sends to the targeted Service. Kong uses that Host header as the SNI and to verify
+	// the upstream certificate, so it has to be the policy's hostname.
+	backendTLSPolicyHostnameMismatchMessage = "hostname %q has to be set as the Service's %s annotation, " +
+		"Kong uses the upstream Host header as the SNI and to verify the upstream certificate"
+)
+
+// BackendTLSPolicyReconciler reconciles BackendTLSPolicy resources.
+type BackendTLSPolicyReconciler struct {
+	client.Client
+
+	Log               logr.Logger
+	Scheme            *runtime.Scheme
+	DataplaneClient   controllers.DataPlane
+	CacheSyncTimeout  time.Duration
+	StatusQueue       *status.Queue
+	ReferenceIndexers ctrlref.CacheIndexers
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackendTLSPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetCache().IndexField(
+		context.Background(),
+		&gatewayapi.BackendTLSPolicy{},
+		backendTLSPolicyTargetServiceIndexKey,
+		indexBackendTLSPolicyOnTargetServices,
+	); err != nil {
+		return fmt.Errorf("failed to index BackendTLSPolicies on target Services: %w", err)
+	}
+	if err := mgr.GetCache().IndexField(
+		context.Background(),
+		&gatewayapi.BackendTLSPolicy{},
+		backendTLSPolicyCACertificateRefIndexKey,
+		indexBackendTLSPolicyOnCACertificateRefs,
+	); err != nil {
+		return fmt.Errorf("failed to index BackendTLSPolicies on CA certificate references: %w", err)
+	}
+
+	blder := ctrl.NewControllerManagedBy(mgr).
+		Named("backendtlspolicy-controller").
+		WithOptions(controller.Options{
+			LogConstructor: func(_ *reconcile.Request) logr.Logger {
+				return r.Log
+			},
+			CacheSyncTimeout: r.CacheSyncTimeout,
+		}).
+		Watches(&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.listBackendTLSPoliciesForService),
+		).
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.listBackendTLSPoliciesForCACertificate("ConfigMap")),
+		).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.listBackendTLSPoliciesForCACertificate("Secret")),
+		)
+
+	if r.StatusQueue != nil {
+		// Services' configuration status changes have to be propagated to the policies' ancestor Programmed status.
+		blder.WatchesRawSource(
+			source.Channel(
+				r.StatusQueue.Subscribe(schema.GroupVersionKind{
+					Version: "v1",
+					Kind:    "Service",
+				}),
+				handler.EnqueueRequestsFromMapFunc(r.listBackendTLSPoliciesForService),
+			),
+		)
+	}
+
+	return blder.For(&gatewayapi.BackendTLSPolicy{}).
+		Complete(r)
+}
+
+// -----------------------------------------------------------------------------
+// BackendTLSPolicy Controller - Indexers
+// -----------------------------------------------------------------------------
+
+// indexBackendTLSPolicyOnTargetServices indexes BackendTLSPolicies on "namespace/name" of the Services they target.
+func indexBackendTLSPolicyOnTargetServices(o client.Object) []string {
+	policy, ok := o.(*gatewayapi.BackendTLSPolicy)
+	if !ok {
+		return []string{}
+	}
+	return lo.Uniq(lo.FilterMap(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReferenceWithSectionName, _ int) (string, bool) {
+		return policy.Namespace + "/" + string(ref.Name), isBackendTLSPolicyTargetRefService(ref)
+	}))
+}
+
+// indexBackendTLSPolicyOnCACertificateRefs indexes BackendTLSPolicies on "kind/namespace/name" of the ConfigMaps
+// and Secrets they reference as CA certificates.
+func indexBackendTLSPolicyOnCACertificateRefs(o client.Object) []string {
+	policy, ok := o.(*gatewayapi.BackendTLSPolicy)
+	if !ok {
+		return []string{}
+	}
+	return lo.Uniq(lo.Map(policy.Spec.Validation.CACertificateRefs, func(ref gatewayapi.LocalObjectReference, _ int) string {
+		return backendTLSPolicyCACertificateRefKey(string(ref.Kind), policy.Namespace, string(ref.Name))
+	}))
+}
+
+func backendTLSPolicyCACertificateRefKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func isBackendTLSPolicyTargetRefService(ref gatewayapi.LocalPolicyTargetReferenceWithSectionName) bool {
+	return (ref.Group == "" || ref.Group == "core") && ref.Kind == "Service"
+}
+
+// -----------------------------------------------------------------------------
+// BackendTLSPolicy Controller - Watch Predicates
+// -----------------------------------------------------------------------------
+
+// listBackendTLSPoliciesForService enqueues reconcile requests for all the BackendTLSPolicies targeting a Service.
+func (r *BackendTLSPolicyReconciler) listBackendTLSPoliciesForService(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.listBackendTLSPoliciesByIndex(ctx, obj.GetNamespace(), backendTLSPolicyTargetServiceIndexKey,
+		obj.GetNamespace()+"/"+obj.GetName())
+}
+
+// listBackendTLSPoliciesForCACertificate returns a map function enqueueing reconcile requests for all
+// the BackendTLSPolicies referencing an object of the given kind as a CA certificate.
+func (r *BackendTLSPolicyReconciler) listBackendTLSPoliciesForCACertificate(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return r.listBackendTLSPoliciesByIndex(ctx, obj.GetNamespace(), backendTLSPolicyCACertificateRefIndexKey,
+			backendTLSPolicyCACertificateRefKey(kind, obj.GetNamespace(), obj.GetName()))
+	}
+}
+
+func (r *BackendTLSPolicyReconciler) listBackendTLSPoliciesByIndex(ctx context.Context, namespace, index, value string) []reconcile.Request {
+	policies := &gatewayapi.BackendTLSPolicyList{}
+	if err := r.List(ctx, policies,
+		client.InNamespace(namespace),
+		client.MatchingFields{index: value},
+	); err != nil {
+		r.Log.Error(err, "Failed to list BackendTLSPolicies in watch predicates", index, value)
+		return nil
+	}
+	return lo.Map(policies.Items, func(p gatewayapi.BackendTLSPolicy, _ int) reconcile.Request {
+		return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)}
+	})
+}
+
+// -----------------------------------------------------------------------------
+// BackendTLSPolicy Controller - Reconciliation
+// -----------------------------------------------------------------------------
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile processes the watched objects.
+func (r *BackendTLSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("GatewayV1Alpha3BackendTLSPolicy", req.NamespacedName)
+
+	policy := new(gatewayapi.BackendTLSPolicy)
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			debug(log, policy, "Object does not exist, ensuring it is not present in the proxy cache")
+			policy.Namespace = req.Namespace
+			policy.Name = req.Name
+			if err := ctrlref.DeleteReferencesByReferrer(r.ReferenceIndexers, r.DataplaneClient, policy); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, r.DataplaneClient.DeleteObject(policy)
+		}
+		return ctrl.Result{}, err
+	}
+	debug(log, policy, "Processing BackendTLSPolicy")
+
+	// clean the object up if it's being deleted
+	if !policy.DeletionTimestamp.IsZero() && time.Now().After(policy.DeletionTimestamp.Time) {
+		debug(log, policy, "Resource is being deleted, its configuration will be removed")
+		if err := ctrlref.DeleteReferencesByReferrer(r.ReferenceIndexers, r.DataplaneClient, policy); err != nil {
+			return ctrl.Result{}, err
+		}
+		objectExistsInCache, err := r.DataplaneClient.ObjectExists(policy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if objectExistsInCache {
+			if err := r.DataplaneClient.DeleteObject(policy); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil // wait until the object is no longer present in the cache
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// make the referenced CA certificates available to the translator
+	caCertificatesErr, err := r.syncCACertificates(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// enforce the desired BackendTLSPolicy status
+	updated, err := r.enforceBackendTLSPolicyStatus(ctx, policy, caCertificatesErr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if updated {
+		// status update will re-trigger reconciliation
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.DataplaneClient.UpdateObject(policy); err != nil {
+		debug(log, policy, "Failed to update object in data-plane, requeueing")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// syncCACertificates pushes ConfigMaps and Secrets referenced by the BackendTLSPolicy to the data-plane cache.
+// It returns a non-nil caCertificatesErr when any of the references cannot be resolved, which should be
+// reflected in the policy's status, and a non-nil err when the reconciliation should be retried.
+func (r *BackendTLSPolicyReconciler) syncCACertificates(
+	ctx context.Context, policy *gatewayapi.BackendTLSPolicy,
+) (caCertificatesErr error, err error) {
+	validation := policy.Spec.Validation
+	if len(validation.CACertificateRefs) == 0 && validation.WellKnownCACertificates == nil {
+		caCertificatesErr = fmt.Errorf("either caCertificateRefs or wellKnownCACertificates must be specified")
+	}
+	if validation.WellKnownCACertificates != nil && *validation.WellKnownCACertificates != gatewayapi.WellKnownCACertificatesSystem {
+		caCertificatesErr = fmt.Errorf("unsupported wellKnownCACertificates %q", *validation.WellKnownCACertificates)
+	}
+
+	secretNames := make(map[k8stypes.NamespacedName]struct{})
+	for _, ref := range validation.CACertificateRefs {
+		nn := k8stypes.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)}
+		if ref.Group != "" && ref.Group != "core" {
+			caCertificatesErr = fmt.Errorf("unsupported caCertificateRef group %q", ref.Group)
+			continue
+		}
+		switch ref.Kind {
+		case "ConfigMap":
+			configMap := &corev1.ConfigMap{}
+			if err := r.Get(ctx, nn, configMap); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				configMap.Namespace, configMap.Name = nn.Namespace, nn.Name
+				if err := r.DataplaneClient.DeleteObject(configMap); err != nil {
+					return nil, err
+				}
+				caCertificatesErr = fmt.Errorf("ConfigMap %s not found", nn)
+				continue
+			}
+			if _, ok := configMap.Data[backendTLSPolicyCACertificateKey]; !ok {
+				caCertificatesErr = fmt.Errorf("ConfigMap %s is missing %q key", nn, backendTLSPolicyCACertificateKey)
+			}
+			if err := r.DataplaneClient.UpdateObject(configMap); err != nil {
+				return nil, err
+			}
+		case "Secret":
+			secretNames[nn] = struct{}{}
+			secret := &corev1.Secret{}
+			if err := r.Get(ctx, nn, secret); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				caCertificatesErr = fmt.Errorf("Secret %s not found", nn)
+				continue
+			}
+			if _, ok := secret.Data[backendTLSPolicyCACertificateKey]; !ok {
+				caCertificatesErr = fmt.Errorf("Secret %s is missing %q key", nn, backendTLSPolicyCACertificateKey)
+			}
+		default:
+			caCertificatesErr = fmt.Errorf("unsupported caCertificateRef kind %q", ref.Kind)
+		}
+	}
+
+	// Secrets are synced through the reference indexers so that the Secret controller keeps them up to date.
+	if err := ctrlref.UpdateReferencesToSecret(
+		ctx, r.Client, r.ReferenceIndexers, r.DataplaneClient, policy, secretNames,
+	); err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return caCertificatesErr, nil
+}
+
+// enforceBackendTLSPolicyStatus builds the desired status of the BackendTLSPolicy with an ancestor for each
+// targeted Service and patches the policy if it differs from the current one.
+func (r *BackendTLSPolicyReconciler) enforceBackendTLSPolicyStatus(
+	ctx context.Context, oldPolicy *gatewayapi.BackendTLSPolicy, caCertificatesErr error,
+) (bool, error) {
+	newStatus, err := r.buildBackendTLSPolicyStatus(ctx, oldPolicy, caCertificatesErr)
+	if err != nil {
+		return false, err
+	}
+	if isPolicyStatusEqual(oldPolicy.Status, newStatus) {
+		return false, nil
+	}
+	newPolicy := oldPolicy.DeepCopy()
+	newPolicy.Status = newStatus
+	return true, r.Client.Status().Patch(ctx, newPolicy, client.MergeFrom(oldPolicy))
+}
+
+func (r *BackendTLSPolicyReconciler) buildBackendTLSPolicyStatus(
+	ctx context.Context, policy *gatewayapi.BackendTLSPolicy, caCertificatesErr error,
+) (gatewayapi.PolicyStatus, error) {
+	// Policies in the same namespace are needed to detect conflicts.
+	policies := &gatewayapi.BackendTLSPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(policy.Namespace)); err != nil {
+		return gatewayapi.PolicyStatus{}, fmt.Errorf("failed listing BackendTLSPolicies: %w", err)
+	}
+
+	var services []corev1.Service
+	for _, serviceName := range lo.Uniq(lo.FilterMap(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReferenceWithSectionName, _ int) (string, bool) {
+		return string(ref.Name), isBackendTLSPolicyTargetRefService(ref)
+	})) {
+		service := corev1.Service{}
+		if err := r.Get(ctx, k8stypes.NamespacedName{Namespace: policy.Namespace, Name: serviceName}, &service); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return gatewayapi.PolicyStatus{}, err
+		}
+		services = append(services, service)
+	}
+	// Keep the oldest Services if there are more of them than the Gateway API permits.
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].CreationTimestamp.Before(&services[j].CreationTimestamp)
+	})
+	if len(services) > backendTLSPolicyMaxAncestors {
+		info(r.Log, policy, "Status has more ancestors than the Gateway API permits, the newest ones will be ignored",
+			"ancestorsCount", len(services),
+			"maxAllowedAncestors", backendTLSPolicyMaxAncestors,
+		)
+		services = services[:backendTLSPolicyMaxAncestors]
+	}
+
+	policyStatus := gatewayapi.PolicyStatus{}
+	for _, service := range services {
+		service := service
+		acceptedCondition := metav1.Condition{
+			Type:               string(gatewayapi.PolicyConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayapi.PolicyReasonAccepted),
+			ObservedGeneration: policy.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
+		programmedCondition := metav1.Condition{
+			Type:               string(gatewayapi.GatewayConditionProgrammed),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayapi.GatewayReasonProgrammed),
+			ObservedGeneration: policy.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
+
+		if conflicting, ok := lo.Find(policies.Items, func(p gatewayapi.BackendTLSPolicy) bool {
+			return isBackendTLSPolicyOlder(&p, policy) && backendTLSPoliciesOverlapOnService(&p, policy, service.Name)
+		}); ok {
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayapi.PolicyReasonConflicted)
+			acceptedCondition.Message = fmt.Sprintf("Service is already targeted by BackendTLSPolicy %s", conflicting.Name)
+		} else if caCertificatesErr != nil {
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayapi.PolicyReasonInvalid)
+			acceptedCondition.Message = caCertificatesErr.Error()
+		} else if hostname := string(policy.Spec.Validation.Hostname); annotations.ExtractHostHeader(service.Annotations) != hostname {
+			// Routes preserving the client's Host header are reported by the translator, the Service is then
+			// not Programmed.
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayapi.PolicyReasonInvalid)
+			acceptedCondition.Message = fmt.Sprintf(backendTLSPolicyHostnameMismatchMessage,
+				hostname, annotations.AnnotationPrefix+annotations.HostHeaderKey)
+		}
+		if acceptedCondition.Status == metav1.ConditionFalse || !r.DataplaneClient.KubernetesObjectIsConfigured(&service) {
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = string(gatewayapi.GatewayReasonPending)
+		}
+
+		policyStatus.Ancestors = append(policyStatus.Ancestors, gatewayapi.PolicyAncestorStatus{
+			AncestorRef: gatewayapi.ParentReference{
+				Group:     lo.ToPtr(gatewayapi.Group("core")),
+				Kind:      lo.ToPtr(gatewayapi.Kind("Service")),
+				Namespace: lo.ToPtr(gatewayapi.Namespace(service.Namespace)),
+				Name:      gatewayapi.ObjectName(service.Name),
+			},
+			ControllerName: GetControllerName(),
+			Conditions:     []metav1.Condition{acceptedCondition, programmedCondition},
+		})
+	}
+	return policyStatus, nil
+}
+
+// isBackendTLSPolicyOlder returns true if policy a takes precedence over policy b, i.e. it was created earlier
+// or, when created at the same time, its name is alphabetically first.
+func isBackendTLSPolicyOlder(a, b *gatewayapi.BackendTLSPolicy) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// backendTLSPoliciesOverlapOnService returns true if both policies target the same port of the Service
+// with the given name. A target reference without a section name targets all the Service's ports.
+func backendTLSPoliciesOverlapOnService(a, b *gatewayapi.BackendTLSPolicy, serviceName string) bool {
+	targetRefs := func(p *gatewayapi.BackendTLSPolicy) []gatewayapi.LocalPolicyTargetReferenceWithSectionName {
+		return lo.Filter(p.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReferenceWithSectionName, _ int) bool {
+			return isBackendTLSPolicyTargetRefService(ref) && string(ref.Name) == serviceName
+		})
+	}
+	for _, refA := range targetRefs(a) {
+		for _, refB := range targetRefs(b) {
+			if refA.SectionName == nil || refB.SectionName == nil || *refA.SectionName == *refB.SectionName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isPolicyStatusEqual compares policy statuses ignoring conditions' transition times.
+func isPolicyStatusEqual(oldStatus, newStatus gatewayapi.PolicyStatus) bool {
+	if len(oldStatus.Ancestors) != len(newStatus.Ancestors) {
+		return false
+	}
+	for i, oldAncestor := range oldStatus.Ancestors {
+		newAncestor := newStatus.Ancestors[i]
+		if newAncestor.ControllerName != oldAncestor.ControllerName ||
+			!reflect.DeepEqual(newAncestor.AncestorRef, oldAncestor.AncestorRef) ||
+			len(oldAncestor.Conditions) != len(newAncestor.Conditions) {
+			return false
+		}
+		for j, oldCondition := range oldAncestor.Conditions {
+			newCondition := newAncestor.Conditions[j]
+			if newCondition.Type != oldCondition.Type ||
+				newCondition.Status != oldCondition.Status ||
+				newCondition.Reason != oldCondition.Reason ||
+				newCondition.Message != oldCondition.Message ||
+				newCondition.ObservedGeneration != oldCondition.ObservedGeneration {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SetLogger sets the logger.
+func (r *BackendTLSPolicyReconciler) SetLogger(l logr.Logger) {
+	r.Log = l
+}
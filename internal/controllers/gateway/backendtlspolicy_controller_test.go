@@ -0,0 +1,192 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+)
+
+type policyDataPlaneMock struct {
+	controllers.DataPlane
+	objectsConfigured bool
+}
+
+func (d policyDataPlaneMock) KubernetesObjectIsConfigured(client.Object) bool {
+	return d.objectsConfigured
+}
+
+func TestBackendTLSPolicyReconciler_EnforceStatus(t *testing.T) {
+	const namespace = "default"
+	now := metav1.Now()
+	newPolicy := func(name string, created metav1.Time, targetRefs ...gatewayapi.LocalPolicyTargetReferenceWithSectionName) *gatewayapi.BackendTLSPolicy {
+		return &gatewayapi.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: created},
+			Spec: gatewayapi.BackendTLSPolicySpec{
+				TargetRefs: targetRefs,
+				Validation: gatewayapi.BackendTLSPolicyValidation{
+					WellKnownCACertificates: lo.ToPtr(gatewayapi.WellKnownCACertificatesSystem),
+					Hostname:                "example.com",
+				},
+			},
+		}
+	}
+	serviceTargetRef := func(name string, sectionName *string) gatewayapi.LocalPolicyTargetReferenceWithSectionName {
+		return gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: gatewayapi.ObjectName(name)},
+			SectionName:                (*gatewayapi.SectionName)(sectionName),
+		}
+	}
+	serviceAncestor := func(name string, accepted, programmed metav1.Condition) gatewayapi.PolicyAncestorStatus {
+		return gatewayapi.PolicyAncestorStatus{
+			AncestorRef: gatewayapi.ParentReference{
+				Group:     lo.ToPtr(gatewayapi.Group("core")),
+				Kind:      lo.ToPtr(gatewayapi.Kind("Service")),
+				Namespace: lo.ToPtr(gatewayapi.Namespace(namespace)),
+				Name:      gatewayapi.ObjectName(name),
+			},
+			ControllerName: GetControllerName(),
+			Conditions:     []metav1.Condition{accepted, programmed},
+		}
+	}
+	accepted := metav1.Condition{
+		Type:   string(gatewayapi.PolicyConditionAccepted),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayapi.PolicyReasonAccepted),
+	}
+	programmed := metav1.Condition{
+		Type:   string(gatewayapi.GatewayConditionProgrammed),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayapi.GatewayReasonProgrammed),
+	}
+	pending := metav1.Condition{
+		Type:   string(gatewayapi.GatewayConditionProgrammed),
+		Status: metav1.ConditionFalse,
+		Reason: string(gatewayapi.GatewayReasonPending),
+	}
+	hostHeader := map[string]string{"konghq.com/host-header": "example.com"}
+	services := []client.Object{
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "svc-1", Annotations: hostHeader}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "svc-2", Annotations: hostHeader}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "svc-other-host"}},
+	}
+
+	testCases := []struct {
+		name              string
+		policy            *gatewayapi.BackendTLSPolicy
+		otherPolicies     []client.Object
+		objectsConfigured bool
+		caCertificatesErr error
+		expectedStatus    gatewayapi.PolicyStatus
+	}{
+		{
+			name:              "accepted and programmed, not existing Service is skipped",
+			policy:            newPolicy("policy", now, serviceTargetRef("svc-1", nil), serviceTargetRef("not-existing", nil)),
+			objectsConfigured: true,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{serviceAncestor("svc-1", accepted, programmed)},
+			},
+		},
+		{
+			name:              "accepted, Service not configured yet",
+			policy:            newPolicy("policy", now, serviceTargetRef("svc-1", nil)),
+			objectsConfigured: false,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{serviceAncestor("svc-1", accepted, pending)},
+			},
+		},
+		{
+			name:              "invalid CA certificates",
+			policy:            newPolicy("policy", now, serviceTargetRef("svc-1", nil)),
+			objectsConfigured: true,
+			caCertificatesErr: errors.New("ConfigMap default/ca not found"),
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{serviceAncestor("svc-1", metav1.Condition{
+					Type:    string(gatewayapi.PolicyConditionAccepted),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(gatewayapi.PolicyReasonInvalid),
+					Message: "ConfigMap default/ca not found",
+				}, pending)},
+			},
+		},
+		{
+			name:              "hostname not sent as the Service's Host header",
+			policy:            newPolicy("policy", now, serviceTargetRef("svc-other-host", nil)),
+			objectsConfigured: true,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{serviceAncestor("svc-other-host", metav1.Condition{
+					Type:   string(gatewayapi.PolicyConditionAccepted),
+					Status: metav1.ConditionFalse,
+					Reason: string(gatewayapi.PolicyReasonInvalid),
+					Message: `hostname "example.com" has to be set as the Service's konghq.com/host-header annotation, ` +
+						"Kong uses the upstream Host header as the SNI and to verify the upstream certificate",
+				}, pending)},
+			},
+		},
+		{
+			name:   "conflicting with an older policy on one of the Services",
+			policy: newPolicy("policy", now, serviceTargetRef("svc-1", lo.ToPtr("https")), serviceTargetRef("svc-2", lo.ToPtr("https"))),
+			otherPolicies: []client.Object{
+				newPolicy("older", metav1.NewTime(now.Add(-time.Hour)), serviceTargetRef("svc-1", nil), serviceTargetRef("svc-2", lo.ToPtr("http"))),
+				newPolicy("newer", metav1.NewTime(now.Add(time.Hour)), serviceTargetRef("svc-2", nil)),
+			},
+			objectsConfigured: true,
+			expectedStatus: gatewayapi.PolicyStatus{
+				Ancestors: []gatewayapi.PolicyAncestorStatus{
+					serviceAncestor("svc-1", metav1.Condition{
+						Type:    string(gatewayapi.PolicyConditionAccepted),
+						Status:  metav1.ConditionFalse,
+						Reason:  string(gatewayapi.PolicyReasonConflicted),
+						Message: "Service is already targeted by BackendTLSPolicy older",
+					}, pending),
+					serviceAncestor("svc-2", accepted, programmed),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := append([]client.Object{tc.policy}, services...)
+			objs = append(objs, tc.otherPolicies...)
+			fakeClient := fakeclient.NewClientBuilder().
+				WithScheme(lo.Must(scheme.Get())).
+				WithObjects(objs...).
+				WithStatusSubresource(tc.policy).
+				Build()
+			reconciler := BackendTLSPolicyReconciler{
+				Client:          fakeClient,
+				DataplaneClient: policyDataPlaneMock{objectsConfigured: tc.objectsConfigured},
+			}
+
+			policy := &gatewayapi.BackendTLSPolicy{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.policy), policy))
+			updated, err := reconciler.enforceBackendTLSPolicyStatus(context.Background(), policy, tc.caCertificatesErr)
+			require.NoError(t, err)
+			assert.True(t, updated)
+
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(tc.policy), policy))
+			ignoreConditionTimeAndGeneration := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration")
+			assert.Empty(t, cmp.Diff(tc.expectedStatus, policy.Status, ignoreConditionTimeAndGeneration))
+
+			t.Log("Verifying the status is not updated again when nothing changed")
+			updated, err = reconciler.enforceBackendTLSPolicyStatus(context.Background(), policy, tc.caCertificatesErr)
+			require.NoError(t, err)
+			assert.False(t, updated)
+		})
+	}
+}
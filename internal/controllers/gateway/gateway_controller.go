@@ -13,7 +13,9 @@ import (
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
 	"github.com/samber/mo"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,9 +25,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
@@ -69,6 +74,10 @@ type GatewayReconciler struct { //nolint:revive
 	// If GatewayNN is set,
 	// only resources managed by the specified Gateway are reconciled.
 	GatewayNN controllers.OptionalNamespacedName
+
+	// ManagedGateways, if set, enables provisioning of Kong proxies for Gateways whose GatewayClass
+	// is not annotated as unmanaged. If nil, such Gateways are not provisioned by the controller.
+	ManagedGateways *ManagedGatewaysConfig
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -120,9 +129,37 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// if a HTTPRoute gets accepted by a Gateway, we need to make sure to trigger
 		// reconciliation on the gateway, as we need to update the number of attachedRoutes.
 		Watches(&gatewayapi.HTTPRoute{},
-			handler.EnqueueRequestsFromMapFunc(r.listGatewaysForHTTPRoute),
+			handler.EnqueueRequestsFromMapFunc(listGatewaysForRoute[*gatewayapi.HTTPRoute](r)),
 		)
 
+	// the same applies to other route kinds, which are watched only if their CRDs are installed
+	for _, route := range []struct {
+		gvr     schema.GroupVersionResource
+		obj     client.Object
+		mapFunc handler.MapFunc
+	}{
+		{gvr: gatewayv1.SchemeGroupVersion.WithResource("grpcroutes"), obj: &gatewayapi.GRPCRoute{}, mapFunc: listGatewaysForRoute[*gatewayapi.GRPCRoute](r)},
+		{gvr: gatewayv1alpha2.SchemeGroupVersion.WithResource("tcproutes"), obj: &gatewayapi.TCPRoute{}, mapFunc: listGatewaysForRoute[*gatewayapi.TCPRoute](r)},
+		{gvr: gatewayv1alpha2.SchemeGroupVersion.WithResource("udproutes"), obj: &gatewayapi.UDPRoute{}, mapFunc: listGatewaysForRoute[*gatewayapi.UDPRoute](r)},
+		{gvr: gatewayv1alpha2.SchemeGroupVersion.WithResource("tlsroutes"), obj: &gatewayapi.TLSRoute{}, mapFunc: listGatewaysForRoute[*gatewayapi.TLSRoute](r)},
+	} {
+		if ctrlutils.CRDExists(mgr.GetRESTMapper(), route.gvr) {
+			blder.Watches(route.obj, handler.EnqueueRequestsFromMapFunc(route.mapFunc))
+		}
+	}
+
+	// watch resources provisioned for managed Gateways and EndpointSlices of admin Services of their proxies and of
+	// data-planes dedicated to unmanaged Gateways.
+	if r.ManagedGateways != nil {
+		blder.Owns(&appsv1.Deployment{}).
+			Owns(&corev1.Service{}).
+			Owns(&corev1.ConfigMap{}).
+			Owns(&corev1.Secret{}).
+			Watches(&discoveryv1.EndpointSlice{},
+				handler.EnqueueRequestsFromMapFunc(r.listGatewaysForEndpointSlice),
+			)
+	}
+
 	// watch ReferenceGrants, which may invalidate or allow cross-namespace TLSConfigs
 	if r.enableReferenceGrant {
 		blder.Watches(&gatewayapi.ReferenceGrant{},
@@ -315,29 +352,32 @@ func (r *GatewayReconciler) listGatewaysForService(ctx context.Context, svc clie
 	return nil
 }
 
-// listGatewaysForHTTPRoute retrieves all the gateways referenced as parents by the HTTPRoute.
-func (r *GatewayReconciler) listGatewaysForHTTPRoute(_ context.Context, obj client.Object) []reconcile.Request {
-	httpRoute, ok := obj.(*gatewayapi.HTTPRoute)
-	if !ok {
-		r.Log.Error(
-			fmt.Errorf("unexpected object type"),
-			"HTTPRoute watch predicate received unexpected object type",
-			"expected", "*gatewayapi.HTTPRoute", "found", reflect.TypeOf(obj),
-		)
-		return nil
-	}
-	recs := []reconcile.Request{}
-	for _, gateway := range routeAcceptedByGateways(httpRoute) {
-		if !r.GatewayNN.MatchesNN(gateway) {
-			continue
+// listGatewaysForRoute returns a map function enqueueing all the Gateways the route of type T
+// has been accepted by.
+func listGatewaysForRoute[T gatewayapi.RouteT](r *GatewayReconciler) handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		route, ok := obj.(T)
+		if !ok {
+			r.Log.Error(
+				fmt.Errorf("unexpected object type"),
+				"Route watch predicate received unexpected object type",
+				"expected", fmt.Sprintf("%T", *new(T)), "found", reflect.TypeOf(obj),
+			)
+			return nil
 		}
+		recs := []reconcile.Request{}
+		for _, gateway := range routeAcceptedByGateways(route) {
+			if !r.GatewayNN.MatchesNN(gateway) {
+				continue
+			}
 
-		recs = append(recs, reconcile.Request{
-			NamespacedName: gateway,
-		})
-	}
+			recs = append(recs, reconcile.Request{
+				NamespacedName: gateway,
+			})
+		}
 
-	return recs
+		return recs
+	}
 }
 
 // isGatewayService is a watch predicate that filters out events for objects that aren't
@@ -404,6 +444,12 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	debug(log, gateway, "Processing gateway")
 
+	// managed Gateways carry a finalizer that has to be removed regardless of their GatewayClass
+	// being still available or managed, otherwise they would never get deleted.
+	if gateway.DeletionTimestamp != nil && controllerutil.ContainsFinalizer(gateway, ManagedGatewayFinalizer) {
+		return r.finalizeManagedGateway(ctx, log, gateway)
+	}
+
 	// though our watch configuration eliminates reconciliation of unsupported gateways it's
 	// technically possible for the gatewayclass configuration of a gateway to change in
 	// the interim while the object has been queued for reconciliation. This double check
@@ -440,8 +486,8 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// if there's any deletion timestamp on the object, we can simply ignore it. At this point
-	// with unmanaged mode being the only option supported there are no finalizers and
-	// the object should be cleaned up by GC promptly.
+	// the managed Gateway finalizer has been handled already and the object should be cleaned
+	// up by GC promptly.
 	debug(log, gateway, "Checking deletion timestamp")
 	if gateway.DeletionTimestamp != nil {
 		debug(log, gateway, "Gateway is being deleted, ignoring")
@@ -456,11 +502,17 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return reconcile.Result{}, nil
 	}
 
-	if isGatewayClassUnmanaged(gwc.Annotations) {
-		// The Gateway has to be reconciled by KIC only if it is unmanaged.
+	switch {
+	case isGatewayClassUnmanaged(gwc.Annotations):
+		// The Gateway has to be reconciled by KIC if it is unmanaged.
 		if result, err := r.reconcileUnmanagedGateway(ctx, log, gateway); err != nil {
 			return result, err
 		}
+	case r.ManagedGateways != nil:
+		// Or if it is managed and managed Gateways are enabled.
+		if result, err := r.reconcileManagedGateway(ctx, log, gateway); err != nil {
+			return result, err
+		}
 	}
 
 	// If the Gateway has been accepted (by KIC or the managing controller), the dataplane update must be performed.
@@ -509,6 +561,14 @@ func (r *GatewayReconciler) reconcileUnmanagedGateway(ctx context.Context, log l
 		return ctrl.Result{}, r.Update(ctx, gateway)
 	}
 
+	// unmanaged Gateways can have a data-plane of their own when managed Gateways are enabled.
+	if r.ManagedGateways != nil {
+		debug(log, gateway, "Registering Admin API endpoints of the gateway's dedicated data-plane if any")
+		if updated, err := r.reconcileDedicatedAdminAPIs(ctx, log, gateway); err != nil || updated {
+			return ctrl.Result{}, err
+		}
+	}
+
 	serviceRefs := annotations.ExtractGatewayPublishService(gateway.Annotations)
 	// Validation check of the Gateway to ensure that the ingress service is actually available
 	// in the cluster. If it is not the object will be requeued until it exists (or is otherwise retrievable).
@@ -642,6 +702,7 @@ var (
 	// supportedKinds indicates which gateway kinds are supported by this implementation.
 	supportedKinds = []gatewayapi.Kind{
 		gatewayapi.Kind("HTTPRoute"),
+		gatewayapi.Kind("GRPCRoute"),
 		gatewayapi.Kind("TCPRoute"),
 		gatewayapi.Kind("UDPRoute"),
 		gatewayapi.Kind("TLSRoute"),
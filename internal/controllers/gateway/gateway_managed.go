@@ -0,0 +1,703 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
+)
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultManagedGatewayProxyImage is the default Kong Gateway image used for proxies of managed Gateways.
+	DefaultManagedGatewayProxyImage = "kong:3.7"
+
+	// ManagedGatewayFinalizer is set on managed Gateways and on unmanaged Gateways with a dedicated data-plane
+	// to make sure the Admin API endpoints of their data-planes are unregistered before the Gateways are gone.
+	ManagedGatewayFinalizer = gatewayapi.ManagedGatewayFinalizer
+
+	// managedGatewayAdminPort is the port Kong Admin API of a managed proxy listens on.
+	managedGatewayAdminPort = 8444
+	// managedGatewayStatusPort is the port Kong status API of a managed proxy listens on.
+	managedGatewayStatusPort = 8100
+	// managedGatewayUnprivilegedPortOffset is added to listener ports below 1024, as the Kong
+	// container is not allowed to bind to privileged ports.
+	managedGatewayUnprivilegedPortOffset = 8000
+
+	// managedGatewayAdminClientCAKey is the key of the Admin API client CA certificate in the ConfigMap
+	// provisioned for a managed Gateway.
+	managedGatewayAdminClientCAKey = "ca.crt"
+	// managedGatewayAdminClientCAMountPath is where the Admin API client CA ConfigMap is mounted in the proxy container.
+	managedGatewayAdminClientCAMountPath = "/etc/kong/admin-client-ca"
+	// managedGatewayAdminClientCAChecksumAnnotation is set on the proxy pod template, so that proxies are restarted
+	// to pick up a changed Admin API client CA certificate.
+	managedGatewayAdminClientCAChecksumAnnotation = "konghq.com/admin-client-ca-checksum"
+	// managedGatewayAdminTLSMountPath is where the Admin API certificate Secret is mounted in the proxy container.
+	managedGatewayAdminTLSMountPath = "/etc/kong/admin-tls"
+	// managedGatewayAdminTLSChecksumAnnotation is set on the proxy pod template, so that proxies are restarted
+	// to pick up a changed Admin API certificate.
+	managedGatewayAdminTLSChecksumAnnotation = "konghq.com/admin-tls-checksum"
+
+	// managedGatewayProxyContainerName is the name of the Kong container in the proxy Deployment.
+	managedGatewayProxyContainerName = "proxy"
+	// managedGatewayAdminServiceSuffix is appended to a managed Gateway's name to get the name of its admin Service.
+	managedGatewayAdminServiceSuffix = "-admin"
+	// managedGatewayLabelHashLength is the length of the hash suffixing truncated values of labels.ManagedGatewayLabel.
+	managedGatewayLabelHashLength = 10
+)
+
+// errManagedGatewayResourceNotOwned is returned when an object a managed Gateway's proxy resource would be
+// provisioned as already exists, but is not controlled by the Gateway.
+var errManagedGatewayResourceNotOwned = errors.New("object exists and is not controlled by the managed gateway")
+
+// GatewayAdminAPIsNotifier is notified about Admin API endpoints of proxies provisioned for managed Gateways.
+type GatewayAdminAPIsNotifier interface {
+	NotifyGatewayAdminAPIs(gateway k8stypes.NamespacedName, adminAPIs []adminapi.DiscoveredAdminAPI)
+}
+
+// AdminAPIsDiscoverer discovers Admin API endpoints from EndpointSlices.
+type AdminAPIsDiscoverer interface {
+	AdminAPIsFromEndpointSlice(discoveryv1.EndpointSlice) (sets.Set[adminapi.DiscoveredAdminAPI], error)
+}
+
+// ManagedGatewaysConfig configures provisioning of Kong proxies for Gateways whose
+// GatewayClass is not annotated as unmanaged.
+type ManagedGatewaysConfig struct {
+	// ProxyImage is the Kong Gateway image used for provisioned proxies.
+	ProxyImage string
+	// ProxyReplicas is the number of replicas of provisioned proxy Deployments.
+	ProxyReplicas int32
+	// ServiceType is the type of the proxy Service exposing Gateway listeners.
+	ServiceType corev1.ServiceType
+	// RouterFlavor is the router flavor provisioned proxies are configured with. It has to match the
+	// router flavor of the other Kong Gateways configured by the controller.
+	RouterFlavor string
+	// AdminAPIPortName is the name of the Admin API port of the provisioned proxies. It has to be
+	// recognized by AdminAPIsDiscoverer.
+	AdminAPIPortName string
+	// AdminAPIClientCACert is the PEM-encoded CA certificate the Admin API of provisioned proxies verifies
+	// client certificates with. Only clients presenting a certificate signed by it (i.e. the controller)
+	// are allowed to use the Admin API.
+	AdminAPIClientCACert string
+	// AdminAPIServerCert and AdminAPIServerKey are the PEM-encoded certificate and key the Admin API of
+	// provisioned proxies is served with. The certificate has to be trusted by the controller.
+	AdminAPIServerCert string
+	AdminAPIServerKey  string
+
+	AdminAPIsNotifier   GatewayAdminAPIsNotifier
+	AdminAPIsDiscoverer AdminAPIsDiscoverer
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
+
+// reconcileManagedGateway reconciles a Gateway that is configured for managed mode. In this mode
+// a dedicated Kong proxy Deployment with Services matching the Gateway's listeners is provisioned
+// for each Gateway and its Admin API endpoints are registered with the Admin API clients manager.
+func (r *GatewayReconciler) reconcileManagedGateway(ctx context.Context, log logr.Logger, gateway *gatewayapi.Gateway) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(gateway, ManagedGatewayFinalizer) {
+		debug(log, gateway, "Adding managed gateway finalizer")
+		controllerutil.AddFinalizer(gateway, ManagedGatewayFinalizer)
+		return ctrl.Result{}, r.Update(ctx, gateway)
+	}
+
+	debug(log, gateway, "Ensuring managed gateway proxy resources")
+	adminClientCA := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminClientCAName(gateway)}}
+	if err := r.ensureManagedGatewayResource(ctx, gateway, adminClientCA, func() {
+		setManagedGatewayAdminClientCAData(adminClientCA, gateway, r.ManagedGateways)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure admin client CA ConfigMap for managed gateway: %w", err)
+	}
+
+	adminTLS := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminTLSName(gateway)}}
+	if err := r.ensureManagedGatewayResource(ctx, gateway, adminTLS, func() {
+		setManagedGatewayAdminTLSData(adminTLS, gateway, r.ManagedGateways)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure admin TLS Secret for managed gateway: %w", err)
+	}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayProxyName(gateway)}}
+	if err := r.ensureManagedGatewayResource(ctx, gateway, deployment, func() {
+		setManagedGatewayDeploymentSpec(deployment, gateway, r.ManagedGateways)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure proxy Deployment for managed gateway: %w", err)
+	}
+
+	proxyService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayProxyName(gateway)}}
+	if err := r.ensureManagedGatewayResource(ctx, gateway, proxyService, func() {
+		setManagedGatewayProxyServiceSpec(proxyService, gateway, r.ManagedGateways)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure proxy Service for managed gateway: %w", err)
+	}
+
+	adminService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: managedGatewayAdminServiceName(gateway)}}
+	if err := r.ensureManagedGatewayResource(ctx, gateway, adminService, func() {
+		setManagedGatewayAdminServiceSpec(adminService, gateway, r.ManagedGateways)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure admin Service for managed gateway: %w", err)
+	}
+
+	debug(log, gateway, "Registering managed gateway Admin API endpoints")
+	if err := r.notifyManagedGatewayAdminAPIs(ctx, gateway, adminService); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	debug(log, gateway, "Updating the managed gateway status if necessary")
+	if err := r.updateManagedGatewayStatus(ctx, log, gateway, deployment, proxyService); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// ensureManagedGatewayResource creates or updates an object provisioned for a managed Gateway using mutate to set
+// its desired state. Existing objects not controlled by the Gateway are never modified, so that objects which only
+// happen to have a clashing name are not taken over.
+func (r *GatewayReconciler) ensureManagedGatewayResource(
+	ctx context.Context,
+	gateway *gatewayapi.Gateway,
+	obj client.Object,
+	mutate func(),
+) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, gateway) {
+			return fmt.Errorf("%s/%s: %w", obj.GetNamespace(), obj.GetName(), errManagedGatewayResourceNotOwned)
+		}
+		mutate()
+		return controllerutil.SetControllerReference(gateway, obj, r.Scheme)
+	})
+	return err
+}
+
+// finalizeManagedGateway unregisters the Admin API endpoints of a managed Gateway's proxy and removes
+// the finalizer. Provisioned resources are garbage collected by Kubernetes through their owner references.
+func (r *GatewayReconciler) finalizeManagedGateway(ctx context.Context, log logr.Logger, gateway *gatewayapi.Gateway) (ctrl.Result, error) {
+	info(log, gateway, "Managed gateway is being deleted, unregistering its Admin API endpoints")
+	if r.ManagedGateways != nil {
+		r.ManagedGateways.AdminAPIsNotifier.NotifyGatewayAdminAPIs(client.ObjectKeyFromObject(gateway), nil)
+	}
+	controllerutil.RemoveFinalizer(gateway, ManagedGatewayFinalizer)
+	return ctrl.Result{}, r.Update(ctx, gateway)
+}
+
+// notifyManagedGatewayAdminAPIs discovers Admin API endpoints of the managed Gateway's proxy from
+// EndpointSlices of its admin Service and notifies about them.
+func (r *GatewayReconciler) notifyManagedGatewayAdminAPIs(ctx context.Context, gateway *gatewayapi.Gateway, adminService *corev1.Service) error {
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, endpointSlices,
+		client.InNamespace(adminService.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: adminService.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list EndpointSlices of managed gateway admin Service: %w", err)
+	}
+
+	adminAPIs := sets.New[adminapi.DiscoveredAdminAPI]()
+	for _, endpointSlice := range endpointSlices.Items {
+		discovered, err := r.ManagedGateways.AdminAPIsDiscoverer.AdminAPIsFromEndpointSlice(endpointSlice)
+		if err != nil {
+			return fmt.Errorf("failed getting Admin API from endpoints: %s/%s: %w", endpointSlice.Namespace, endpointSlice.Name, err)
+		}
+		adminAPIs = adminAPIs.Union(discovered)
+	}
+
+	r.ManagedGateways.AdminAPIsNotifier.NotifyGatewayAdminAPIs(client.ObjectKeyFromObject(gateway), adminAPIs.UnsortedList())
+	return nil
+}
+
+// updateManagedGatewayStatus updates a managed Gateway's status with the addresses of its proxy Service
+// and its listeners' statuses. The Gateway is marked as Programmed once its proxy Deployment is available.
+func (r *GatewayReconciler) updateManagedGatewayStatus(
+	ctx context.Context,
+	log logr.Logger,
+	gateway *gatewayapi.Gateway,
+	deployment *appsv1.Deployment,
+	proxyService *corev1.Service,
+) error {
+	var addresses []gatewayapi.GatewayStatusAddress
+	if len(proxyService.Spec.ClusterIPs) > 0 {
+		var err error
+		if addresses, _, err = r.determineL4ListenersFromService(log, proxyService); err != nil {
+			return err
+		}
+	}
+
+	referenceGrantList := &gatewayapi.ReferenceGrantList{}
+	if r.enableReferenceGrant {
+		if err := r.Client.List(ctx, referenceGrantList); err != nil {
+			return err
+		}
+	}
+	// Listeners which couldn't be exposed by the proxy (see managedGatewayPorts) are reported as not accepted.
+	kongListens := managedGatewayListeners(managedGatewayPorts(gateway))
+	listenerStatuses, err := getListenerStatus(ctx, gateway, kongListens, referenceGrantList.Items, r.Client)
+	if err != nil {
+		return err
+	}
+
+	oldStatus := gateway.Status.DeepCopy()
+	gateway.Status.Addresses = addresses
+	gateway.Status.Listeners = listenerStatuses
+	setGatewayCondition(gateway, metav1.Condition{
+		Type:               string(gatewayapi.GatewayConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayapi.GatewayReasonAccepted),
+		Message:            "this managed gateway has been picked up by the controller and will be processed",
+	})
+	programmedCondition := metav1.Condition{
+		Type:               string(gatewayapi.GatewayConditionProgrammed),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(gatewayapi.GatewayReasonPending),
+		Message:            "waiting for the proxy Deployment to become available",
+	}
+	if deployment.Status.AvailableReplicas > 0 && len(addresses) > 0 {
+		programmedCondition.Status = metav1.ConditionTrue
+		programmedCondition.Reason = string(gatewayapi.GatewayReasonProgrammed)
+		programmedCondition.Message = ""
+	}
+	setGatewayCondition(gateway, programmedCondition)
+
+	preserveTransitionTimes(oldStatus.Conditions, gateway.Status.Conditions)
+	for i, listener := range gateway.Status.Listeners {
+		if oldListener, ok := lo.Find(oldStatus.Listeners, func(l gatewayapi.ListenerStatus) bool {
+			return l.Name == listener.Name
+		}); ok {
+			preserveTransitionTimes(oldListener.Conditions, gateway.Status.Listeners[i].Conditions)
+		}
+	}
+	if reflect.DeepEqual(*oldStatus, gateway.Status) {
+		return nil
+	}
+
+	debug(log, gateway, "Managed gateway status updated")
+	return r.Status().Update(ctx, pruneGatewayStatusConds(gateway))
+}
+
+// preserveTransitionTimes copies the LastTransitionTime from the old conditions to the new
+// conditions of the same type that have not changed their status, reason or message.
+func preserveTransitionTimes(oldConditions []metav1.Condition, newConditions []metav1.Condition) {
+	for i, newCondition := range newConditions {
+		for _, oldCondition := range oldConditions {
+			if oldCondition.Type == newCondition.Type &&
+				oldCondition.Status == newCondition.Status &&
+				oldCondition.Reason == newCondition.Reason &&
+				oldCondition.Message == newCondition.Message &&
+				oldCondition.ObservedGeneration == newCondition.ObservedGeneration {
+				newConditions[i].LastTransitionTime = oldCondition.LastTransitionTime
+			}
+		}
+	}
+}
+
+// reconcileDedicatedAdminAPIs registers Admin API endpoints of the data-plane dedicated to an unmanaged Gateway
+// annotated with konghq.com/admin-service. Such Gateways get the managed Gateway finalizer, so that the Admin API
+// endpoints are unregistered on their deletion and the translator recognizes them as having a data-plane of their
+// own. It returns true when the Gateway was updated and will be reconciled again.
+func (r *GatewayReconciler) reconcileDedicatedAdminAPIs(ctx context.Context, log logr.Logger, gateway *gatewayapi.Gateway) (bool, error) {
+	adminServiceRef, ok := annotations.ExtractGatewayAdminService(gateway.Annotations)
+	if !ok {
+		if !controllerutil.ContainsFinalizer(gateway, ManagedGatewayFinalizer) {
+			return false, nil
+		}
+		info(log, gateway, "Gateway no longer has a dedicated data-plane, unregistering its Admin API endpoints")
+		r.ManagedGateways.AdminAPIsNotifier.NotifyGatewayAdminAPIs(client.ObjectKeyFromObject(gateway), nil)
+		controllerutil.RemoveFinalizer(gateway, ManagedGatewayFinalizer)
+		return true, r.Update(ctx, gateway)
+	}
+
+	namespace, name, ok := strings.Cut(adminServiceRef, "/")
+	if !ok || namespace == "" || name == "" {
+		const annotation = annotations.AnnotationPrefix + annotations.GatewayAdminServiceKey
+		return false, fmt.Errorf("invalid %s annotation value %q, expected namespace/name", annotation, adminServiceRef)
+	}
+	if !controllerutil.ContainsFinalizer(gateway, ManagedGatewayFinalizer) {
+		debug(log, gateway, "Adding managed gateway finalizer to gateway with a dedicated data-plane")
+		controllerutil.AddFinalizer(gateway, ManagedGatewayFinalizer)
+		return true, r.Update(ctx, gateway)
+	}
+	adminService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	return false, r.notifyManagedGatewayAdminAPIs(ctx, gateway, adminService)
+}
+
+// listGatewaysForEndpointSlice enqueues the managed Gateway whose admin Service the EndpointSlice belongs to, or
+// the unmanaged Gateways annotated with the Service as their konghq.com/admin-service.
+func (r *GatewayReconciler) listGatewaysForEndpointSlice(ctx context.Context, obj client.Object) []reconcile.Request {
+	serviceName, ok := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if !ok {
+		return nil
+	}
+	// The value of labels.ManagedGatewayLabel (copied from the Service) may be truncated, so the managed Gateway's
+	// name is derived from the name of the admin Service instead.
+	if _, ok := obj.GetLabels()[labels.ManagedGatewayLabel]; ok {
+		gatewayName, ok := strings.CutSuffix(serviceName, managedGatewayAdminServiceSuffix)
+		if !ok || gatewayName == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: obj.GetNamespace(), Name: gatewayName}}}
+	}
+
+	gateways := &gatewayapi.GatewayList{}
+	if err := r.List(ctx, gateways); err != nil {
+		r.Log.Error(err, "Failed to list gateways in watch", "endpointslice", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	service := k8stypes.NamespacedName{Namespace: obj.GetNamespace(), Name: serviceName}.String()
+	var requests []reconcile.Request
+	for _, gateway := range gateways.Items {
+		if adminServiceRef, ok := annotations.ExtractGatewayAdminService(gateway.Annotations); ok && adminServiceRef == service {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&gateway)})
+		}
+	}
+	return requests
+}
+
+// -----------------------------------------------------------------------------
+// Gateway Controller - Managed Gateways - Proxy Resources
+// -----------------------------------------------------------------------------
+
+// managedGatewayPort is a single port exposed by a managed Gateway's proxy.
+type managedGatewayPort struct {
+	name          string
+	protocol      gatewayapi.ProtocolType
+	port          int32
+	containerPort int32
+}
+
+// managedGatewayPorts returns the ports a managed Gateway's proxy has to expose for the Gateway's listeners.
+// Listeners sharing a port and a transport protocol (e.g. with different hostnames) are exposed once, while
+// e.g. a TCP and a UDP listener on the same port are both exposed. Of listeners with different protocols using
+// the same transport on the same port (e.g. HTTP and TCP), only the first one can be exposed. The others are
+// reported as not accepted by updateManagedGatewayStatus.
+func managedGatewayPorts(gateway *gatewayapi.Gateway) []managedGatewayPort {
+	var ports []managedGatewayPort
+	for _, listener := range gateway.Spec.Listeners {
+		port := int32(listener.Port)
+		if lo.ContainsBy(ports, func(p managedGatewayPort) bool {
+			return p.port == port && serviceProtocolForListener(p.protocol) == serviceProtocolForListener(listener.Protocol)
+		}) {
+			continue
+		}
+		ports = append(ports, managedGatewayPort{
+			name:     fmt.Sprintf("%s-%d", strings.ToLower(string(listener.Protocol)), port),
+			protocol: listener.Protocol,
+			port:     port,
+		})
+	}
+	slices.SortFunc(ports, func(a, b managedGatewayPort) int {
+		if a.port != b.port {
+			return int(a.port - b.port)
+		}
+		return strings.Compare(string(serviceProtocolForListener(a.protocol)), string(serviceProtocolForListener(b.protocol)))
+	})
+
+	// Container ports are allocated in two passes. Listener ports the proxy can bind to are used as they are first,
+	// so that privileged ports shifted by managedGatewayUnprivilegedPortOffset and ports clashing with the admin and
+	// status ports are moved to the next free port instead of colliding with them.
+	allocated := map[corev1.Protocol]sets.Set[int32]{
+		corev1.ProtocolTCP: sets.New[int32](managedGatewayAdminPort, managedGatewayStatusPort),
+		corev1.ProtocolUDP: sets.New[int32](),
+	}
+	for i, p := range ports {
+		transport := serviceProtocolForListener(p.protocol)
+		if p.port >= 1024 && !allocated[transport].Has(p.port) {
+			ports[i].containerPort = p.port
+			allocated[transport].Insert(p.port)
+		}
+	}
+	for i, p := range ports {
+		if p.containerPort != 0 {
+			continue
+		}
+		transport := serviceProtocolForListener(p.protocol)
+		containerPort := p.port
+		if containerPort < 1024 {
+			containerPort += managedGatewayUnprivilegedPortOffset
+		}
+		for allocated[transport].Has(containerPort) {
+			containerPort++
+		}
+		ports[i].containerPort = containerPort
+		allocated[transport].Insert(containerPort)
+	}
+	return ports
+}
+
+// managedGatewayListeners returns listeners matching the given ports, i.e. the listeners a managed Gateway's
+// proxy actually serves.
+func managedGatewayListeners(ports []managedGatewayPort) []gatewayapi.Listener {
+	listeners := make([]gatewayapi.Listener, 0, len(ports))
+	for _, p := range ports {
+		listeners = append(listeners, gatewayapi.Listener{
+			Name:     gatewayapi.SectionName(p.name),
+			Protocol: p.protocol,
+			Port:     gatewayapi.PortNumber(p.port),
+		})
+	}
+	return listeners
+}
+
+func managedGatewayProxyName(gateway *gatewayapi.Gateway) string {
+	return gateway.Name + "-proxy"
+}
+
+func managedGatewayAdminServiceName(gateway *gatewayapi.Gateway) string {
+	return gateway.Name + managedGatewayAdminServiceSuffix
+}
+
+func managedGatewayAdminClientCAName(gateway *gatewayapi.Gateway) string {
+	return gateway.Name + "-admin-client-ca"
+}
+
+func managedGatewayAdminTLSName(gateway *gatewayapi.Gateway) string {
+	return gateway.Name + "-admin-tls"
+}
+
+func managedGatewaySelector(gateway *gatewayapi.Gateway) map[string]string {
+	return map[string]string{labels.ManagedGatewayLabel: managedGatewayLabelValue(gateway)}
+}
+
+// managedGatewayLabelValue returns the value of labels.ManagedGatewayLabel for a managed Gateway. Gateway names
+// can be longer than label values are allowed to be, so longer names are truncated and suffixed with a hash of
+// the full name to keep them unique.
+func managedGatewayLabelValue(gateway *gatewayapi.Gateway) string {
+	if len(gateway.Name) <= validation.LabelValueMaxLength {
+		return gateway.Name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(gateway.Name)))[:managedGatewayLabelHashLength]
+	// Label values have to begin and end with an alphanumeric character.
+	prefix := strings.TrimRight(gateway.Name[:validation.LabelValueMaxLength-len(hash)-1], ".-")
+	return prefix + "-" + hash
+}
+
+// managedGatewayListens returns values of KONG_PROXY_LISTEN, KONG_STREAM_LISTEN and KONG_PORT_MAPS
+// for the given ports.
+func managedGatewayListens(ports []managedGatewayPort) (proxyListen, streamListen, portMaps string) {
+	var proxyListens, streamListens, maps []string
+	for _, p := range ports {
+		listen := fmt.Sprintf("0.0.0.0:%d", p.containerPort)
+		switch p.protocol {
+		case gatewayapi.HTTPProtocolType:
+			proxyListens = append(proxyListens, listen)
+		case gatewayapi.HTTPSProtocolType:
+			proxyListens = append(proxyListens, listen+" http2 ssl")
+		case gatewayapi.TCPProtocolType:
+			streamListens = append(streamListens, listen)
+		case gatewayapi.TLSProtocolType:
+			streamListens = append(streamListens, listen+" ssl")
+		case gatewayapi.UDPProtocolType:
+			streamListens = append(streamListens, listen+" udp")
+		default:
+			continue
+		}
+		maps = append(maps, fmt.Sprintf("%d:%d", p.port, p.containerPort))
+	}
+	if len(proxyListens) == 0 {
+		proxyListens = []string{"off"}
+	}
+	if len(streamListens) == 0 {
+		streamListens = []string{"off"}
+	}
+	return strings.Join(proxyListens, ", "), strings.Join(streamListens, ", "), strings.Join(maps, ", ")
+}
+
+func serviceProtocolForListener(protocol gatewayapi.ProtocolType) corev1.Protocol {
+	if protocol == gatewayapi.UDPProtocolType {
+		return corev1.ProtocolUDP
+	}
+	return corev1.ProtocolTCP
+}
+
+// setManagedGatewayDeploymentSpec sets the desired state of the proxy Deployment of a managed Gateway.
+func setManagedGatewayDeploymentSpec(deployment *appsv1.Deployment, gateway *gatewayapi.Gateway, cfg *ManagedGatewaysConfig) {
+	selector := managedGatewaySelector(gateway)
+	ports := managedGatewayPorts(gateway)
+	proxyListen, streamListen, portMaps := managedGatewayListens(ports)
+
+	containerPorts := []corev1.ContainerPort{
+		{Name: cfg.AdminAPIPortName, ContainerPort: managedGatewayAdminPort, Protocol: corev1.ProtocolTCP},
+		{Name: "status", ContainerPort: managedGatewayStatusPort, Protocol: corev1.ProtocolTCP},
+	}
+	for _, p := range ports {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
+			Name:          p.name,
+			ContainerPort: p.containerPort,
+			Protocol:      serviceProtocolForListener(p.protocol),
+		})
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "KONG_DATABASE", Value: "off"},
+		{Name: "KONG_ADMIN_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d http2 ssl", managedGatewayAdminPort)},
+		{Name: "KONG_ADMIN_SSL_CERT", Value: path.Join(managedGatewayAdminTLSMountPath, corev1.TLSCertKey)},
+		{Name: "KONG_ADMIN_SSL_CERT_KEY", Value: path.Join(managedGatewayAdminTLSMountPath, corev1.TLSPrivateKeyKey)},
+		// The Admin API is reachable from the whole cluster, so only clients presenting a certificate
+		// signed by the Admin API client CA are allowed to use it.
+		{Name: "KONG_NGINX_ADMIN_SSL_VERIFY_CLIENT", Value: "on"},
+		{Name: "KONG_NGINX_ADMIN_SSL_CLIENT_CERTIFICATE", Value: path.Join(managedGatewayAdminClientCAMountPath, managedGatewayAdminClientCAKey)},
+		{Name: "KONG_STATUS_LISTEN", Value: fmt.Sprintf("0.0.0.0:%d", managedGatewayStatusPort)},
+		{Name: "KONG_PROXY_LISTEN", Value: proxyListen},
+		{Name: "KONG_STREAM_LISTEN", Value: streamListen},
+		{Name: "KONG_PORT_MAPS", Value: portMaps},
+		{Name: "KONG_PROXY_ACCESS_LOG", Value: "/dev/stdout"},
+		{Name: "KONG_PROXY_ERROR_LOG", Value: "/dev/stderr"},
+		{Name: "KONG_ADMIN_ACCESS_LOG", Value: "/dev/stdout"},
+		{Name: "KONG_ADMIN_ERROR_LOG", Value: "/dev/stderr"},
+	}
+	if cfg.RouterFlavor != "" {
+		env = append(env, corev1.EnvVar{Name: "KONG_ROUTER_FLAVOR", Value: cfg.RouterFlavor})
+	}
+
+	deployment.Labels = selector
+	deployment.Spec.Replicas = lo.ToPtr(cfg.ProxyReplicas)
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	deployment.Spec.Template.Labels = selector
+
+	// Annotations, volumes and containers may have been added to the pod template by others (e.g. by
+	// kubectl rollout restart or a sidecar injector), so only the ones the proxy needs are set.
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[managedGatewayAdminClientCAChecksumAnnotation] = fmt.Sprintf("%x", sha256.Sum256([]byte(cfg.AdminAPIClientCACert)))
+	deployment.Spec.Template.Annotations[managedGatewayAdminTLSChecksumAnnotation] = fmt.Sprintf("%x", sha256.Sum256([]byte(cfg.AdminAPIServerCert+cfg.AdminAPIServerKey)))
+
+	podSpec := &deployment.Spec.Template.Spec
+	podSpec.Volumes = upsertByName(podSpec.Volumes, func(v corev1.Volume) string { return v.Name },
+		corev1.Volume{
+			Name: "admin-client-ca",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: managedGatewayAdminClientCAName(gateway)},
+				},
+			},
+		},
+		corev1.Volume{
+			Name: "admin-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: managedGatewayAdminTLSName(gateway)},
+			},
+		},
+	)
+
+	var proxy corev1.Container
+	if i := slices.IndexFunc(podSpec.Containers, func(c corev1.Container) bool {
+		return c.Name == managedGatewayProxyContainerName
+	}); i >= 0 {
+		// Settings of the proxy container the controller doesn't manage (e.g. resources) are kept.
+		proxy = podSpec.Containers[i]
+	}
+	proxy.Name = managedGatewayProxyContainerName
+	proxy.Image = cfg.ProxyImage
+	proxy.Env = env
+	proxy.Ports = containerPorts
+	proxy.VolumeMounts = []corev1.VolumeMount{
+		{Name: "admin-client-ca", MountPath: managedGatewayAdminClientCAMountPath, ReadOnly: true},
+		{Name: "admin-tls", MountPath: managedGatewayAdminTLSMountPath, ReadOnly: true},
+	}
+	proxy.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   "/status/ready",
+				Port:   intstr.FromInt32(managedGatewayStatusPort),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+	}
+	podSpec.Containers = upsertByName(podSpec.Containers, func(c corev1.Container) string { return c.Name }, proxy)
+}
+
+// upsertByName replaces items of the list having the same name as one of items and appends the other items,
+// keeping the rest of the list intact.
+func upsertByName[T any](list []T, name func(T) string, items ...T) []T {
+	for _, item := range items {
+		if i := slices.IndexFunc(list, func(existing T) bool { return name(existing) == name(item) }); i >= 0 {
+			list[i] = item
+			continue
+		}
+		list = append(list, item)
+	}
+	return list
+}
+
+// setManagedGatewayAdminClientCAData sets the data of the ConfigMap holding the CA certificate the Admin API
+// of a managed Gateway's proxy verifies client certificates with.
+func setManagedGatewayAdminClientCAData(configMap *corev1.ConfigMap, gateway *gatewayapi.Gateway, cfg *ManagedGatewaysConfig) {
+	configMap.Labels = managedGatewaySelector(gateway)
+	configMap.Data = map[string]string{managedGatewayAdminClientCAKey: cfg.AdminAPIClientCACert}
+}
+
+// setManagedGatewayAdminTLSData sets the data of the Secret holding the certificate and key the Admin API
+// of a managed Gateway's proxy is served with.
+func setManagedGatewayAdminTLSData(secret *corev1.Secret, gateway *gatewayapi.Gateway, cfg *ManagedGatewaysConfig) {
+	secret.Labels = managedGatewaySelector(gateway)
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       []byte(cfg.AdminAPIServerCert),
+		corev1.TLSPrivateKeyKey: []byte(cfg.AdminAPIServerKey),
+	}
+}
+
+// setManagedGatewayProxyServiceSpec sets the desired state of the Service exposing a managed Gateway's listeners.
+func setManagedGatewayProxyServiceSpec(service *corev1.Service, gateway *gatewayapi.Gateway, cfg *ManagedGatewaysConfig) {
+	servicePorts := make([]corev1.ServicePort, 0, len(gateway.Spec.Listeners))
+	for _, p := range managedGatewayPorts(gateway) {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       p.name,
+			Protocol:   serviceProtocolForListener(p.protocol),
+			Port:       p.port,
+			TargetPort: intstr.FromInt32(p.containerPort),
+		})
+	}
+
+	service.Labels = managedGatewaySelector(gateway)
+	service.Spec.Type = cfg.ServiceType
+	service.Spec.Selector = managedGatewaySelector(gateway)
+	service.Spec.Ports = servicePorts
+}
+
+// setManagedGatewayAdminServiceSpec sets the desired state of the headless Service used to discover
+// Admin API endpoints of a managed Gateway's proxy.
+func setManagedGatewayAdminServiceSpec(service *corev1.Service, gateway *gatewayapi.Gateway, cfg *ManagedGatewaysConfig) {
+	service.Labels = managedGatewaySelector(gateway)
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	service.Spec.Selector = managedGatewaySelector(gateway)
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       cfg.AdminAPIPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       managedGatewayAdminPort,
+			TargetPort: intstr.FromInt32(managedGatewayAdminPort),
+		},
+	}
+}
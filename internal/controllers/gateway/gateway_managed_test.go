@@ -0,0 +1,494 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+)
+
+func managedGatewayForTest() *gatewayapi.Gateway {
+	return &gatewayapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "gw",
+		},
+		Spec: gatewayapi.GatewaySpec{
+			GatewayClassName: "kong",
+			Listeners: []gatewayapi.Listener{
+				{Name: "https", Protocol: gatewayapi.HTTPSProtocolType, Port: 443},
+				{Name: "http", Protocol: gatewayapi.HTTPProtocolType, Port: 80},
+				{Name: "http-other-host", Protocol: gatewayapi.HTTPProtocolType, Port: 80},
+				{Name: "tcp", Protocol: gatewayapi.TCPProtocolType, Port: 5432},
+				{Name: "udp", Protocol: gatewayapi.UDPProtocolType, Port: 5353},
+				{Name: "tcp-dns", Protocol: gatewayapi.TCPProtocolType, Port: 5353},
+			},
+		},
+	}
+}
+
+func TestManagedGatewayPorts(t *testing.T) {
+	ports := managedGatewayPorts(managedGatewayForTest())
+	assert.Equal(t, []managedGatewayPort{
+		{name: "http-80", protocol: gatewayapi.HTTPProtocolType, port: 80, containerPort: 8080},
+		{name: "https-443", protocol: gatewayapi.HTTPSProtocolType, port: 443, containerPort: 8443},
+		{name: "tcp-5353", protocol: gatewayapi.TCPProtocolType, port: 5353, containerPort: 5353},
+		{name: "udp-5353", protocol: gatewayapi.UDPProtocolType, port: 5353, containerPort: 5353},
+		{name: "tcp-5432", protocol: gatewayapi.TCPProtocolType, port: 5432, containerPort: 5432},
+	}, ports, "ports should be deduplicated by port and transport protocol, sorted and privileged ports should be shifted")
+
+	proxyListen, streamListen, portMaps := managedGatewayListens(ports)
+	assert.Equal(t, "0.0.0.0:8080, 0.0.0.0:8443 http2 ssl", proxyListen)
+	assert.Equal(t, "0.0.0.0:5353, 0.0.0.0:5353 udp, 0.0.0.0:5432", streamListen)
+	assert.Equal(t, "80:8080, 443:8443, 5353:5353, 5353:5353, 5432:5432", portMaps)
+
+	proxyListen, streamListen, portMaps = managedGatewayListens(nil)
+	assert.Equal(t, "off", proxyListen)
+	assert.Equal(t, "off", streamListen)
+	assert.Empty(t, portMaps)
+}
+
+func TestManagedGatewayPorts_Clashes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		listeners []gatewayapi.Listener
+		expected  []managedGatewayPort
+	}{
+		{
+			name: "shifted privileged port clashing with another listener's port is moved to the next free port",
+			listeners: []gatewayapi.Listener{
+				{Name: "http", Protocol: gatewayapi.HTTPProtocolType, Port: 80},
+				{Name: "http-alt", Protocol: gatewayapi.HTTPProtocolType, Port: 8080},
+				{Name: "http-alt-2", Protocol: gatewayapi.HTTPProtocolType, Port: 8081},
+			},
+			expected: []managedGatewayPort{
+				{name: "http-80", protocol: gatewayapi.HTTPProtocolType, port: 80, containerPort: 8082},
+				{name: "http-8080", protocol: gatewayapi.HTTPProtocolType, port: 8080, containerPort: 8080},
+				{name: "http-8081", protocol: gatewayapi.HTTPProtocolType, port: 8081, containerPort: 8081},
+			},
+		},
+		{
+			name: "shifted privileged ports clashing with the admin and status ports are moved to the next free port",
+			listeners: []gatewayapi.Listener{
+				{Name: "https", Protocol: gatewayapi.HTTPSProtocolType, Port: 444},
+				{Name: "tcp", Protocol: gatewayapi.TCPProtocolType, Port: 100},
+			},
+			expected: []managedGatewayPort{
+				{name: "tcp-100", protocol: gatewayapi.TCPProtocolType, port: 100, containerPort: 8101},
+				{name: "https-444", protocol: gatewayapi.HTTPSProtocolType, port: 444, containerPort: 8445},
+			},
+		},
+		{
+			name: "ports clashing with the admin and status ports are moved to the next free port only for TCP",
+			listeners: []gatewayapi.Listener{
+				{Name: "tcp", Protocol: gatewayapi.TCPProtocolType, Port: 8444},
+				{Name: "udp", Protocol: gatewayapi.UDPProtocolType, Port: 8444},
+				{Name: "tcp-next", Protocol: gatewayapi.TCPProtocolType, Port: 8445},
+			},
+			expected: []managedGatewayPort{
+				{name: "tcp-8444", protocol: gatewayapi.TCPProtocolType, port: 8444, containerPort: 8446},
+				{name: "udp-8444", protocol: gatewayapi.UDPProtocolType, port: 8444, containerPort: 8444},
+				{name: "tcp-8445", protocol: gatewayapi.TCPProtocolType, port: 8445, containerPort: 8445},
+			},
+		},
+		{
+			name: "only the first of listeners with different protocols using the same transport on a port is exposed",
+			listeners: []gatewayapi.Listener{
+				{Name: "http", Protocol: gatewayapi.HTTPProtocolType, Port: 80},
+				{Name: "tcp", Protocol: gatewayapi.TCPProtocolType, Port: 80},
+			},
+			expected: []managedGatewayPort{
+				{name: "http-80", protocol: gatewayapi.HTTPProtocolType, port: 80, containerPort: 8080},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := managedGatewayForTest()
+			gateway.Spec.Listeners = tc.listeners
+			assert.Equal(t, tc.expected, managedGatewayPorts(gateway))
+		})
+	}
+}
+
+func TestManagedGatewayListenerStatuses(t *testing.T) {
+	testCases := []struct {
+		name      string
+		listeners []gatewayapi.Listener
+		// expected maps listener names to the expected reasons of their Accepted and Conflicted conditions.
+		expected map[gatewayapi.SectionName][2]gatewayapi.ListenerConditionReason
+	}{
+		{
+			name: "HTTP and TCP listeners on the same port are conflicted",
+			listeners: []gatewayapi.Listener{
+				{Name: "http", Protocol: gatewayapi.HTTPProtocolType, Port: 80},
+				{Name: "tcp", Protocol: gatewayapi.TCPProtocolType, Port: 80},
+			},
+			expected: map[gatewayapi.SectionName][2]gatewayapi.ListenerConditionReason{
+				"http": {gatewayapi.ListenerReasonAccepted, gatewayapi.ListenerReasonProtocolConflict},
+				"tcp":  {gatewayapi.ListenerReasonUnsupportedProtocol, gatewayapi.ListenerReasonProtocolConflict},
+			},
+		},
+		{
+			name: "TLS listener on the port of an HTTPS listener is not accepted",
+			listeners: []gatewayapi.Listener{
+				{Name: "https", Protocol: gatewayapi.HTTPSProtocolType, Port: 443, Hostname: lo.ToPtr(gatewayapi.Hostname("a.example.com"))},
+				{Name: "tls", Protocol: gatewayapi.TLSProtocolType, Port: 443, Hostname: lo.ToPtr(gatewayapi.Hostname("b.example.com"))},
+				{Name: "tls-other", Protocol: gatewayapi.TLSProtocolType, Port: 9443},
+			},
+			expected: map[gatewayapi.SectionName][2]gatewayapi.ListenerConditionReason{
+				"https":     {gatewayapi.ListenerReasonAccepted, gatewayapi.ListenerReasonNoConflicts},
+				"tls":       {gatewayapi.ListenerReasonPortUnavailable, ""},
+				"tls-other": {gatewayapi.ListenerReasonAccepted, gatewayapi.ListenerReasonNoConflicts},
+			},
+		},
+		{
+			name: "listeners with container ports moved because of clashes are accepted",
+			listeners: []gatewayapi.Listener{
+				{Name: "https", Protocol: gatewayapi.HTTPSProtocolType, Port: 444},
+				{Name: "tcp", Protocol: gatewayapi.TCPProtocolType, Port: 100},
+			},
+			expected: map[gatewayapi.SectionName][2]gatewayapi.ListenerConditionReason{
+				"https": {gatewayapi.ListenerReasonAccepted, gatewayapi.ListenerReasonNoConflicts},
+				"tcp":   {gatewayapi.ListenerReasonAccepted, gatewayapi.ListenerReasonNoConflicts},
+			},
+		},
+	}
+
+	fakeClient := fakeclient.NewClientBuilder().WithScheme(lo.Must(scheme.Get())).Build()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := managedGatewayForTest()
+			gateway.Spec.Listeners = tc.listeners
+			kongListens := managedGatewayListeners(managedGatewayPorts(gateway))
+
+			statuses, err := getListenerStatus(context.Background(), gateway, kongListens, nil, fakeClient)
+			require.NoError(t, err)
+			require.Len(t, statuses, len(tc.expected))
+			for _, status := range statuses {
+				expected, ok := tc.expected[status.Name]
+				require.True(t, ok, "unexpected listener status %s", status.Name)
+
+				accepted, ok := lo.Find(status.Conditions, func(c metav1.Condition) bool {
+					return c.Type == string(gatewayapi.ListenerConditionAccepted)
+				})
+				require.True(t, ok)
+				assert.Equal(t, string(expected[0]), accepted.Reason, "listener %s", status.Name)
+
+				conflicted, ok := lo.Find(status.Conditions, func(c metav1.Condition) bool {
+					return c.Type == string(gatewayapi.ListenerConditionConflicted)
+				})
+				assert.Equal(t, string(expected[1]), conflicted.Reason, "listener %s", status.Name)
+				assert.Equal(t, expected[1] != "", ok, "listener %s", status.Name)
+			}
+		})
+	}
+}
+
+func TestManagedGatewayLabelValue(t *testing.T) {
+	gateway := managedGatewayForTest()
+	assert.Equal(t, "gw", managedGatewayLabelValue(gateway))
+
+	gateway.Name = strings.Repeat("a", 63)
+	assert.Equal(t, gateway.Name, managedGatewayLabelValue(gateway))
+
+	gateway.Name = strings.Repeat("a", 51) + "." + strings.Repeat("b", 200)
+	value := managedGatewayLabelValue(gateway)
+	assert.Empty(t, validation.IsValidLabelValue(value))
+	assert.Equal(t, strings.Repeat("a", 51)+"-", value[:52], "trailing dots should be trimmed from the truncated name")
+
+	other := managedGatewayForTest()
+	other.Name = strings.Repeat("a", 51) + "." + strings.Repeat("c", 200)
+	assert.NotEqual(t, value, managedGatewayLabelValue(other), "truncated names should be kept unique")
+}
+
+func TestListGatewaysForEndpointSlice(t *testing.T) {
+	dedicated := managedGatewayForTest()
+	dedicated.Name = "dedicated"
+	dedicated.Annotations = map[string]string{"konghq.com/admin-service": "kong/fleet-admin"}
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(lo.Must(scheme.Get())).
+		WithObjects(managedGatewayForTest(), dedicated).
+		Build()
+	r := &GatewayReconciler{Client: fakeClient}
+	longName := strings.Repeat("a", 100)
+	newEndpointSlice := func(namespace string, lbls map[string]string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "slice", Labels: lbls}}
+	}
+
+	assert.Equal(t, []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: longName}}},
+		r.listGatewaysForEndpointSlice(context.Background(), newEndpointSlice("default", map[string]string{
+			labels.ManagedGatewayLabel:   "truncated",
+			discoveryv1.LabelServiceName: longName + "-admin",
+		})), "managed gateway name should be derived from the admin Service name")
+	assert.Empty(t, r.listGatewaysForEndpointSlice(context.Background(), newEndpointSlice("default", map[string]string{
+		labels.ManagedGatewayLabel:   "gw",
+		discoveryv1.LabelServiceName: "gw-proxy",
+	})), "EndpointSlices of proxy Services should be ignored")
+	assert.Equal(t, []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "dedicated"}}},
+		r.listGatewaysForEndpointSlice(context.Background(), newEndpointSlice("kong", map[string]string{
+			discoveryv1.LabelServiceName: "fleet-admin",
+		})), "gateways annotated with the Service should be enqueued")
+	assert.Empty(t, r.listGatewaysForEndpointSlice(context.Background(), newEndpointSlice("default", map[string]string{
+		discoveryv1.LabelServiceName: "gw-admin",
+	})), "EndpointSlices of other Services should be ignored")
+}
+
+type mockGatewayAdminAPIsNotifier struct {
+	adminAPIs map[k8stypes.NamespacedName][]adminapi.DiscoveredAdminAPI
+}
+
+func (n *mockGatewayAdminAPIsNotifier) NotifyGatewayAdminAPIs(gateway k8stypes.NamespacedName, adminAPIs []adminapi.DiscoveredAdminAPI) {
+	n.adminAPIs[gateway] = adminAPIs
+}
+
+type mockAdminAPIsDiscoverer struct{}
+
+func (mockAdminAPIsDiscoverer) AdminAPIsFromEndpointSlice(endpointSlice discoveryv1.EndpointSlice) (sets.Set[adminapi.DiscoveredAdminAPI], error) {
+	return sets.New(adminapi.DiscoveredAdminAPI{Address: "https://" + endpointSlice.Name + ":8444"}), nil
+}
+
+func TestReconcileDedicatedAdminAPIs(t *testing.T) {
+	gateway := managedGatewayForTest()
+	gateway.Annotations = map[string]string{"konghq.com/admin-service": "kong/fleet-admin"}
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kong",
+			Name:      "fleet-admin-abc",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "fleet-admin"},
+		},
+	}
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(lo.Must(scheme.Get())).
+		WithObjects(gateway, endpointSlice).
+		Build()
+	notifier := &mockGatewayAdminAPIsNotifier{adminAPIs: map[k8stypes.NamespacedName][]adminapi.DiscoveredAdminAPI{}}
+	r := &GatewayReconciler{
+		Client: fakeClient,
+		ManagedGateways: &ManagedGatewaysConfig{
+			AdminAPIsNotifier:   notifier,
+			AdminAPIsDiscoverer: mockAdminAPIsDiscoverer{},
+		},
+	}
+	log := logr.Discard()
+	key := client.ObjectKeyFromObject(gateway)
+
+	updated, err := r.reconcileDedicatedAdminAPIs(context.Background(), log, gateway)
+	require.NoError(t, err)
+	require.True(t, updated, "finalizer should be added first")
+	require.NoError(t, fakeClient.Get(context.Background(), key, gateway))
+	require.Contains(t, gateway.Finalizers, ManagedGatewayFinalizer)
+
+	updated, err = r.reconcileDedicatedAdminAPIs(context.Background(), log, gateway)
+	require.NoError(t, err)
+	require.False(t, updated)
+	require.Equal(t, []adminapi.DiscoveredAdminAPI{{Address: "https://fleet-admin-abc:8444"}}, notifier.adminAPIs[key],
+		"Admin API endpoints of the annotated Service should be registered for the gateway")
+
+	delete(gateway.Annotations, "konghq.com/admin-service")
+	updated, err = r.reconcileDedicatedAdminAPIs(context.Background(), log, gateway)
+	require.NoError(t, err)
+	require.True(t, updated)
+	require.NoError(t, fakeClient.Get(context.Background(), key, gateway))
+	require.NotContains(t, gateway.Finalizers, ManagedGatewayFinalizer, "finalizer should be removed with the annotation")
+	require.Contains(t, notifier.adminAPIs, key)
+	require.Empty(t, notifier.adminAPIs[key], "Admin API endpoints should be unregistered with the annotation")
+
+	gateway.Annotations = map[string]string{"konghq.com/admin-service": "fleet-admin"}
+	gateway.Finalizers = []string{ManagedGatewayFinalizer}
+	_, err = r.reconcileDedicatedAdminAPIs(context.Background(), log, gateway)
+	require.ErrorContains(t, err, "expected namespace/name")
+}
+
+func TestManagedGatewayProxyResources(t *testing.T) {
+	gateway := managedGatewayForTest()
+	cfg := &ManagedGatewaysConfig{
+		ProxyImage:           "kong:3.7",
+		ProxyReplicas:        3,
+		ServiceType:          corev1.ServiceTypeLoadBalancer,
+		RouterFlavor:         "traditional_compatible",
+		AdminAPIPortName:     "admin-tls",
+		AdminAPIClientCACert: "ca-cert",
+		AdminAPIServerCert:   "server-cert",
+		AdminAPIServerKey:    "server-key",
+	}
+	expectedSelector := map[string]string{labels.ManagedGatewayLabel: "gw"}
+
+	t.Run("deployment", func(t *testing.T) {
+		deployment := &appsv1.Deployment{}
+		setManagedGatewayDeploymentSpec(deployment, gateway, cfg)
+
+		assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+		assert.Equal(t, expectedSelector, deployment.Spec.Selector.MatchLabels)
+		assert.Equal(t, expectedSelector, deployment.Spec.Template.Labels)
+		require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+		container := deployment.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "kong:3.7", container.Image)
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_DATABASE", Value: "off"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_ROUTER_FLAVOR", Value: "traditional_compatible"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_PROXY_LISTEN", Value: "0.0.0.0:8080, 0.0.0.0:8443 http2 ssl"})
+		assert.Contains(t, container.Ports, corev1.ContainerPort{Name: "admin-tls", ContainerPort: 8444, Protocol: corev1.ProtocolTCP})
+		assert.Contains(t, container.Ports, corev1.ContainerPort{Name: "udp-5353", ContainerPort: 5353, Protocol: corev1.ProtocolUDP})
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_NGINX_ADMIN_SSL_VERIFY_CLIENT", Value: "on"},
+			"Admin API should require client certificates")
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_NGINX_ADMIN_SSL_CLIENT_CERTIFICATE", Value: "/etc/kong/admin-client-ca/ca.crt"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_ADMIN_SSL_CERT", Value: "/etc/kong/admin-tls/tls.crt"},
+			"Admin API should be served with the certificate trusted by the controller")
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KONG_ADMIN_SSL_CERT_KEY", Value: "/etc/kong/admin-tls/tls.key"})
+		assert.Equal(t, []corev1.VolumeMount{
+			{Name: "admin-client-ca", MountPath: "/etc/kong/admin-client-ca", ReadOnly: true},
+			{Name: "admin-tls", MountPath: "/etc/kong/admin-tls", ReadOnly: true},
+		}, container.VolumeMounts)
+		require.Len(t, deployment.Spec.Template.Spec.Volumes, 2)
+		assert.Equal(t, "gw-admin-client-ca", deployment.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+		assert.Equal(t, "gw-admin-tls", deployment.Spec.Template.Spec.Volumes[1].Secret.SecretName)
+		assert.Contains(t, deployment.Spec.Template.Annotations, "konghq.com/admin-client-ca-checksum",
+			"proxies should be restarted when the CA changes")
+		assert.Contains(t, deployment.Spec.Template.Annotations, "konghq.com/admin-tls-checksum",
+			"proxies should be restarted when the Admin API certificate changes")
+	})
+
+	t.Run("deployment update keeps containers, volumes and annotations added by others", func(t *testing.T) {
+		deployment := &appsv1.Deployment{}
+		setManagedGatewayDeploymentSpec(deployment, gateway, cfg)
+
+		podTemplate := &deployment.Spec.Template
+		podTemplate.Annotations["kubectl.kubernetes.io/restartedAt"] = "now"
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{Name: "sidecar-config"})
+		podTemplate.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, corev1.Container{Name: "sidecar", Image: "sidecar"})
+
+		updatedCfg := *cfg
+		updatedCfg.ProxyImage = "kong:3.8"
+		setManagedGatewayDeploymentSpec(deployment, gateway, &updatedCfg)
+
+		assert.Equal(t, "now", podTemplate.Annotations["kubectl.kubernetes.io/restartedAt"])
+		assert.Len(t, podTemplate.Spec.Volumes, 3)
+		assert.Equal(t, "sidecar-config", podTemplate.Spec.Volumes[2].Name)
+		require.Len(t, podTemplate.Spec.Containers, 2)
+		assert.Equal(t, "kong:3.8", podTemplate.Spec.Containers[0].Image)
+		assert.Contains(t, podTemplate.Spec.Containers[0].Resources.Limits, corev1.ResourceCPU)
+		assert.Equal(t, corev1.Container{Name: "sidecar", Image: "sidecar"}, podTemplate.Spec.Containers[1])
+	})
+
+	t.Run("admin TLS secret", func(t *testing.T) {
+		secret := &corev1.Secret{}
+		setManagedGatewayAdminTLSData(secret, gateway, cfg)
+
+		assert.Equal(t, expectedSelector, secret.Labels)
+		assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+		assert.Equal(t, map[string][]byte{"tls.crt": []byte("server-cert"), "tls.key": []byte("server-key")}, secret.Data)
+	})
+
+	t.Run("admin client CA config map", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{}
+		setManagedGatewayAdminClientCAData(configMap, gateway, cfg)
+
+		assert.Equal(t, expectedSelector, configMap.Labels)
+		assert.Equal(t, map[string]string{"ca.crt": "ca-cert"}, configMap.Data)
+	})
+
+	t.Run("proxy service", func(t *testing.T) {
+		service := &corev1.Service{}
+		setManagedGatewayProxyServiceSpec(service, gateway, cfg)
+
+		assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+		assert.Equal(t, expectedSelector, service.Spec.Selector)
+		assert.Equal(t, []corev1.ServicePort{
+			{Name: "http-80", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt32(8080)},
+			{Name: "https-443", Protocol: corev1.ProtocolTCP, Port: 443, TargetPort: intstr.FromInt32(8443)},
+			{Name: "tcp-5353", Protocol: corev1.ProtocolTCP, Port: 5353, TargetPort: intstr.FromInt32(5353)},
+			{Name: "udp-5353", Protocol: corev1.ProtocolUDP, Port: 5353, TargetPort: intstr.FromInt32(5353)},
+			{Name: "tcp-5432", Protocol: corev1.ProtocolTCP, Port: 5432, TargetPort: intstr.FromInt32(5432)},
+		}, service.Spec.Ports)
+	})
+
+	t.Run("admin service", func(t *testing.T) {
+		service := &corev1.Service{}
+		setManagedGatewayAdminServiceSpec(service, gateway, cfg)
+
+		assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+		assert.Equal(t, expectedSelector, service.Labels, "labels are needed to map EndpointSlices to the Gateway")
+		assert.Equal(t, []corev1.ServicePort{
+			{Name: "admin-tls", Protocol: corev1.ProtocolTCP, Port: 8444, TargetPort: intstr.FromInt32(8444)},
+		}, service.Spec.Ports)
+	})
+}
+
+func TestEnsureManagedGatewayResource(t *testing.T) {
+	gateway := managedGatewayForTest()
+	gateway.UID = "gateway-uid"
+	otherOwner := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "other",
+		UID:        "other-uid",
+		Controller: lo.ToPtr(true),
+	}
+	newService := func(name string, ownerRefs ...metav1.OwnerReference) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: gateway.Namespace, Name: name, OwnerReferences: ownerRefs}}
+	}
+
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(lo.Must(scheme.Get())).
+		WithObjects(newService("not-owned"), newService("owned-by-other", otherOwner)).
+		Build()
+	r := &GatewayReconciler{Client: fakeClient, Scheme: fakeClient.Scheme()}
+	setType := func(service *corev1.Service) func() {
+		return func() { service.Spec.Type = corev1.ServiceTypeNodePort }
+	}
+
+	t.Run("missing object is created", func(t *testing.T) {
+		service := newService("new")
+		require.NoError(t, r.ensureManagedGatewayResource(context.Background(), gateway, service, setType(service)))
+
+		created := &corev1.Service{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(service), created))
+		assert.Equal(t, corev1.ServiceTypeNodePort, created.Spec.Type)
+		assert.True(t, metav1.IsControlledBy(created, gateway))
+	})
+
+	t.Run("object controlled by the gateway is updated", func(t *testing.T) {
+		service := newService("new")
+		require.NoError(t, r.ensureManagedGatewayResource(context.Background(), gateway, service, func() {
+			service.Spec.Type = corev1.ServiceTypeClusterIP
+		}))
+		assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type)
+	})
+
+	for _, name := range []string{"not-owned", "owned-by-other"} {
+		t.Run(name+" object is not modified", func(t *testing.T) {
+			service := newService(name)
+			err := r.ensureManagedGatewayResource(context.Background(), gateway, service, setType(service))
+			require.ErrorIs(t, err, errManagedGatewayResourceNotOwned)
+
+			existing := &corev1.Service{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(service), existing))
+			assert.Empty(t, existing.Spec.Type)
+			assert.False(t, metav1.IsControlledBy(existing, gateway))
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +22,6 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
-	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/builder"
 )
 
 // -----------------------------------------------------------------------------
@@ -601,25 +601,27 @@ func isGatewayClassEventInClass(log logr.Logger, watchEvent interface{}) bool {
 	return false
 }
 
+// listenerProtocolRouteKinds maps listener protocols to the kinds of routes that can be attached
+// to listeners using them.
+var listenerProtocolRouteKinds = map[gatewayapi.ProtocolType][]gatewayapi.Kind{
+	gatewayapi.HTTPProtocolType:  {"HTTPRoute", "GRPCRoute"},
+	gatewayapi.HTTPSProtocolType: {"HTTPRoute", "GRPCRoute"},
+	gatewayapi.TCPProtocolType:   {"TCPRoute"},
+	gatewayapi.UDPProtocolType:   {"UDPRoute"},
+	gatewayapi.TLSProtocolType:   {"TLSRoute"},
+}
+
 // getListenerSupportedRouteKinds determines what RouteGroupKinds are supported by the Listener.
 // If no AllowedRoutes.Kinds are specified for the Listener, the supported RouteGroupKind is derived directly
 // from the Listener's Protocol.
-// Otherwise, user specified AllowedRoutes.Kinds are used, filtered by the global Gateway supported kinds.
+// Otherwise, user specified AllowedRoutes.Kinds are used, filtered by the global Gateway supported kinds
+// and the kinds that can be attached to the Listener's Protocol.
 func getListenerSupportedRouteKinds(l gatewayapi.Listener) ([]gatewayapi.RouteGroupKind, gatewayapi.ListenerConditionReason) {
+	protocolKinds := listenerProtocolRouteKinds[l.Protocol]
 	if l.AllowedRoutes == nil || len(l.AllowedRoutes.Kinds) == 0 {
-		switch l.Protocol {
-		case gatewayapi.HTTPProtocolType, gatewayapi.HTTPSProtocolType:
-			return []gatewayapi.RouteGroupKind{
-				builder.NewRouteGroupKind().HTTPRoute().Build(),
-				builder.NewRouteGroupKind().GRPCRoute().Build(),
-			}, gatewayapi.ListenerReasonResolvedRefs
-		case gatewayapi.TCPProtocolType:
-			return builder.NewRouteGroupKind().TCPRoute().IntoSlice(), gatewayapi.ListenerReasonResolvedRefs
-		case gatewayapi.UDPProtocolType:
-			return builder.NewRouteGroupKind().UDPRoute().IntoSlice(), gatewayapi.ListenerReasonResolvedRefs
-		case gatewayapi.TLSProtocolType:
-			return builder.NewRouteGroupKind().TLSRoute().IntoSlice(), gatewayapi.ListenerReasonResolvedRefs
-		}
+		return lo.Map(protocolKinds, func(k gatewayapi.Kind, _ int) gatewayapi.RouteGroupKind {
+			return gatewayapi.RouteGroupKind{Group: lo.ToPtr(gatewayapi.V1Group), Kind: k}
+		}), gatewayapi.ListenerReasonResolvedRefs
 	}
 
 	var (
@@ -627,18 +629,14 @@ func getListenerSupportedRouteKinds(l gatewayapi.Listener) ([]gatewayapi.RouteGr
 		reason       = gatewayapi.ListenerReasonResolvedRefs
 	)
 	for _, gk := range l.AllowedRoutes.Kinds {
-		if gk.Group != nil && *gk.Group == gatewayv1.GroupName {
-			_, ok := lo.Find(supportedKinds, func(k gatewayapi.Kind) bool {
-				return gk.Kind == k
-			})
-			if ok {
-				supportedRGK = append(supportedRGK, gk)
-				continue
-			}
-			reason = gatewayapi.ListenerReasonInvalidRouteKinds
-		} else {
-			reason = gatewayapi.ListenerReasonInvalidRouteKinds
+		// A kind is supported only if it's implemented and it can be attached to the listener's protocol,
+		// e.g. TCPRoute can't be attached to an HTTP listener.
+		if gk.Group != nil && *gk.Group == gatewayv1.GroupName &&
+			lo.Contains(supportedKinds, gk.Kind) && lo.Contains(protocolKinds, gk.Kind) {
+			supportedRGK = append(supportedRGK, gk)
+			continue
 		}
+		reason = gatewayapi.ListenerReasonInvalidRouteKinds
 	}
 
 	return supportedRGK, reason
@@ -664,8 +662,7 @@ func isTLSSecretValid(secret *corev1.Secret) bool {
 
 // routeAcceptedByGateways finds all the Gateways the route has been accepted by
 // and returns them in the form of a NamespacedName slice.
-func routeAcceptedByGateways(route *gatewayapi.HTTPRoute,
-) []k8stypes.NamespacedName {
+func routeAcceptedByGateways[T gatewayapi.RouteT](route T) []k8stypes.NamespacedName {
 	gateways := []k8stypes.NamespacedName{}
 	for _, routeParentStatus := range getRouteStatusParents(route) {
 		gatewayNamespace := route.GetNamespace()
@@ -689,42 +686,78 @@ func routeAcceptedByGateways(route *gatewayapi.HTTPRoute,
 }
 
 // getAttachedRoutesForListener returns the number of all the routes that are attached
-// to the provided Gateway.
+// to the provided Gateway's listener.
 //
-// NOTE: At this point we take into account HTTPRoutes only, as they are the
-// only routes in GA.
+// Routes of kinds whose CRDs are not installed in the cluster are not taken into account.
 func getAttachedRoutesForListener(ctx context.Context, mgrc client.Client, gateway gatewayapi.Gateway, listenerIndex int) (int32, error) {
-	httpRouteList := gatewayapi.HTTPRouteList{}
-	if err := mgrc.List(ctx, &httpRouteList); err != nil {
+	var attachedRoutes int32
+	for _, count := range []func() (int32, error){
+		func() (int32, error) {
+			return countAttachedRoutesForListener[*gatewayapi.HTTPRoute](ctx, mgrc, &gatewayapi.HTTPRouteList{}, gatewayapi.V1HTTPRouteTypeMeta, gateway, listenerIndex)
+		},
+		func() (int32, error) {
+			return countAttachedRoutesForListener[*gatewayapi.GRPCRoute](ctx, mgrc, &gatewayapi.GRPCRouteList{}, gatewayapi.GRPCRouteTypeMeta, gateway, listenerIndex)
+		},
+		func() (int32, error) {
+			return countAttachedRoutesForListener[*gatewayapi.TCPRoute](ctx, mgrc, &gatewayapi.TCPRouteList{}, gatewayapi.TCPRouteTypeMeta, gateway, listenerIndex)
+		},
+		func() (int32, error) {
+			return countAttachedRoutesForListener[*gatewayapi.UDPRoute](ctx, mgrc, &gatewayapi.UDPRouteList{}, gatewayapi.UDPRouteTypeMeta, gateway, listenerIndex)
+		},
+		func() (int32, error) {
+			return countAttachedRoutesForListener[*gatewayapi.TLSRoute](ctx, mgrc, &gatewayapi.TLSRouteList{}, gatewayapi.TLSRouteTypeMeta, gateway, listenerIndex)
+		},
+	} {
+		n, err := count()
+		if err != nil {
+			return 0, err
+		}
+		attachedRoutes += n
+	}
+	return attachedRoutes, nil
+}
+
+// countAttachedRoutesForListener lists the routes of type T and returns the number of them that are accepted
+// by the provided Gateway's listener. Listed routes get the typeMeta set, as the listener's allowed kinds
+// are matched against it.
+func countAttachedRoutesForListener[T gatewayapi.RouteT](
+	ctx context.Context,
+	mgrc client.Client,
+	list client.ObjectList,
+	typeMeta metav1.TypeMeta,
+	gateway gatewayapi.Gateway,
+	listenerIndex int,
+) (int32, error) {
+	if err := mgrc.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	routes, err := meta.ExtractList(list)
+	if err != nil {
 		return 0, err
 	}
 
 	var attachedRoutes int32
-	for _, route := range httpRouteList.Items {
-		route := route
-		acceptedByGateway := lo.ContainsBy(route.Status.Parents, func(parentStatus gatewayapi.RouteParentStatus) bool {
-			parentRef := parentStatus.ParentRef
-			if parentRef.Group != nil && *parentRef.Group != gatewayapi.V1Group {
-				return false
-			}
-			if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
-				return false
-			}
-			gatewayNamespace := route.Namespace
-			if parentRef.Namespace != nil {
-				gatewayNamespace = string(*parentRef.Namespace)
-			}
-			return gateway.Namespace == gatewayNamespace && gateway.Name == string(parentRef.Name)
+	for _, obj := range routes {
+		route, ok := obj.(T)
+		if !ok {
+			return 0, fmt.Errorf("unexpected object type %T in %T", obj, list)
+		}
+		route.GetObjectKind().SetGroupVersionKind(typeMeta.GroupVersionKind())
+		acceptedByGateway := lo.ContainsBy(routeAcceptedByGateways(route), func(nn k8stypes.NamespacedName) bool {
+			return gateway.Namespace == nn.Namespace && gateway.Name == nn.Name
 		})
 		if !acceptedByGateway {
 			continue
 		}
 
-		for _, parentRef := range route.Spec.ParentRefs {
+		for _, parentRef := range getRouteParentRefs(route) {
 			accepted, err := isRouteAcceptedByListener(
 				ctx,
 				mgrc,
-				&route,
+				route,
 				gateway,
 				listenerIndex,
 				parentRef,
@@ -8,12 +8,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/builder"
 )
 
@@ -106,6 +106,31 @@ func TestGetListenerSupportedRouteKinds(t *testing.T) {
 			expectedSupportedKinds: builder.NewRouteGroupKind().HTTPRoute().IntoSlice(),
 			resolvedRefsReason:     gatewayapi.ListenerReasonResolvedRefs,
 		},
+		{
+			name: "Kind not matching the protocol gets discarded",
+			listener: gatewayapi.Listener{
+				Protocol: gatewayapi.HTTPSProtocolType,
+				AllowedRoutes: &gatewayapi.AllowedRoutes{
+					Kinds: []gatewayapi.RouteGroupKind{
+						builder.NewRouteGroupKind().GRPCRoute().Build(),
+						builder.NewRouteGroupKind().TCPRoute().Build(),
+					},
+				},
+			},
+			expectedSupportedKinds: builder.NewRouteGroupKind().GRPCRoute().IntoSlice(),
+			resolvedRefsReason:     gatewayapi.ListenerReasonInvalidRouteKinds,
+		},
+		{
+			name: "TLSRoute on TLS listener gets passed",
+			listener: gatewayapi.Listener{
+				Protocol: gatewayapi.TLSProtocolType,
+				AllowedRoutes: &gatewayapi.AllowedRoutes{
+					Kinds: builder.NewRouteGroupKind().TLSRoute().IntoSlice(),
+				},
+			},
+			expectedSupportedKinds: builder.NewRouteGroupKind().TLSRoute().IntoSlice(),
+			resolvedRefsReason:     gatewayapi.ListenerReasonResolvedRefs,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -120,15 +145,12 @@ func TestGetListenerSupportedRouteKinds(t *testing.T) {
 
 func TestGetListenerStatus(t *testing.T) {
 	ctx := context.Background()
-	scheme := runtime.NewScheme()
-	require.NoError(t, gatewayapi.InstallV1(scheme))
-
-	client := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 	testCases := []struct {
 		name                     string
 		gateway                  *gatewayapi.Gateway
 		kongListens              []gatewayapi.Listener
+		routes                   []client.Object
 		expectedListenerStatuses []gatewayapi.ListenerStatus
 	}{
 		{
@@ -253,11 +275,98 @@ func TestGetListenerStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "L4 routes attached to listeners are counted",
+			gateway: &gatewayapi.Gateway{
+				TypeMeta: gatewayapi.V1GatewayTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "l4-listeners",
+				},
+				Spec: gatewayapi.GatewaySpec{
+					GatewayClassName: "kong",
+					Listeners: []gatewayapi.Listener{
+						{
+							Name:     "tcp-80",
+							Port:     80,
+							Protocol: gatewayapi.TCPProtocolType,
+						},
+						{
+							Name:     "tls-443",
+							Port:     443,
+							Protocol: gatewayapi.TLSProtocolType,
+							TLS: &gatewayapi.GatewayTLSConfig{
+								Mode: lo.ToPtr(gatewayapi.TLSModePassthrough),
+							},
+						},
+					},
+				},
+				Status: gatewayapi.GatewayStatus{
+					Listeners: []gatewayapi.ListenerStatus{
+						{
+							Name:           "tcp-80",
+							SupportedKinds: builder.NewRouteGroupKind().TCPRoute().IntoSlice(),
+						},
+						{
+							Name:           "tls-443",
+							SupportedKinds: builder.NewRouteGroupKind().TLSRoute().IntoSlice(),
+						},
+					},
+				},
+			},
+			kongListens: []gatewayapi.Listener{
+				{
+					Port:     80,
+					Protocol: gatewayapi.TCPProtocolType,
+				},
+				{
+					Port:     443,
+					Protocol: gatewayapi.TLSProtocolType,
+				},
+			},
+			routes: []client.Object{
+				l4RouteAcceptedByGateway(&gatewayapi.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp-1"}}, "l4-listeners"),
+				l4RouteAcceptedByGateway(&gatewayapi.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp-2"}}, "l4-listeners"),
+				l4RouteAcceptedByGateway(&gatewayapi.TLSRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls"}}, "l4-listeners"),
+				// Not attached, as it hasn't been accepted by the Gateway.
+				&gatewayapi.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp-not-accepted"},
+					Spec: gatewayapi.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi.CommonRouteSpec{
+							ParentRefs: []gatewayapi.ParentReference{{Name: "l4-listeners"}},
+						},
+					},
+				},
+			},
+			expectedListenerStatuses: []gatewayapi.ListenerStatus{
+				{
+					Name: gatewayapi.SectionName("tcp-80"),
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(gatewayapi.ListenerConditionAccepted),
+							Status: metav1.ConditionTrue,
+						},
+					},
+					AttachedRoutes: 2,
+				},
+				{
+					Name: gatewayapi.SectionName("tls-443"),
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(gatewayapi.ListenerConditionAccepted),
+							Status: metav1.ConditionTrue,
+						},
+					},
+					AttachedRoutes: 1,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(lo.Must(scheme.Get())).WithObjects(tc.routes...).Build()
 			statuses, err := getListenerStatus(ctx, tc.gateway, tc.kongListens, nil, client)
 			require.NoError(t, err)
 			require.Len(t, statuses, len(tc.expectedListenerStatuses), "should return expected number of listener statused")
@@ -266,6 +375,7 @@ func TestGetListenerStatus(t *testing.T) {
 					return ls.Name == expectedListenerStatus.Name
 				})
 				require.Truef(t, ok, "should find listener status of listener %s", expectedListenerStatus.Name)
+				assert.Equal(t, expectedListenerStatus.AttachedRoutes, listenerStatus.AttachedRoutes)
 				assertOnlyOneConditionForType(t, listenerStatus.Conditions)
 				for _, expectedCondition := range expectedListenerStatus.Conditions {
 					assert.Truef(t,
@@ -281,6 +391,29 @@ func TestGetListenerStatus(t *testing.T) {
 	}
 }
 
+// l4RouteAcceptedByGateway sets the route's parentRefs and status so that it's accepted by the Gateway
+// with the given name in the route's namespace.
+func l4RouteAcceptedByGateway[T *gatewayapi.TCPRoute | *gatewayapi.TLSRoute](route T, gatewayName string) client.Object {
+	parentRefs := []gatewayapi.ParentReference{{Name: gatewayapi.ObjectName(gatewayName)}}
+	parentStatuses := []gatewayapi.RouteParentStatus{
+		{
+			ParentRef:      parentRefs[0],
+			ControllerName: GetControllerName(),
+		},
+	}
+	switch r := any(route).(type) {
+	case *gatewayapi.TCPRoute:
+		r.Spec.ParentRefs = parentRefs
+		r.Status.Parents = parentStatuses
+		return r
+	case *gatewayapi.TLSRoute:
+		r.Spec.ParentRefs = parentRefs
+		r.Status.Parents = parentStatuses
+		return r
+	}
+	return nil
+}
+
 func assertOnlyOneConditionForType(t *testing.T, conditions []metav1.Condition) {
 	conditionsNum := lo.CountValuesBy(conditions, func(c metav1.Condition) string {
 		return c.Type
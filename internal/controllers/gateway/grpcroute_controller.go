@@ -408,10 +408,9 @@ func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ctrl.Result{Requeue: !statusUpdated}, nil
 		}
 
-		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), grpcroute, grpcroute.Status.Parents, gateways, metav1.Condition{
-			Status: metav1.ConditionTrue,
-			Reason: string(ConditionReasonConfiguredInGateway),
-		})
+		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), grpcroute, grpcroute.Status.Parents, gateways,
+			configuredInGatewayCondition(r.DataplaneClient, grpcroute),
+		)
 		if err != nil {
 			// don't proceed until the statuses can be updated appropriately
 			debug(log, grpcroute, "Failed to update programmed condition")
@@ -29,12 +29,15 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/utils"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v3/internal/util/kubernetes/object"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/kubernetes/object/status"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 )
 
 // -----------------------------------------------------------------------------
@@ -60,6 +63,16 @@ type HTTPRouteReconciler struct {
 	// If GatewayNN is set,
 	// only resources managed by the specified Gateway are reconciled.
 	GatewayNN controllers.OptionalNamespacedName
+
+	// ExpressionRoutes indicates whether the expressions router is used. When it's not, HTTPRoutes with
+	// matches the traditional router can't express are not accepted.
+	ExpressionRoutes bool
+
+	// HTTPRouteRequestMirror indicates whether the HTTPRouteRequestMirror feature gate is enabled and
+	// UntrustedLua whether Kong Gateway is configured with untrusted_lua set to on. Unless both are,
+	// HTTPRoutes with RequestMirror filters are not accepted.
+	HTTPRouteRequestMirror bool
+	UntrustedLua           bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -347,6 +360,7 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForGateway(ctx context.Context, obj
 // -----------------------------------------------------------------------------
 
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=configuration.konghq.com,resources=kongplugins;kongclusterplugins,verbs=get;list;watch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get;update
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;watch;get
 
@@ -432,6 +446,24 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// if the router can't express the httproute's matches, the httproute should not be accepted
+	// instead of being translated into routes matching different requests.
+	if !r.ExpressionRoutes {
+		if err := subtranslator.ValidateHTTPRouteForTraditionalRouter(httproute); err != nil {
+			debug(log, httproute, "HTTPRoute matches are not supported by the traditional router", "reason", err.Error())
+			setRouteNotAcceptedWithUnsupportedValue(gateways, err)
+		}
+	}
+
+	// RequestMirror filters are translated into Lua code that Kong has to be allowed to run, so the httproute
+	// should not be accepted when they can't be translated.
+	if err := subtranslator.ValidateHTTPRouteRequestMirrors(
+		httproute, r.HTTPRouteRequestMirror, r.UntrustedLua, r.routePluginNames(ctx, httproute),
+	); err != nil {
+		debug(log, httproute, "HTTPRoute RequestMirror filters are not supported", "reason", err.Error())
+		setRouteNotAcceptedWithUnsupportedValue(gateways, err)
+	}
+
 	// if there is no matched hosts in listeners for the httproute, the httproute should not be accepted
 	// and have an "Accepted" condition with status false.
 	// https://gateway-api.sigs.k8s.io/references/spec/#gateway.networking.k8s.io/v1.HTTPRoute
@@ -498,10 +530,9 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ctrl.Result{Requeue: !statusUpdated}, nil
 		}
 
-		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), httproute, httproute.Status.Parents, gateways, metav1.Condition{
-			Status: metav1.ConditionTrue,
-			Reason: string(ConditionReasonConfiguredInGateway),
-		})
+		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), httproute, httproute.Status.Parents, gateways,
+			configuredInGatewayCondition(r.DataplaneClient, httproute),
+		)
 		if err != nil {
 			// don't proceed until the statuses can be updated appropriately
 			debug(log, httproute, "Failed to update programmed condition")
@@ -555,6 +586,7 @@ func (r *HTTPRouteReconciler) ensureGatewayReferenceStatusAdded(ctx context.Cont
 				ObservedGeneration: httproute.Generation,
 				LastTransitionTime: metav1.Now(),
 				Reason:             gateway.condition.Reason,
+				Message:            gateway.condition.Message,
 			}},
 		}
 		if gateway.listenerName != "" {
@@ -672,7 +704,7 @@ func (r *HTTPRouteReconciler) setRouteConditionResolvedRefsCondition(
 
 func (r *HTTPRouteReconciler) getHTTPRouteRuleReason(ctx context.Context, httpRoute gatewayapi.HTTPRoute) (gatewayapi.RouteConditionReason, error) {
 	for _, rule := range httpRoute.Spec.Rules {
-		for _, backendRef := range rule.BackendRefs {
+		for _, backendRef := range httpRouteRuleBackendRefs(rule) {
 			backendNamespace := httpRoute.Namespace
 			if backendRef.Namespace != nil && *backendRef.Namespace != "" {
 				backendNamespace = string(*backendRef.Namespace)
@@ -724,6 +756,23 @@ func (r *HTTPRouteReconciler) getHTTPRouteRuleReason(ctx context.Context, httpRo
 	return gatewayapi.RouteReasonResolvedRefs, nil
 }
 
+// httpRouteRuleBackendRefs returns backendRefs of the rule along with backendRefs of its RequestMirror filters,
+// as both need to be resolved for the rule to be configured properly.
+func httpRouteRuleBackendRefs(rule gatewayapi.HTTPRouteRule) []gatewayapi.HTTPBackendRef {
+	backendRefs := slices.Clone(rule.BackendRefs)
+	for _, filter := range rule.Filters {
+		if filter.Type != gatewayapi.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil {
+			continue
+		}
+		backendRefs = append(backendRefs, gatewayapi.HTTPBackendRef{
+			BackendRef: gatewayapi.BackendRef{
+				BackendObjectReference: filter.RequestMirror.BackendRef,
+			},
+		})
+	}
+	return backendRefs
+}
+
 // SetLogger sets the logger.
 func (r *HTTPRouteReconciler) SetLogger(l logr.Logger) {
 	r.Log = l
@@ -771,3 +820,42 @@ func parentReferenceKey(routeNamespace string, parentRef gatewayapi.ParentRefere
 	// and that's the only kind we should be getting here thanks to the admission webhook validation.
 	return fmt.Sprintf("%s/%s/%s/%s", namespace, parentRef.Name, sectionName, portNumber)
 }
+
+// setRouteNotAcceptedWithUnsupportedValue sets the Accepted condition of the route for the Gateways which
+// have accepted it to False with the UnsupportedValue reason and the given error as the message.
+func setRouteNotAcceptedWithUnsupportedValue(gateways []supportedGatewayWithCondition, err error) {
+	for i := range gateways {
+		if gateways[i].condition.Type != string(gatewayapi.RouteConditionAccepted) ||
+			gateways[i].condition.Status != metav1.ConditionTrue {
+			continue
+		}
+		gateways[i].condition = metav1.Condition{
+			Type:    string(gatewayapi.RouteConditionAccepted),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(gatewayapi.RouteReasonUnsupportedValue),
+			Message: err.Error(),
+		}
+	}
+}
+
+// routePluginNames returns names of Kong plugins (e.g. "pre-function") of the KongPlugins and KongClusterPlugins
+// attached to the HTTPRoute with the konghq.com/plugins annotation. References which can't be resolved are skipped.
+func (r *HTTPRouteReconciler) routePluginNames(ctx context.Context, httproute *gatewayapi.HTTPRoute) []string {
+	var names []string
+	for _, ref := range annotations.ExtractNamespacedKongPluginsFromAnnotations(httproute.Annotations) {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = httproute.Namespace
+		}
+		var plugin kongv1.KongPlugin
+		if err := r.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: ref.Name}, &plugin); err == nil {
+			names = append(names, plugin.PluginName)
+			continue
+		}
+		var clusterPlugin kongv1.KongClusterPlugin
+		if err := r.Get(ctx, k8stypes.NamespacedName{Name: ref.Name}, &clusterPlugin); err == nil {
+			names = append(names, clusterPlugin.PluginName)
+		}
+	}
+	return names
+}
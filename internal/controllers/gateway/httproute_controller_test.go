@@ -1,12 +1,18 @@
 package gateway
 
 import (
+	"context"
 	"testing"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 )
 
 func TestEnsureNoStaleParentStatus(t *testing.T) {
@@ -225,3 +231,65 @@ func TestParentReferenceKey(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPRouteReconcilerRoutePluginNames(t *testing.T) {
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(lo.Must(scheme.Get())).
+		WithObjects(
+			&kongv1.KongPlugin{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror-lua", Namespace: "default"},
+				PluginName: "pre-function",
+			},
+			&kongv1.KongPlugin{
+				ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "other"},
+				PluginName: "key-auth",
+			},
+			&kongv1.KongClusterPlugin{
+				ObjectMeta: metav1.ObjectMeta{Name: "global"},
+				PluginName: "cors",
+			},
+		).
+		Build()
+	r := &HTTPRouteReconciler{Client: fakeClient}
+	httproute := &gatewayapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"konghq.com/plugins": "mirror-lua, other:remote, global, missing",
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"pre-function", "key-auth", "cors"}, r.routePluginNames(context.Background(), httproute))
+}
+
+func TestSetRouteNotAcceptedWithUnsupportedValue(t *testing.T) {
+	gateways := []supportedGatewayWithCondition{
+		{
+			condition: metav1.Condition{
+				Type:   string(gatewayapi.RouteConditionAccepted),
+				Status: metav1.ConditionTrue,
+				Reason: string(gatewayapi.RouteReasonAccepted),
+			},
+		},
+		{
+			condition: metav1.Condition{
+				Type:   string(gatewayapi.RouteConditionAccepted),
+				Status: metav1.ConditionFalse,
+				Reason: string(gatewayapi.RouteReasonNoMatchingListenerHostname),
+			},
+		},
+	}
+
+	setRouteNotAcceptedWithUnsupportedValue(gateways, subtranslator.ErrRouteValidationRequestMirrorUntrustedLua)
+
+	assert.Equal(t, metav1.Condition{
+		Type:    string(gatewayapi.RouteConditionAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(gatewayapi.RouteReasonUnsupportedValue),
+		Message: subtranslator.ErrRouteValidationRequestMirrorUntrustedLua.Error(),
+	}, gateways[0].condition)
+	assert.Equal(t, string(gatewayapi.RouteReasonNoMatchingListenerHostname), gateways[1].condition.Reason,
+		"condition of a Gateway that hasn't accepted the route should be kept")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/samber/lo"
@@ -409,12 +410,8 @@ func routeTypeMatchesListenerType[T gatewayapi.RouteT](route T, listener gateway
 		if listener.Protocol != gatewayapi.TLSProtocolType {
 			return false
 		}
-		// TLSRoutes currently support Passthrough only
-		// Note: this is a guess we are doing as the upstream documentation is unclear at the moment.
-		// see https://github.com/kubernetes-sigs/gateway-api/issues/1474
-		if listener.TLS != nil && *listener.TLS.Mode != gatewayapi.TLSModePassthrough {
-			return false
-		}
+		// TLSRoutes support both Passthrough, where Kong routes the TLS stream by its SNI, and Terminate,
+		// where Kong terminates TLS with the listener's certificate and proxies plain TCP to the backends.
 	case *gatewayapi.GRPCRoute:
 		if listener.Protocol != gatewayapi.HTTPSProtocolType && listener.Protocol != gatewayapi.HTTPProtocolType {
 			return false
@@ -844,6 +841,17 @@ func ensureParentsProgrammedCondition[
 	return false, nil
 }
 
+// configuredInGatewayCondition returns a Programmed condition for a route successfully configured
+// in the data-plane. Warnings reported for the route, e.g. backend weights that could only be
+// approximated, are included in the condition message.
+func configuredInGatewayCondition(dataplaneClient controllers.DataPlaneStatusClient, route client.Object) metav1.Condition {
+	return metav1.Condition{
+		Status:  metav1.ConditionTrue,
+		Reason:  string(ConditionReasonConfiguredInGateway),
+		Message: strings.Join(dataplaneClient.KubernetesObjectConfigurationWarnings(route), "; "),
+	}
+}
+
 // setRouteParentInStatusForParent checks if the provided route Status, contains
 // status for the provided parent and if it does it sets it to the provided
 // RouteStatusParent. If it does not then it appends the provided RouteStatusParent
@@ -1182,7 +1182,7 @@ func TestGetSupportedGatewayForRoute(t *testing.T) {
 				},
 			},
 			{
-				name:  "basic TLSRoute does not get accepted because there is no listener with TLS in passthrough mode",
+				name:  "basic TLSRoute gets accepted by a listener with TLS in terminate mode",
 				route: basicTLSRoute(),
 				objects: []client.Object{
 					func() *gatewayapi.Gateway {
@@ -1200,7 +1200,7 @@ func TestGetSupportedGatewayForRoute(t *testing.T) {
 				},
 				expected: []expected{
 					{
-						condition: routeConditionAccepted(metav1.ConditionFalse, gatewayapi.RouteReasonNoMatchingParent),
+						condition: routeConditionAccepted(metav1.ConditionTrue, gatewayapi.RouteReasonAccepted),
 					},
 				},
 			},
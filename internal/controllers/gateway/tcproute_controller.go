@@ -414,10 +414,9 @@ func (r *TCPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{Requeue: !statusUpdated}, nil
 		}
 
-		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), tcproute, tcproute.Status.Parents, gateways, metav1.Condition{
-			Status: metav1.ConditionTrue,
-			Reason: string(ConditionReasonConfiguredInGateway),
-		})
+		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), tcproute, tcproute.Status.Parents, gateways,
+			configuredInGatewayCondition(r.DataplaneClient, tcproute),
+		)
 		if err != nil {
 			// don't proceed until the statuses can be updated appropriately
 			debug(log, tcproute, "Failed to update programmed condition")
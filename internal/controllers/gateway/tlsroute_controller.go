@@ -399,10 +399,9 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{Requeue: !statusUpdated}, nil
 		}
 
-		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), tlsroute, tlsroute.Status.Parents, gateways, metav1.Condition{
-			Status: metav1.ConditionTrue,
-			Reason: string(ConditionReasonConfiguredInGateway),
-		})
+		statusUpdated, err := ensureParentsProgrammedCondition(ctx, r.Status(), tlsroute, tlsroute.Status.Parents, gateways,
+			configuredInGatewayCondition(r.DataplaneClient, tlsroute),
+		)
 		if err != nil {
 			// don't proceed until the statuses can be updated appropriately
 			debug(log, tlsroute, "Failed to update programmed condition")
@@ -0,0 +1,109 @@
+package configfetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// DefaultLastValidConfigPersistInterval is the default minimum interval between writes of the persisted last valid
+	// configuration.
+	DefaultLastValidConfigPersistInterval = 10 * time.Second
+
+	// lastValidConfigFlushTimeout is the time the pending configuration is given to be persisted on shutdown.
+	lastValidConfigFlushTimeout = 10 * time.Second
+)
+
+// AsyncLastValidConfigPersister persists the last valid configuration in the background, so that encoding and writing
+// it doesn't hold up syncs. Writes are debounced: at most one happens per interval and only the latest configuration
+// passed to Persist in the meantime is written. The pending configuration is flushed on shutdown. It implements
+// the controller-runtime Runnable interface.
+type AsyncLastValidConfigPersister struct {
+	logger    logr.Logger
+	persister LastValidConfigPersister
+	interval  time.Duration
+
+	lock    sync.Mutex
+	pending *PersistedConfig
+	notify  chan struct{}
+}
+
+// NewAsyncLastValidConfigPersister creates an AsyncLastValidConfigPersister writing with persister at most once
+// per interval.
+func NewAsyncLastValidConfigPersister(
+	logger logr.Logger,
+	persister LastValidConfigPersister,
+	interval time.Duration,
+) *AsyncLastValidConfigPersister {
+	return &AsyncLastValidConfigPersister{
+		logger:    logger,
+		persister: persister,
+		interval:  interval,
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Persist schedules the configuration to be persisted, replacing the configuration scheduled before. It never fails,
+// as errors of the actual write are only logged.
+func (p *AsyncLastValidConfigPersister) Persist(_ context.Context, config PersistedConfig) error {
+	p.lock.Lock()
+	p.pending = &config
+	p.lock.Unlock()
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Load loads the persisted configuration synchronously.
+func (p *AsyncLastValidConfigPersister) Load(ctx context.Context) (PersistedConfig, bool, error) {
+	return p.persister.Load(ctx)
+}
+
+// Start persists scheduled configurations until the context is done.
+func (p *AsyncLastValidConfigPersister) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			// Give the pending configuration a chance to be persisted, so that the latest one survives the restart.
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lastValidConfigFlushTimeout)
+			p.persistPending(flushCtx)
+			cancel()
+			p.logger.Info("Context done: shutting down the last valid configuration persistence")
+			return nil
+		case <-p.notify:
+			p.persistPending(ctx)
+		}
+
+		// Debounce writes, configurations scheduled in the meantime replace each other.
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime Runnable interface. Only the leader pushes configuration,
+// so only the leader persists it.
+func (p *AsyncLastValidConfigPersister) NeedLeaderElection() bool {
+	return true
+}
+
+func (p *AsyncLastValidConfigPersister) persistPending(ctx context.Context) {
+	p.lock.Lock()
+	config := p.pending
+	p.pending = nil
+	p.lock.Unlock()
+	if config == nil {
+		return
+	}
+
+	if err := p.persister.Persist(ctx, *config); err != nil {
+		p.logger.Error(err, "Failed to persist last valid configuration")
+	}
+}
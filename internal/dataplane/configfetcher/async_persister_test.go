@@ -0,0 +1,73 @@
+package configfetcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+)
+
+// recordingPersister records names of the first service of persisted configurations.
+type recordingPersister struct {
+	lock      sync.Mutex
+	persisted []string
+}
+
+func (p *recordingPersister) Persist(_ context.Context, config PersistedConfig) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.persisted = append(p.persisted, *config.KongState.Services[0].Name)
+	return nil
+}
+
+func (p *recordingPersister) Load(context.Context) (PersistedConfig, bool, error) {
+	return PersistedConfig{}, false, nil
+}
+
+func (p *recordingPersister) persistedServices() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]string{}, p.persisted...)
+}
+
+func TestAsyncLastValidConfigPersister(t *testing.T) {
+	newConfig := func(serviceName string) PersistedConfig {
+		return PersistedConfig{
+			KongState: &kongstate.KongState{
+				Services: []kongstate.Service{{Service: kong.Service{Name: kong.String(serviceName)}}},
+			},
+		}
+	}
+
+	recorder := &recordingPersister{}
+	p := NewAsyncLastValidConfigPersister(logr.Discard(), recorder, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, p.Start(ctx))
+	}()
+
+	require.NoError(t, p.Persist(ctx, newConfig("first")))
+	require.Eventually(t, func() bool {
+		return len(recorder.persistedServices()) == 1
+	}, time.Second, time.Millisecond, "first configuration should be persisted right away")
+
+	require.NoError(t, p.Persist(ctx, newConfig("second")))
+	require.NoError(t, p.Persist(ctx, newConfig("third")))
+	require.Never(t, func() bool {
+		return len(recorder.persistedServices()) > 1
+	}, 50*time.Millisecond, time.Millisecond, "configurations should not be persisted before the interval elapses")
+
+	cancel()
+	<-done
+	require.Equal(t, []string{"first", "third"}, recorder.persistedServices(),
+		"only the latest pending configuration should be flushed on shutdown")
+}
@@ -0,0 +1,101 @@
+package configfetcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+// ConfigDrift describes a gateway whose configuration differs from the configuration that was last pushed to it.
+type ConfigDrift struct {
+	// URL is the base root URL of the gateway's Admin API.
+	URL string
+	// ExpectedHash is the configuration hash the gateway reported after the configuration was pushed to it.
+	ExpectedHash string
+	// ActualHash is the configuration hash the gateway currently reports.
+	ActualHash string
+}
+
+type ConfigDriftDetector interface {
+	// RecordPushed records the configuration hash reported by a gateway the configuration was just pushed to.
+	RecordPushed(ctx context.Context, client sendconfig.AdminAPIClient) error
+
+	// DetectDrift compares the configuration hash currently reported by a gateway with the one recorded after
+	// the configuration was last pushed to it. It returns the drift and true if they differ.
+	DetectDrift(ctx context.Context, client sendconfig.AdminAPIClient) (ConfigDrift, bool, error)
+}
+
+// DefaultConfigDriftDetector detects configuration drift of DB-less gateways using the configuration hash they
+// report in their status. The hash changes when configuration is changed out of band, e.g. by calling the Admin API
+// directly, or when a gateway restarts with a different configuration than the one that was pushed to it.
+// It's safe for concurrent use.
+type DefaultConfigDriftDetector struct {
+	lock sync.Mutex
+	// pushed are configurations last pushed to gateways, keyed by their base root URLs.
+	pushed map[string]pushedConfig
+}
+
+// pushedConfig is a configuration pushed to a gateway.
+type pushedConfig struct {
+	// sha is the SHA of the pushed configuration as computed by the controller.
+	sha []byte
+	// hash is the configuration hash reported by the gateway after the configuration was pushed.
+	hash string
+}
+
+func NewDefaultConfigDriftDetector() *DefaultConfigDriftDetector {
+	return &DefaultConfigDriftDetector{
+		pushed: map[string]pushedConfig{},
+	}
+}
+
+func (d *DefaultConfigDriftDetector) RecordPushed(ctx context.Context, client sendconfig.AdminAPIClient) error {
+	status, err := client.AdminAPIClient().Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get status of %s: %w", client.BaseRootURL(), err)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.pushed[client.BaseRootURL()] = pushedConfig{
+		sha:  client.LastConfigSHA(),
+		hash: status.ConfigurationHash,
+	}
+	return nil
+}
+
+func (d *DefaultConfigDriftDetector) DetectDrift(ctx context.Context, client sendconfig.AdminAPIClient) (ConfigDrift, bool, error) {
+	status, err := client.AdminAPIClient().Status(ctx)
+	if err != nil {
+		return ConfigDrift{}, false, fmt.Errorf("failed to get status of %s: %w", client.BaseRootURL(), err)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	url := client.BaseRootURL()
+	sha := client.LastConfigSHA()
+	pushed, ok := d.pushed[url]
+	if !ok || !bytes.Equal(pushed.sha, sha) {
+		// The configuration pushed to the gateway wasn't recorded, the current hash is the best guess we have.
+		// Nothing was pushed to the gateway yet if there's no SHA, so there's nothing to compare with.
+		if len(sha) > 0 {
+			d.pushed[url] = pushedConfig{sha: sha, hash: status.ConfigurationHash}
+		}
+		return ConfigDrift{}, false, nil
+	}
+	if pushed.hash == status.ConfigurationHash {
+		return ConfigDrift{}, false, nil
+	}
+
+	// The configuration is going to be pushed again, the hash it results in will be recorded then.
+	delete(d.pushed, url)
+	return ConfigDrift{
+		URL:          url,
+		ExpectedHash: pushed.hash,
+		ActualHash:   status.ConfigurationHash,
+	}, true, nil
+}
@@ -0,0 +1,84 @@
+package configfetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+)
+
+func TestDefaultConfigDriftDetector(t *testing.T) {
+	ctx := context.Background()
+
+	startAdminAPI := func(t *testing.T) (*adminapi.Client, *atomic.Value) {
+		hash := &atomic.Value{}
+		hash.Store("8f1dd2f83bc2627cc6b71c76d1476592")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/status" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"configuration_hash": %q, "server": {}, "memory": {}}`, hash.Load())
+		}))
+		t.Cleanup(server.Close)
+		client, err := adminapi.NewTestClient(server.URL)
+		require.NoError(t, err)
+		return client, hash
+	}
+
+	t.Run("no drift is detected for a gateway nothing was pushed to", func(t *testing.T) {
+		client, _ := startAdminAPI(t)
+		_, drifted, err := NewDefaultConfigDriftDetector().DetectDrift(ctx, client)
+		require.NoError(t, err)
+		require.False(t, drifted)
+	})
+
+	t.Run("drift is detected when the hash reported by a gateway changes", func(t *testing.T) {
+		client, hash := startAdminAPI(t)
+		client.SetLastConfigSHA([]byte("sha"))
+		d := NewDefaultConfigDriftDetector()
+		require.NoError(t, d.RecordPushed(ctx, client))
+
+		_, drifted, err := d.DetectDrift(ctx, client)
+		require.NoError(t, err)
+		require.False(t, drifted, "configuration is intact")
+
+		hash.Store("00000000000000000000000000000000")
+		drift, drifted, err := d.DetectDrift(ctx, client)
+		require.NoError(t, err)
+		require.True(t, drifted)
+		require.Equal(t, ConfigDrift{
+			URL:          client.BaseRootURL(),
+			ExpectedHash: "8f1dd2f83bc2627cc6b71c76d1476592",
+			ActualHash:   "00000000000000000000000000000000",
+		}, drift)
+
+		_, drifted, err = d.DetectDrift(ctx, client)
+		require.NoError(t, err)
+		require.False(t, drifted, "drift should be reported only once until configuration is pushed again")
+	})
+
+	t.Run("configuration pushed without recording it is used as a baseline", func(t *testing.T) {
+		client, hash := startAdminAPI(t)
+		client.SetLastConfigSHA([]byte("sha"))
+		d := NewDefaultConfigDriftDetector()
+		require.NoError(t, d.RecordPushed(ctx, client))
+
+		client.SetLastConfigSHA([]byte("another-sha"))
+		hash.Store("a2e8b9a5f5d7c4a1e3f6b8d0c2e4f6a8")
+		_, drifted, err := d.DetectDrift(ctx, client)
+		require.NoError(t, err)
+		require.False(t, drifted, "hash change caused by a push shouldn't be reported")
+
+		hash.Store("8f1dd2f83bc2627cc6b71c76d1476592")
+		_, drifted, err = d.DetectDrift(ctx, client)
+		require.NoError(t, err)
+		require.True(t, drifted)
+	})
+}
@@ -13,7 +13,7 @@ import (
 
 // KongRawStateToKongState converts a Deck kongRawState to a KIC KongState.
 func KongRawStateToKongState(rawstate *utils.KongRawState) *kongstate.KongState {
-	kongState := &kongstate.KongState{}
+	kongState := &kongstate.KongState{FetchedFromGateways: true}
 	if rawstate == nil {
 		return kongState
 	}
@@ -172,6 +172,7 @@ func TestKongRawStateToKongState(t *testing.T) {
 				},
 			},
 			expectedKongState: &kongstate.KongState{
+				FetchedFromGateways: true,
 				Services: []kongstate.Service{
 					{
 						Service: kong.Service{
@@ -347,6 +348,9 @@ func ensureAllKongStateFieldsAreTested(t *testing.T, testedFields []string) {
 		// CustomEntities are not supported yet because go-database-reconciler does not include custom entities.
 		// TODO: support custom entities: https://github.com/Kong/kubernetes-ingress-controller/issues/6054
 		"CustomEntities",
+		// DedicatedGateways are set by the translator, configuration fetched from gateways doesn't tell which Gateways
+		// it belongs to.
+		"DedicatedGateways",
 	}
 	allKongStateFields := func() []string {
 		var fields []string
@@ -0,0 +1,338 @@
+package configfetcher
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// PersistedConfig is the last valid configuration persisted by LastValidConfigPersister.
+type PersistedConfig struct {
+	// KongState is the last valid configuration.
+	KongState *kongstate.KongState
+
+	// CacheSnapshot is the snapshot of Kubernetes objects the last valid configuration was translated from.
+	// It's nil when the snapshot is not preserved.
+	CacheSnapshot *store.CacheStores
+}
+
+// LastValidConfigPersister persists the last valid configuration so that it's available after the controller restarts,
+// even when none of the gateways has it anymore.
+type LastValidConfigPersister interface {
+	// Persist persists the given configuration, replacing the previously persisted one.
+	Persist(ctx context.Context, config PersistedConfig) error
+
+	// Load loads the persisted configuration. The second return value is false when there's no persisted configuration.
+	Load(ctx context.Context) (PersistedConfig, bool, error)
+}
+
+const (
+	// DefaultPersistenceShardSize is the default maximum size of the persisted configuration stored in a single object.
+	// It leaves room for the object's metadata within the 1 MiB limit of Secrets.
+	DefaultPersistenceShardSize = 768 * 1024
+
+	// PersistedConfigDataKey is the key of Secrets' data holding the persisted configuration.
+	PersistedConfigDataKey = "config.json.gz"
+
+	// PersistedConfigHashAnnotationKey is the annotation holding the hash of the whole persisted configuration.
+	// Every shard has it to allow detecting shards belonging to different configurations.
+	PersistedConfigHashAnnotationKey = "konghq.com/last-valid-config-hash"
+
+	// PersistedConfigShardsAnnotationKey is the annotation holding the number of shards of the persisted configuration.
+	// Only the first shard has it.
+	PersistedConfigShardsAnnotationKey = "konghq.com/last-valid-config-shards"
+)
+
+// KubernetesLastValidConfigPersister persists the last valid configuration in Kubernetes Secrets, as it may contain
+// credentials and TLS keys. The configuration is serialized to JSON, compressed with gzip and split into shards
+// of limited size. The first shard is stored in the Secret with the configured name and the following ones in Secrets
+// with the shard index suffix appended to it (e.g. `name-1`, `name-2`).
+type KubernetesLastValidConfigPersister struct {
+	client    client.Client
+	nn        k8stypes.NamespacedName
+	shardSize int
+
+	// lastPersistedHash is the hash of the last persisted configuration. It's used to skip persisting unchanged
+	// configuration.
+	lastPersistedHash string
+}
+
+func NewKubernetesLastValidConfigPersister(
+	c client.Client,
+	nn k8stypes.NamespacedName,
+) *KubernetesLastValidConfigPersister {
+	return &KubernetesLastValidConfigPersister{
+		client:    c,
+		nn:        nn,
+		shardSize: DefaultPersistenceShardSize,
+	}
+}
+
+// persistedConfig is the serialized form of PersistedConfig.
+type persistedConfig struct {
+	KongState     *kongstate.KongState `json:"kongState"`
+	CacheSnapshot []json.RawMessage    `json:"cacheSnapshot,omitempty"`
+}
+
+func (p *KubernetesLastValidConfigPersister) Persist(ctx context.Context, config PersistedConfig) error {
+	data, err := encodePersistedConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	hash := hashOf(data)
+	if hash == p.lastPersistedHash {
+		return nil
+	}
+
+	previousShardsCount := 0
+	head := &corev1.Secret{}
+	if err := p.client.Get(ctx, p.nn, head); err == nil {
+		previousShardsCount, _ = strconv.Atoi(head.Annotations[PersistedConfigShardsAnnotationKey])
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get Secret %s: %w", p.nn, err)
+	}
+
+	shards := splitIntoShards(data, p.shardSize)
+	// The first shard is written last, so that it never points to shards that were not written yet.
+	for i := len(shards) - 1; i >= 0; i-- {
+		annotations := map[string]string{PersistedConfigHashAnnotationKey: hash}
+		if i == 0 {
+			annotations[PersistedConfigShardsAnnotationKey] = strconv.Itoa(len(shards))
+		}
+		if err := p.writeShard(ctx, p.shardName(i), shards[i], annotations); err != nil {
+			return err
+		}
+	}
+	for i := len(shards); i < previousShardsCount; i++ {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: p.nn.Namespace, Name: p.shardName(i)}}
+		if err := p.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale shard Secret %s/%s: %w", p.nn.Namespace, secret.Name, err)
+		}
+	}
+
+	p.lastPersistedHash = hash
+	return nil
+}
+
+func (p *KubernetesLastValidConfigPersister) Load(ctx context.Context) (PersistedConfig, bool, error) {
+	head := &corev1.Secret{}
+	if err := p.client.Get(ctx, p.nn, head); err != nil {
+		if apierrors.IsNotFound(err) {
+			return PersistedConfig{}, false, nil
+		}
+		return PersistedConfig{}, false, fmt.Errorf("failed to get Secret %s: %w", p.nn, err)
+	}
+	hash := head.Annotations[PersistedConfigHashAnnotationKey]
+	shardsCount, err := strconv.Atoi(head.Annotations[PersistedConfigShardsAnnotationKey])
+	if err != nil || shardsCount < 1 {
+		return PersistedConfig{}, false, fmt.Errorf("invalid %s annotation of Secret %s", PersistedConfigShardsAnnotationKey, p.nn)
+	}
+
+	var data []byte
+	for i := range shardsCount {
+		shard := head
+		if i > 0 {
+			shard = &corev1.Secret{}
+			key := k8stypes.NamespacedName{Namespace: p.nn.Namespace, Name: p.shardName(i)}
+			if err := p.client.Get(ctx, key, shard); err != nil {
+				return PersistedConfig{}, false, fmt.Errorf("failed to get shard Secret %s: %w", key, err)
+			}
+		}
+		if shardHash := shard.Annotations[PersistedConfigHashAnnotationKey]; shardHash != hash {
+			return PersistedConfig{}, false, fmt.Errorf("shard Secret %s/%s belongs to a different configuration", p.nn.Namespace, shard.Name)
+		}
+		data = append(data, shard.Data[PersistedConfigDataKey]...)
+	}
+	if hashOf(data) != hash {
+		return PersistedConfig{}, false, errors.New("persisted configuration doesn't match its hash")
+	}
+
+	config, err := decodePersistedConfig(data)
+	if err != nil {
+		return PersistedConfig{}, false, fmt.Errorf("failed to decode persisted configuration: %w", err)
+	}
+	p.lastPersistedHash = hash
+	return config, true, nil
+}
+
+// writeShard creates or updates a Secret holding a single shard of the persisted configuration.
+func (p *KubernetesLastValidConfigPersister) writeShard(ctx context.Context, name string, data []byte, annotations map[string]string) error {
+	secret := &corev1.Secret{}
+	key := k8stypes.NamespacedName{Namespace: p.nn.Namespace, Name: name}
+	err := p.client.Get(ctx, key, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get shard Secret %s: %w", key, err)
+	}
+	exists := err == nil
+
+	secret.Namespace = key.Namespace
+	secret.Name = key.Name
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, len(annotations))
+	}
+	delete(secret.Annotations, PersistedConfigShardsAnnotationKey)
+	for k, v := range annotations {
+		secret.Annotations[k] = v
+	}
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = map[string][]byte{PersistedConfigDataKey: data}
+
+	if exists {
+		if err := p.client.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to update shard Secret %s: %w", key, err)
+		}
+		return nil
+	}
+	if err := p.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create shard Secret %s: %w", key, err)
+	}
+	return nil
+}
+
+func (p *KubernetesLastValidConfigPersister) shardName(i int) string {
+	if i == 0 {
+		return p.nn.Name
+	}
+	return fmt.Sprintf("%s-%d", p.nn.Name, i)
+}
+
+// encodePersistedConfig serializes the config to gzip compressed JSON.
+func encodePersistedConfig(config PersistedConfig) ([]byte, error) {
+	serialized := persistedConfig{
+		KongState: persistableKongState(config.KongState),
+	}
+	if config.CacheSnapshot != nil {
+		s, err := scheme.Get()
+		if err != nil {
+			return nil, err
+		}
+		for _, cacheStore := range config.CacheSnapshot.ListAllStores() {
+			for _, item := range cacheStore.List() {
+				obj, ok := item.(runtime.Object)
+				if !ok {
+					return nil, fmt.Errorf("expected runtime.Object, got %T", item)
+				}
+				// Objects in the cache may have no TypeMeta, but it's required to decode them.
+				obj = obj.DeepCopyObject()
+				if err := util.PopulateTypeMeta(obj, s); err != nil {
+					return nil, err
+				}
+				raw, err := json.Marshal(obj)
+				if err != nil {
+					return nil, err
+				}
+				serialized.CacheSnapshot = append(serialized.CacheSnapshot, raw)
+			}
+		}
+		// Cache stores list objects in random order, sort them to get the same result for the same snapshot.
+		slices.SortFunc(serialized.CacheSnapshot, func(a, b json.RawMessage) int { return bytes.Compare(a, b) })
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(serialized); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodePersistedConfig deserializes the config encoded with encodePersistedConfig.
+func decodePersistedConfig(data []byte) (PersistedConfig, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return PersistedConfig{}, err
+	}
+	defer r.Close()
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		return PersistedConfig{}, err
+	}
+	var serialized persistedConfig
+	if err := json.Unmarshal(decompressed, &serialized); err != nil {
+		return PersistedConfig{}, err
+	}
+	if serialized.KongState == nil {
+		return PersistedConfig{}, errors.New("persisted configuration has no Kong state")
+	}
+
+	config := PersistedConfig{KongState: serialized.KongState}
+	if serialized.CacheSnapshot != nil {
+		cacheSnapshot, err := store.NewCacheStoresFromObjYAML(
+			lo.Map(serialized.CacheSnapshot, func(raw json.RawMessage, _ int) []byte { return raw })...,
+		)
+		if err != nil {
+			return PersistedConfig{}, fmt.Errorf("failed to decode cache snapshot: %w", err)
+		}
+		config.CacheSnapshot = &cacheSnapshot
+	}
+	return config, nil
+}
+
+// persistableKongState returns a shallow copy of the state without references to the Kubernetes objects entities
+// were translated from. These are only used during translation and can't be deserialized as they're interfaces.
+func persistableKongState(s *kongstate.KongState) *kongstate.KongState {
+	if s == nil {
+		return nil
+	}
+	persistable := *s
+	if s.Services != nil {
+		persistable.Services = make([]kongstate.Service, len(s.Services))
+		for i, service := range s.Services {
+			service.Parent = nil
+			persistable.Services[i] = service
+		}
+	}
+	if s.Upstreams != nil {
+		persistable.Upstreams = make([]kongstate.Upstream, len(s.Upstreams))
+		for i, upstream := range s.Upstreams {
+			upstream.Service.Parent = nil
+			persistable.Upstreams[i] = upstream
+		}
+	}
+	if s.Plugins != nil {
+		persistable.Plugins = make([]kongstate.Plugin, len(s.Plugins))
+		for i, plugin := range s.Plugins {
+			plugin.K8sParent = nil
+			persistable.Plugins[i] = plugin
+		}
+	}
+	return &persistable
+}
+
+func splitIntoShards(data []byte, shardSize int) [][]byte {
+	var shards [][]byte
+	for len(data) > shardSize {
+		shards = append(shards, data[:shardSize])
+		data = data[shardSize:]
+	}
+	return append(shards, data)
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,151 @@
+package configfetcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+)
+
+func TestKubernetesLastValidConfigPersister(t *testing.T) {
+	ctx := context.Background()
+	nn := k8stypes.NamespacedName{Namespace: "kong", Name: "last-valid-config"}
+
+	ingress := &netv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"},
+	}
+	cacheSnapshot, err := store.NewCacheStoresFromObjs(ingress)
+	require.NoError(t, err)
+	newConfig := func(serviceName string) PersistedConfig {
+		return PersistedConfig{
+			KongState: &kongstate.KongState{
+				Services: []kongstate.Service{
+					{
+						Service: kong.Service{Name: kong.String(serviceName)},
+						Parent:  ingress,
+					},
+				},
+				Consumers: []kongstate.Consumer{
+					{Consumer: kong.Consumer{Username: kong.String("consumer")}},
+				},
+			},
+			CacheSnapshot: &cacheSnapshot,
+		}
+	}
+	newPersister := func(c client.Client) *KubernetesLastValidConfigPersister {
+		return NewKubernetesLastValidConfigPersister(c, nn)
+	}
+
+	t.Run("persisted configuration can be loaded", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		require.NoError(t, newPersister(c).Persist(ctx, newConfig("service")))
+
+		loaded, ok, err := newPersister(c).Load(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Len(t, loaded.KongState.Services, 1)
+		require.Equal(t, "service", *loaded.KongState.Services[0].Name)
+		require.Nil(t, loaded.KongState.Services[0].Parent, "parent objects are not persisted")
+		require.Len(t, loaded.KongState.Consumers, 1)
+		require.NotNil(t, loaded.CacheSnapshot)
+		_, exists, err := loaded.CacheSnapshot.Get(ingress)
+		require.NoError(t, err)
+		require.True(t, exists, "cache snapshot should contain the Ingress")
+	})
+
+	t.Run("nothing is loaded when no configuration was persisted", func(t *testing.T) {
+		_, ok, err := newPersister(fake.NewClientBuilder().Build()).Load(ctx)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("configuration exceeding shard size is sharded and stale shards are deleted", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		p := newPersister(c)
+		p.shardSize = 64
+		require.NoError(t, p.Persist(ctx, newConfig("service")))
+
+		head := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, nn, head))
+		shards := head.Annotations[PersistedConfigShardsAnnotationKey]
+		require.NotEqual(t, "1", shards)
+		require.NoError(t, c.Get(ctx, k8stypes.NamespacedName{Namespace: nn.Namespace, Name: nn.Name + "-1"}, &corev1.Secret{}))
+
+		loaded, ok, err := newPersister(c).Load(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "service", *loaded.KongState.Services[0].Name)
+
+		p.shardSize = DefaultPersistenceShardSize
+		require.NoError(t, p.Persist(ctx, newConfig("another-service")))
+		require.NoError(t, c.Get(ctx, nn, head))
+		require.Equal(t, "1", head.Annotations[PersistedConfigShardsAnnotationKey])
+		secrets := &corev1.SecretList{}
+		require.NoError(t, c.List(ctx, secrets, client.InNamespace(nn.Namespace)))
+		require.Len(t, secrets.Items, 1, "stale shards should be deleted")
+
+		loaded, ok, err = newPersister(c).Load(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "another-service", *loaded.KongState.Services[0].Name)
+	})
+
+	t.Run("shard of a different configuration is detected", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		p := newPersister(c)
+		p.shardSize = 64
+		require.NoError(t, p.Persist(ctx, newConfig("service")))
+
+		shard := &corev1.Secret{}
+		shardNN := k8stypes.NamespacedName{Namespace: nn.Namespace, Name: nn.Name + "-1"}
+		require.NoError(t, c.Get(ctx, shardNN, shard))
+		shard.Annotations[PersistedConfigHashAnnotationKey] = "different"
+		require.NoError(t, c.Update(ctx, shard))
+
+		_, ok, err := newPersister(c).Load(ctx)
+		require.ErrorContains(t, err, "belongs to a different configuration")
+		require.False(t, ok)
+	})
+
+	t.Run("corrupted configuration is detected", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		require.NoError(t, newPersister(c).Persist(ctx, newConfig("service")))
+
+		head := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, nn, head))
+		head.Data[PersistedConfigDataKey] = append(head.Data[PersistedConfigDataKey], 0)
+		require.NoError(t, c.Update(ctx, head))
+
+		_, ok, err := newPersister(c).Load(ctx)
+		require.ErrorContains(t, err, "doesn't match its hash")
+		require.False(t, ok)
+	})
+
+	t.Run("unchanged configuration is not written again", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		p := newPersister(c)
+		require.NoError(t, p.Persist(ctx, newConfig("service")))
+		head := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, nn, head))
+		resourceVersion := head.ResourceVersion
+
+		require.NoError(t, p.Persist(ctx, newConfig("service")))
+		require.NoError(t, c.Get(ctx, nn, head))
+		require.Equal(t, resourceVersion, head.ResourceVersion)
+
+		require.NoError(t, p.Persist(ctx, newConfig("another-service")))
+		require.NoError(t, c.Get(ctx, nn, head))
+		require.NotEqual(t, resourceVersion, head.ResourceVersion)
+	})
+}
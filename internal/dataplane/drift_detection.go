@@ -0,0 +1,54 @@
+package dataplane
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// ConfigDriftDetectingClient is a client able to detect drifts of configuration applied to gateways.
+type ConfigDriftDetectingClient interface {
+	// DetectConfigDrift checks whether configuration of any gateway drifted from the configuration pushed to it.
+	DetectConfigDrift(ctx context.Context) error
+}
+
+// ConfigDriftDetectionLoop periodically detects drifts of configuration applied to gateways. It implements
+// the controller-runtime Runnable interface.
+type ConfigDriftDetectionLoop struct {
+	logger   logr.Logger
+	client   ConfigDriftDetectingClient
+	interval time.Duration
+}
+
+// NewConfigDriftDetectionLoop creates a ConfigDriftDetectionLoop detecting drifts at the given interval.
+func NewConfigDriftDetectionLoop(logger logr.Logger, client ConfigDriftDetectingClient, interval time.Duration) *ConfigDriftDetectionLoop {
+	return &ConfigDriftDetectionLoop{
+		logger:   logger,
+		client:   client,
+		interval: interval,
+	}
+}
+
+// Start runs drift detection until the context is done.
+func (l *ConfigDriftDetectionLoop) Start(ctx context.Context) error {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			l.logger.Info("Context done: shutting down the configuration drift detection")
+			return nil
+		case <-ticker.C:
+			if err := l.client.DetectConfigDrift(ctx); err != nil {
+				l.logger.Error(err, "Failed to detect configuration drift")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime Runnable interface. Only the leader pushes configuration,
+// so only the leader detects its drifts.
+func (l *ConfigDriftDetectionLoop) NeedLeaderElection() bool {
+	return true
+}
@@ -99,3 +99,40 @@ func (c *ResourceFailuresCollector) PopResourceFailures() []ResourceFailure {
 
 	return errs
 }
+
+// ResourceWarningsCollector collects issues encountered when processing resources that don't prevent the resources
+// from being translated into Kong configuration, but make the configuration differ from what was requested.
+type ResourceWarningsCollector struct {
+	warnings []ResourceFailure
+	logger   logr.Logger
+}
+
+func NewResourceWarningsCollector(logger logr.Logger) *ResourceWarningsCollector {
+	return &ResourceWarningsCollector{logger: logger}
+}
+
+// PushResourceWarning adds a resource processing warning to the collector and logs it.
+func (c *ResourceWarningsCollector) PushResourceWarning(reason string, causingObjects ...client.Object) {
+	resourceWarning, err := NewResourceFailure(reason, causingObjects...)
+	if err != nil {
+		c.logger.Error(err, "Failed to create resource warning", "resource_warning_reason", reason)
+		return
+	}
+
+	c.warnings = append(c.warnings, resourceWarning)
+	for _, obj := range causingObjects {
+		c.logger.Info(reason,
+			"name", obj.GetName(),
+			"namespace", obj.GetNamespace(),
+			"GVK", obj.GetObjectKind().GroupVersionKind().String())
+	}
+}
+
+// PopResourceWarnings returns all resource processing warnings stored in the collector and clears the collector's
+// stored warnings.
+func (c *ResourceWarningsCollector) PopResourceWarnings() []ResourceFailure {
+	warnings := c.warnings
+	c.warnings = nil
+
+	return warnings
+}
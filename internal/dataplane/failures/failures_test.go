@@ -104,6 +104,40 @@ func TestResourceFailuresCollector(t *testing.T) {
 	})
 }
 
+func TestResourceWarningsCollector(t *testing.T) {
+	t.Run("pushes, logs and pops resource warnings", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		logger := zapr.NewLogger(zap.New(core))
+
+		collector := NewResourceWarningsCollector(logger)
+
+		collector.PushResourceWarning(someValidResourceFailureReason, someResourceFailureCausingObjects()...)
+
+		require.Equal(t, len(someResourceFailureCausingObjects()), logs.Len(), "expecting one log entry per causing object")
+		for _, entry := range logs.All() {
+			assert.Equal(t, zapcore.InfoLevel, entry.Level)
+		}
+
+		collectedWarnings := collector.PopResourceWarnings()
+		require.Len(t, collectedWarnings, 1)
+		require.Equal(t, someValidResourceFailureReason, collectedWarnings[0].Message())
+		require.Empty(t, collector.PopResourceWarnings(), "second call should not return any warning")
+	})
+
+	t.Run("does not crash but logs error when no causing objects passed", func(t *testing.T) {
+		core, logs := observer.New(zap.DebugLevel)
+		logger := zapr.NewLogger(zap.New(core))
+
+		collector := NewResourceWarningsCollector(logger)
+
+		collector.PushResourceWarning(someValidResourceFailureReason)
+
+		require.Equal(t, 1, logs.Len())
+		require.Equal(t, zap.ErrorLevel, logs.All()[0].Level)
+		require.Empty(t, collector.PopResourceWarnings(), "no warnings expected - causing objects missing")
+	})
+}
+
 func assertErrorLogs(t *testing.T, logs *observer.ObservedLogs) {
 	for i := range logs.All() {
 		assert.Equalf(t, zapcore.ErrorLevel, logs.All()[i].Entry.Level, "%d-nth log entry expected to have ErrorLevel", i)
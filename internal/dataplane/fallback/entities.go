@@ -0,0 +1,190 @@
+package fallback
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+)
+
+// pluginEntityType is the type of Kong plugin entities.
+const pluginEntityType = "plugin"
+
+// individuallyExcludablePlugins are plugins whose instances can be excluded on their own, leaving the entities
+// they're attached to configured without them. These only observe traffic (logging, metrics, tracing) or cache
+// responses, so dropping them never opens access to anything. Any other plugin (including custom ones) may
+// authenticate, authorize, restrict or otherwise guard the entities it's attached to, so it's excluded along with
+// them.
+var individuallyExcludablePlugins = map[string]struct{}{
+	"correlation-id":       {},
+	"datadog":              {},
+	"file-log":             {},
+	"http-log":             {},
+	"kafka-log":            {},
+	"loggly":               {},
+	"opentelemetry":        {},
+	"prometheus":           {},
+	"proxy-cache":          {},
+	"proxy-cache-advanced": {},
+	"statsd":               {},
+	"statsd-advanced":      {},
+	"syslog":               {},
+	"tcp-log":              {},
+	"udp-log":              {},
+	"zipkin":               {},
+}
+
+// BrokenEntity is a single Kong entity that was reported as broken by the Kong Admin API.
+type BrokenEntity struct {
+	// Type is the type of the Kong entity, e.g. "plugin".
+	Type string
+	// Name is the name of the Kong entity. For plugins, it's the plugin name.
+	Name string
+	// ForeignKeys are IDs or names of entities the Kong entity is associated with, keyed by their type (e.g. "route").
+	ForeignKeys map[string]string
+	// CausingObject is the object the Kong entity was translated from.
+	CausingObject ObjectHash
+}
+
+// String returns a string representation of the BrokenEntity.
+func (e BrokenEntity) String() string {
+	keys := lo.Keys(e.ForeignKeys)
+	sort.Strings(keys)
+	refs := lo.Map(keys, func(k string, _ int) string {
+		return fmt.Sprintf("%s=%s", k, e.ForeignKeys[k])
+	})
+	return fmt.Sprintf("%s %s (%s) of %s", e.Type, e.Name, strings.Join(refs, ","), e.CausingObject)
+}
+
+// canExcludeEntitiesIndividually tells whether broken entities of an object can be excluded from the configuration
+// one by one instead of excluding the object with all its dependants. It's the case for plugins only, as objects
+// depending on KongPlugins and KongClusterPlugins merely attach them and remain valid without them, and only for
+// plugins known to be safe to drop (see individuallyExcludablePlugins): dropping any other plugin could expose
+// the objects it protects, so these are excluded with the plugin.
+func canExcludeEntitiesIndividually(obj ObjectHash, entities []BrokenEntity) bool {
+	if obj.Kind != "KongPlugin" && obj.Kind != "KongClusterPlugin" {
+		return false
+	}
+	if len(entities) == 0 {
+		return false
+	}
+	return lo.EveryBy(entities, func(e BrokenEntity) bool {
+		_, isExcludable := individuallyExcludablePlugins[e.Name]
+		return e.Type == pluginEntityType && isExcludable
+	})
+}
+
+// ExcludeEntities removes the given entities from the Kong state. Only plugins are supported, other entities are
+// ignored. It returns the number of removed entities.
+func ExcludeEntities(state *kongstate.KongState, entities []BrokenEntity) int {
+	pluginEntities := lo.Filter(entities, func(e BrokenEntity, _ int) bool {
+		return e.Type == pluginEntityType
+	})
+	if len(pluginEntities) == 0 {
+		return 0
+	}
+
+	// Plugins may refer to entities they're associated with by either their IDs or names, while gateways can report
+	// either of them, so both sides are resolved to the same references before comparing them.
+	resolver := newForeignKeyResolver(state)
+	var removed int
+	state.Plugins = lo.Reject(state.Plugins, func(p kongstate.Plugin, _ int) bool {
+		matches := lo.SomeBy(pluginEntities, func(e BrokenEntity) bool {
+			return pluginMatchesEntity(p, e, resolver)
+		})
+		if matches {
+			removed++
+		}
+		return matches
+	})
+	return removed
+}
+
+func pluginMatchesEntity(p kongstate.Plugin, e BrokenEntity, resolver foreignKeyResolver) bool {
+	if p.K8sParent == nil || p.K8sParent.GetUID() != e.CausingObject.UID {
+		return false
+	}
+	if p.Name == nil || *p.Name != e.Name {
+		return false
+	}
+	return maps.Equal(resolver.resolveAll(pluginForeignKeys(p.Plugin)), resolver.resolveAll(e.ForeignKeys))
+}
+
+// pluginForeignKeys returns references to entities a plugin is associated with, keyed by their type.
+func pluginForeignKeys(p kong.Plugin) map[string]string {
+	foreignKeys := map[string]string{}
+	if p.Service != nil {
+		foreignKeys["service"] = firstRef(p.Service.ID, p.Service.Name)
+	}
+	if p.Route != nil {
+		foreignKeys["route"] = firstRef(p.Route.ID, p.Route.Name)
+	}
+	if p.Consumer != nil {
+		foreignKeys["consumer"] = firstRef(p.Consumer.ID, p.Consumer.Username)
+	}
+	if p.ConsumerGroup != nil {
+		foreignKeys["consumer_group"] = firstRef(p.ConsumerGroup.ID, p.ConsumerGroup.Name)
+	}
+	return foreignKeys
+}
+
+// foreignKeyResolver resolves IDs and names of entities in a Kong state to their canonical references, keyed by
+// entity type.
+type foreignKeyResolver map[string]map[string]string
+
+func newForeignKeyResolver(state *kongstate.KongState) foreignKeyResolver {
+	r := foreignKeyResolver{
+		"service":        {},
+		"route":          {},
+		"consumer":       {},
+		"consumer_group": {},
+	}
+	register := func(entityType string, id, name *string) {
+		canonical := firstRef(name, id)
+		for _, ref := range []*string{id, name} {
+			if ref != nil {
+				r[entityType][*ref] = canonical
+			}
+		}
+	}
+	for _, s := range state.Services {
+		register("service", s.ID, s.Name)
+		for _, route := range s.Routes {
+			register("route", route.ID, route.Name)
+		}
+	}
+	for _, c := range state.Consumers {
+		register("consumer", c.ID, c.Username)
+	}
+	for _, cg := range state.ConsumerGroups {
+		register("consumer_group", cg.ID, cg.Name)
+	}
+	return r
+}
+
+// firstRef returns the first non-nil reference.
+func firstRef(refs ...*string) string {
+	for _, ref := range refs {
+		if ref != nil {
+			return *ref
+		}
+	}
+	return ""
+}
+
+func (r foreignKeyResolver) resolveAll(foreignKeys map[string]string) map[string]string {
+	resolved := make(map[string]string, len(foreignKeys))
+	for entityType, ref := range foreignKeys {
+		if canonical, ok := r[entityType][ref]; ok {
+			resolved[entityType] = canonical
+		} else {
+			resolved[entityType] = ref
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,126 @@
+package fallback_test
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+)
+
+func TestExcludeEntities(t *testing.T) {
+	kongPlugin := testKongPlugin(t, "rate-limiting")
+	kongPlugin.UID = k8stypes.UID("plugin-uid")
+	anotherKongPlugin := testKongPlugin(t, "another-rate-limiting")
+	anotherKongPlugin.UID = k8stypes.UID("another-plugin-uid")
+
+	newState := func() *kongstate.KongState {
+		return &kongstate.KongState{
+			Services: []kongstate.Service{
+				{
+					Service: kong.Service{Name: kong.String("service"), ID: kong.String("service-id")},
+					Routes: []kongstate.Route{
+						{Route: kong.Route{Name: kong.String("route-a"), ID: kong.String("route-a-id")}},
+						{Route: kong.Route{Name: kong.String("route-b"), ID: kong.String("route-b-id")}},
+					},
+				},
+			},
+			Plugins: []kongstate.Plugin{
+				{
+					Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Route: &kong.Route{ID: kong.String("route-a")}},
+					K8sParent: kongPlugin,
+				},
+				{
+					Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Route: &kong.Route{ID: kong.String("route-b")}},
+					K8sParent: kongPlugin,
+				},
+				{
+					Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Service: &kong.Service{ID: kong.String("service")}},
+					K8sParent: kongPlugin,
+				},
+				{
+					Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Route: &kong.Route{ID: kong.String("route-a")}},
+					K8sParent: anotherKongPlugin,
+				},
+			},
+		}
+	}
+	pluginRoutes := func(state *kongstate.KongState) []string {
+		return lo.Map(state.Plugins, func(p kongstate.Plugin, _ int) string {
+			if p.Route != nil {
+				return p.K8sParent.GetName() + "/" + *p.Route.ID
+			}
+			return p.K8sParent.GetName() + "/" + *p.Service.ID
+		})
+	}
+
+	testCases := []struct {
+		name            string
+		entities        []fallback.BrokenEntity
+		expectedRemoved int
+		expectedPlugins []string
+	}{
+		{
+			name: "plugin instance referring to a route by ID",
+			entities: []fallback.BrokenEntity{
+				{
+					Type:          "plugin",
+					Name:          "rate-limiting",
+					ForeignKeys:   map[string]string{"route": "route-a-id"},
+					CausingObject: fallback.GetObjectHash(kongPlugin),
+				},
+			},
+			expectedRemoved: 1,
+			expectedPlugins: []string{"rate-limiting/route-b", "rate-limiting/service", "another-rate-limiting/route-a"},
+		},
+		{
+			name: "plugin instances referring to a route and a service by name",
+			entities: []fallback.BrokenEntity{
+				{
+					Type:          "plugin",
+					Name:          "rate-limiting",
+					ForeignKeys:   map[string]string{"route": "route-b"},
+					CausingObject: fallback.GetObjectHash(kongPlugin),
+				},
+				{
+					Type:          "plugin",
+					Name:          "rate-limiting",
+					ForeignKeys:   map[string]string{"service": "service-id"},
+					CausingObject: fallback.GetObjectHash(kongPlugin),
+				},
+			},
+			expectedRemoved: 2,
+			expectedPlugins: []string{"rate-limiting/route-a", "another-rate-limiting/route-a"},
+		},
+		{
+			name: "entities matching no plugin instance are ignored",
+			entities: []fallback.BrokenEntity{
+				{
+					Type:          "plugin",
+					Name:          "rate-limiting",
+					ForeignKeys:   map[string]string{"route": "route-a", "consumer": "consumer"},
+					CausingObject: fallback.GetObjectHash(kongPlugin),
+				},
+				{
+					Type:          "route",
+					Name:          "route-a",
+					CausingObject: fallback.GetObjectHash(kongPlugin),
+				},
+			},
+			expectedRemoved: 0,
+			expectedPlugins: []string{"rate-limiting/route-a", "rate-limiting/route-b", "rate-limiting/service", "another-rate-limiting/route-a"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := newState()
+			removed := fallback.ExcludeEntities(state, tc.entities)
+			require.Equal(t, tc.expectedRemoved, removed)
+			require.Equal(t, tc.expectedPlugins, pluginRoutes(state))
+		})
+	}
+}
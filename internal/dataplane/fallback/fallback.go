@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
@@ -44,20 +45,85 @@ func (g *Generator) GenerateExcludingBrokenObjects(
 		return store.CacheStores{}, GeneratedCacheMetadata{}, fmt.Errorf("failed to take cache snapshot: %w", err)
 	}
 
+	if err := excludeBrokenObjects(graph, fallbackCache, brokenObjects, metadataCollector); err != nil {
+		return store.CacheStores{}, GeneratedCacheMetadata{}, err
+	}
+
+	return fallbackCache, metadataCollector.Metadata(), nil
+}
+
+// GenerateExcludingBrokenEntities generates a new cache snapshot like GenerateExcludingBrokenObjects, except for broken
+// objects whose broken entities can be excluded one by one (plugins generated from KongPlugins and KongClusterPlugins).
+// As long as such an object doesn't depend on other broken objects, it's kept in the cache along with its dependants
+// and its broken entities are returned in GeneratedCacheMetadata.ExcludedEntities instead. These have to be removed
+// from the configuration translated from the generated cache snapshot with ExcludeEntities. Broken security plugins
+// (e.g. key-auth, acl or ip-restriction) are always excluded along with their dependants, so that no route or service
+// is left unprotected.
+func (g *Generator) GenerateExcludingBrokenEntities(
+	cache store.CacheStores,
+	brokenObjects []ObjectHash,
+	brokenEntities []BrokenEntity,
+) (store.CacheStores, GeneratedCacheMetadata, error) {
+	metadataCollector := NewGenerateCacheMetadataCollector(brokenObjects...)
+
+	graph, err := g.cacheGraphProvider.CacheToGraph(cache)
+	if err != nil {
+		return store.CacheStores{}, GeneratedCacheMetadata{}, fmt.Errorf("failed to build cache graph: %w", err)
+	}
+
+	fallbackCache, err := cache.TakeSnapshot()
+	if err != nil {
+		return store.CacheStores{}, GeneratedCacheMetadata{}, fmt.Errorf("failed to take cache snapshot: %w", err)
+	}
+
+	entitiesByObject := lo.GroupBy(brokenEntities, func(e BrokenEntity) ObjectHash {
+		return e.CausingObject
+	})
+	var entityLevelObjects, objectLevelObjects []ObjectHash
+	for _, obj := range brokenObjects {
+		if canExcludeEntitiesIndividually(obj, entitiesByObject[obj]) {
+			entityLevelObjects = append(entityLevelObjects, obj)
+		} else {
+			objectLevelObjects = append(objectLevelObjects, obj)
+		}
+	}
+	if err := excludeBrokenObjects(graph, fallbackCache, objectLevelObjects, metadataCollector); err != nil {
+		return store.CacheStores{}, GeneratedCacheMetadata{}, err
+	}
+
+	for _, obj := range lo.Uniq(entityLevelObjects) {
+		// If the object depends on another broken object, it was already excluded along with its broken entities.
+		if metadataCollector.IsExcluded(obj) {
+			continue
+		}
+		for _, entity := range entitiesByObject[obj] {
+			metadataCollector.CollectExcludedEntity(entity)
+		}
+	}
+
+	return fallbackCache, metadataCollector.Metadata(), nil
+}
+
+// excludeBrokenObjects deletes broken objects along with all objects depending on them from the fallback cache.
+func excludeBrokenObjects(
+	graph *ConfigGraph,
+	fallbackCache store.CacheStores,
+	brokenObjects []ObjectHash,
+	metadataCollector *GeneratedCacheMetadataCollector,
+) error {
 	for _, brokenObject := range brokenObjects {
 		subgraphObjects, err := graph.SubgraphObjects(brokenObject)
 		if err != nil {
-			return store.CacheStores{}, GeneratedCacheMetadata{}, fmt.Errorf("failed to find dependants for %s: %w", brokenObject, err)
+			return fmt.Errorf("failed to find dependants for %s: %w", brokenObject, err)
 		}
 		for _, obj := range subgraphObjects {
 			if err := fallbackCache.Delete(obj); err != nil {
-				return store.CacheStores{}, GeneratedCacheMetadata{}, fmt.Errorf("failed to delete %s from the cache: %w", GetObjectHash(obj), err)
+				return fmt.Errorf("failed to delete %s from the cache: %w", GetObjectHash(obj), err)
 			}
 			metadataCollector.CollectExcluded(obj, brokenObject)
 		}
 	}
-
-	return fallbackCache, metadataCollector.Metadata(), nil
+	return nil
 }
 
 func (g *Generator) GenerateBackfillingBrokenObjects(
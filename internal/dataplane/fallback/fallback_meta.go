@@ -15,6 +15,9 @@ type GeneratedCacheMetadata struct {
 	// BackfilledObjects are objects that were backfilled from the last valid cache state as they were broken or either of
 	// their dependencies was broken.
 	BackfilledObjects []AffectedCacheObjectMetadata
+	// ExcludedEntities are broken Kong entities that were excluded from the fallback configuration individually,
+	// without excluding objects they were translated from.
+	ExcludedEntities []BrokenEntity
 }
 
 // GeneratedCacheMetadataCollector is a collector for cache metadata generated during the fallback process.
@@ -23,6 +26,7 @@ type GeneratedCacheMetadataCollector struct {
 	brokenObjects     []ObjectHash
 	excludedObjects   map[ObjectHash]AffectedCacheObjectMetadata
 	backfilledObjects map[ObjectHash]AffectedCacheObjectMetadata
+	excludedEntities  []BrokenEntity
 }
 
 // AffectedCacheObjectMetadata contains an object and a list of objects that caused it to be excluded or backfilled
@@ -65,11 +69,23 @@ func (m *GeneratedCacheMetadataCollector) CollectBackfilled(backfilled client.Ob
 	}
 }
 
+// CollectExcludedEntity collects a broken Kong entity that was excluded from the fallback configuration individually.
+func (m *GeneratedCacheMetadataCollector) CollectExcludedEntity(entity BrokenEntity) {
+	m.excludedEntities = append(m.excludedEntities, entity)
+}
+
+// IsExcluded tells whether an object was collected as excluded.
+func (m *GeneratedCacheMetadataCollector) IsExcluded(obj ObjectHash) bool {
+	_, ok := m.excludedObjects[obj]
+	return ok
+}
+
 // Metadata generates the final cache metadata from the collected data.
 func (m *GeneratedCacheMetadataCollector) Metadata() GeneratedCacheMetadata {
 	return GeneratedCacheMetadata{
 		BrokenObjects:     m.brokenObjects,
 		ExcludedObjects:   lo.Values(m.excludedObjects),
 		BackfilledObjects: lo.Values(m.backfilledObjects),
+		ExcludedEntities:  m.excludedEntities,
 	}
 }
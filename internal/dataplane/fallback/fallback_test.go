@@ -145,6 +145,138 @@ func TestGenerator_GenerateExcludingBrokenObjects(t *testing.T) {
 	})
 }
 
+func TestGenerator_GenerateExcludingBrokenEntities(t *testing.T) {
+	// We have to use real-world object types here as we're testing integration with store.CacheStores.
+	ingressClass := testIngressClass(t, "ingressClass")
+	secret := testSecret(t, "secret")
+	plugin := testKongPlugin(t, "kongPlugin")
+	service := testService(t, "service")
+	serviceFacade := testKongServiceFacade(t, "serviceFacade")
+	inputCacheStores := cacheStoresFromObjs(t, ingressClass, secret, plugin, service, serviceFacade)
+
+	// This graph doesn't reflect real dependencies between the objects - it's only used for testing purposes.
+	//
+	// Graph structure (edges define dependency -> dependant relationship):
+	//                   ┌──────┐
+	//                   │secret│
+	//                   └───┬──┘
+	//   ┌────────────┐  ┌───▼──┐
+	//   │ingressClass│  │plugin│
+	//   └──────┬─────┘  └───┬──┘
+	//          ├────────────┘
+	//      ┌───▼───┐
+	//      │service│
+	//      └───┬───┘
+	//   ┌──────▼──────┐
+	//   │serviceFacade│
+	//   └─────────────┘
+	graph, err := NewGraphBuilder().
+		WithVertices(ingressClass, secret, plugin, service, serviceFacade).
+		WithEdge(secret, plugin).
+		WithEdge(ingressClass, service).
+		WithEdge(plugin, service).
+		WithEdge(service, serviceFacade).
+		Build()
+	require.NoError(t, err)
+
+	graphProvider := &mockGraphProvider{}
+	graphProvider.ReturnGraphOn(inputCacheStores, graph)
+	g := fallback.NewGenerator(graphProvider, logr.Discard())
+
+	pluginEntity := fallback.BrokenEntity{
+		Type:          "plugin",
+		Name:          "http-log",
+		ForeignKeys:   map[string]string{"service": "service"},
+		CausingObject: fallback.GetObjectHash(plugin),
+	}
+
+	t.Run("plugin is broken", func(t *testing.T) {
+		fallbackCache, meta, err := g.GenerateExcludingBrokenEntities(
+			inputCacheStores,
+			[]fallback.ObjectHash{fallback.GetObjectHash(plugin)},
+			[]fallback.BrokenEntity{pluginEntity},
+		)
+		require.NoError(t, err)
+		require.ElementsMatch(t, fallbackCache.Plugin.List(), []any{plugin}, "plugin shouldn't be excluded as only its entity is broken")
+		require.ElementsMatch(t, fallbackCache.Service.List(), []any{service}, "service shouldn't be excluded as it only attaches plugin")
+		require.ElementsMatch(t, fallbackCache.KongServiceFacade.List(), []any{serviceFacade}, "serviceFacade shouldn't be excluded")
+		require.Empty(t, meta.ExcludedObjects)
+		require.Equal(t, []fallback.BrokenEntity{pluginEntity}, meta.ExcludedEntities)
+		require.Equal(t, []fallback.ObjectHash{fallback.GetObjectHash(plugin)}, meta.BrokenObjects)
+	})
+
+	for _, pluginName := range []string{"key-auth", "request-termination", "rate-limiting", "pre-function", "custom-auth"} {
+		t.Run(pluginName+" plugin not known to be safe to drop is broken", func(t *testing.T) {
+			guardingPluginEntity := pluginEntity
+			guardingPluginEntity.Name = pluginName
+			fallbackCache, meta, err := g.GenerateExcludingBrokenEntities(
+				inputCacheStores,
+				[]fallback.ObjectHash{fallback.GetObjectHash(plugin)},
+				[]fallback.BrokenEntity{guardingPluginEntity},
+			)
+			require.NoError(t, err)
+			require.Empty(t, fallbackCache.Plugin.List(), "plugin should be excluded as it's broken")
+			require.Empty(t, fallbackCache.Service.List(), "service should be excluded so that it's not exposed without the plugin")
+			require.Empty(t, fallbackCache.KongServiceFacade.List(), "serviceFacade should be excluded as it depends on service")
+			require.Empty(t, meta.ExcludedEntities)
+		})
+	}
+
+	t.Run("plugin is broken with no broken entities reported", func(t *testing.T) {
+		fallbackCache, meta, err := g.GenerateExcludingBrokenEntities(
+			inputCacheStores,
+			[]fallback.ObjectHash{fallback.GetObjectHash(plugin)},
+			nil,
+		)
+		require.NoError(t, err)
+		require.Empty(t, fallbackCache.Plugin.List(), "plugin should be excluded as it's broken")
+		require.Empty(t, fallbackCache.Service.List(), "service should be excluded as it depends on plugin")
+		require.Empty(t, fallbackCache.KongServiceFacade.List(), "serviceFacade should be excluded as it depends on service")
+		require.Empty(t, meta.ExcludedEntities)
+	})
+
+	t.Run("plugin and ingressClass are broken", func(t *testing.T) {
+		fallbackCache, meta, err := g.GenerateExcludingBrokenEntities(
+			inputCacheStores,
+			[]fallback.ObjectHash{fallback.GetObjectHash(plugin), fallback.GetObjectHash(ingressClass)},
+			[]fallback.BrokenEntity{pluginEntity},
+		)
+		require.NoError(t, err)
+		require.ElementsMatch(t, fallbackCache.Plugin.List(), []any{plugin}, "plugin shouldn't be excluded as only its entity is broken")
+		require.Empty(t, fallbackCache.IngressClassV1.List(), "ingressClass should be excluded as it's broken")
+		require.Empty(t, fallbackCache.Service.List(), "service should be excluded as it depends on ingressClass")
+		require.Empty(t, fallbackCache.KongServiceFacade.List(), "serviceFacade should be excluded as it depends on service")
+		require.Equal(t, []fallback.BrokenEntity{pluginEntity}, meta.ExcludedEntities)
+	})
+
+	t.Run("plugin and secret it depends on are broken", func(t *testing.T) {
+		fallbackCache, meta, err := g.GenerateExcludingBrokenEntities(
+			inputCacheStores,
+			[]fallback.ObjectHash{fallback.GetObjectHash(secret), fallback.GetObjectHash(plugin)},
+			[]fallback.BrokenEntity{pluginEntity},
+		)
+		require.NoError(t, err)
+		require.Empty(t, fallbackCache.Secret.List(), "secret should be excluded as it's broken")
+		require.Empty(t, fallbackCache.Plugin.List(), "plugin should be excluded as it depends on secret")
+		require.Empty(t, fallbackCache.Service.List(), "service should be excluded as it depends on plugin")
+		require.ElementsMatch(t, fallbackCache.IngressClassV1.List(), []any{ingressClass}, "ingressClass shouldn't be excluded")
+		require.Empty(t, meta.ExcludedEntities, "plugin entity shouldn't be excluded individually as the whole plugin was excluded")
+	})
+
+	t.Run("service with a broken non-plugin entity is excluded with its dependants", func(t *testing.T) {
+		fallbackCache, meta, err := g.GenerateExcludingBrokenEntities(
+			inputCacheStores,
+			[]fallback.ObjectHash{fallback.GetObjectHash(service)},
+			[]fallback.BrokenEntity{{Type: "service", Name: "service", CausingObject: fallback.GetObjectHash(service)}},
+		)
+		require.NoError(t, err)
+		require.Empty(t, fallbackCache.Service.List(), "service should be excluded as it's broken")
+		require.Empty(t, fallbackCache.KongServiceFacade.List(), "serviceFacade should be excluded as it depends on service")
+		require.ElementsMatch(t, fallbackCache.Plugin.List(), []any{plugin}, "plugin shouldn't be excluded")
+		require.Empty(t, meta.ExcludedEntities)
+	})
+}
+
 func TestGenerator_GenerateBackfillingBrokenObjects(t *testing.T) {
 	// We have to use real-world object types here as we're testing integration with store.CacheStores.
 	ingressClass := testIngressClass(t, "ingressClass")
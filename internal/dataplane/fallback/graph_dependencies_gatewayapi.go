@@ -56,6 +56,36 @@ func resolveTLSRouteDependencies(cache store.CacheStores, route *gatewayapi.TLSR
 	)
 }
 
+// resolveBackendTLSPolicyDependencies resolves potential dependencies for a given BackendTLSPolicy object:
+// - ConfigMap
+// - Secret.
+func resolveBackendTLSPolicyDependencies(cache store.CacheStores, policy *gatewayapi.BackendTLSPolicy) []client.Object {
+	var dependencies []client.Object
+	for _, ref := range policy.Spec.Validation.CACertificateRefs {
+		if ref.Group != "" && ref.Group != "core" {
+			continue
+		}
+		var (
+			key    = fmt.Sprintf("%s/%s", policy.Namespace, ref.Name)
+			obj    any
+			exists bool
+			err    error
+		)
+		switch ref.Kind {
+		case "ConfigMap":
+			obj, exists, err = cache.ConfigMap.GetByKey(key)
+		case "Secret":
+			obj, exists, err = cache.Secret.GetByKey(key)
+		default:
+			continue
+		}
+		if err == nil && exists {
+			dependencies = append(dependencies, obj.(client.Object))
+		}
+	}
+	return dependencies
+}
+
 // resolveGRPCRouteDependencies resolves potential dependencies for a given GRPCRoute object:
 // - Service
 // - KongPlugin
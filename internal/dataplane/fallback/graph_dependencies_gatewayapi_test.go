@@ -458,3 +458,40 @@ func TestResolveDependencies_GRPCRoute(t *testing.T) {
 		runResolveDependenciesTest(t, tc)
 	}
 }
+
+func TestResolveDependencies_BackendTLSPolicy(t *testing.T) {
+	testCases := []resolveDependenciesTestCase{
+		{
+			name:   "no dependencies",
+			object: testBackendTLSPolicy(t, "policy"),
+			cache: cacheStoresFromObjs(t,
+				testConfigMap(t, "1"),
+				testSecret(t, "1"),
+			),
+			expected: []client.Object{},
+		},
+		{
+			name: "BackendTLSPolicy -> ConfigMap, Secret",
+			object: testBackendTLSPolicy(t, "policy", func(p *gatewayapi.BackendTLSPolicy) {
+				p.Spec.Validation.CACertificateRefs = []gatewayapi.LocalObjectReference{
+					{Kind: "ConfigMap", Name: "1"},
+					{Kind: "Secret", Name: "1"},
+					{Kind: "ConfigMap", Name: "not-existing"},
+				}
+			}),
+			cache: cacheStoresFromObjs(t,
+				testConfigMap(t, "1"),
+				testConfigMap(t, "2"),
+				testSecret(t, "1"),
+			),
+			expected: []client.Object{
+				testConfigMap(t, "1"),
+				testSecret(t, "1"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		runResolveDependenciesTest(t, tc)
+	}
+}
@@ -1,16 +1,62 @@
 package fallback
 
 import (
+	"slices"
+
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
 )
 
 // resolveServiceDependencies resolves potential dependencies for a Service object:
 // - KongPlugin
 // - KongClusterPlugin
-// - KongUpstreamPolicy.
+// - KongUpstreamPolicy
+// - BackendTLSPolicy
+// - BackendLBPolicy.
 func resolveServiceDependencies(cache store.CacheStores, service *corev1.Service) []client.Object {
-	return resolveDependenciesForServiceLikeObj(cache, service)
+	return slices.Concat(
+		resolveDependenciesForServiceLikeObj(cache, service),
+		resolveServiceDependenciesBackendTLSPolicy(cache, service),
+		resolveServiceDependenciesBackendLBPolicy(cache, service),
+	)
+}
+
+// resolveServiceDependenciesBackendTLSPolicy resolves BackendTLSPolicies targeting the given Service.
+func resolveServiceDependenciesBackendTLSPolicy(cache store.CacheStores, service *corev1.Service) []client.Object {
+	var dependencies []client.Object
+	for _, obj := range cache.BackendTLSPolicy.List() {
+		policy, ok := obj.(*gatewayapi.BackendTLSPolicy)
+		if !ok || policy.Namespace != service.Namespace {
+			continue
+		}
+		targetsService := lo.ContainsBy(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReferenceWithSectionName) bool {
+			return (ref.Group == "" || ref.Group == "core") && ref.Kind == "Service" && string(ref.Name) == service.Name
+		})
+		if targetsService {
+			dependencies = append(dependencies, policy)
+		}
+	}
+	return dependencies
+}
+
+// resolveServiceDependenciesBackendLBPolicy resolves BackendLBPolicies targeting the given Service.
+func resolveServiceDependenciesBackendLBPolicy(cache store.CacheStores, service *corev1.Service) []client.Object {
+	var dependencies []client.Object
+	for _, obj := range cache.BackendLBPolicy.List() {
+		policy, ok := obj.(*gatewayapi.BackendLBPolicy)
+		if !ok || policy.Namespace != service.Namespace {
+			continue
+		}
+		targetsService := lo.ContainsBy(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReference) bool {
+			return (ref.Group == "" || ref.Group == "core") && ref.Kind == "Service" && string(ref.Name) == service.Name
+		})
+		if targetsService {
+			dependencies = append(dependencies, policy)
+		}
+	}
+	return dependencies
 }
@@ -8,6 +8,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
 )
 
@@ -105,6 +106,50 @@ func TestResolveDependencies_Service(t *testing.T) {
 			),
 			expected: []client.Object{testKongUpstreamPolicy(t, "1")},
 		},
+		{
+			name: "Service -> BackendTLSPolicy",
+			object: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service",
+					Namespace: "test-namespace",
+				},
+			},
+			cache: cacheStoresFromObjs(t,
+				testBackendTLSPolicy(t, "1", func(p *gatewayapi.BackendTLSPolicy) {
+					p.Spec.TargetRefs = []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+						{LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "test-service"}},
+					}
+				}),
+				testBackendTLSPolicy(t, "2", func(p *gatewayapi.BackendTLSPolicy) {
+					p.Spec.TargetRefs = []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+						{LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "other-service"}},
+					}
+				}),
+			),
+			expected: []client.Object{
+				testBackendTLSPolicy(t, "1", func(p *gatewayapi.BackendTLSPolicy) {
+					p.Spec.TargetRefs = []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+						{LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "test-service"}},
+					}
+				}),
+			},
+		},
+		{
+			name: "Service -> BackendLBPolicy",
+			object: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service",
+					Namespace: "test-namespace",
+				},
+			},
+			cache: cacheStoresFromObjs(t,
+				testBackendLBPolicy(t, "1", "other-service", "test-service"),
+				testBackendLBPolicy(t, "2", "other-service"),
+			),
+			expected: []client.Object{
+				testBackendLBPolicy(t, "1", "other-service", "test-service"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
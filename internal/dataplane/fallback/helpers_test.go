@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
 	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
@@ -57,6 +58,44 @@ func testSecret(t *testing.T, name string, modifiers ...func(s *corev1.Secret))
 	return s
 }
 
+func testConfigMap(t *testing.T, name string) *corev1.ConfigMap {
+	return helpers.WithTypeMeta(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+	})
+}
+
+func testBackendTLSPolicy(t *testing.T, name string, modifiers ...func(p *gatewayapi.BackendTLSPolicy)) *gatewayapi.BackendTLSPolicy {
+	p := helpers.WithTypeMeta(t, &gatewayapi.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+	})
+	for _, mod := range modifiers {
+		mod(p)
+	}
+	return p
+}
+
+func testBackendLBPolicy(t *testing.T, name string, targetServices ...string) *gatewayapi.BackendLBPolicy {
+	p := helpers.WithTypeMeta(t, &gatewayapi.BackendLBPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+	})
+	for _, svc := range targetServices {
+		p.Spec.TargetRefs = append(p.Spec.TargetRefs, gatewayapi.LocalPolicyTargetReference{
+			Kind: "Service",
+			Name: gatewayapi.ObjectName(svc),
+		})
+	}
+	return p
+}
+
 func testKongServiceFacade(t *testing.T, name string) *incubatorv1alpha1.KongServiceFacade {
 	return helpers.WithTypeMeta(t, &incubatorv1alpha1.KongServiceFacade{
 		ObjectMeta: metav1.ObjectMeta{
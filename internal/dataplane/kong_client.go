@@ -1,6 +1,8 @@
 package dataplane
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -57,8 +59,15 @@ const (
 	FallbackKongConfigurationTranslationFailedEventReason = "FallbackKongConfigurationTranslationFailed"
 	// FallbackKongConfigurationApplyFailedEventReason defines an event reason used for creating fallback config apply resource failure events.
 	FallbackKongConfigurationApplyFailedEventReason = "FallbackKongConfigurationApplyFailed"
+
+	// ConfigDriftEventReason defines an event reason used for creating events about configuration drifts of gateways.
+	ConfigDriftEventReason = "ConfigDrift"
 )
 
+// dbModeMaxFallbackAttempts is the maximum number of fallback configurations generated to recover from a single
+// configuration rejection in DB mode.
+const dbModeMaxFallbackAttempts = 5
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Public Types
 // -----------------------------------------------------------------------------
@@ -80,6 +89,11 @@ type FallbackConfigGenerator interface {
 		lastValidCache *store.CacheStores,
 		brokenObjects []fallback.ObjectHash,
 	) (store.CacheStores, fallback.GeneratedCacheMetadata, error)
+	GenerateExcludingBrokenEntities(
+		cache store.CacheStores,
+		brokenObjects []fallback.ObjectHash,
+		brokenEntities []fallback.BrokenEntity,
+	) (store.CacheStores, fallback.GeneratedCacheMetadata, error)
 }
 
 // KongClient is a threadsafe high level API client for the Kong data-plane(s)
@@ -183,6 +197,26 @@ type KongClient struct {
 
 	// brokenObjects is a list of the Kubernetes resources that failed to sync and triggered a fallback sync.
 	brokenObjects []fallback.ObjectHash
+
+	// lastValidConfigPersister persists the last valid configuration and cache snapshot so that they survive restarts
+	// of the controller. It's nil when persistence is disabled.
+	lastValidConfigPersister configfetcher.LastValidConfigPersister
+
+	// configDriftDetector detects gateways whose configuration drifted from the configuration pushed to them.
+	// It's nil when drift detection is disabled.
+	configDriftDetector configfetcher.ConfigDriftDetector
+
+	// persistedLastValidConfigLoaded tells whether the persisted last valid configuration was already loaded.
+	persistedLastValidConfigLoaded bool
+
+	// stagedRollout rolls configuration out to gateways in stages. It keeps the state of the rollout between updates,
+	// as canary gateways are observed across them. It's created on the first staged push.
+	stagedRollout *sendconfig.StagedRollout
+
+	// recordedObjectVersions are resource versions of Kubernetes objects the configuration last recorded in
+	// the diagnostics config history was translated from. It's used to determine objects changed between recorded
+	// configurations.
+	recordedObjectVersions map[diagnostics.ObjectChange]string
 }
 
 // NewKongClient provides a new KongClient object after connecting to the
@@ -408,6 +442,14 @@ func (c *KongClient) KubernetesObjectConfigurationStatus(obj client.Object) k8so
 	return c.kubernetesObjectReportsFilter.Get(obj)
 }
 
+// KubernetesObjectConfigurationWarnings returns warnings describing how the configuration
+// of the provided successfully configured object differs from what the object requested.
+func (c *KongClient) KubernetesObjectConfigurationWarnings(obj client.Object) []string {
+	c.kubernetesObjectReportLock.RLock()
+	defer c.kubernetesObjectReportLock.RUnlock()
+	return c.kubernetesObjectReportsFilter.GetWarnings(obj)
+}
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Interface Implementation
 // -----------------------------------------------------------------------------
@@ -426,20 +468,40 @@ func (c *KongClient) Update(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	// If Kong is running in dbless mode, we can fetch and store the last good configuration.
-	if c.dbmode.IsDBLessMode() {
-		// Fetch the last valid configuration from the proxy only in case there is no valid
-		// configuration already stored in memory. This can happen when KIC restarts and there
-		// already is a Kong Proxy with a valid configuration loaded.
+	// In dry-run mode, configuration is never applied, so the configuration currently applied to the gateways
+	// is fetched to compare the new configuration against it. As in DB-less mode, it's fetched only in case there
+	// is no configuration already stored in memory.
+	if c.kongConfig.DryRun {
 		if _, found := c.kongConfigFetcher.LastValidConfig(); !found {
-			if err := c.kongConfigFetcher.TryFetchingValidConfigFromGateways(ctx, c.logger, c.clientsProvider.GatewayClients()); err != nil {
-				// If the client fails to fetch the last good configuration, we log it
-				// and carry on, as this is a condition that can be recovered with the following steps.
-				c.logger.Error(err, "Failed to fetch last good configuration from gateways")
+			if err := c.fetchLastValidConfigFromSharedGateways(ctx); err != nil {
+				c.logger.Error(err, "Failed to fetch current configuration from gateways")
+			}
+		}
+	} else {
+		// The persisted last valid configuration is preferred over fetching it from the gateways, as it's available
+		// even when all the gateways restarted and comes with the cache snapshot it was translated from.
+		c.maybeLoadPersistedLastValidConfig(ctx)
+
+		if c.dbmode.IsDBLessMode() {
+			// If Kong is running in dbless mode, we can fetch and store the last good configuration.
+			// Fetch the last valid configuration from the proxy only in case there is no valid
+			// configuration already stored in memory. This can happen when KIC restarts and there
+			// already is a Kong Proxy with a valid configuration loaded.
+			if _, found := c.kongConfigFetcher.LastValidConfig(); !found {
+				if err := c.fetchLastValidConfigFromSharedGateways(ctx); err != nil {
+					// If the client fails to fetch the last good configuration, we log it
+					// and carry on, as this is a condition that can be recovered with the following steps.
+					c.logger.Error(err, "Failed to fetch last good configuration from gateways")
+				}
 			}
 		}
 	}
 
+	// Gateways pinned to a configuration from history get it instead of the current configuration until unpinned.
+	if entry, state, pinned := c.pinnedConfig(); pinned {
+		return c.pushPinnedConfig(ctx, entry, state)
+	}
+
 	// If FallbackConfiguration is enabled, we take a snapshot of the cache so that we operate on a consistent
 	// set of resources in case of failures being returned from Kong. As we're going to generate a fallback config
 	// based on the cache contents, we need to ensure it is not modified during the process.
@@ -462,6 +524,11 @@ func (c *KongClient) Update(ctx context.Context) error {
 		c.prometheusMetrics.RecordProcessedConfigSnapshotCacheMiss()
 		c.lastProcessedSnapshotHash = newSnapshotHash
 		c.kongConfigBuilder.UpdateCache(cacheSnapshot)
+	} else {
+		// Without a snapshot, the translator works on the cache itself. It's still passed explicitly, as changes
+		// since the previous translation can only be detected in caches passed to the translator (which is
+		// the case with the IngressIncrementalTranslation feature).
+		c.kongConfigBuilder.UpdateCache(*c.cache)
 	}
 
 	c.logger.V(util.DebugLevel).Info("Parsing kubernetes objects into data-plane configuration")
@@ -477,8 +544,25 @@ func (c *KongClient) Update(ctx context.Context) error {
 		c.logger.V(util.DebugLevel).Info("Successfully built data-plane configuration")
 	}
 
+	// The diff is computed against the configuration last successfully pushed to gateways before sending the new one
+	// out, so that it describes what the push is going to change regardless of its result.
+	lastValidState, _ := c.kongConfigFetcher.LastValidConfig()
+	configDiff := kongstate.DiffStates(lastValidState, parsingResult.KongState)
+
 	const isFallback = false
 	shas, gatewaysSyncErr := c.sendOutToGatewayClients(ctx, parsingResult.KongState, c.kongConfig, isFallback)
+	// Canary gateways are being observed, the rest of gateways (and Konnect) get the configuration in one of the next
+	// updates. The cache has to be processed again then, even if it doesn't change.
+	if errors.Is(gatewaysSyncErr, sendconfig.ErrStagedRolloutInProgress) {
+		c.logger.V(util.DebugLevel).Info("Staged rollout in progress, canary gateways are being observed")
+		c.lastProcessedSnapshotHash = store.SnapshotHashEmpty
+		return nil
+	}
+	// A diff without changes is only reported when the configuration was rejected, as there's nothing to report
+	// otherwise.
+	if gatewaysSyncErr != nil || len(configDiff.Changes) > 0 {
+		c.reportConfigDiff(configDiff, gatewaysSyncErr != nil)
+	}
 	konnectSyncErr := c.maybeSendOutToKonnectClient(ctx, parsingResult.KongState, c.kongConfig, isFallback)
 
 	// Taking into account the results of syncing configuration with Gateways and Konnect, and potential translation
@@ -491,6 +575,11 @@ func (c *KongClient) Update(ctx context.Context) error {
 		},
 	))
 
+	// In dry-run mode, nothing was applied, so there's nothing to recover and no objects to report on.
+	if c.kongConfig.DryRun {
+		return c.reportDryRunResult(parsingResult.TranslationFailures, gatewaysSyncErr)
+	}
+
 	// In case of a failure in syncing configuration with Gateways, propagate the error.
 	if gatewaysSyncErr != nil {
 		if recoveringErr := c.tryRecoveringFromGatewaysSyncError(
@@ -500,6 +589,7 @@ func (c *KongClient) Update(ctx context.Context) error {
 		); recoveringErr != nil {
 			return fmt.Errorf("failed to recover from gateways sync error: %w", recoveringErr)
 		}
+		c.maybePersistLastValidConfig(ctx)
 		// Update result is positive only if gateways were successfully synced with the current config, so we still
 		// need to return the error here even if we succeeded recovering.
 		return gatewaysSyncErr
@@ -507,6 +597,8 @@ func (c *KongClient) Update(ctx context.Context) error {
 
 	// Gateways were successfully synced with the current configuration, so we can update the last valid cache snapshot.
 	c.maybePreserveTheLastValidConfigCache(cacheSnapshot)
+	c.maybePersistLastValidConfig(ctx)
+	c.maybeRecordConfigHistory(shas, parsingResult.KongState, parsingResult.ConfiguredKubernetesObjects, isFallback)
 
 	// report on configured Kubernetes objects if enabled
 	if c.AreKubernetesObjectReportsEnabled() {
@@ -515,7 +607,11 @@ func (c *KongClient) Update(ctx context.Context) error {
 		if !slices.Equal(shas, c.SHAs) {
 			c.logger.V(util.DebugLevel).Info("Triggering report for configured Kubernetes objects", "count",
 				len(parsingResult.ConfiguredKubernetesObjects))
-			c.triggerKubernetesObjectReport(parsingResult.ConfiguredKubernetesObjects, parsingResult.TranslationFailures)
+			c.triggerKubernetesObjectReport(
+				parsingResult.ConfiguredKubernetesObjects,
+				parsingResult.TranslationFailures,
+				parsingResult.TranslationWarnings,
+			)
 		} else {
 			c.logger.V(util.DebugLevel).Info("No configuration change; resource status update not necessary, skipping")
 		}
@@ -523,6 +619,15 @@ func (c *KongClient) Update(ctx context.Context) error {
 	return nil
 }
 
+// fetchLastValidConfigFromSharedGateways fetches the last valid configuration from the gateways shared by all Gateways.
+// Gateways dedicated to Gateways hold only their part of the configuration, so they're skipped.
+func (c *KongClient) fetchLastValidConfigFromSharedGateways(ctx context.Context) error {
+	sharedClients := lo.Filter(c.clientsProvider.GatewayClients(), func(client *adminapi.Client, _ int) bool {
+		return len(c.clientsProvider.GatewaysForClient(client)) == 0
+	})
+	return c.kongConfigFetcher.TryFetchingValidConfigFromGateways(ctx, c.logger, sharedClients)
+}
+
 // maybePreserveTheLastValidConfigCache preserves the last valid configuration cache if the `FallbackConfiguration`
 // feature gate is enabled and the `--enable-last-valid-config-fallback` flag is set.
 func (c *KongClient) maybePreserveTheLastValidConfigCache(lastValidCache store.CacheStores) {
@@ -532,6 +637,143 @@ func (c *KongClient) maybePreserveTheLastValidConfigCache(lastValidCache store.C
 	}
 }
 
+// maybeLoadPersistedLastValidConfig loads the persisted last valid configuration and cache snapshot if persistence is
+// enabled and there's no last valid configuration yet. It's done only once, after the controller starts.
+func (c *KongClient) maybeLoadPersistedLastValidConfig(ctx context.Context) {
+	if c.lastValidConfigPersister == nil || c.persistedLastValidConfigLoaded {
+		return
+	}
+	if _, found := c.kongConfigFetcher.LastValidConfig(); found {
+		c.persistedLastValidConfigLoaded = true
+		return
+	}
+
+	persisted, found, err := c.lastValidConfigPersister.Load(ctx)
+	if err != nil {
+		// Loading will be retried on the next update.
+		c.logger.Error(err, "Failed to load persisted last valid configuration")
+		return
+	}
+	c.persistedLastValidConfigLoaded = true
+	if !found {
+		c.logger.V(util.DebugLevel).Info("No persisted last valid configuration found")
+		return
+	}
+
+	c.kongConfigFetcher.StoreLastValidConfig(persisted.KongState)
+	if persisted.CacheSnapshot != nil {
+		c.maybePreserveTheLastValidConfigCache(*persisted.CacheSnapshot)
+	}
+	c.logger.Info("Loaded persisted last valid configuration")
+}
+
+// maybePersistLastValidConfig persists the last valid configuration and cache snapshot if persistence is enabled.
+// Failures are only logged as they don't affect the configuration applied to the gateways.
+func (c *KongClient) maybePersistLastValidConfig(ctx context.Context) {
+	if c.lastValidConfigPersister == nil {
+		return
+	}
+	state, found := c.kongConfigFetcher.LastValidConfig()
+	if !found {
+		return
+	}
+	if err := c.lastValidConfigPersister.Persist(ctx, configfetcher.PersistedConfig{
+		KongState:     state,
+		CacheSnapshot: c.lastValidCacheSnapshot,
+	}); err != nil {
+		c.logger.Error(err, "Failed to persist last valid configuration")
+	}
+}
+
+// pinnedConfig returns the configuration from the diagnostics config history gateways are pinned to, if any.
+// Pins are ignored in dry-run mode as no configuration is applied.
+func (c *KongClient) pinnedConfig() (diagnostics.ConfigHistoryEntry, *kongstate.KongState, bool) {
+	if c.diagnostic.ConfigHistory == nil || c.kongConfig.DryRun {
+		return diagnostics.ConfigHistoryEntry{}, nil, false
+	}
+	return c.diagnostic.ConfigHistory.Pinned()
+}
+
+// pushPinnedConfig pushes the configuration gateways are pinned to. Konnect and statuses of Kubernetes objects are left
+// intact as they describe the current configuration.
+func (c *KongClient) pushPinnedConfig(ctx context.Context, entry diagnostics.ConfigHistoryEntry, state *kongstate.KongState) error {
+	c.logger.V(util.DebugLevel).Info("Gateways are pinned to configuration from history, pushing it", "id", entry.ID)
+
+	// The cache isn't processed while gateways are pinned, so it has to be processed once they're unpinned even if
+	// it didn't change.
+	c.lastProcessedSnapshotHash = store.SnapshotHashEmpty
+
+	// The pinned configuration was already accepted by the gateways, so there's no need to roll it out in stages.
+	config := c.kongConfig
+	config.StagedRollout = sendconfig.StagedRolloutConfig{}
+	const isFallback = true
+	if _, err := c.sendOutToGatewayClients(ctx, state, config, isFallback); err != nil {
+		return fmt.Errorf("failed to push pinned configuration %d: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// maybeRecordConfigHistory records the configuration in the diagnostics config history if config dumps are enabled
+// and the configuration pushed to gateways changed. configuredObjects are the Kubernetes objects the configuration was
+// translated from, used to determine objects that changed since the last recorded configuration. It's nil when
+// they're unknown.
+func (c *KongClient) maybeRecordConfigHistory(
+	previousSHAs []string,
+	s *kongstate.KongState,
+	configuredObjects []client.Object,
+	isFallback bool,
+) {
+	history := c.diagnostic.ConfigHistory
+	if history == nil || slices.Equal(previousSHAs, c.SHAs) {
+		return
+	}
+
+	var objectChanges []diagnostics.ObjectChange
+	if configuredObjects != nil {
+		versions := make(map[diagnostics.ObjectChange]string, len(configuredObjects))
+		for _, obj := range configuredObjects {
+			key := diagnostics.ObjectChange{
+				Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+			}
+			versions[key] = obj.GetResourceVersion()
+			previousVersion, existed := c.recordedObjectVersions[key]
+			switch {
+			case !existed:
+				key.ChangeType = diagnostics.ObjectChangeTypeCreate
+				objectChanges = append(objectChanges, key)
+			case previousVersion != obj.GetResourceVersion():
+				key.ChangeType = diagnostics.ObjectChangeTypeUpdate
+				objectChanges = append(objectChanges, key)
+			}
+		}
+		for key := range c.recordedObjectVersions {
+			if _, exists := versions[key]; !exists {
+				key.ChangeType = diagnostics.ObjectChangeTypeDelete
+				objectChanges = append(objectChanges, key)
+			}
+		}
+		slices.SortFunc(objectChanges, func(a, b diagnostics.ObjectChange) int {
+			return cmp.Or(
+				cmp.Compare(a.Kind, b.Kind),
+				cmp.Compare(a.Namespace, b.Namespace),
+				cmp.Compare(a.Name, b.Name),
+			)
+		})
+		c.recordedObjectVersions = versions
+	}
+
+	id := history.Record(diagnostics.ConfigHistoryEntry{
+		Timestamp:          time.Now(),
+		SHAs:               slices.Clone(c.SHAs),
+		Fallback:           isFallback,
+		ObjectChanges:      objectChanges,
+		ObjectChangesCount: len(objectChanges),
+	}, s)
+	c.logger.V(util.DebugLevel).Info("Recorded configuration in history", "id", id, "objectChanges", len(objectChanges))
+}
+
 // tryRecoveringFromGatewaysSyncError tries to recover from a configuration rejection by:
 // 1. Generating a fallback configuration and pushing it to the gateways if FallbackConfiguration feature is enabled.
 // 2. Applying the last valid configuration to the gateways if FallbackConfiguration is disabled or fallback
@@ -557,9 +799,14 @@ func (c *KongClient) tryRecoveringFromGatewaysSyncError(
 	// apply the last valid configuration to the gateways.
 	if state, found := c.kongConfigFetcher.LastValidConfig(); found {
 		const isFallback = true
-		if _, fallbackSyncErr := c.sendOutToGatewayClients(ctx, state, c.kongConfig, isFallback); fallbackSyncErr != nil {
+		// The last valid configuration was already accepted by the gateways, so there's no need to roll it out in stages.
+		config := c.kongConfig
+		config.StagedRollout = sendconfig.StagedRolloutConfig{}
+		previousSHAs, fallbackSyncErr := c.sendOutToGatewayClients(ctx, state, config, isFallback)
+		if fallbackSyncErr != nil {
 			return errors.Join(gatewaysSyncErr, fallbackSyncErr)
 		}
+		c.maybeRecordConfigHistory(previousSHAs, state, nil, isFallback)
 		c.logger.V(util.DebugLevel).Info("Due to errors in the current config, the last valid config has been pushed to Gateways")
 	}
 	return nil
@@ -571,6 +818,10 @@ func (c *KongClient) cacheBrokenObjectList(list []fallback.ObjectHash) {
 
 // tryRecoveringWithFallbackConfiguration tries to recover from a configuration rejection by generating a fallback
 // configuration excluding affected objects from the cache.
+//
+// In DB mode, gateways stop applying configuration at the first entity they reject, so the fallback configuration can
+// be rejected because of objects that weren't reported as broken before. In that case, fallback configuration excluding
+// them as well is generated again, up to dbModeMaxFallbackAttempts times.
 func (c *KongClient) tryRecoveringWithFallbackConfiguration(
 	ctx context.Context,
 	currentCache store.CacheStores,
@@ -581,20 +832,80 @@ func (c *KongClient) tryRecoveringWithFallbackConfiguration(
 	if err != nil {
 		return fmt.Errorf("failed to extract broken objects from update error: %w", err)
 	}
+	brokenEntities := extractBrokenEntitiesFromUpdateError(gatewaysSyncErr)
+
+	for attempt := 1; ; attempt++ {
+		fallbackCache, fallbackParsingResult, err := c.buildFallbackConfiguration(ctx, currentCache, brokenObjects, brokenEntities)
+		if err != nil {
+			return err
+		}
+		fallbackState := fallbackParsingResult.KongState
+
+		const isFallback = true
+		c.cacheBrokenObjectList(brokenObjects)
+		var previousSHAs []string
+		previousSHAs, gatewaysSyncErr = c.sendOutToGatewayClients(ctx, fallbackState, c.kongConfig, isFallback)
+		if gatewaysSyncErr != nil {
+			if c.dbmode.IsDBLessMode() || attempt >= dbModeMaxFallbackAttempts {
+				return fmt.Errorf("failed to sync fallback configuration with gateways: %w", gatewaysSyncErr)
+			}
+			moreBrokenObjects, err := extractBrokenObjectsFromUpdateError(gatewaysSyncErr)
+			if err != nil {
+				return fmt.Errorf("failed to sync fallback configuration with gateways: %w", gatewaysSyncErr)
+			}
+			newBrokenObjects := lo.Without(lo.Uniq(moreBrokenObjects), brokenObjects...)
+			// With entity-level exclusion, other entities of an already broken object can be rejected too.
+			newBrokenEntities := lo.Reject(extractBrokenEntitiesFromUpdateError(gatewaysSyncErr), func(e fallback.BrokenEntity, _ int) bool {
+				return lo.ContainsBy(brokenEntities, func(known fallback.BrokenEntity) bool { return known.String() == e.String() })
+			})
+			if len(newBrokenObjects) == 0 && len(newBrokenEntities) == 0 {
+				return fmt.Errorf("failed to sync fallback configuration with gateways: %w", gatewaysSyncErr)
+			}
+			c.logger.V(util.DebugLevel).Info("Fallback configuration was rejected because of more broken objects, retrying",
+				"attempt", attempt, "newBrokenObjects", len(newBrokenObjects), "newBrokenEntities", len(newBrokenEntities))
+			brokenObjects = append(brokenObjects, newBrokenObjects...)
+			brokenEntities = append(brokenEntities, newBrokenEntities...)
+			continue
+		}
+
+		konnectSyncErr := c.maybeSendOutToKonnectClient(ctx, fallbackState, c.kongConfig, isFallback)
+		if konnectSyncErr != nil {
+			// If Konnect sync fails, we should log the error and carry on as it's not a critical error.
+			c.logger.Error(konnectSyncErr, "Failed to sync fallback configuration with Konnect")
+		}
+
+		// Configuration was successfully recovered with the fallback configuration. Store the last valid configuration.
+		c.maybePreserveTheLastValidConfigCache(fallbackCache)
+		c.maybeRecordConfigHistory(previousSHAs, fallbackState, fallbackParsingResult.ConfiguredKubernetesObjects, isFallback)
+		return nil
+	}
+}
 
+// buildFallbackConfiguration generates a fallback cache snapshot without broken objects and translates it into Kong
+// configuration.
+func (c *KongClient) buildFallbackConfiguration(
+	ctx context.Context,
+	currentCache store.CacheStores,
+	brokenObjects []fallback.ObjectHash,
+	brokenEntities []fallback.BrokenEntity,
+) (store.CacheStores, translator.KongConfigBuildingResult, error) {
 	// Generate a fallback cache snapshot.
-	fallbackCache, generatedCacheMetadata, err := c.generateFallbackCache(currentCache, brokenObjects)
+	fallbackCache, generatedCacheMetadata, err := c.generateFallbackCache(currentCache, brokenObjects, brokenEntities)
 	if err != nil {
-		return fmt.Errorf("failed to generate fallback configuration: %w", err)
+		return store.CacheStores{}, translator.KongConfigBuildingResult{}, fmt.Errorf("failed to generate fallback configuration: %w", err)
 	}
 	c.logFallbackCacheMetadata(generatedCacheMetadata)
 	if err := c.maybeSendFallbackConfigDiagnostics(ctx, generatedCacheMetadata); err != nil {
-		return fmt.Errorf("failed to send fallback configuration diagnostics: %w", err)
+		return store.CacheStores{}, translator.KongConfigBuildingResult{}, fmt.Errorf("failed to send fallback configuration diagnostics: %w", err)
 	}
 
 	// Update the KongConfigBuilder with the fallback configuration and build the KongConfig.
 	c.kongConfigBuilder.UpdateCache(fallbackCache)
 	fallbackParsingResult := c.kongConfigBuilder.BuildKongConfig()
+	if len(generatedCacheMetadata.ExcludedEntities) > 0 {
+		excluded := fallback.ExcludeEntities(fallbackParsingResult.KongState, generatedCacheMetadata.ExcludedEntities)
+		c.logger.V(util.DebugLevel).Info("Excluded broken entities from fallback configuration", "count", excluded)
+	}
 
 	if failuresCount := len(fallbackParsingResult.TranslationFailures); failuresCount > 0 {
 		c.recordResourceFailureEvents(fallbackParsingResult.TranslationFailures, FallbackKongConfigurationTranslationFailedEventReason)
@@ -605,30 +916,17 @@ func (c *KongClient) tryRecoveringWithFallbackConfiguration(
 		c.prometheusMetrics.RecordFallbackTranslationBrokenResources(0)
 		c.prometheusMetrics.RecordFallbackTranslationSuccess()
 	}
-
-	const isFallback = true
-	c.cacheBrokenObjectList(brokenObjects)
-	_, gatewaysSyncErr = c.sendOutToGatewayClients(ctx, fallbackParsingResult.KongState, c.kongConfig, isFallback)
-	if gatewaysSyncErr != nil {
-		return fmt.Errorf("failed to sync fallback configuration with gateways: %w", gatewaysSyncErr)
-	}
-	konnectSyncErr := c.maybeSendOutToKonnectClient(ctx, fallbackParsingResult.KongState, c.kongConfig, isFallback)
-	if konnectSyncErr != nil {
-		// If Konnect sync fails, we should log the error and carry on as it's not a critical error.
-		c.logger.Error(konnectSyncErr, "Failed to sync fallback configuration with Konnect")
-	}
-
-	// Configuration was successfully recovered with the fallback configuration. Store the last valid configuration.
-	c.maybePreserveTheLastValidConfigCache(fallbackCache)
-	return nil
+	return fallbackCache, fallbackParsingResult, nil
 }
 
 // generateFallbackCache generates a fallback configuration based on the current cache and a set of broken objects.
-// It will either exclude the broken objects from the cache or backfill them from the last valid cache snapshot
-// depending on the UseLastValidConfigForFallback flag.
+// It will either exclude the broken objects from the cache, backfill them from the last valid cache snapshot or
+// exclude only their broken entities depending on the UseLastValidConfigForFallback and
+// UseEntityLevelExclusionForFallback flags.
 func (c *KongClient) generateFallbackCache(
 	currentCache store.CacheStores,
 	brokenObjects []fallback.ObjectHash,
+	brokenEntities []fallback.BrokenEntity,
 ) (s store.CacheStores, metadata fallback.GeneratedCacheMetadata, err error) {
 	start := time.Now()
 	defer func() {
@@ -641,6 +939,13 @@ func (c *KongClient) generateFallbackCache(
 			brokenObjects,
 		)
 	}
+	if c.kongConfig.UseEntityLevelExclusionForFallback {
+		return c.fallbackConfigGenerator.GenerateExcludingBrokenEntities(
+			currentCache,
+			brokenObjects,
+			brokenEntities,
+		)
+	}
 	return c.fallbackConfigGenerator.GenerateExcludingBrokenObjects(
 		currentCache,
 		brokenObjects,
@@ -667,6 +972,23 @@ func extractBrokenObjectsFromUpdateError(err error) ([]fallback.ObjectHash, erro
 	}), nil
 }
 
+// extractBrokenEntitiesFromUpdateError extracts broken Kong entities from an UpdateError. It returns nil if the error
+// is not an UpdateError.
+func extractBrokenEntitiesFromUpdateError(err error) []fallback.BrokenEntity {
+	var updateErr sendconfig.UpdateError
+	if ok := errors.As(err, &updateErr); !ok {
+		return nil
+	}
+	return lo.Map(updateErr.EntityFailures(), func(f sendconfig.EntityFailure, _ int) fallback.BrokenEntity {
+		return fallback.BrokenEntity{
+			Type:          f.Type,
+			Name:          f.Name,
+			ForeignKeys:   f.ForeignKeys,
+			CausingObject: fallback.GetObjectHash(f.CausingObject),
+		}
+	})
+}
+
 // sendOutToGatewayClients will generate deck content (config) from the provided kong state
 // and send it out to each of the configured gateway clients.
 func (c *KongClient) sendOutToGatewayClients(
@@ -689,9 +1011,33 @@ func (c *KongClient) sendOutToGatewayClients(
 	configureGatewayClientURLs := lo.Map(gatewayClientsToConfigure, func(cl *adminapi.Client, _ int) string { return cl.BaseRootURL() })
 	c.logger.V(util.DebugLevel).Info("Sending configuration to gateway clients", "urls", configureGatewayClientURLs)
 
-	shas, err := iter.MapErr(gatewayClientsToConfigure, func(client **adminapi.Client) (string, error) {
-		return c.sendToClient(ctx, *client, s, config, isFallback)
-	})
+	var (
+		shas []string
+		err  error
+	)
+	// In dry-run mode, all gateways would get the same configuration, so it's validated only once.
+	if config.DryRun && len(gatewayClientsToConfigure) > 1 {
+		gatewayClientsToConfigure = gatewayClientsToConfigure[:1]
+	}
+	pushToClient := c.pushFuncForState(s, config, isFallback)
+	// In dry-run mode nothing is applied, so there's nothing to roll out in stages. Fallback configuration is pushed
+	// right away, as the configuration being rolled out was rejected.
+	if config.StagedRollout.Enabled() && !config.DryRun && !isFallback {
+		if c.stagedRollout == nil {
+			c.stagedRollout = sendconfig.NewStagedRollout(config.StagedRollout, c.logger)
+		}
+		shas, err = c.stagedRollout.Push(
+			ctx,
+			gatewayClientsToConfigure,
+			c.configSHAFuncForState(s, config),
+			pushToClient,
+			c.lastValidConfigPushFunc(config),
+		)
+	} else {
+		shas, err = iter.MapErr(gatewayClientsToConfigure, func(client **adminapi.Client) (string, error) {
+			return pushToClient(ctx, *client)
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -711,11 +1057,74 @@ func (c *KongClient) sendOutToGatewayClients(
 	sort.Strings(shas)
 	c.SHAs = shas
 
-	c.kongConfigFetcher.StoreLastValidConfig(s)
+	// In dry-run mode, the configuration was only validated, so the gateways still have the configuration fetched
+	// from them before the update.
+	if !config.DryRun {
+		c.kongConfigFetcher.StoreLastValidConfig(s)
+	}
 
 	return previousSHAs, nil
 }
 
+// pushFuncForState returns a function pushing the given state to a single gateway client.
+func (c *KongClient) pushFuncForState(s *kongstate.KongState, config sendconfig.Config, isFallback bool) sendconfig.PushFunc {
+	return func(ctx context.Context, client *adminapi.Client) (string, error) {
+		clientState, ok := c.stateForClient(s, client)
+		if !ok {
+			return string(client.LastConfigSHA()), nil
+		}
+		return c.sendToClient(ctx, client, clientState, config, isFallback)
+	}
+}
+
+// configSHAFuncForState returns a function telling the SHA of the configuration pushFuncForState would push
+// to a single gateway client.
+func (c *KongClient) configSHAFuncForState(s *kongstate.KongState, config sendconfig.Config) sendconfig.ConfigSHAFunc {
+	return func(ctx context.Context, client *adminapi.Client) (string, error) {
+		clientState, ok := c.stateForClient(s, client)
+		if !ok {
+			return string(client.LastConfigSHA()), nil
+		}
+		logger := c.logger.WithValues("url", client.BaseRootURL())
+		targetContent, _ := c.deckContentForClient(ctx, logger, client, clientState, config)
+		sha, err := deckgen.GenerateSHA(targetContent, sendconfig.CustomEntitiesByType(clientState.CustomEntityObjectsByType()))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate SHA for %s: %w", client.BaseRootURL(), err)
+		}
+		return string(sha), nil
+	}
+}
+
+// stateForClient returns the part of the given state that is pushed to a single gateway client. It returns false when
+// the client has to be left with its current configuration.
+func (c *KongClient) stateForClient(s *kongstate.KongState, client *adminapi.Client) (*kongstate.KongState, bool) {
+	// Data-planes dedicated to Gateways get only the part of the configuration relevant to them, data-planes shared
+	// by all Gateways get the configuration without the parts relevant only to dedicated data-planes.
+	gateways := c.clientsProvider.GatewaysForClient(client)
+	if len(gateways) == 0 {
+		return s.ForSharedGateways(), true
+	}
+	// The configuration fetched from shared data-planes doesn't tell which Gateways its routes belong to,
+	// so dedicated data-planes are left with their current configuration.
+	if s.FetchedFromGateways {
+		c.logger.V(util.DebugLevel).Info("Not pushing configuration fetched from shared gateways to a gateway dedicated to Gateways",
+			"url", client.BaseRootURL(), "gateways", gateways)
+		return nil, false
+	}
+	return s.ForGateways(gateways...), true
+}
+
+// lastValidConfigPushFunc returns a function rolling a single gateway client back to the last valid configuration.
+// It returns nil when there's no last valid configuration.
+func (c *KongClient) lastValidConfigPushFunc(config sendconfig.Config) sendconfig.PushFunc {
+	state, found := c.kongConfigFetcher.LastValidConfig()
+	if !found {
+		return nil
+	}
+	const isFallback = true
+	return c.pushFuncForState(state, config, isFallback)
+}
+
 // maybeSendOutToKonnectClient sends out the configuration to Konnect when KonnectClient is provided.
 // It's a noop when Konnect integration is not enabled.
 func (c *KongClient) maybeSendOutToKonnectClient(
@@ -782,19 +1191,8 @@ func (c *KongClient) sendToClient(
 	if client.IsKonnect() && config.SanitizeKonnectConfigDumps {
 		s = s.SanitizedCopy(util.DefaultUUIDGenerator{})
 	}
-	deckGenParams := deckgen.GenerateDeckContentParams{
-		SelectorTags:                    config.FilterTags,
-		ExpressionRoutes:                config.ExpressionRoutes,
-		PluginSchemas:                   client.PluginSchemaStore(),
-		AppendStubEntityWhenConfigEmpty: !client.IsKonnect() && config.InMemory,
-	}
-	targetContent := deckgen.ToDeckContent(ctx, logger, s, deckGenParams)
-	customEntities := make(sendconfig.CustomEntitiesByType)
-	for entityType, collection := range s.CustomEntities {
-		for _, entity := range collection.Entities {
-			customEntities[entityType] = append(customEntities[entityType], entity.Object)
-		}
-	}
+	targetContent, deckGenParams := c.deckContentForClient(ctx, logger, client, s, config)
+	customEntities := sendconfig.CustomEntitiesByType(s.CustomEntityObjectsByType())
 
 	sendDiagnostic := prepareSendDiagnosticFn(ctx, logger, c.diagnostic, s, targetContent, deckGenParams, isFallback)
 
@@ -842,12 +1240,33 @@ func (c *KongClient) sendToClient(
 		return "", fmt.Errorf("performing update for %s failed: %w", client.BaseRootURL(), err)
 	}
 	sendDiagnostic(diagnostics.DumpMeta{Failed: false, Hash: string(newConfigSHA)}, nil) // No error occurred.
+	configPushed := !bytes.Equal(client.LastConfigSHA(), newConfigSHA)
 	// update the lastConfigSHA with the new updated checksum
 	client.SetLastConfigSHA(newConfigSHA)
+	if configPushed {
+		c.maybeRecordPushedConfigForDriftDetection(ctx, client)
+	}
 
 	return string(newConfigSHA), nil
 }
 
+// deckContentForClient generates the configuration content sent to the client from the given state.
+func (c *KongClient) deckContentForClient(
+	ctx context.Context,
+	logger logr.Logger,
+	client sendconfig.AdminAPIClient,
+	s *kongstate.KongState,
+	config sendconfig.Config,
+) (*file.Content, deckgen.GenerateDeckContentParams) {
+	deckGenParams := deckgen.GenerateDeckContentParams{
+		SelectorTags:                    config.FilterTags,
+		ExpressionRoutes:                config.ExpressionRoutes,
+		PluginSchemas:                   client.PluginSchemaStore(),
+		AppendStubEntityWhenConfigEmpty: !client.IsKonnect() && config.InMemory,
+	}
+	return deckgen.ToDeckContent(ctx, logger, s, deckGenParams), deckGenParams
+}
+
 // SetConfigStatusNotifier sets a notifier which notifies subscribers about configuration sending results.
 // Currently it is used for uploading the node status to konnect control plane.
 func (c *KongClient) SetConfigStatusNotifier(n clients.ConfigStatusNotifier) {
@@ -857,10 +1276,87 @@ func (c *KongClient) SetConfigStatusNotifier(n clients.ConfigStatusNotifier) {
 	c.configStatusNotifier = n
 }
 
+// SetLastValidConfigPersister sets a persister of the last valid configuration. When set, the persisted configuration
+// is loaded on the first update, and the last valid configuration is persisted after every successful update.
+func (c *KongClient) SetLastValidConfigPersister(p configfetcher.LastValidConfigPersister) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lastValidConfigPersister = p
+}
+
+// SetConfigDriftDetector sets a detector of configuration drifts. When set, configuration pushed to gateways is
+// recorded, so that DetectConfigDrift can tell which gateways' configuration drifted from it.
+func (c *KongClient) SetConfigDriftDetector(d configfetcher.ConfigDriftDetector) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.configDriftDetector = d
+}
+
+// DetectConfigDrift checks whether configuration of any gateway drifted from the configuration that was last pushed
+// to it, e.g. because it was changed out of band or the gateway restarted with different configuration. Drifted
+// gateways get the configuration pushed again with the next update, while the rest of them are left intact.
+// It's a no-op when no ConfigDriftDetector is set.
+func (c *KongClient) DetectConfigDrift(ctx context.Context) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.configDriftDetector == nil {
+		return nil
+	}
+
+	var (
+		errs    error
+		drifted bool
+	)
+	for _, client := range c.clientsProvider.GatewayClients() {
+		timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+		drift, ok, err := c.configDriftDetector.DetectDrift(timedCtx, client)
+		cancel()
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		drifted = true
+		c.logger.Info("Configuration drift detected, configuration will be pushed again",
+			"url", drift.URL,
+			"expected_hash", drift.ExpectedHash,
+			"actual_hash", drift.ActualHash,
+		)
+		c.prometheusMetrics.RecordConfigDrift(drift.URL)
+		c.recordConfigDriftEvent(drift)
+		// Forgetting the SHA makes the change detector push the configuration to the drifted gateway again, while
+		// gateways with their configuration intact still skip it.
+		client.SetLastConfigSHA(nil)
+	}
+	if drifted {
+		// Make sure the next update doesn't skip pushing because Kubernetes objects haven't changed.
+		c.lastProcessedSnapshotHash = store.SnapshotHashEmpty
+	}
+	return errs
+}
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Private
 // -----------------------------------------------------------------------------
 
+// maybeRecordPushedConfigForDriftDetection records configuration just pushed to a gateway if drift detection is
+// enabled. Konnect is not a gateway, so it's never recorded.
+func (c *KongClient) maybeRecordPushedConfigForDriftDetection(ctx context.Context, client sendconfig.AdminAPIClient) {
+	if c.configDriftDetector == nil || client.IsKonnect() {
+		return
+	}
+	if err := c.configDriftDetector.RecordPushed(ctx, client); err != nil {
+		// Configuration will be recorded with the next drift detection, it just won't catch drifts happening before.
+		c.logger.Error(err, "Failed to record pushed configuration for drift detection", "url", client.BaseRootURL())
+	}
+}
+
 type sendDiagnosticFn func(meta diagnostics.DumpMeta, raw []byte)
 
 // prepareSendDiagnosticFn generates sendDiagnosticFn.
@@ -918,7 +1414,11 @@ func prepareSendDiagnosticFn(
 // enables filtering for which objects are currently applied to the data-plane,
 // as well as updating the c.kubernetesObjectStatusQueue to queue those objects
 // for reconciliation so their statuses can be properly updated.
-func (c *KongClient) triggerKubernetesObjectReport(reportedObjects []client.Object, translationFailures []failures.ResourceFailure) {
+func (c *KongClient) triggerKubernetesObjectReport(
+	reportedObjects []client.Object,
+	translationFailures []failures.ResourceFailure,
+	translationWarnings []failures.ResourceFailure,
+) {
 	// first a new set of the included objects for the most recent configuration
 	// needs to be generated.
 	set := k8sobj.ConfigurationStatusSet{}
@@ -935,6 +1435,13 @@ func (c *KongClient) triggerKubernetesObjectReport(reportedObjects []client.Obje
 		}
 	}
 
+	// warnings are attached to the objects which got configured despite them.
+	for _, translationWarning := range translationWarnings {
+		for _, obj := range translationWarning.CausingObjects() {
+			set.AddWarning(obj, translationWarning.Message())
+		}
+	}
+
 	c.updateKubernetesObjectReportFilter(set)
 
 	// after the filter has been updated we signal the status queue so that the
@@ -1023,6 +1530,26 @@ func (c *KongClient) recordApplyConfigurationEvents(err error, rootURL string, i
 	c.eventRecorder.Event(pod, eventType, reason, message)
 }
 
+// recordConfigDriftEvent records a warning event about a configuration drift of a gateway.
+func (c *KongClient) recordConfigDriftEvent(drift configfetcher.ConfigDrift) {
+	podNN, ok := c.controllerPodReference.Get()
+	if !ok {
+		// Can't record an event without a controller pod reference to attach to.
+		return
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podNN.Name,
+			Namespace: podNN.Namespace,
+		},
+	}
+	c.eventRecorder.Event(pod, corev1.EventTypeWarning, ConfigDriftEventReason, fmt.Sprintf(
+		"configuration of %s drifted from the pushed configuration (expected hash %q, got %q)",
+		drift.URL, drift.ExpectedHash, drift.ActualHash,
+	))
+}
+
 // updateConfigStatus updates the current config status and notifies about the change. It is a no-op if the status
 // hasn't changed.
 func (c *KongClient) updateConfigStatus(ctx context.Context, configStatus clients.ConfigStatus) {
@@ -1071,6 +1598,80 @@ func (c *KongClient) logFallbackCacheMetadata(metadata fallback.GeneratedCacheMe
 			"causing_objects", strings.Join(causingObjects, ","),
 		)
 	}
+
+	// Log excluded entities.
+	for _, excluded := range metadata.ExcludedEntities {
+		log.V(util.DebugLevel).Info("Excluded entity from fallback configuration",
+			"type", excluded.Type,
+			"name", excluded.Name,
+			"foreign_keys", excluded.ForeignKeys,
+			"causing_object", excluded.CausingObject.String(),
+		)
+	}
+}
+
+// reportConfigDiff records counts of changes of Kong entities between the configuration last successfully applied
+// to gateways and the pushed one in metrics and ships them to the diagnostics server if it's enabled. failed
+// indicates that gateways rejected the pushed configuration, so the changes weren't applied.
+func (c *KongClient) reportConfigDiff(diff kongstate.StateDiff, failed bool) {
+	for entityType, counts := range diff.Counts() {
+		for changeType, count := range counts {
+			c.prometheusMetrics.RecordConfigDiffEntityCount(string(entityType), string(changeType), !failed, count)
+		}
+	}
+
+	if ch := c.diagnostic.ConfigDiffs; ch != nil {
+		select {
+		case ch <- diagnostics.ConfigDiff{Diff: diff, Failed: failed, Timestamp: time.Now()}:
+			c.logger.V(util.DebugLevel).Info("Shipping config diff to diagnostics server", "changes", len(diff.Changes))
+		default:
+			c.logger.Error(nil, "Config diff buffer full, dropping diagnostics")
+		}
+	}
+}
+
+// reportDryRunResult logs the result of validating the configuration in dry-run mode and ships it to the diagnostics
+// server if it's enabled. Validation failures are expected in dry-run mode, so only errors that prevented validating
+// the configuration are returned.
+func (c *KongClient) reportDryRunResult(translationFailures []failures.ResourceFailure, validationErr error) error {
+	var (
+		updateErr          sendconfig.UpdateError
+		validationFailures []failures.ResourceFailure
+	)
+	if errors.As(validationErr, &updateErr) {
+		validationFailures = updateErr.ResourceFailures()
+	}
+
+	if validationErr != nil {
+		c.prometheusMetrics.RecordDryRunFailure(len(validationFailures), validationErr)
+		c.logger.Info("Dry run: configuration failed validation",
+			"error", validationErr.Error(),
+			"translation_failures", len(translationFailures),
+			"broken_objects", len(validationFailures),
+		)
+	} else {
+		c.prometheusMetrics.RecordDryRunSuccess()
+		c.logger.Info("Dry run: configuration passed validation", "translation_failures", len(translationFailures))
+	}
+
+	if ch := c.diagnostic.DryRunResults; ch != nil {
+		select {
+		case ch <- diagnostics.DryRunResult{
+			Timestamp:           time.Now(),
+			TranslationFailures: translationFailures,
+			ValidationFailures:  validationFailures,
+			ValidationErr:       validationErr,
+		}:
+			c.logger.V(util.DebugLevel).Info("Shipping dry run result to diagnostics server")
+		default:
+			c.logger.Error(nil, "Dry run result buffer full, dropping diagnostics")
+		}
+	}
+
+	if validationErr != nil && !errors.As(validationErr, &updateErr) {
+		return validationErr
+	}
+	return nil
 }
 
 func (c *KongClient) maybeSendFallbackConfigDiagnostics(ctx context.Context, generatedCacheMetadata fallback.GeneratedCacheMetadata) error {
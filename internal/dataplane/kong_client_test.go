@@ -1,10 +1,12 @@
 package dataplane
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -25,6 +27,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -147,9 +151,10 @@ var (
 
 // mockGatewayClientsProvider is a mock implementation of dataplane.AdminAPIClientsProvider.
 type mockGatewayClientsProvider struct {
-	gatewayClients []*adminapi.Client
-	konnectClient  *adminapi.KonnectClient
-	dbMode         dpconf.DBMode
+	gatewayClients    []*adminapi.Client
+	konnectClient     *adminapi.KonnectClient
+	dbMode            dpconf.DBMode
+	gatewaysByClients map[string][]k8stypes.NamespacedName
 }
 
 func (p mockGatewayClientsProvider) KonnectClient() *adminapi.KonnectClient {
@@ -170,6 +175,10 @@ func (p mockGatewayClientsProvider) GatewayClientsToConfigure() []*adminapi.Clie
 	return p.gatewayClients[:1]
 }
 
+func (p mockGatewayClientsProvider) GatewaysForClient(client *adminapi.Client) []k8stypes.NamespacedName {
+	return p.gatewaysByClients[client.BaseRootURL()]
+}
+
 // mockUpdateStrategy is a mock implementation of sendconfig.UpdateStrategyResolver.
 type mockUpdateStrategyResolver struct {
 	updateCalledForURLs       []string
@@ -295,6 +304,7 @@ type mockFallbackConfigGenerator struct {
 
 	GenerateExcludingBrokenObjectsCalledWith   lo.Tuple2[store.CacheStores, []fallback.ObjectHash]
 	GenerateBackfillingBrokenObjectsCalledWith lo.Tuple3[store.CacheStores, *store.CacheStores, []fallback.ObjectHash]
+	GenerateExcludingBrokenEntitiesCalledWith  lo.Tuple3[store.CacheStores, []fallback.ObjectHash, []fallback.BrokenEntity]
 }
 
 func newMockFallbackConfigGenerator() *mockFallbackConfigGenerator {
@@ -318,6 +328,19 @@ func (m *mockFallbackConfigGenerator) GenerateBackfillingBrokenObjects(
 	return m.GenerateResult, fallback.GeneratedCacheMetadata{}, nil
 }
 
+// GenerateExcludingBrokenEntities returns all broken entities as excluded ones.
+func (m *mockFallbackConfigGenerator) GenerateExcludingBrokenEntities(
+	stores store.CacheStores,
+	brokenObjects []fallback.ObjectHash,
+	brokenEntities []fallback.BrokenEntity,
+) (store.CacheStores, fallback.GeneratedCacheMetadata, error) {
+	m.GenerateExcludingBrokenEntitiesCalledWith = lo.T3(stores, brokenObjects, brokenEntities)
+	return m.GenerateResult, fallback.GeneratedCacheMetadata{
+		BrokenObjects:    brokenObjects,
+		ExcludedEntities: brokenEntities,
+	}, nil
+}
+
 func TestKongClientUpdate_AllExpectedClientsAreCalledAndErrorIsPropagated(t *testing.T) {
 	var (
 		ctx                = context.Background()
@@ -439,6 +462,54 @@ func TestKongClientUpdate_WhenNoChangeInConfigNoClientGetsCalled(t *testing.T) {
 	updateStrategyResolver.assertNoUpdateCalled()
 }
 
+func TestKongClientUpdate_ConfigDiffIsReportedOnlyWhenConfigChanges(t *testing.T) {
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{mustSampleGatewayClient(t)},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+	configBuilder := newMockKongConfigBuilder()
+	kongClient := setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, &mockKongLastValidConfigFetcher{})
+	configDiffs := make(chan diagnostics.ConfigDiff, 10)
+	kongClient.diagnostic.ConfigDiffs = configDiffs
+
+	ctx := context.Background()
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("service")}}},
+	}
+	require.NoError(t, kongClient.Update(ctx))
+	require.Len(t, configDiffs, 1, "diff should be reported for the first configuration")
+	diff := <-configDiffs
+	require.Len(t, diff.Diff.Changes, 1)
+
+	require.False(t, diff.Failed)
+
+	require.NoError(t, kongClient.Update(ctx))
+	require.Empty(t, configDiffs, "diff should not be reported when the configuration doesn't change")
+
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("other-service")}}},
+	}
+	require.NoError(t, kongClient.Update(ctx))
+	require.Len(t, configDiffs, 1, "diff should be reported when the configuration changes")
+	diff = <-configDiffs
+	require.Len(t, diff.Diff.Changes, 2, "diff should be computed against the previously applied configuration")
+	require.False(t, diff.Failed)
+
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("rejected-service")}}},
+	}
+	updateStrategyResolver.returnErrorOnUpdate(clientsProvider.gatewayClients[0].BaseRootURL())
+	require.Error(t, kongClient.Update(ctx))
+	require.Len(t, configDiffs, 1, "diff should be reported when the configuration is rejected")
+	diff = <-configDiffs
+	require.Equal(t, []kongstate.EntityChange{
+		{EntityType: kongstate.EntityTypeService, ChangeType: kongstate.ChangeTypeDelete, Name: "other-service"},
+		{EntityType: kongstate.EntityTypeService, ChangeType: kongstate.ChangeTypeCreate, Name: "rejected-service"},
+	}, diff.Diff.Changes, "diff should be computed against the last applied configuration")
+	require.True(t, diff.Failed, "diff of a rejected configuration should be marked as failed")
+}
+
 type mockConfigStatusQueue struct {
 	notifications []clients.ConfigStatus
 	lock          sync.RWMutex
@@ -465,6 +536,7 @@ func (m *mockConfigStatusQueue) Notifications() []clients.ConfigStatus {
 type mockKongConfigBuilder struct {
 	translationFailuresToReturn []failures.ResourceFailure
 	kongState                   *kongstate.KongState
+	configuredObjects           []client.Object
 	updateCacheCalls            []store.CacheStores
 
 	// onlyFirstCallWithNoTranslationFailures is used to simulate a scenario where the first call to the
@@ -489,8 +561,9 @@ func (p *mockKongConfigBuilder) BuildKongConfig() translator.KongConfigBuildingR
 		}
 	}
 	return translator.KongConfigBuildingResult{
-		KongState:           p.kongState,
-		TranslationFailures: p.translationFailuresToReturn,
+		KongState:                   p.kongState,
+		TranslationFailures:         p.translationFailuresToReturn,
+		ConfiguredKubernetesObjects: p.configuredObjects,
 	}
 }
 
@@ -972,8 +1045,9 @@ func mapClientsToUrls(clients mockGatewayClientsProvider) []string {
 }
 
 type mockKongLastValidConfigFetcher struct {
-	kongRawState  *utils.KongRawState
-	lastKongState *kongstate.KongState
+	kongRawState       *utils.KongRawState
+	lastKongState      *kongstate.KongState
+	fetchedFromClients []*adminapi.Client
 }
 
 func (cf *mockKongLastValidConfigFetcher) LastValidConfig() (*kongstate.KongState, bool) {
@@ -987,7 +1061,8 @@ func (cf *mockKongLastValidConfigFetcher) StoreLastValidConfig(s *kongstate.Kong
 	cf.lastKongState = s
 }
 
-func (cf *mockKongLastValidConfigFetcher) TryFetchingValidConfigFromGateways(context.Context, logr.Logger, []*adminapi.Client) error {
+func (cf *mockKongLastValidConfigFetcher) TryFetchingValidConfigFromGateways(_ context.Context, _ logr.Logger, clients []*adminapi.Client) error {
+	cf.fetchedFromClients = clients
 	if cf.kongRawState != nil {
 		cf.lastKongState = configfetcher.KongRawStateToKongState(cf.kongRawState)
 	}
@@ -1161,6 +1236,111 @@ func TestKongClientUpdate_KonnectUpdatesAreSanitized(t *testing.T) {
 	require.Equal(t, "{vault://redacted-value}", *cert.Key, "expected Konnect to have redacted certificate key")
 }
 
+func TestKongClientUpdate_ManagedGatewaysGetTheirPartitions(t *testing.T) {
+	ctx := context.Background()
+	gateway := k8stypes.NamespacedName{Namespace: "default", Name: "gw"}
+	sharedClient := mustSampleGatewayClient(t)
+	gatewayClient := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients:    []*adminapi.Client{sharedClient, gatewayClient},
+		gatewaysByClients: map[string][]k8stypes.NamespacedName{gatewayClient.BaseRootURL(): {gateway}},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+	configBuilder := newMockKongConfigBuilder()
+	configBuilder.kongState = &kongstate.KongState{
+		DedicatedGateways: []k8stypes.NamespacedName{gateway},
+		Services: []kongstate.Service{
+			{
+				Service: kong.Service{Name: kong.String("gateway-service"), Host: kong.String("gateway-service")},
+				Routes: []kongstate.Route{
+					{
+						Route:          kong.Route{Name: kong.String("gateway-route")},
+						ParentGateways: []k8stypes.NamespacedName{gateway},
+					},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("ingress-service"), Host: kong.String("ingress-service")},
+				Routes: []kongstate.Route{
+					{Route: kong.Route{Name: kong.String("ingress-route")}},
+				},
+			},
+		},
+	}
+
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		clientsProvider,
+		configChangeDetector,
+		configBuilder,
+		nil,
+		&mockKongLastValidConfigFetcher{},
+	)
+	require.NoError(t, kongClient.Update(ctx))
+
+	sharedContent, ok := updateStrategyResolver.lastUpdatedContentForURL(sharedClient.BaseRootURL())
+	require.True(t, ok, "expected shared gateway to be updated")
+	require.Len(t, sharedContent.Content.Services, 1, "expected shared gateway not to get managed gateway's services")
+	require.Equal(t, "ingress-service", *sharedContent.Content.Services[0].Name)
+
+	gatewayContent, ok := updateStrategyResolver.lastUpdatedContentForURL(gatewayClient.BaseRootURL())
+	require.True(t, ok, "expected managed gateway to be updated")
+	require.Len(t, gatewayContent.Content.Services, 1, "expected managed gateway to get only its own services")
+	require.Equal(t, "gateway-service", *gatewayContent.Content.Services[0].Name)
+}
+
+func TestKongClientUpdate_LastValidConfigFetchedFromSharedGatewaysIsNotPushedToManagedGateways(t *testing.T) {
+	ctx := context.Background()
+	gateway := k8stypes.NamespacedName{Namespace: "default", Name: "gw"}
+	sharedClient := mustSampleGatewayClient(t)
+	gatewayClient := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients:    []*adminapi.Client{sharedClient, gatewayClient},
+		gatewaysByClients: map[string][]k8stypes.NamespacedName{gatewayClient.BaseRootURL(): {gateway}},
+	}
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	updateStrategyResolver.returnErrorOnUpdate(sharedClient.BaseRootURL())
+	configChangeDetector := mockConfigurationChangeDetector{hasConfigurationChanged: true}
+	configChangeDetector.status.ConfigurationHash = "xyz"
+	configBuilder := newMockKongConfigBuilder()
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{
+				Service: kong.Service{Name: kong.String("new-service")},
+				Routes:  []kongstate.Route{{Route: kong.Route{Name: kong.String("new-route")}, ParentGateways: []k8stypes.NamespacedName{gateway}}},
+			},
+		},
+	}
+	lastValidConfigFetcher := &mockKongLastValidConfigFetcher{
+		kongRawState: &utils.KongRawState{
+			Services: []*kong.Service{{Name: kong.String("last-service"), ID: kong.String("abc")}},
+			Routes:   []*kong.Route{{Name: kong.String("last-route"), Service: &kong.Service{ID: kong.String("abc")}}},
+		},
+	}
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		clientsProvider,
+		configChangeDetector,
+		configBuilder,
+		nil,
+		lastValidConfigFetcher,
+	)
+	require.Error(t, kongClient.Update(ctx))
+
+	require.Equal(t, []*adminapi.Client{sharedClient}, lastValidConfigFetcher.fetchedFromClients,
+		"last valid config should be fetched from shared gateways only")
+	updateStrategyResolver.assertUpdateCalledForURLs(
+		[]string{sharedClient.BaseRootURL(), gatewayClient.BaseRootURL(), sharedClient.BaseRootURL()},
+		"fetched last valid config should be pushed to the shared gateway only",
+	)
+	gatewayContent, ok := updateStrategyResolver.lastUpdatedContentForURL(gatewayClient.BaseRootURL())
+	require.True(t, ok)
+	require.Equal(t, "new-service", *gatewayContent.Content.Services[0].Name)
+}
+
 func TestKongClient_FallbackConfiguration_SuccessfulRecovery(t *testing.T) {
 	ctx := context.Background()
 	gwClient := mustSampleGatewayClient(t)
@@ -1333,6 +1513,191 @@ func TestKongClient_FallbackConfiguration_SuccessfulRecovery(t *testing.T) {
 	require.True(t, dump.Meta.Fallback)
 }
 
+func TestKongClient_FallbackConfiguration_MoreBrokenObjectsInDBMode(t *testing.T) {
+	ctx := context.Background()
+	someConsumer := func(name string) *kongv1.KongConsumer {
+		return &kongv1.KongConsumer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "namespace",
+				Annotations: map[string]string{
+					annotations.IngressClassKey: annotations.DefaultIngressClass,
+				},
+			},
+			Username: name,
+		}
+	}
+	validConsumer := someConsumer("valid")
+	brokenConsumer := someConsumer("broken")
+	anotherBrokenConsumer := someConsumer("another-broken")
+	updateErrorFor := func(obj client.Object) error {
+		return sendconfig.NewUpdateError(
+			[]failures.ResourceFailure{
+				lo.Must(failures.NewResourceFailure("violated constraint", obj)),
+			},
+			errors.New("error on update"),
+		)
+	}
+
+	testCases := []struct {
+		name                        string
+		dbMode                      dpconf.DBMode
+		expectedUpdates             int
+		expectedLastBrokenObjects   []*kongv1.KongConsumer
+		expectFallbackSyncSucceeded bool
+	}{
+		{
+			name:                        "DB mode retries fallback excluding more broken objects",
+			dbMode:                      dpconf.DBModePostgres,
+			expectedUpdates:             3,
+			expectedLastBrokenObjects:   []*kongv1.KongConsumer{brokenConsumer, anotherBrokenConsumer},
+			expectFallbackSyncSucceeded: true,
+		},
+		{
+			name:                      "DB-less mode doesn't retry fallback as all broken objects are reported at once",
+			dbMode:                    dpconf.DBModeOff,
+			expectedUpdates:           2,
+			expectedLastBrokenObjects: []*kongv1.KongConsumer{brokenConsumer},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gwClient := mustSampleGatewayClient(t)
+			updateStrategyResolver := newMockUpdateStrategyResolver(t)
+			configBuilder := newMockKongConfigBuilder()
+			fallbackConfigGenerator := newMockFallbackConfigGenerator()
+			fallbackConfigGenerator.GenerateResult = cacheStoresFromObjs(t, validConsumer)
+			lastValidConfigFetcher := &mockKongLastValidConfigFetcher{}
+			kongClient, err := NewKongClient(
+				zapr.NewLogger(zap.NewNop()),
+				time.Second,
+				diagnostics.ConfigDumpDiagnostic{},
+				sendconfig.Config{
+					FallbackConfiguration: true,
+				},
+				mocks.NewEventRecorder(),
+				tc.dbMode,
+				mockGatewayClientsProvider{gatewayClients: []*adminapi.Client{gwClient}},
+				updateStrategyResolver,
+				mockConfigurationChangeDetector{hasConfigurationChanged: true},
+				lastValidConfigFetcher,
+				configBuilder,
+				cacheStoresFromObjs(t, validConsumer, brokenConsumer, anotherBrokenConsumer),
+				fallbackConfigGenerator,
+			)
+			require.NoError(t, err)
+
+			t.Log("Rejecting the configuration because of one consumer and the fallback configuration because of another one")
+			updateStrategyResolver.returnSpecificErrorOnUpdate(gwClient.BaseRootURL(), updateErrorFor(brokenConsumer))
+			updateStrategyResolver.returnSpecificErrorOnUpdate(gwClient.BaseRootURL(), updateErrorFor(anotherBrokenConsumer))
+
+			require.Error(t, kongClient.Update(ctx))
+			updateStrategyResolver.assertUpdateCalledForURLs(lo.Times(tc.expectedUpdates, func(int) string {
+				return gwClient.BaseRootURL()
+			}))
+			expectedLastBrokenObjects := lo.Map(tc.expectedLastBrokenObjects, func(c *kongv1.KongConsumer, _ int) fallback.ObjectHash {
+				return fallback.GetObjectHash(c)
+			})
+			require.Equal(t, expectedLastBrokenObjects, fallbackConfigGenerator.GenerateExcludingBrokenObjectsCalledWith.B)
+			_, fallbackSynced := lastValidConfigFetcher.LastValidConfig()
+			require.Equal(t, tc.expectFallbackSyncSucceeded, fallbackSynced)
+		})
+	}
+}
+
+func TestKongClient_FallbackConfiguration_EntityLevelExclusion(t *testing.T) {
+	ctx := context.Background()
+	plugin := &kongv1.KongPlugin{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KongPlugin",
+			APIVersion: kongv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rate-limiting",
+			Namespace: "namespace",
+			UID:       "plugin-uid",
+		},
+		PluginName: "rate-limiting",
+	}
+	brokenPluginInstance := sendconfig.EntityFailure{
+		Type:          "plugin",
+		Name:          "rate-limiting",
+		ForeignKeys:   map[string]string{"route": "broken-route-id"},
+		CausingObject: plugin,
+	}
+	pluginOnRoute := func(route string) kongstate.Plugin {
+		return kongstate.Plugin{
+			Plugin:    kong.Plugin{Name: kong.String("rate-limiting"), Route: &kong.Route{ID: kong.String(route)}},
+			K8sParent: plugin,
+		}
+	}
+
+	gwClient := mustSampleGatewayClient(t)
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configBuilder := newMockKongConfigBuilder()
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{
+				Service: kong.Service{Name: kong.String("service")},
+				Routes: []kongstate.Route{
+					{Route: kong.Route{Name: kong.String("broken-route"), ID: kong.String("broken-route-id")}},
+					{Route: kong.Route{Name: kong.String("valid-route"), ID: kong.String("valid-route-id")}},
+				},
+			},
+		},
+		Plugins: []kongstate.Plugin{pluginOnRoute("broken-route"), pluginOnRoute("valid-route")},
+	}
+	fallbackConfigGenerator := newMockFallbackConfigGenerator()
+	fallbackConfigGenerator.GenerateResult = cacheStoresFromObjs(t, plugin)
+	lastValidConfigFetcher := &mockKongLastValidConfigFetcher{}
+	kongClient, err := NewKongClient(
+		zapr.NewLogger(zap.NewNop()),
+		time.Second,
+		diagnostics.ConfigDumpDiagnostic{},
+		sendconfig.Config{
+			FallbackConfiguration:              true,
+			UseEntityLevelExclusionForFallback: true,
+		},
+		mocks.NewEventRecorder(),
+		dpconf.DBModeOff,
+		mockGatewayClientsProvider{gatewayClients: []*adminapi.Client{gwClient}},
+		updateStrategyResolver,
+		mockConfigurationChangeDetector{hasConfigurationChanged: true},
+		lastValidConfigFetcher,
+		configBuilder,
+		cacheStoresFromObjs(t, plugin),
+		fallbackConfigGenerator,
+	)
+	require.NoError(t, err)
+
+	t.Log("Rejecting the configuration because of the plugin instance on the broken route")
+	updateStrategyResolver.returnSpecificErrorOnUpdate(gwClient.BaseRootURL(), sendconfig.NewUpdateError(
+		[]failures.ResourceFailure{
+			lo.Must(failures.NewResourceFailure("invalid config.minute: expected a number", plugin)),
+		},
+		errors.New("error on update"),
+	).WithEntityFailures([]sendconfig.EntityFailure{brokenPluginInstance}))
+
+	require.Error(t, kongClient.Update(ctx))
+
+	t.Log("Verifying that the fallback config generator was called with the broken plugin instance")
+	require.Empty(t, fallbackConfigGenerator.GenerateExcludingBrokenObjectsCalledWith)
+	require.Equal(t, []fallback.ObjectHash{fallback.GetObjectHash(plugin)}, fallbackConfigGenerator.GenerateExcludingBrokenEntitiesCalledWith.B)
+	require.Equal(t, []fallback.BrokenEntity{
+		{
+			Type:          "plugin",
+			Name:          "rate-limiting",
+			ForeignKeys:   map[string]string{"route": "broken-route-id"},
+			CausingObject: fallback.GetObjectHash(plugin),
+		},
+	}, fallbackConfigGenerator.GenerateExcludingBrokenEntitiesCalledWith.C)
+
+	t.Log("Verifying that only the broken plugin instance was excluded from the fallback configuration")
+	lastValidConfig, ok := lastValidConfigFetcher.LastValidConfig()
+	require.True(t, ok)
+	require.Equal(t, []kongstate.Plugin{pluginOnRoute("valid-route")}, lastValidConfig.Plugins)
+}
+
 func TestKongClient_FallbackConfiguration_SkipMakingRedundantSnapshot(t *testing.T) {
 	ctx := context.Background()
 	gwClient := mustSampleGatewayClient(t)
@@ -1398,6 +1763,38 @@ func TestKongClient_FallbackConfiguration_SkipMakingRedundantSnapshot(t *testing
 	require.Len(t, configBuilder.updateCacheCalls, 1)
 }
 
+func TestKongClient_UpdatesTranslatorCacheWithoutFallbackConfiguration(t *testing.T) {
+	ctx := context.Background()
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{mustSampleGatewayClient(t)},
+	}
+	configBuilder := newMockKongConfigBuilder()
+	cache := store.NewCacheStores()
+	kongClient, err := NewKongClient(
+		zapr.NewLogger(zap.NewNop()),
+		time.Second,
+		diagnostics.ConfigDumpDiagnostic{},
+		sendconfig.Config{},
+		mocks.NewEventRecorder(),
+		dpconf.DBModeOff,
+		clientsProvider,
+		newMockUpdateStrategyResolver(t),
+		mockConfigurationChangeDetector{hasConfigurationChanged: true},
+		&mockKongLastValidConfigFetcher{},
+		configBuilder,
+		cache,
+		newMockFallbackConfigGenerator(),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, kongClient.Update(ctx))
+	require.NoError(t, kongClient.Update(ctx))
+
+	t.Log("Verifying that the config builder cache was updated with the cache on every update, so that changes in it can be detected")
+	require.Len(t, configBuilder.updateCacheCalls, 2)
+	require.Equal(t, cache, configBuilder.updateCacheCalls[1])
+}
+
 func TestKongClient_FallbackConfiguration_FailedRecovery(t *testing.T) {
 	ctx := context.Background()
 	gwClient := mustSampleGatewayClient(t)
@@ -1654,3 +2051,422 @@ func TestKongClient_ConfigDumpSanitization(t *testing.T) {
 		})
 	}
 }
+
+func TestKongClientUpdate_DryRun(t *testing.T) {
+	ctx := context.Background()
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{mustSampleGatewayClient(t), mustSampleGatewayClient(t)},
+	}
+	gatewayURL := clientsProvider.gatewayClients[0].BaseRootURL()
+	currentKongRawState := &utils.KongRawState{
+		Services: []*kong.Service{{Name: kong.String("current_service"), ID: kong.String("abc")}},
+	}
+	brokenPlugin := &kongv1.KongPlugin{
+		TypeMeta:   metav1.TypeMeta{Kind: "KongPlugin", APIVersion: kongv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin", Namespace: "default"},
+	}
+	validationFailure, err := failures.NewResourceFailure("invalid plugin:rate-limiting: schema violation", brokenPlugin)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                       string
+		validationErr              error
+		expectedErr                bool
+		expectedValidationFailures []failures.ResourceFailure
+	}{
+		{
+			name: "configuration passes validation",
+		},
+		{
+			name:                       "configuration fails validation",
+			validationErr:              sendconfig.NewUpdateError([]failures.ResourceFailure{validationFailure}, errors.New("1 entities failed validation")),
+			expectedValidationFailures: []failures.ResourceFailure{validationFailure},
+		},
+		{
+			name:          "configuration can't be validated",
+			validationErr: errors.New("connection refused"),
+			expectedErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			updateStrategyResolver := newMockUpdateStrategyResolver(t)
+			if tc.validationErr != nil {
+				updateStrategyResolver.returnSpecificErrorOnUpdate(gatewayURL, tc.validationErr)
+			}
+			configBuilder := newMockKongConfigBuilder()
+			configBuilder.returnTranslationFailures(true)
+			configFetcher := &mockKongLastValidConfigFetcher{kongRawState: currentKongRawState}
+
+			kongClient := setupTestKongClient(
+				t,
+				updateStrategyResolver,
+				clientsProvider,
+				mockConfigurationChangeDetector{hasConfigurationChanged: true},
+				configBuilder,
+				nil,
+				configFetcher,
+			)
+			kongClient.kongConfig.DryRun = true
+			dryRunResults := make(chan diagnostics.DryRunResult, 1)
+			kongClient.diagnostic = diagnostics.ConfigDumpDiagnostic{DryRunResults: dryRunResults}
+
+			err := kongClient.Update(ctx)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			updateStrategyResolver.assertUpdateCalledForURLs([]string{gatewayURL})
+
+			lastValidConfig, ok := kongClient.kongConfigFetcher.LastValidConfig()
+			require.True(t, ok)
+			require.Equal(t, configfetcher.KongRawStateToKongState(currentKongRawState), lastValidConfig,
+				"configuration fetched from gateways should not be replaced in dry run mode")
+
+			select {
+			case result := <-dryRunResults:
+				require.Equal(t, configBuilder.translationFailuresToReturn, result.TranslationFailures)
+				require.Equal(t, tc.expectedValidationFailures, result.ValidationFailures)
+				if tc.validationErr != nil {
+					require.ErrorContains(t, result.ValidationErr, tc.validationErr.Error())
+				} else {
+					require.NoError(t, result.ValidationErr)
+				}
+			default:
+				require.Fail(t, "expected a dry run result to be sent to diagnostics")
+			}
+
+			// Like in DB-less mode, configuration is fetched from gateways only when there's none stored.
+			configFetcher.kongRawState = &utils.KongRawState{}
+			_ = kongClient.Update(ctx)
+			lastValidConfig, ok = kongClient.kongConfigFetcher.LastValidConfig()
+			require.True(t, ok)
+			require.Equal(t, configfetcher.KongRawStateToKongState(currentKongRawState), lastValidConfig,
+				"configuration should not be fetched from gateways again")
+		})
+	}
+}
+
+func TestKongClientUpdate_StagedRollout(t *testing.T) {
+	ctx := context.Background()
+	canary, rest := mustSampleGatewayClient(t), mustSampleGatewayClient(t)
+	// Canaries are chosen by their URLs.
+	if canary.BaseRootURL() > rest.BaseRootURL() {
+		canary, rest = rest, canary
+	}
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{canary, rest},
+	}
+	lastKongRawState := &utils.KongRawState{
+		Services: []*kong.Service{{Name: kong.String("last_service"), ID: kong.String("abc")}},
+	}
+	configBuilder := newMockKongConfigBuilder()
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("new_service")}}},
+	}
+
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	updateStrategyResolver.returnErrorOnUpdate(canary.BaseRootURL())
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		clientsProvider,
+		mockConfigurationChangeDetector{hasConfigurationChanged: true},
+		configBuilder,
+		nil,
+		&mockKongLastValidConfigFetcher{kongRawState: lastKongRawState},
+	)
+	kongClient.kongConfig.StagedRollout = sendconfig.StagedRolloutConfig{
+		Canaries:   intstr.FromInt32(1),
+		SoakPeriod: time.Millisecond,
+	}
+
+	require.Error(t, kongClient.Update(ctx))
+
+	updateStrategyResolver.lock.RLock()
+	defer updateStrategyResolver.lock.RUnlock()
+	require.Equal(t, canary.BaseRootURL(), updateStrategyResolver.updateCalledForURLs[0],
+		"canary should get the new configuration first")
+	require.Equal(t, canary.BaseRootURL(), updateStrategyResolver.updateCalledForURLs[1],
+		"canary should be rolled back right after it failed")
+	require.Equal(t, 1, lo.Count(updateStrategyResolver.updateCalledForURLs, rest.BaseRootURL()),
+		"the rest of gateways should only get the last valid configuration")
+	for _, url := range []string{canary.BaseRootURL(), rest.BaseRootURL()} {
+		content := updateStrategyResolver.lastUpdatedContentForURLs[url]
+		require.Len(t, content.Content.Services, 1)
+		require.Equal(t, "last_service", *content.Content.Services[0].Name)
+	}
+}
+
+func TestKongClientUpdate_StagedRolloutInProgress(t *testing.T) {
+	ctx := context.Background()
+	statusServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"configuration_hash":"8f2e8ee8bd6e3e3c6e3a2f4f15f6d1a7","server":{"total_requests":1}}`))
+	}))
+	t.Cleanup(statusServer.Close)
+	canary, err := adminapi.NewTestClient(statusServer.URL)
+	require.NoError(t, err)
+	// Canaries are chosen by their URLs, "http://127.0.0.1:<port>" of the canary goes before "https://<uuid>:8080".
+	rest := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{canary, rest},
+	}
+	configBuilder := newMockKongConfigBuilder()
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("new_service")}}},
+	}
+
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configFetcher := &mockKongLastValidConfigFetcher{}
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		clientsProvider,
+		mockConfigurationChangeDetector{hasConfigurationChanged: true},
+		configBuilder,
+		nil,
+		configFetcher,
+	)
+	kongClient.kongConfig.StagedRollout = sendconfig.StagedRolloutConfig{
+		Canaries:   intstr.FromInt32(1),
+		SoakPeriod: time.Hour,
+	}
+
+	for range 2 {
+		require.NoError(t, kongClient.Update(ctx), "update should not wait for the soak period")
+	}
+
+	updateStrategyResolver.lock.RLock()
+	defer updateStrategyResolver.lock.RUnlock()
+	require.Equal(t, []string{canary.BaseRootURL()}, updateStrategyResolver.updateCalledForURLs,
+		"only the canary should get the configuration, once, while it's observed")
+	_, found := configFetcher.LastValidConfig()
+	require.False(t, found, "configuration should not be stored as the last valid one before it's rolled out")
+}
+
+type mockLastValidConfigPersister struct {
+	persisted *configfetcher.PersistedConfig
+	loadCalls int
+}
+
+func (p *mockLastValidConfigPersister) Persist(_ context.Context, config configfetcher.PersistedConfig) error {
+	p.persisted = &config
+	return nil
+}
+
+func (p *mockLastValidConfigPersister) Load(context.Context) (configfetcher.PersistedConfig, bool, error) {
+	p.loadCalls++
+	if p.persisted == nil {
+		return configfetcher.PersistedConfig{}, false, nil
+	}
+	return *p.persisted, true, nil
+}
+
+func TestKongClientUpdate_PersistedLastValidConfig(t *testing.T) {
+	ctx := context.Background()
+	gatewayClient := mustSampleGatewayClient(t)
+	clientsProvider := mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{gatewayClient},
+	}
+	configBuilder := newMockKongConfigBuilder()
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("new_service")}}},
+	}
+	persister := &mockLastValidConfigPersister{
+		persisted: &configfetcher.PersistedConfig{
+			KongState: &kongstate.KongState{
+				Services: []kongstate.Service{{Service: kong.Service{Name: kong.String("persisted_service")}}},
+			},
+		},
+	}
+
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configFetcher := &mockKongLastValidConfigFetcher{
+		kongRawState: &utils.KongRawState{
+			Services: []*kong.Service{{Name: kong.String("fetched_service"), ID: kong.String("abc")}},
+		},
+	}
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		clientsProvider,
+		mockConfigurationChangeDetector{hasConfigurationChanged: true},
+		configBuilder,
+		nil,
+		configFetcher,
+	)
+	kongClient.SetLastValidConfigPersister(persister)
+
+	t.Log("Rejecting configuration so that the persisted last valid configuration is pushed instead")
+	updateStrategyResolver.returnErrorOnUpdate(gatewayClient.BaseRootURL())
+	require.Error(t, kongClient.Update(ctx))
+	lastValidConfig, found := configFetcher.LastValidConfig()
+	require.True(t, found)
+	require.Equal(t, "persisted_service", *lastValidConfig.Services[0].Name,
+		"persisted configuration should be preferred over the one fetched from gateways")
+	content, ok := updateStrategyResolver.lastUpdatedContentForURL(gatewayClient.BaseRootURL())
+	require.True(t, ok)
+	require.Len(t, content.Content.Services, 1)
+	require.Equal(t, "persisted_service", *content.Content.Services[0].Name)
+
+	t.Log("Accepting configuration so that it's persisted as the last valid one")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, "new_service", *persister.persisted.KongState.Services[0].Name)
+	require.Equal(t, 1, persister.loadCalls, "persisted configuration should be loaded only once")
+}
+
+func TestKongClientUpdate_ConfigHistory(t *testing.T) {
+	ctx := context.Background()
+	gatewayClient := mustSampleGatewayClient(t)
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	configBuilder := newMockKongConfigBuilder()
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		mockGatewayClientsProvider{gatewayClients: []*adminapi.Client{gatewayClient}},
+		mockConfigurationChangeDetector{hasConfigurationChanged: true},
+		configBuilder,
+		nil,
+		&mockKongLastValidConfigFetcher{},
+	)
+	history := diagnostics.NewConfigHistory(diagnostics.DefaultConfigHistorySize)
+	kongClient.diagnostic.ConfigHistory = history
+
+	ingress := &netv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default", ResourceVersion: "1"},
+	}
+	withService := func(name string) *kongstate.KongState {
+		return &kongstate.KongState{
+			Services: []kongstate.Service{{Service: kong.Service{Name: kong.String(name)}}},
+		}
+	}
+	pushedServiceName := func() string {
+		content, ok := updateStrategyResolver.lastUpdatedContentForURL(gatewayClient.BaseRootURL())
+		require.True(t, ok)
+		require.Len(t, content.Content.Services, 1)
+		return *content.Content.Services[0].Name
+	}
+
+	t.Log("Pushing the first configuration")
+	configBuilder.kongState = withService("first")
+	configBuilder.configuredObjects = []client.Object{ingress}
+	require.NoError(t, kongClient.Update(ctx))
+	entries := history.Entries()
+	require.Len(t, entries, 1)
+	require.False(t, entries[0].Fallback)
+	require.Equal(t, []diagnostics.ObjectChange{
+		{Kind: "Ingress", Namespace: "default", Name: "ingress", ChangeType: diagnostics.ObjectChangeTypeCreate},
+	}, entries[0].ObjectChanges)
+	firstID := entries[0].ID
+
+	t.Log("Pushing the second configuration after the Ingress changed")
+	updatedIngress := ingress.DeepCopy()
+	updatedIngress.ResourceVersion = "2"
+	configBuilder.kongState = withService("second")
+	configBuilder.configuredObjects = []client.Object{updatedIngress}
+	require.NoError(t, kongClient.Update(ctx))
+	entries = history.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, []diagnostics.ObjectChange{
+		{Kind: "Ingress", Namespace: "default", Name: "ingress", ChangeType: diagnostics.ObjectChangeTypeUpdate},
+	}, entries[0].ObjectChanges)
+
+	t.Log("Pushing unchanged configuration doesn't record it again")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Len(t, history.Entries(), 2)
+
+	t.Log("Pinning the first configuration")
+	require.NoError(t, history.Pin(firstID))
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, "first", pushedServiceName())
+	require.Len(t, history.Entries(), 2, "pushing pinned configuration shouldn't record it again")
+
+	t.Log("Releasing the pin")
+	history.Unpin()
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, "second", pushedServiceName())
+}
+
+// mockConfigDriftDetector is a mock implementation of configfetcher.ConfigDriftDetector.
+type mockConfigDriftDetector struct {
+	recordedPushesForURLs []string
+	driftedURLs           map[string]bool
+	lock                  sync.Mutex
+}
+
+func (d *mockConfigDriftDetector) RecordPushed(_ context.Context, client sendconfig.AdminAPIClient) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.recordedPushesForURLs = append(d.recordedPushesForURLs, client.BaseRootURL())
+	return nil
+}
+
+func (d *mockConfigDriftDetector) DetectDrift(_ context.Context, client sendconfig.AdminAPIClient) (configfetcher.ConfigDrift, bool, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if !d.driftedURLs[client.BaseRootURL()] {
+		return configfetcher.ConfigDrift{}, false, nil
+	}
+	delete(d.driftedURLs, client.BaseRootURL())
+	return configfetcher.ConfigDrift{URL: client.BaseRootURL(), ExpectedHash: "expected", ActualHash: "actual"}, true, nil
+}
+
+// shaComparingConfigurationChangeDetector reports configuration changes based on SHAs only.
+type shaComparingConfigurationChangeDetector struct{}
+
+func (shaComparingConfigurationChangeDetector) HasConfigurationChanged(
+	_ context.Context, oldSHA, newSHA []byte, _ *file.Content, _ sendconfig.KonnectAwareClient, _ sendconfig.StatusClient,
+) (bool, error) {
+	return !bytes.Equal(oldSHA, newSHA), nil
+}
+
+func TestKongClient_ConfigDriftDetection(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "test-namespace")
+	t.Setenv("POD_NAME", "test-pod")
+
+	ctx := context.Background()
+	driftedClient := mustSampleGatewayClient(t)
+	intactClient := mustSampleGatewayClient(t)
+	updateStrategyResolver := newMockUpdateStrategyResolver(t)
+	eventRecorder := mocks.NewEventRecorder()
+	kongClient := setupTestKongClient(
+		t,
+		updateStrategyResolver,
+		mockGatewayClientsProvider{gatewayClients: []*adminapi.Client{driftedClient, intactClient}},
+		shaComparingConfigurationChangeDetector{},
+		newMockKongConfigBuilder(),
+		eventRecorder,
+		&mockKongLastValidConfigFetcher{},
+	)
+	kongClient.kongConfig.FallbackConfiguration = true
+	detector := &mockConfigDriftDetector{driftedURLs: map[string]bool{}}
+	kongClient.SetConfigDriftDetector(detector)
+
+	t.Log("Pushing configuration to both gateways and recording it")
+	require.NoError(t, kongClient.Update(ctx))
+	updateStrategyResolver.assertUpdateCalledForURLs([]string{driftedClient.BaseRootURL(), intactClient.BaseRootURL()})
+	require.ElementsMatch(t, []string{driftedClient.BaseRootURL(), intactClient.BaseRootURL()}, detector.recordedPushesForURLs)
+
+	t.Log("Detecting no drift doesn't cause another push")
+	require.NoError(t, kongClient.DetectConfigDrift(ctx))
+	require.NoError(t, kongClient.Update(ctx))
+	updateStrategyResolver.assertUpdateCalledForURLs([]string{driftedClient.BaseRootURL(), intactClient.BaseRootURL()})
+
+	t.Log("Detecting a drift of one gateway causes pushing configuration only to it")
+	detector.driftedURLs[driftedClient.BaseRootURL()] = true
+	require.NoError(t, kongClient.DetectConfigDrift(ctx))
+	require.Nil(t, driftedClient.LastConfigSHA())
+	require.NotNil(t, intactClient.LastConfigSHA())
+	require.True(t, lo.ContainsBy(eventRecorder.Events(), func(e string) bool {
+		return strings.Contains(e, ConfigDriftEventReason) && strings.Contains(e, driftedClient.BaseRootURL())
+	}), "expected drift event not found in %v", eventRecorder.Events())
+
+	require.NoError(t, kongClient.Update(ctx))
+	updateStrategyResolver.assertUpdateCalledForURLs([]string{
+		driftedClient.BaseRootURL(), intactClient.BaseRootURL(), driftedClient.BaseRootURL(),
+	})
+	require.Len(t, detector.recordedPushesForURLs, 3, "configuration pushed again should be recorded")
+}
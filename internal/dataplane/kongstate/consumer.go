@@ -24,6 +24,8 @@ type Consumer struct {
 	Oauth2Creds []*Oauth2Credential
 	MTLSAuths   []*MTLSAuth
 
+	SchemaBasedCredentials []*SchemaBasedCredential
+
 	K8sKongConsumer kongv1.KongConsumer
 }
 
@@ -62,8 +64,14 @@ func (c *Consumer) SanitizedCopy(uuidGenerator util.UUIDGenerator) *Consumer {
 			}
 			return
 		}(),
-		ACLGroups:       c.ACLGroups,
-		MTLSAuths:       c.MTLSAuths,
+		ACLGroups: c.ACLGroups,
+		MTLSAuths: c.MTLSAuths,
+		SchemaBasedCredentials: func() (res []*SchemaBasedCredential) {
+			for _, v := range c.SchemaBasedCredentials {
+				res = append(res, v.SanitizedCopy())
+			}
+			return
+		}(),
 		K8sKongConsumer: c.K8sKongConsumer,
 	}
 }
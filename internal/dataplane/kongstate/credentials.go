@@ -1,9 +1,13 @@
 package kongstate
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/kong/go-kong/kong/custom"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
@@ -57,6 +61,14 @@ type MTLSAuth struct {
 	kong.MTLSAuth
 }
 
+// SchemaBasedCredential represents a credential of a type whose fields are unknown to the controller
+// (e.g. one provided by a plugin). It's sent to Kong as a generic entity of its EntityType.
+type SchemaBasedCredential struct {
+	custom.Object
+	// EntityType is the type of the Kong entity of the credential.
+	EntityType string
+}
+
 func NewKeyAuth(config interface{}) (*KeyAuth, error) {
 	var res KeyAuth
 	err := decodeCredential(config, &res.KeyAuth)
@@ -233,6 +245,63 @@ func (c *Oauth2Credential) SanitizedCopy() *Oauth2Credential {
 	}
 }
 
+// NewSchemaBasedCredential creates a credential of the entity type from data of a credential Secret.
+// Values are converted to types of the respective fields in the schema of the entity type.
+func NewSchemaBasedCredential(entityType string, schema EntitySchema, data map[string][]byte) (*SchemaBasedCredential, error) {
+	obj := custom.Object{}
+	for k, v := range data {
+		field, ok := schema.Fields[k]
+		if !ok {
+			// Leave reporting unknown fields to Kong.
+			obj[k] = string(v)
+			continue
+		}
+		value, err := parseCredentialFieldValue(field, string(v))
+		if err != nil {
+			return nil, fmt.Errorf("%s is invalid: field %s: %w", entityType, k, err)
+		}
+		obj[k] = value
+	}
+	return &SchemaBasedCredential{Object: obj, EntityType: entityType}, nil
+}
+
+func parseCredentialFieldValue(field EntityField, value string) (any, error) {
+	switch field.Type {
+	case EntityFieldTypeBoolean:
+		return strconv.ParseBool(value)
+	case EntityFieldTypeInteger:
+		return strconv.Atoi(value)
+	case EntityFieldTypeNumber:
+		return strconv.ParseFloat(value, 64)
+	case EntityFieldTypeArray, EntityFieldTypeSet:
+		return strings.Split(value, ","), nil
+	case EntityFieldTypeMap, EntityFieldTypeRecord, EntityFieldTypeJSON:
+		var parsed any
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, err
+		}
+		return parsed, nil
+	case EntityFieldTypeForeign:
+		return nil, fmt.Errorf("fields referring to other entities can't be set")
+	default:
+		return value, nil
+	}
+}
+
+// SanitizedCopy returns a shallow copy with sensitive values redacted best-effort. As it's unknown
+// which fields are sensitive, all string values are redacted.
+func (c *SchemaBasedCredential) SanitizedCopy() *SchemaBasedCredential {
+	obj := make(custom.Object, len(c.Object))
+	for k, v := range c.Object {
+		if _, ok := v.(string); ok && k != "id" {
+			obj[k] = *redactedString
+			continue
+		}
+		obj[k] = v
+	}
+	return &SchemaBasedCredential{Object: obj, EntityType: c.EntityType}
+}
+
 func decodeCredential(credConfig interface{},
 	credStructPointer interface{},
 ) error {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kong/go-kong/kong"
 	"github.com/kong/go-kong/kong/custom"
+	"github.com/samber/lo"
 
 	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
 )
@@ -138,6 +139,43 @@ type SchemaGetter interface {
 	Get(ctx context.Context, entityType string) (kong.Schema, error)
 }
 
+// CustomEntityObjectsByType returns objects of custom entities and schema-based credentials of consumers
+// grouped by their entity types. They're not a part of decK content and have to be added to the configuration
+// sent to Kong separately.
+func (ks *KongState) CustomEntityObjectsByType() map[string][]custom.Object {
+	objects := make(map[string][]custom.Object)
+	for entityType, collection := range ks.CustomEntities {
+		for _, entity := range collection.Entities {
+			objects[entityType] = append(objects[entityType], entity.Object)
+		}
+	}
+
+	// Consumers are not sorted in KongState, sort them to get a stable order of credentials. Consumers may have
+	// only one of username and custom_id set.
+	consumers := make([]*Consumer, 0, len(ks.Consumers))
+	for i := range ks.Consumers {
+		if len(ks.Consumers[i].SchemaBasedCredentials) > 0 {
+			consumers = append(consumers, &ks.Consumers[i])
+		}
+	}
+	sort.SliceStable(consumers, func(i, j int) bool {
+		ci, cj := consumers[i], consumers[j]
+		if ui, uj := lo.FromPtr(ci.Username), lo.FromPtr(cj.Username); ui != uj {
+			return ui < uj
+		}
+		if idi, idj := lo.FromPtr(ci.CustomID), lo.FromPtr(cj.CustomID); idi != idj {
+			return idi < idj
+		}
+		return lo.FromPtr(ci.ID) < lo.FromPtr(cj.ID)
+	})
+	for _, c := range consumers {
+		for _, cred := range c.SchemaBasedCredentials {
+			objects[cred.EntityType] = append(objects[cred.EntityType], cred.Object)
+		}
+	}
+	return objects
+}
+
 // sortCustomEntities sorts the custom entities of each type.
 // Since there may not be a consistent field to identify an entity, here we sort them by the k8s namespace/name.
 func (ks *KongState) sortCustomEntities() {
@@ -208,3 +208,37 @@ func TestSortCustomEntities(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomEntityObjectsByType(t *testing.T) {
+	credential := func(key string) *SchemaBasedCredential {
+		return &SchemaBasedCredential{EntityType: "keyauth_enc_credentials", Object: custom.Object{"key": key}}
+	}
+	ks := KongState{
+		Consumers: []Consumer{
+			{
+				Consumer:               kong.Consumer{CustomID: kong.String("b"), ID: kong.String("2")},
+				SchemaBasedCredentials: []*SchemaBasedCredential{credential("custom-id-b")},
+			},
+			{
+				Consumer:               kong.Consumer{Username: kong.String("a")},
+				SchemaBasedCredentials: []*SchemaBasedCredential{credential("username-a")},
+			},
+			{
+				Consumer: kong.Consumer{Username: kong.String("c")},
+			},
+			{
+				Consumer:               kong.Consumer{CustomID: kong.String("a"), ID: kong.String("1")},
+				SchemaBasedCredentials: []*SchemaBasedCredential{credential("custom-id-a")},
+			},
+		},
+		CustomEntities: map[string]*KongCustomEntityCollection{
+			"foo": {Entities: []CustomEntity{{Object: custom.Object{"name": "e1"}}}},
+		},
+	}
+
+	require.Equal(t, map[string][]custom.Object{
+		"foo": {{"name": "e1"}},
+		// Consumers without a username are sorted by custom_id.
+		"keyauth_enc_credentials": {{"key": "custom-id-a"}, {"key": "custom-id-b"}, {"key": "username-a"}},
+	}, ks.CustomEntityObjectsByType())
+}
@@ -0,0 +1,231 @@
+package kongstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+)
+
+// EntityType is a type of Kong entity compared by DiffStates.
+type EntityType string
+
+const (
+	EntityTypeService  EntityType = "service"
+	EntityTypeRoute    EntityType = "route"
+	EntityTypePlugin   EntityType = "plugin"
+	EntityTypeConsumer EntityType = "consumer"
+)
+
+// DiffEntityTypes are all entity types compared by DiffStates.
+var DiffEntityTypes = []EntityType{EntityTypeService, EntityTypeRoute, EntityTypePlugin, EntityTypeConsumer}
+
+// ChangeType is a type of change of a Kong entity.
+type ChangeType string
+
+const (
+	ChangeTypeCreate ChangeType = "create"
+	ChangeTypeUpdate ChangeType = "update"
+	ChangeTypeDelete ChangeType = "delete"
+)
+
+// DiffChangeTypes are all types of changes reported by DiffStates.
+var DiffChangeTypes = []ChangeType{ChangeTypeCreate, ChangeTypeUpdate, ChangeTypeDelete}
+
+// EntityChange is a change of a single Kong entity.
+type EntityChange struct {
+	EntityType EntityType
+	ChangeType ChangeType
+	// Name identifies the entity among entities of the same type. For plugins, it consists of the plugin name
+	// and the entities the plugin is scoped to.
+	Name string
+	// Fields are names of the entity's fields that changed. It's only set for updates.
+	Fields []string
+}
+
+// StateDiff is a difference between two KongStates.
+type StateDiff struct {
+	// Changes are changes of entities sorted by entity type, name and change type.
+	Changes []EntityChange
+}
+
+// Counts returns the number of changes by entity and change types. All entity and change types are included.
+func (d StateDiff) Counts() map[EntityType]map[ChangeType]int {
+	counts := make(map[EntityType]map[ChangeType]int, len(DiffEntityTypes))
+	for _, entityType := range DiffEntityTypes {
+		counts[entityType] = make(map[ChangeType]int, len(DiffChangeTypes))
+		for _, changeType := range DiffChangeTypes {
+			counts[entityType][changeType] = 0
+		}
+	}
+	for _, change := range d.Changes {
+		counts[change.EntityType][change.ChangeType]++
+	}
+	return counts
+}
+
+// DiffStates compares services, routes, plugins and consumers of the KongStates by their names, the same way
+// decK does, and returns changes required to turn the old state into the new one. A nil state is considered empty.
+func DiffStates(oldState, newState *KongState) StateDiff {
+	oldEntities, newEntities := diffableEntities(oldState), diffableEntities(newState)
+
+	var diff StateDiff
+	for _, entityType := range DiffEntityTypes {
+		oldOfType, newOfType := oldEntities[entityType], newEntities[entityType]
+		for name, newEntity := range newOfType {
+			oldEntity, ok := oldOfType[name]
+			if !ok {
+				diff.Changes = append(diff.Changes, EntityChange{EntityType: entityType, ChangeType: ChangeTypeCreate, Name: name})
+				continue
+			}
+			if reflect.DeepEqual(oldEntity, newEntity) {
+				continue
+			}
+			diff.Changes = append(diff.Changes, EntityChange{
+				EntityType: entityType,
+				ChangeType: ChangeTypeUpdate,
+				Name:       name,
+				Fields:     changedFields(oldEntity, newEntity),
+			})
+		}
+		for name := range oldOfType {
+			if _, ok := newOfType[name]; !ok {
+				diff.Changes = append(diff.Changes, EntityChange{EntityType: entityType, ChangeType: ChangeTypeDelete, Name: name})
+			}
+		}
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.EntityType != b.EntityType {
+			return lo.IndexOf(DiffEntityTypes, a.EntityType) < lo.IndexOf(DiffEntityTypes, b.EntityType)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ChangeType < b.ChangeType
+	})
+	return diff
+}
+
+// diffableEntities returns Kong entities of the state compared by DiffStates by their types and names.
+func diffableEntities(ks *KongState) map[EntityType]map[string]any {
+	entities := lo.SliceToMap(DiffEntityTypes, func(t EntityType) (EntityType, map[string]any) {
+		return t, make(map[string]any)
+	})
+	if ks == nil {
+		return entities
+	}
+
+	addPlugin := func(plugin kong.Plugin, scope ...string) {
+		name := pluginDiffName(plugin, scope...)
+		// Plugins of the same name and scope are not accepted by Kong, but make sure none is skipped anyway.
+		uniqueName := name
+		for i := 2; ; i++ {
+			if _, ok := entities[EntityTypePlugin][uniqueName]; !ok {
+				break
+			}
+			uniqueName = fmt.Sprintf("%s#%d", name, i)
+		}
+		entities[EntityTypePlugin][uniqueName] = plugin
+	}
+
+	for _, service := range ks.Services {
+		serviceName := lo.FromPtr(service.Name)
+		entities[EntityTypeService][serviceName] = service.Service
+		for _, plugin := range service.Plugins {
+			addPlugin(plugin, "service", serviceName)
+		}
+		for _, route := range service.Routes {
+			routeName := lo.FromPtr(route.Name)
+			entities[EntityTypeRoute][routeName] = route.Route
+			for _, plugin := range route.Plugins {
+				addPlugin(plugin, "route", routeName)
+			}
+		}
+	}
+	for _, plugin := range ks.Plugins {
+		addPlugin(plugin.Plugin)
+	}
+	for _, consumer := range ks.Consumers {
+		consumerName := lo.FromPtr(consumer.Username)
+		if consumerName == "" {
+			consumerName = lo.FromPtr(consumer.CustomID)
+		}
+		entities[EntityTypeConsumer][consumerName] = consumer.Consumer
+		for _, plugin := range consumer.Plugins {
+			addPlugin(plugin, "consumer", consumerName)
+		}
+	}
+	return entities
+}
+
+// pluginDiffName returns a name identifying the plugin by its name and the entities it's scoped to: either
+// given explicitly or referenced by the plugin.
+func pluginDiffName(plugin kong.Plugin, scope ...string) string {
+	parts := []string{lo.FromPtr(plugin.Name)}
+	if instanceName := lo.FromPtr(plugin.InstanceName); instanceName != "" {
+		parts = append(parts, "instance_name", instanceName)
+	}
+	parts = append(parts, scope...)
+	if plugin.Service != nil {
+		parts = append(parts, "service", pluginReferenceName(plugin.Service.ID, plugin.Service.Name))
+	}
+	if plugin.Route != nil {
+		parts = append(parts, "route", pluginReferenceName(plugin.Route.ID, plugin.Route.Name))
+	}
+	if plugin.Consumer != nil {
+		parts = append(parts, "consumer", pluginReferenceName(plugin.Consumer.ID, plugin.Consumer.Username))
+	}
+	if plugin.ConsumerGroup != nil {
+		parts = append(parts, "consumer_group", pluginReferenceName(plugin.ConsumerGroup.ID, plugin.ConsumerGroup.Name))
+	}
+	return strings.Join(parts, ".")
+}
+
+// pluginReferenceName returns the ID of an entity referenced by a plugin or its name if the ID is not set.
+func pluginReferenceName(id, name *string) string {
+	if id != nil {
+		return *id
+	}
+	return lo.FromPtr(name)
+}
+
+// changedFields returns sorted names of top-level fields of the entities' JSON representations that differ.
+func changedFields(oldEntity, newEntity any) []string {
+	oldFields, oldErr := entityFields(oldEntity)
+	newFields, newErr := entityFields(newEntity)
+	if oldErr != nil || newErr != nil {
+		return nil
+	}
+
+	var fields []string
+	for name, newValue := range newFields {
+		if oldValue, ok := oldFields[name]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range oldFields {
+		if _, ok := newFields[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func entityFields(entity any) (map[string]any, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
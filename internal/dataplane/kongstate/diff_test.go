@@ -0,0 +1,86 @@
+package kongstate
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffStates(t *testing.T) {
+	oldState := &KongState{
+		Services: []Service{
+			{
+				Service: kong.Service{Name: kong.String("svc-updated"), Host: kong.String("old.example.com"), Port: kong.Int(80)},
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("route-unchanged"), Paths: kong.StringSlice("/")}},
+					{Route: kong.Route{Name: kong.String("route-deleted"), Paths: kong.StringSlice("/deleted")}},
+				},
+				Plugins: []kong.Plugin{
+					{Name: kong.String("key-auth")},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("svc-deleted"), Host: kong.String("deleted.example.com")},
+			},
+		},
+		Plugins: []Plugin{
+			{Plugin: kong.Plugin{Name: kong.String("cors"), Config: kong.Configuration{"origins": []any{"a"}}}},
+		},
+		Consumers: []Consumer{
+			{Consumer: kong.Consumer{Username: kong.String("consumer-unchanged")}},
+		},
+	}
+	newState := &KongState{
+		Services: []Service{
+			{
+				Service: kong.Service{Name: kong.String("svc-updated"), Host: kong.String("new.example.com"), Port: kong.Int(8080)},
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("route-unchanged"), Paths: kong.StringSlice("/")}},
+					{Route: kong.Route{Name: kong.String("route-created"), Paths: kong.StringSlice("/created")}},
+				},
+				Plugins: []kong.Plugin{
+					{Name: kong.String("key-auth")},
+					{Name: kong.String("rate-limiting"), InstanceName: kong.String("limit")},
+				},
+			},
+		},
+		Plugins: []Plugin{
+			{Plugin: kong.Plugin{Name: kong.String("cors"), Config: kong.Configuration{"origins": []any{"b"}}}},
+		},
+		Consumers: []Consumer{
+			{Consumer: kong.Consumer{Username: kong.String("consumer-unchanged")}},
+			{Consumer: kong.Consumer{CustomID: kong.String("consumer-created")}},
+		},
+	}
+
+	diff := DiffStates(oldState, newState)
+	require.Equal(t, []EntityChange{
+		{EntityType: EntityTypeService, ChangeType: ChangeTypeDelete, Name: "svc-deleted"},
+		{EntityType: EntityTypeService, ChangeType: ChangeTypeUpdate, Name: "svc-updated", Fields: []string{"host", "port"}},
+		{EntityType: EntityTypeRoute, ChangeType: ChangeTypeCreate, Name: "route-created"},
+		{EntityType: EntityTypeRoute, ChangeType: ChangeTypeDelete, Name: "route-deleted"},
+		{EntityType: EntityTypePlugin, ChangeType: ChangeTypeUpdate, Name: "cors", Fields: []string{"config"}},
+		{EntityType: EntityTypePlugin, ChangeType: ChangeTypeCreate, Name: "rate-limiting.instance_name.limit.service.svc-updated"},
+		{EntityType: EntityTypeConsumer, ChangeType: ChangeTypeCreate, Name: "consumer-created"},
+	}, diff.Changes)
+
+	require.Equal(t, map[EntityType]map[ChangeType]int{
+		EntityTypeService:  {ChangeTypeCreate: 0, ChangeTypeUpdate: 1, ChangeTypeDelete: 1},
+		EntityTypeRoute:    {ChangeTypeCreate: 1, ChangeTypeUpdate: 0, ChangeTypeDelete: 1},
+		EntityTypePlugin:   {ChangeTypeCreate: 1, ChangeTypeUpdate: 1, ChangeTypeDelete: 0},
+		EntityTypeConsumer: {ChangeTypeCreate: 1, ChangeTypeUpdate: 0, ChangeTypeDelete: 0},
+	}, diff.Counts())
+
+	t.Run("nil old state", func(t *testing.T) {
+		diff := DiffStates(nil, newState)
+		require.Len(t, diff.Changes, 8)
+		for _, change := range diff.Changes {
+			require.Equal(t, ChangeTypeCreate, change.ChangeType)
+		}
+	})
+
+	t.Run("equal states", func(t *testing.T) {
+		require.Empty(t, DiffStates(newState, newState).Changes)
+	})
+}
@@ -5,8 +5,10 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
@@ -14,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -41,6 +44,16 @@ type KongState struct {
 	Vaults         []Vault
 
 	CustomEntities map[string]*KongCustomEntityCollection
+
+	// DedicatedGateways are the Gateways having data-planes of their own (managed Gateways and unmanaged Gateways
+	// annotated with konghq.com/admin-service). Routes accepted only by them and Certificates used only by their
+	// listeners are not configured in the data-planes shared by all Gateways (see ForSharedGateways).
+	DedicatedGateways []k8stypes.NamespacedName
+
+	// FetchedFromGateways tells whether the KongState was fetched from gateways shared by all Gateways rather than
+	// translated from Kubernetes objects. Such a KongState doesn't tell which Gateways its routes belong to, so it
+	// can't be partitioned for data-planes dedicated to Gateways.
+	FetchedFromGateways bool
 }
 
 // SanitizedCopy returns a shallow copy with sensitive values redacted best-effort.
@@ -68,18 +81,31 @@ func (ks *KongState) SanitizedCopy(uuidGenerator util.UUIDGenerator) *KongState
 			}
 			return
 		}(),
-		ConsumerGroups: ks.ConsumerGroups,
-		Vaults:         ks.Vaults,
-		CustomEntities: ks.CustomEntities,
+		ConsumerGroups:      ks.ConsumerGroups,
+		Vaults:              ks.Vaults,
+		CustomEntities:      ks.CustomEntities,
+		DedicatedGateways:   ks.DedicatedGateways,
+		FetchedFromGateways: ks.FetchedFromGateways,
 	}
 }
 
+// FillConsumersAndCredentials fills consumers and their credentials in KongState. Only credentials of types
+// registered in credentialTypes are translated. Schemas of schema-based
+// credential types are fetched using the schemaGetter. Credentials of these types are rejected unless
+// schemaBasedCredentials is true, as they can only be sent to DB-less Kong Gateways.
 func (ks *KongState) FillConsumersAndCredentials(
-	_ logr.Logger,
+	logger logr.Logger,
 	s store.Storer,
 	failuresCollector *failures.ResourceFailuresCollector,
+	credentialTypes *credentials.Registry,
+	schemaGetter SchemaGetter,
+	schemaBasedCredentials bool,
+	workspace string,
 ) {
 	consumerIndex := make(map[string]Consumer)
+	credentialSchemas := make(map[string]EntitySchema)
+	// Expiry of grace periods of credential rotations is evaluated at the same time for all consumers.
+	now := time.Now()
 
 	// build consumer index
 	for _, consumer := range s.ListKongConsumers() {
@@ -111,7 +137,7 @@ func (ks *KongState) FillConsumersAndCredentials(
 			})
 		}
 
-		for _, cred := range consumer.Credentials {
+		for _, cred := range credentialSecretsWithSuccessors(s, consumer, failuresCollector, now) {
 			pushCredentialResourceFailures := func(message string) {
 				failuresCollector.PushResourceFailure(fmt.Sprintf("credential %q failure: %s", cred, message), consumer)
 			}
@@ -126,10 +152,26 @@ func (ks *KongState) FillConsumersAndCredentials(
 			if err != nil {
 				pushCredentialResourceFailures(fmt.Sprintf("could not load credential from Secret: %s", err))
 			}
-			if !credentials.SupportedTypes.Has(credType) {
-				pushCredentialResourceFailures(
-					fmt.Sprintf("failed to provision credential: unsupported credential type: %q", credType),
-				)
+			credentialType, err := credentialTypes.Lookup(credType)
+			if err != nil {
+				logger.Info("Skipping credential of an unknown type", "reason", err.Error(),
+					"secret_namespace", secret.Namespace, "secret_name", secret.Name)
+				pushCredentialResourceFailures(fmt.Sprintf("failed to provision credential: unsupported credential type: %q", credType))
+				continue
+			}
+			if credentialType.SchemaBased {
+				if !schemaBasedCredentials {
+					pushCredentialResourceFailures(fmt.Sprintf(
+						"failed to provision credential: credential type %s is only supported with DB-less Kong Gateways", credentialType.Name,
+					))
+					continue
+				}
+				cred, err := newConsumerSchemaBasedCredential(&c, credentialType, secret, schemaGetter, credentialSchemas, workspace)
+				if err != nil {
+					pushCredentialResourceFailures(fmt.Sprintf("failed to provision credential: %v", err))
+					continue
+				}
+				c.SchemaBasedCredentials = append(c.SchemaBasedCredentials, cred)
 				continue
 			}
 			for k, v := range secret.Data {
@@ -189,6 +231,105 @@ func (ks *KongState) FillConsumersAndCredentials(
 	}
 }
 
+// credentialSecretsWithSuccessors returns names of the credential Secrets that should be attached to the consumer
+// at the given time. These are the Secrets it references and their successors declared for rotation. Secrets being
+// rotated are attached together with their successors until the grace period expires, counting from the start of
+// the rotation recorded by the controller in the consumer's status. After that, only their successors are.
+func credentialSecretsWithSuccessors(
+	s store.Storer,
+	consumer *kongv1.KongConsumer,
+	failuresCollector *failures.ResourceFailuresCollector,
+	now time.Time,
+) []string {
+	attached := sets.New[string]()
+	var secretNames []string
+	attach := func(name string) {
+		if !attached.Has(name) {
+			attached.Insert(name)
+			secretNames = append(secretNames, name)
+		}
+	}
+
+	for _, cred := range consumer.Credentials {
+		secret, err := s.GetSecret(consumer.Namespace, cred)
+		if err != nil {
+			// Failing to fetch the Secret is reported when the credential is provisioned.
+			attach(cred)
+			continue
+		}
+		rotation, ok, err := credentials.ExtractRotation(secret)
+		if err != nil {
+			failuresCollector.PushResourceFailure(fmt.Sprintf("credential %q failure: invalid rotation: %s", cred, err), consumer)
+			attach(cred)
+			continue
+		}
+		if !ok {
+			attach(cred)
+			continue
+		}
+		if _, err := s.GetSecret(consumer.Namespace, rotation.Successor); err != nil {
+			failuresCollector.PushResourceFailure(
+				fmt.Sprintf("credential %q failure: failed to fetch successor secret %q: %v", cred, rotation.Successor, err),
+				consumer,
+			)
+			attach(cred)
+			continue
+		}
+		if !rotation.WithRecordedStart(consumer.Status).Expired(now) {
+			attach(cred)
+		}
+		attach(rotation.Successor)
+	}
+	return secretNames
+}
+
+// newConsumerSchemaBasedCredential creates a credential of a schema-based type for the consumer from the secret.
+// Schemas fetched are cached in the schemas map. It fills the consumer's ID as Kong requires credentials to refer
+// to consumers by their IDs.
+func newConsumerSchemaBasedCredential(
+	c *Consumer,
+	credentialType credentials.Type,
+	secret *corev1.Secret,
+	schemaGetter SchemaGetter,
+	schemas map[string]EntitySchema,
+	workspace string,
+) (*SchemaBasedCredential, error) {
+	schema, ok := schemas[credentialType.EntityType]
+	if !ok {
+		// Use `context.Background()` here because `BuildKongConfig` does not provide a context.
+		kongSchema, err := schemaGetter.Get(context.Background(), credentialType.EntityType)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported credential type: %q: failed to fetch schema of entity type %s: %w",
+				credentialType.Name, credentialType.EntityType, err)
+		}
+		schema = ExtractEntityFieldDefinitions(kongSchema)
+		schemas[credentialType.EntityType] = schema
+	}
+
+	consumerField, ok := lo.FindKeyBy(schema.Fields, func(_ string, f EntityField) bool {
+		return f.Type == EntityFieldTypeForeign && f.Reference == string(kong.EntityTypeConsumers) && f.Required
+	})
+	if !ok {
+		return nil, fmt.Errorf("unsupported credential type: %q: %s entities don't belong to consumers",
+			credentialType.Name, credentialType.EntityType)
+	}
+	if err := c.FillID(workspace); err != nil {
+		return nil, fmt.Errorf("failed to fill consumer ID: %w", err)
+	}
+
+	cred, err := NewSchemaBasedCredential(credentialType.EntityType, schema, secret.Data)
+	if err != nil {
+		return nil, err
+	}
+	cred.Object[consumerField] = map[string]interface{}{
+		"id": *c.ID,
+	}
+	if _, ok := schema.Fields["tags"]; ok {
+		cred.Object["tags"] = lo.Map(util.GenerateTagsForObject(secret), func(tag *string, _ int) string { return *tag })
+	}
+	return cred, nil
+}
+
 func (ks *KongState) FillConsumerGroups(_ logr.Logger, s store.Storer) {
 	for _, cg := range s.ListKongConsumerGroups() {
 		ks.ConsumerGroups = append(ks.ConsumerGroups, ConsumerGroup{
@@ -243,6 +384,10 @@ func (ks *KongState) FillUpstreamOverrides(
 ) {
 	for i := 0; i < len(ks.Upstreams); i++ {
 		servicesGroup := lo.Values(ks.Upstreams[i].Service.K8sServices)
+		// Sort the Services so that failures caused by them are reported consistently.
+		sort.Slice(servicesGroup, func(a, b int) bool {
+			return client.ObjectKeyFromObject(servicesGroup[a]).String() < client.ObjectKeyFromObject(servicesGroup[b]).String()
+		})
 
 		// In case `konghq.com/override` annotation is set on any of the services, we should log a deprecation error.
 		maybeLogKongIngressDeprecationError(logger, servicesGroup)
@@ -262,6 +407,20 @@ func (ks *KongState) FillUpstreamOverrides(
 		} else if kongUpstreamPolicy != nil {
 			ks.Upstreams[i].overrideByKongUpstreamPolicy(kongUpstreamPolicy)
 		}
+
+		sessionPersistence, sessionPersistenceSource, err := getSessionPersistenceForService(s, ks.Upstreams[i].Service, servicesGroup)
+		if err != nil {
+			failuresCollector.PushResourceFailure(err.Error(), lo.Map(servicesGroup, servicesAsObjects)...)
+		} else if sessionPersistence != nil {
+			if kongUpstreamPolicy != nil && KongUpstreamPolicyConfiguresHashing(kongUpstreamPolicy) {
+				failuresCollector.PushResourceFailure(
+					fmt.Sprintf("KongUpstreamPolicy load balancing settings conflict with session persistence configured by %s, "+
+						"session persistence takes precedence", sessionPersistenceSource),
+					kongUpstreamPolicy,
+				)
+			}
+			ks.Upstreams[i].overrideBySessionPersistence(sessionPersistence)
+		}
 	}
 }
 
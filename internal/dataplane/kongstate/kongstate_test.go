@@ -25,8 +25,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
@@ -75,6 +77,8 @@ func TestKongState_SanitizedCopy(t *testing.T) {
 						},
 					},
 				},
+				DedicatedGateways:   []k8stypes.NamespacedName{{Namespace: "default", Name: "gateway"}},
+				FetchedFromGateways: true,
 				CustomEntities: map[string]*KongCustomEntityCollection{
 					"test_entities": {
 						Schema: EntitySchema{
@@ -115,6 +119,8 @@ func TestKongState_SanitizedCopy(t *testing.T) {
 						},
 					},
 				},
+				DedicatedGateways:   []k8stypes.NamespacedName{{Namespace: "default", Name: "gateway"}},
+				FetchedFromGateways: true,
 				CustomEntities: map[string]*KongCustomEntityCollection{
 					"test_entities": {
 						Schema: EntitySchema{
@@ -505,6 +511,10 @@ func TestGetPluginRelations(t *testing.T) {
 }
 
 func TestFillConsumersAndCredentials(t *testing.T) {
+	// Schema-based type of entities that don't belong to consumers, only this test uses it.
+	credentialTypes := credentials.NewDefaultRegistry()
+	credentialTypes.Register(credentials.Type{Name: "session", EntityType: "sessions", SchemaBased: true})
+
 	secrets := []*corev1.Secret{
 		{
 			ObjectMeta: metav1.ObjectMeta{
@@ -581,14 +591,375 @@ func TestFillConsumersAndCredentials(t *testing.T) {
 				"foo": []byte("bar"),
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "keyAuthEncSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "key-auth-enc",
+				},
+			},
+			Data: map[string][]byte{
+				"key": []byte("little-rabbits-be-good"),
+				"ttl": []byte("1024"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "notCredentialSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "session",
+				},
+			},
+			Data: map[string][]byte{
+				"name": []byte("session"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rotatedSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "key-auth",
+				},
+				Annotations: map[string]string{
+					"konghq.com/credential-successor":    "successorSecret",
+					"konghq.com/credential-grace-period": "24h",
+				},
+			},
+			Data: map[string][]byte{
+				"key": []byte("old-key"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "successorSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "key-auth",
+				},
+			},
+			Data: map[string][]byte{
+				"key": []byte("new-key"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "expiredRotatedSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "key-auth",
+				},
+				Annotations: map[string]string{
+					"konghq.com/credential-successor":    "successorSecret",
+					"konghq.com/credential-grace-period": "30m",
+				},
+			},
+			Data: map[string][]byte{
+				"key": []byte("expired-key"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pendingRotatedSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "key-auth",
+				},
+				Annotations: map[string]string{
+					"konghq.com/credential-successor":    "successorSecret",
+					"konghq.com/credential-grace-period": "0s",
+				},
+			},
+			Data: map[string][]byte{
+				"key": []byte("pending-key"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "missingSuccessorRotatedSecret",
+				Namespace: "default",
+				Labels: map[string]string{
+					labels.CredentialTypeLabel: "key-auth",
+				},
+				Annotations: map[string]string{
+					"konghq.com/credential-successor":    "missingSecret",
+					"konghq.com/credential-grace-period": "24h",
+				},
+			},
+			Data: map[string][]byte{
+				"key": []byte("kept-key"),
+			},
+		},
 	}
+	keyAuthFromSecret := func(key, secretName string) *KeyAuth {
+		return &KeyAuth{kong.KeyAuth{
+			Key: kong.String(key),
+			Tags: util.GenerateTagsForObject(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: secretName},
+			}),
+		}}
+	}
+	schemas := map[string]kong.Schema{
+		"keyauth_enc_credentials": {
+			"fields": []interface{}{
+				map[string]interface{}{"id": map[string]interface{}{"type": "string", "uuid": true, "auto": true}},
+				map[string]interface{}{"consumer": map[string]interface{}{"type": "foreign", "reference": "consumers", "required": true}},
+				map[string]interface{}{"key": map[string]interface{}{"type": "string", "required": false, "auto": true}},
+				map[string]interface{}{"ttl": map[string]interface{}{"type": "integer"}},
+				map[string]interface{}{"tags": map[string]interface{}{"type": "set"}},
+			},
+		},
+		"sessions": {
+			"fields": []interface{}{
+				map[string]interface{}{"name": map[string]interface{}{"type": "string", "required": true}},
+			},
+		},
+	}
+	fooConsumerID := func() string {
+		c := kong.Consumer{Username: kong.String("foo")}
+		require.NoError(t, c.FillID(""))
+		return *c.ID
+	}()
 
 	testCases := []struct {
 		name                               string
 		k8sConsumers                       []*kongv1.KongConsumer
+		schemaBasedCredentialsUnsupported  bool
 		expectedKongStateConsumers         []Consumer
 		expectedTranslationFailureMessages map[k8stypes.NamespacedName]string
 	}{
+		{
+			name: "KongConsumer with credential being rotated",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"rotatedSecret",
+					},
+					Status: kongv1.KongConsumerStatus{
+						CredentialRotations: []kongv1.CredentialRotationStatus{
+							{Secret: "rotatedSecret", Successor: "successorSecret", StartedAt: metav1.NewTime(time.Now().Add(-time.Hour))},
+						},
+					},
+				},
+			},
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+					KeyAuths: []*KeyAuth{
+						keyAuthFromSecret("old-key", "rotatedSecret"),
+						keyAuthFromSecret("new-key", "successorSecret"),
+					},
+				},
+			},
+		},
+		{
+			name: "KongConsumer with credential rotation not observed by the controller yet",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"pendingRotatedSecret",
+					},
+				},
+			},
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+					KeyAuths: []*KeyAuth{
+						keyAuthFromSecret("pending-key", "pendingRotatedSecret"),
+						keyAuthFromSecret("new-key", "successorSecret"),
+					},
+				},
+			},
+		},
+		{
+			name: "KongConsumer with credential rotation past grace period, referencing successor already",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"expiredRotatedSecret",
+						"successorSecret",
+					},
+					Status: kongv1.KongConsumerStatus{
+						CredentialRotations: []kongv1.CredentialRotationStatus{
+							{Secret: "expiredRotatedSecret", Successor: "successorSecret", StartedAt: metav1.NewTime(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))},
+						},
+					},
+				},
+			},
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+					KeyAuths: []*KeyAuth{
+						keyAuthFromSecret("new-key", "successorSecret"),
+					},
+				},
+			},
+		},
+		{
+			name: "KongConsumer with credential rotated to non-existing successor",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"missingSuccessorRotatedSecret",
+					},
+				},
+			},
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+					KeyAuths: []*KeyAuth{
+						keyAuthFromSecret("kept-key", "missingSuccessorRotatedSecret"),
+					},
+				},
+			},
+			expectedTranslationFailureMessages: map[k8stypes.NamespacedName]string{
+				{Namespace: "default", Name: "foo"}: `credential "missingSuccessorRotatedSecret" failure: failed to fetch successor secret "missingSecret"`,
+			},
+		},
+		{
+			name: "KongConsumer with schema-based credential",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"keyAuthEncSecret",
+					},
+				},
+			},
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+					SchemaBasedCredentials: []*SchemaBasedCredential{
+						{
+							EntityType: "keyauth_enc_credentials",
+							Object: custom.Object{
+								"consumer": map[string]interface{}{"id": fooConsumerID},
+								"key":      "little-rabbits-be-good",
+								"ttl":      1024,
+								"tags": lo.Map(util.GenerateTagsForObject(&corev1.Secret{
+									ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "keyAuthEncSecret"},
+								}), func(tag *string, _ int) string { return *tag }),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KongConsumer with schema-based credential with DB-backed Kong",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"keyAuthEncSecret",
+					},
+				},
+			},
+			schemaBasedCredentialsUnsupported: true,
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+				},
+			},
+			expectedTranslationFailureMessages: map[k8stypes.NamespacedName]string{
+				{Namespace: "default", Name: "foo"}: "failed to provision credential: credential type key-auth-enc is only supported with DB-less Kong Gateways",
+			},
+		},
+		{
+			name: "KongConsumer with schema-based credential of a type not belonging to consumers",
+			k8sConsumers: []*kongv1.KongConsumer{
+				{
+					TypeMeta: kongConsumerTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": annotations.DefaultIngressClass,
+						},
+					},
+					Username: "foo",
+					Credentials: []string{
+						"notCredentialSecret",
+					},
+				},
+			},
+			expectedKongStateConsumers: []Consumer{
+				{
+					Consumer: kong.Consumer{
+						Username: kong.String("foo"),
+					},
+				},
+			},
+			expectedTranslationFailureMessages: map[k8stypes.NamespacedName]string{
+				{Namespace: "default", Name: "foo"}: "failed to provision credential: unsupported credential type: \"session\": sessions entities don't belong to consumers",
+			},
+		},
 		{
 			name: "KongConsumer with key-auth and oauth2",
 			k8sConsumers: []*kongv1.KongConsumer{
@@ -796,7 +1167,7 @@ func TestFillConsumersAndCredentials(t *testing.T) {
 			failuresCollector := failures.NewResourceFailuresCollector(logger)
 
 			state := KongState{}
-			state.FillConsumersAndCredentials(logger, store, failuresCollector)
+			state.FillConsumersAndCredentials(logger, store, failuresCollector, credentialTypes, &fakeSchemaGetter{schemas: schemas}, !tc.schemaBasedCredentialsUnsupported, "")
 			// compare translated consumers.
 			require.Len(t, state.Consumers, len(tc.expectedKongStateConsumers))
 			// compare fields. Since we only test for translating a single consumer, we only compare the first one if exists.
@@ -807,6 +1178,7 @@ func TestFillConsumersAndCredentials(t *testing.T) {
 				// compare credentials.
 				assert.Equal(t, expectedConsumer.KeyAuths, kongStateConsumer.KeyAuths)
 				assert.Equal(t, expectedConsumer.Oauth2Creds, kongStateConsumer.Oauth2Creds)
+				assert.Equal(t, expectedConsumer.SchemaBasedCredentials, kongStateConsumer.SchemaBasedCredentials)
 			}
 			// check for expected translation failures.
 			if len(tc.expectedTranslationFailureMessages) > 0 {
@@ -1049,11 +1421,51 @@ func TestKongState_FillUpstreamOverrides(t *testing.T) {
 		return s
 	}
 
+	hashingKongUpstreamPolicy := func() *kongv1beta1.KongUpstreamPolicy {
+		return &kongv1beta1.KongUpstreamPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "KongUpstreamPolicy",
+				APIVersion: kongv1beta1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      kongUpstreamPolicyName,
+				Namespace: "default",
+			},
+			Spec: kongv1beta1.KongUpstreamPolicySpec{
+				Algorithm: lo.ToPtr("consistent-hashing"),
+				Slots:     lo.ToPtr(100),
+				HashOn:    &kongv1beta1.KongUpstreamHash{Header: lo.ToPtr("x-user")},
+			},
+		}
+	}
+	backendLBPolicy := func(name string, created time.Time, sessionName string, targetServices ...string) *gatewayapi.BackendLBPolicy {
+		return &gatewayapi.BackendLBPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: gatewayapi.BackendLBPolicySpec{
+				TargetRefs: lo.Map(targetServices, func(svc string, _ int) gatewayapi.LocalPolicyTargetReference {
+					return gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: gatewayapi.ObjectName(svc)}
+				}),
+				SessionPersistence: &gatewayapi.SessionPersistence{SessionName: lo.ToPtr(sessionName)},
+			},
+		}
+	}
+	serviceWithName := func(name string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+	}
+
 	testCases := []struct {
 		name                 string
 		upstream             Upstream
 		kongUpstreamPolicies []*kongv1beta1.KongUpstreamPolicy
 		kongIngresses        []*kongv1.KongIngress
+		backendLBPolicies    []*gatewayapi.BackendLBPolicy
 		expectedUpstream     kong.Upstream
 		expectedFailures     []failures.ResourceFailure
 	}{
@@ -1183,6 +1595,126 @@ func TestKongState_FillUpstreamOverrides(t *testing.T) {
 				Algorithm: kong.String("least-connections"),
 			},
 		},
+		{
+			name: "route rule cookie session persistence",
+			upstream: Upstream{
+				Upstream: kong.Upstream{
+					Name: kong.String("foo-upstream"),
+				},
+				Service: Service{
+					SessionPersistence: &gatewayapi.SessionPersistence{SessionName: lo.ToPtr("session")},
+				},
+			},
+			expectedUpstream: kong.Upstream{
+				Name:             kong.String("foo-upstream"),
+				Algorithm:        kong.String("consistent-hashing"),
+				HashOn:           kong.String("cookie"),
+				HashOnCookie:     kong.String("session"),
+				HashOnCookiePath: kong.String("/"),
+			},
+		},
+		{
+			name: "route rule header session persistence takes precedence over BackendLBPolicy",
+			upstream: Upstream{
+				Upstream: kong.Upstream{
+					Name: kong.String("foo-upstream"),
+				},
+				Service: Service{
+					K8sServices: map[string]*corev1.Service{"default/svc": serviceWithName("svc")},
+					SessionPersistence: &gatewayapi.SessionPersistence{
+						Type: lo.ToPtr(gatewayapi.HeaderBasedSessionPersistence),
+					},
+				},
+			},
+			backendLBPolicies: []*gatewayapi.BackendLBPolicy{
+				backendLBPolicy("policy", time.Now(), "from-policy", "svc"),
+			},
+			expectedUpstream: kong.Upstream{
+				Name:         kong.String("foo-upstream"),
+				Algorithm:    kong.String("consistent-hashing"),
+				HashOn:       kong.String("header"),
+				HashOnHeader: kong.String(DefaultSessionPersistenceHeaderName),
+			},
+		},
+		{
+			name: "the oldest BackendLBPolicy targeting all the services is applied",
+			upstream: Upstream{
+				Upstream: kong.Upstream{
+					Name: kong.String("foo-upstream"),
+				},
+				Service: Service{
+					K8sServices: map[string]*corev1.Service{
+						"default/svc-1": serviceWithName("svc-1"),
+						"default/svc-2": serviceWithName("svc-2"),
+					},
+				},
+			},
+			backendLBPolicies: []*gatewayapi.BackendLBPolicy{
+				backendLBPolicy("newer", time.Now(), "newer", "svc-1", "svc-2"),
+				backendLBPolicy("older", time.Now().Add(-time.Hour), "older", "svc-1", "svc-2"),
+			},
+			expectedUpstream: kong.Upstream{
+				Name:             kong.String("foo-upstream"),
+				Algorithm:        kong.String("consistent-hashing"),
+				HashOn:           kong.String("cookie"),
+				HashOnCookie:     kong.String("older"),
+				HashOnCookiePath: kong.String("/"),
+			},
+		},
+		{
+			name: "services targeted by different BackendLBPolicies",
+			upstream: Upstream{
+				Upstream: kong.Upstream{
+					Name: kong.String("foo-upstream"),
+				},
+				Service: Service{
+					K8sServices: map[string]*corev1.Service{
+						"default/svc-1": serviceWithName("svc-1"),
+						"default/svc-2": serviceWithName("svc-2"),
+					},
+				},
+			},
+			backendLBPolicies: []*gatewayapi.BackendLBPolicy{
+				backendLBPolicy("policy", time.Now(), "session", "svc-1"),
+			},
+			expectedUpstream: kong.Upstream{
+				Name: kong.String("foo-upstream"),
+			},
+			expectedFailures: []failures.ResourceFailure{
+				lo.Must(failures.NewResourceFailure(
+					"inconsistent BackendLBPolicy configuration for services default/svc-1, default/svc-2",
+					serviceWithName("svc-1"), serviceWithName("svc-2"),
+				)),
+			},
+		},
+		{
+			name: "session persistence conflicts with KongUpstreamPolicy hashing",
+			upstream: Upstream{
+				Upstream: kong.Upstream{
+					Name: kong.String("foo-upstream"),
+				},
+				Service: Service{
+					K8sServices:        map[string]*corev1.Service{"": serviceAnnotatedWithKongUpstreamPolicy()},
+					SessionPersistence: &gatewayapi.SessionPersistence{SessionName: lo.ToPtr("session")},
+				},
+			},
+			kongUpstreamPolicies: []*kongv1beta1.KongUpstreamPolicy{hashingKongUpstreamPolicy()},
+			expectedUpstream: kong.Upstream{
+				Name:             kong.String("foo-upstream"),
+				Algorithm:        kong.String("consistent-hashing"),
+				Slots:            kong.Int(100),
+				HashOn:           kong.String("cookie"),
+				HashOnCookie:     kong.String("session"),
+				HashOnCookiePath: kong.String("/"),
+			},
+			expectedFailures: []failures.ResourceFailure{
+				lo.Must(failures.NewResourceFailure(
+					"KongUpstreamPolicy load balancing settings conflict with session persistence configured by route rule, "+
+						"session persistence takes precedence",
+					hashingKongUpstreamPolicy(),
+				)),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1190,6 +1722,7 @@ func TestKongState_FillUpstreamOverrides(t *testing.T) {
 			s, err := store.NewFakeStore(store.FakeObjects{
 				KongUpstreamPolicies: tc.kongUpstreamPolicies,
 				KongIngresses:        tc.kongIngresses,
+				BackendLBPolicies:    tc.backendLBPolicies,
 			})
 			require.NoError(t, err)
 			failuresCollector := failures.NewResourceFailuresCollector(logr.Discard())
@@ -0,0 +1,136 @@
+package kongstate
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ForGateways returns a shallow copy of the KongState holding only the entities that should be configured in
+// a data-plane dedicated to the given Gateways. It includes:
+//   - Routes accepted by any of the Gateways along with their Services and Upstreams,
+//   - Plugins associated with these Routes and Services,
+//   - Certificates of the Gateways' listeners and client Certificates of the included Services,
+//   - all the entities that are not associated with any Route or Service (Consumers, global Plugins, Vaults, etc.).
+func (ks *KongState) ForGateways(gateways ...k8stypes.NamespacedName) *KongState {
+	isIncluded := func(parentGateways []k8stypes.NamespacedName) bool {
+		return lo.SomeBy(parentGateways, func(gateway k8stypes.NamespacedName) bool {
+			return slices.Contains(gateways, gateway)
+		})
+	}
+	return ks.partition(
+		func(route Route) bool {
+			return isIncluded(route.ParentGateways)
+		},
+		func(certificate Certificate) bool {
+			return isIncluded(certificate.ParentGateways)
+		},
+		false,
+	)
+}
+
+// ForSharedGateways returns a shallow copy of the KongState holding only the entities that should be configured in
+// the data-planes shared by all Gateways, i.e. the KongState without the entities configured only in data-planes
+// dedicated to DedicatedGateways. It includes:
+//   - Routes not translated from Gateway API routes (e.g. from Ingresses) and Routes accepted by at least one
+//     Gateway that's not dedicated along with their Services and Upstreams,
+//   - Services without Routes along with their Upstreams,
+//   - Plugins associated with these Routes and Services,
+//   - Certificates not used by Gateways' listeners, Certificates used by listeners of at least one Gateway that's
+//     not dedicated and client Certificates of the included Services,
+//   - all the entities that are not associated with any Route or Service (Consumers, global Plugins, Vaults, etc.).
+func (ks *KongState) ForSharedGateways() *KongState {
+	if len(ks.DedicatedGateways) == 0 {
+		return ks
+	}
+	isShared := func(parentGateways []k8stypes.NamespacedName) bool {
+		return len(parentGateways) == 0 || lo.SomeBy(parentGateways, func(gateway k8stypes.NamespacedName) bool {
+			return !slices.Contains(ks.DedicatedGateways, gateway)
+		})
+	}
+	return ks.partition(
+		func(route Route) bool {
+			return isShared(route.ParentGateways)
+		},
+		func(certificate Certificate) bool {
+			return isShared(certificate.ParentGateways)
+		},
+		true,
+	)
+}
+
+// partition returns a shallow copy of the KongState holding only the Routes and Certificates accepted by the given
+// predicates and the entities depending on them. Services without Routes are included only when
+// includeServicesWithoutRoutes is set.
+func (ks *KongState) partition(
+	includeRoute func(Route) bool,
+	includeCertificate func(Certificate) bool,
+	includeServicesWithoutRoutes bool,
+) *KongState {
+	partition := &KongState{
+		CACertificates:      ks.CACertificates,
+		Licenses:            ks.Licenses,
+		Consumers:           ks.Consumers,
+		ConsumerGroups:      ks.ConsumerGroups,
+		Vaults:              ks.Vaults,
+		CustomEntities:      ks.CustomEntities,
+		DedicatedGateways:   ks.DedicatedGateways,
+		FetchedFromGateways: ks.FetchedFromGateways,
+	}
+
+	serviceNames := sets.New[string]()
+	serviceHosts := sets.New[string]()
+	routeNames := sets.New[string]()
+	clientCertificateIDs := sets.New[string]()
+	for _, service := range ks.Services {
+		routes := lo.Filter(service.Routes, func(route Route, _ int) bool {
+			return includeRoute(route)
+		})
+		if len(routes) == 0 && (len(service.Routes) > 0 || !includeServicesWithoutRoutes) {
+			continue
+		}
+		for _, route := range routes {
+			// Plugins refer to Routes by their names or IDs.
+			routeNames.Insert(lo.FromPtr(route.Name), lo.FromPtr(route.ID))
+		}
+		service.Routes = routes
+		partition.Services = append(partition.Services, service)
+		serviceNames.Insert(lo.FromPtr(service.Name), lo.FromPtr(service.ID))
+		if service.Host != nil {
+			serviceHosts.Insert(*service.Host)
+		}
+		if service.ClientCertificate != nil && service.ClientCertificate.ID != nil {
+			clientCertificateIDs.Insert(*service.ClientCertificate.ID)
+		}
+	}
+
+	// Drop empty values that might have been inserted for unset names or IDs.
+	serviceNames.Delete("")
+	routeNames.Delete("")
+
+	for _, upstream := range ks.Upstreams {
+		if upstream.Name != nil && serviceHosts.Has(*upstream.Name) {
+			partition.Upstreams = append(partition.Upstreams, upstream)
+		}
+	}
+
+	for _, plugin := range ks.Plugins {
+		if plugin.Route != nil && !routeNames.Has(lo.FromPtr(plugin.Route.ID)) {
+			continue
+		}
+		if plugin.Service != nil && !serviceNames.Has(lo.FromPtr(plugin.Service.ID)) {
+			continue
+		}
+		partition.Plugins = append(partition.Plugins, plugin)
+	}
+
+	for _, certificate := range ks.Certificates {
+		if includeCertificate(certificate) || (certificate.ID != nil && clientCertificateIDs.Has(*certificate.ID)) {
+			partition.Certificates = append(partition.Certificates, certificate)
+		}
+	}
+
+	return partition
+}
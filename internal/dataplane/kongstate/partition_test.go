@@ -0,0 +1,148 @@
+package kongstate
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestKongState_ForGateways(t *testing.T) {
+	gateway1 := k8stypes.NamespacedName{Namespace: "default", Name: "gateway-1"}
+	gateway2 := k8stypes.NamespacedName{Namespace: "default", Name: "gateway-2"}
+
+	state := &KongState{
+		Services: []Service{
+			{
+				Service: kong.Service{
+					Name:              kong.String("shared"),
+					Host:              kong.String("shared.default.80.svc"),
+					ClientCertificate: &kong.Certificate{ID: kong.String("client-cert")},
+				},
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("shared-gateway-1")}, ParentGateways: []k8stypes.NamespacedName{gateway1}},
+					{Route: kong.Route{Name: kong.String("shared-gateway-2")}, ParentGateways: []k8stypes.NamespacedName{gateway2}},
+					{Route: kong.Route{Name: kong.String("shared-both")}, ParentGateways: []k8stypes.NamespacedName{gateway1, gateway2}},
+				},
+			},
+			{
+				Service: kong.Service{
+					Name: kong.String("gateway-2-only"),
+					Host: kong.String("gateway-2-only.default.80.svc"),
+				},
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("gateway-2-only")}, ParentGateways: []k8stypes.NamespacedName{gateway2}},
+				},
+			},
+		},
+		Upstreams: []Upstream{
+			{Upstream: kong.Upstream{Name: kong.String("shared.default.80.svc")}},
+			{Upstream: kong.Upstream{Name: kong.String("gateway-2-only.default.80.svc")}},
+		},
+		Plugins: []Plugin{
+			{Plugin: kong.Plugin{Name: kong.String("global")}},
+			{Plugin: kong.Plugin{Name: kong.String("on-shared-service"), Service: &kong.Service{ID: kong.String("shared")}}},
+			{Plugin: kong.Plugin{Name: kong.String("on-gateway-1-route"), Route: &kong.Route{ID: kong.String("shared-gateway-1")}}},
+			{Plugin: kong.Plugin{Name: kong.String("on-gateway-2-route"), Route: &kong.Route{ID: kong.String("shared-gateway-2")}}},
+			{Plugin: kong.Plugin{Name: kong.String("on-gateway-2-service"), Service: &kong.Service{ID: kong.String("gateway-2-only")}}},
+		},
+		Certificates: []Certificate{
+			{Certificate: kong.Certificate{ID: kong.String("gateway-1-cert")}, ParentGateways: []k8stypes.NamespacedName{gateway1}},
+			{Certificate: kong.Certificate{ID: kong.String("gateway-2-cert")}, ParentGateways: []k8stypes.NamespacedName{gateway2}},
+			{Certificate: kong.Certificate{ID: kong.String("client-cert")}},
+			{Certificate: kong.Certificate{ID: kong.String("ingress-cert")}},
+		},
+		Consumers: []Consumer{
+			{Consumer: kong.Consumer{Username: kong.String("consumer")}},
+		},
+	}
+
+	partition := state.ForGateways(gateway1)
+
+	assert.Equal(t, []string{"shared"}, lo.Map(partition.Services, func(s Service, _ int) string { return *s.Name }))
+	assert.Equal(t, []string{"shared-gateway-1", "shared-both"}, lo.Map(partition.Services[0].Routes, func(r Route, _ int) string { return *r.Name }))
+	assert.Len(t, state.Services[0].Routes, 3, "original state should not be modified")
+	assert.Equal(t, []string{"shared.default.80.svc"}, lo.Map(partition.Upstreams, func(u Upstream, _ int) string { return *u.Name }))
+	assert.Equal(t, []string{"global", "on-shared-service", "on-gateway-1-route"}, lo.Map(partition.Plugins, func(p Plugin, _ int) string { return *p.Name }))
+	assert.Equal(t, []string{"gateway-1-cert", "client-cert"}, lo.Map(partition.Certificates, func(c Certificate, _ int) string { return *c.ID }))
+	assert.Equal(t, state.Consumers, partition.Consumers)
+
+	partition = state.ForGateways(gateway1, gateway2)
+	assert.Equal(t, []string{"shared", "gateway-2-only"}, lo.Map(partition.Services, func(s Service, _ int) string { return *s.Name }))
+	assert.Len(t, partition.Services[0].Routes, 3, "routes accepted by any of the Gateways should be included")
+	assert.Equal(t, []string{"gateway-1-cert", "gateway-2-cert", "client-cert"}, lo.Map(partition.Certificates, func(c Certificate, _ int) string { return *c.ID }))
+
+	partition = state.ForGateways(k8stypes.NamespacedName{Namespace: "default", Name: "unknown"})
+	assert.Empty(t, partition.Services)
+	assert.Empty(t, partition.Upstreams)
+	assert.Equal(t, []string{"global"}, lo.Map(partition.Plugins, func(p Plugin, _ int) string { return *p.Name }))
+	assert.Empty(t, partition.Certificates)
+}
+
+func TestKongState_ForSharedGateways(t *testing.T) {
+	managed := k8stypes.NamespacedName{Namespace: "default", Name: "managed"}
+	unmanaged := k8stypes.NamespacedName{Namespace: "default", Name: "unmanaged"}
+
+	state := &KongState{
+		DedicatedGateways: []k8stypes.NamespacedName{managed},
+		Services: []Service{
+			{
+				Service: kong.Service{
+					Name:              kong.String("shared"),
+					Host:              kong.String("shared.default.80.svc"),
+					ClientCertificate: &kong.Certificate{ID: kong.String("client-cert")},
+				},
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("shared-managed")}, ParentGateways: []k8stypes.NamespacedName{managed}},
+					{Route: kong.Route{Name: kong.String("shared-unmanaged")}, ParentGateways: []k8stypes.NamespacedName{unmanaged}},
+					{Route: kong.Route{Name: kong.String("shared-both")}, ParentGateways: []k8stypes.NamespacedName{managed, unmanaged}},
+					{Route: kong.Route{Name: kong.String("shared-ingress")}},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("managed-only"), Host: kong.String("managed-only.default.80.svc")},
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("managed-only")}, ParentGateways: []k8stypes.NamespacedName{managed}},
+				},
+			},
+			{
+				Service: kong.Service{Name: kong.String("without-routes"), Host: kong.String("without-routes.default.80.svc")},
+			},
+		},
+		Upstreams: []Upstream{
+			{Upstream: kong.Upstream{Name: kong.String("shared.default.80.svc")}},
+			{Upstream: kong.Upstream{Name: kong.String("managed-only.default.80.svc")}},
+			{Upstream: kong.Upstream{Name: kong.String("without-routes.default.80.svc")}},
+		},
+		Plugins: []Plugin{
+			{Plugin: kong.Plugin{Name: kong.String("global")}},
+			{Plugin: kong.Plugin{Name: kong.String("on-managed-route"), Route: &kong.Route{ID: kong.String("shared-managed")}}},
+			{Plugin: kong.Plugin{Name: kong.String("on-unmanaged-route"), Route: &kong.Route{ID: kong.String("shared-unmanaged")}}},
+			{Plugin: kong.Plugin{Name: kong.String("on-managed-service"), Service: &kong.Service{ID: kong.String("managed-only")}}},
+		},
+		Certificates: []Certificate{
+			{Certificate: kong.Certificate{ID: kong.String("managed-cert")}, ParentGateways: []k8stypes.NamespacedName{managed}},
+			{Certificate: kong.Certificate{ID: kong.String("unmanaged-cert")}, ParentGateways: []k8stypes.NamespacedName{unmanaged}},
+			{Certificate: kong.Certificate{ID: kong.String("client-cert")}, ParentGateways: []k8stypes.NamespacedName{managed}},
+			{Certificate: kong.Certificate{ID: kong.String("ingress-cert")}},
+		},
+		Consumers: []Consumer{
+			{Consumer: kong.Consumer{Username: kong.String("consumer")}},
+		},
+	}
+
+	partition := state.ForSharedGateways()
+
+	assert.Equal(t, []string{"shared", "without-routes"}, lo.Map(partition.Services, func(s Service, _ int) string { return *s.Name }))
+	assert.Equal(t, []string{"shared-unmanaged", "shared-both", "shared-ingress"}, lo.Map(partition.Services[0].Routes, func(r Route, _ int) string { return *r.Name }))
+	assert.Len(t, state.Services[0].Routes, 4, "original state should not be modified")
+	assert.Equal(t, []string{"shared.default.80.svc", "without-routes.default.80.svc"}, lo.Map(partition.Upstreams, func(u Upstream, _ int) string { return *u.Name }))
+	assert.Equal(t, []string{"global", "on-unmanaged-route"}, lo.Map(partition.Plugins, func(p Plugin, _ int) string { return *p.Name }))
+	assert.Equal(t, []string{"unmanaged-cert", "client-cert", "ingress-cert"}, lo.Map(partition.Certificates, func(c Certificate, _ int) string { return *c.ID }))
+	assert.Equal(t, state.Consumers, partition.Consumers)
+
+	state.DedicatedGateways = nil
+	assert.Same(t, state, state.ForSharedGateways(), "state without dedicated gateways should be returned as is")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
@@ -20,6 +21,10 @@ type Route struct {
 	Ingress          util.K8sObjectInfo
 	Plugins          []kong.Plugin
 	ExpressionRoutes bool
+
+	// ParentGateways are the Gateways that accepted the Kubernetes route object this Route was translated from.
+	// It's empty for Routes translated from objects other than Gateway API routes.
+	ParentGateways []k8stypes.NamespacedName
 }
 
 var (
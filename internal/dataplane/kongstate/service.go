@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 )
 
@@ -58,6 +59,10 @@ type Service struct {
 	// For example, if this Service was created as a result of translating a Kubernetes Ingress, then
 	// Parent is expected to be the Ingress object itself.
 	Parent client.Object
+
+	// SessionPersistence is the session persistence configuration of the Gateway API route rules translated
+	// into this Service. It is applied to the Service's Upstream hashing settings.
+	SessionPersistence *gatewayapi.SessionPersistence
 }
 
 func (s *Service) overridePath(anns map[string]string) {
@@ -171,17 +176,40 @@ func (s *Service) override() error {
 	servicesNames := lo.Keys(s.K8sServices)
 	sort.Strings(servicesNames)
 	for _, serviceName := range servicesNames {
-		svc := s.K8sServices[serviceName]
-		s.overrideByAnnotation(svc.Annotations)
-		protocol := annotations.ExtractProtocolName(svc.Annotations)
-		if !util.ValidateProtocol(protocol) {
-			return fmt.Errorf("%s annotation has invalid value: %s", annotations.AnnotationPrefix+annotations.ProtocolKey, protocol)
+		if err := s.overrideByAnnotationWithValidation(s.K8sServices[serviceName].Annotations); err != nil {
+			return err
 		}
 	}
 
+	s.dropPathForGRPC()
+	return nil
+}
+
+// OverrideByAnnotations sets Service fields using annotations of a Kubernetes Service or a KongServiceFacade
+// the same way they are applied during translation. It returns an error when an annotation has an invalid value.
+func (s *Service) OverrideByAnnotations(anns map[string]string) error {
+	if s == nil {
+		return nil
+	}
+	if err := s.overrideByAnnotationWithValidation(anns); err != nil {
+		return err
+	}
+	s.dropPathForGRPC()
+	return nil
+}
+
+func (s *Service) overrideByAnnotationWithValidation(anns map[string]string) error {
+	s.overrideByAnnotation(anns)
+	protocol := annotations.ExtractProtocolName(anns)
+	if !util.ValidateProtocol(protocol) {
+		return fmt.Errorf("%s annotation has invalid value: %s", annotations.AnnotationPrefix+annotations.ProtocolKey, protocol)
+	}
+	return nil
+}
+
+func (s *Service) dropPathForGRPC() {
 	if s.Protocol != nil && (*s.Protocol == "grpc" || *s.Protocol == "grpcs") {
 		// grpc(s) doesn't accept a path
 		s.Path = nil
 	}
-	return nil
 }
@@ -0,0 +1,115 @@
+package kongstate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
+)
+
+const (
+	// DefaultSessionPersistenceCookieName is the name of the cookie used for cookie-based session persistence
+	// when SessionPersistence doesn't specify the session name.
+	DefaultSessionPersistenceCookieName = "KONG_SESSION"
+	// DefaultSessionPersistenceHeaderName is the name of the header used for header-based session persistence
+	// when SessionPersistence doesn't specify the session name.
+	DefaultSessionPersistenceHeaderName = "X-Kong-Session"
+
+	kongAlgorithmConsistentHashing = "consistent-hashing"
+)
+
+// getSessionPersistenceForService returns the SessionPersistence that should be applied to the Upstream of the given
+// Service along with a human-readable description of where it comes from. Session persistence configured on route
+// rules takes precedence over BackendLBPolicies targeting the Service's backends as required by the Gateway API.
+//
+// BackendLBPolicies are taken into account only if all the Kubernetes Services in the group are targeted by the same
+// policy. An error is returned if only some of them are or if they are targeted by different policies.
+func getSessionPersistenceForService(
+	s store.Storer, service Service, servicesGroup []*corev1.Service,
+) (*gatewayapi.SessionPersistence, string, error) {
+	if service.SessionPersistence != nil {
+		source := "route rule"
+		if service.Parent != nil {
+			source = fmt.Sprintf("%s %s/%s rule",
+				service.Parent.GetObjectKind().GroupVersionKind().Kind, service.Parent.GetNamespace(), service.Parent.GetName())
+		}
+		return service.SessionPersistence, source, nil
+	}
+	if len(servicesGroup) == 0 {
+		return nil, "", nil
+	}
+
+	policies, err := s.ListBackendLBPolicies()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed listing BackendLBPolicies: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil, "", nil
+	}
+	// The oldest policy targeting a Service takes precedence as required by the Gateway API.
+	sort.SliceStable(policies, func(i, j int) bool {
+		if !policies[i].CreationTimestamp.Equal(&policies[j].CreationTimestamp) {
+			return policies[i].CreationTimestamp.Before(&policies[j].CreationTimestamp)
+		}
+		return policies[i].Name < policies[j].Name
+	})
+
+	policiesByService := lo.Map(servicesGroup, func(svc *corev1.Service, _ int) *gatewayapi.BackendLBPolicy {
+		policy, _ := lo.Find(policies, func(p *gatewayapi.BackendLBPolicy) bool {
+			return BackendLBPolicyTargetsService(p, svc.Namespace, svc.Name)
+		})
+		return policy
+	})
+	if len(lo.Uniq(policiesByService)) > 1 {
+		return nil, "", fmt.Errorf("inconsistent BackendLBPolicy configuration for services %s",
+			prettyPrintServiceList(servicesGroup))
+	}
+	policy := policiesByService[0]
+	if policy == nil || policy.Spec.SessionPersistence == nil {
+		return nil, "", nil
+	}
+	return policy.Spec.SessionPersistence, fmt.Sprintf("BackendLBPolicy %s/%s", policy.Namespace, policy.Name), nil
+}
+
+// BackendLBPolicyTargetsService returns true if the given BackendLBPolicy targets the Service with the given
+// namespace and name.
+func BackendLBPolicyTargetsService(policy *gatewayapi.BackendLBPolicy, namespace, name string) bool {
+	if policy.Namespace != namespace {
+		return false
+	}
+	return lo.ContainsBy(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReference) bool {
+		return (ref.Group == "" || ref.Group == "core") && ref.Kind == "Service" && string(ref.Name) == name
+	})
+}
+
+// TranslateSessionPersistence translates SessionPersistence to kong.Upstream hashing settings.
+// Cookie-based session persistence relies on the cookie Kong sets when it's missing in a request.
+// Kong doesn't support limiting the lifetime of such sessions, therefore absoluteTimeout, idleTimeout
+// and cookieConfig are not taken into account.
+func TranslateSessionPersistence(sessionPersistence *gatewayapi.SessionPersistence) *kong.Upstream {
+	upstream := &kong.Upstream{
+		Algorithm: kong.String(kongAlgorithmConsistentHashing),
+	}
+	if sessionPersistence.Type != nil && *sessionPersistence.Type == gatewayapi.HeaderBasedSessionPersistence {
+		upstream.HashOn = kong.String(KongHashOnTypeHeader)
+		upstream.HashOnHeader = kong.String(lo.FromPtrOr(sessionPersistence.SessionName, DefaultSessionPersistenceHeaderName))
+		return upstream
+	}
+	upstream.HashOn = kong.String(KongHashOnTypeCookie)
+	upstream.HashOnCookie = kong.String(lo.FromPtrOr(sessionPersistence.SessionName, DefaultSessionPersistenceCookieName))
+	upstream.HashOnCookiePath = kong.String("/")
+	return upstream
+}
+
+// KongUpstreamPolicyConfiguresHashing returns true if the KongUpstreamPolicy sets load balancing settings that
+// conflict with session persistence.
+func KongUpstreamPolicyConfiguresHashing(policy *kongv1beta1.KongUpstreamPolicy) bool {
+	return policy.Spec.HashOn != nil || policy.Spec.HashOnFallback != nil ||
+		(policy.Spec.Algorithm != nil && *policy.Spec.Algorithm != kongAlgorithmConsistentHashing)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/kong/go-kong/kong"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -51,12 +52,15 @@ type Target struct {
 // Certificate represents the certificate object in Kong.
 type Certificate struct {
 	kong.Certificate
+
+	// ParentGateways are the Gateways whose listeners use this Certificate.
+	ParentGateways []k8stypes.NamespacedName
 }
 
 // SanitizedCopy returns a shallow copy with sensitive values redacted best-effort.
 func (c *Certificate) SanitizedCopy() *Certificate {
 	return &Certificate{
-		kong.Certificate{
+		Certificate: kong.Certificate{
 			ID:        c.ID,
 			Cert:      c.Cert,
 			Key:       redactedString,
@@ -64,6 +68,7 @@ func (c *Certificate) SanitizedCopy() *Certificate {
 			SNIs:      c.SNIs,
 			Tags:      c.Tags,
 		},
+		ParentGateways: c.ParentGateways,
 	}
 }
 
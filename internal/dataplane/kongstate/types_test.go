@@ -15,7 +15,7 @@ func TestCertificate_SanitizedCopy(t *testing.T) {
 	}{
 		{
 			name: "fills all fields but Consumer and sanitizes key",
-			in: Certificate{kong.Certificate{
+			in: Certificate{Certificate: kong.Certificate{
 				ID:        kong.String("1"),
 				Cert:      kong.String("2"),
 				Key:       kong.String("3"),
@@ -23,7 +23,7 @@ func TestCertificate_SanitizedCopy(t *testing.T) {
 				SNIs:      []*string{kong.String("5.1"), kong.String("5.2")},
 				Tags:      []*string{kong.String("6.1"), kong.String("6.2")},
 			}},
-			want: Certificate{kong.Certificate{
+			want: Certificate{Certificate: kong.Certificate{
 				ID:        kong.String("1"),
 				Cert:      kong.String("2"),
 				Key:       redactedString,
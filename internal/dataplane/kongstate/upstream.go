@@ -6,6 +6,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1beta1"
 )
@@ -140,6 +141,26 @@ func (u *Upstream) overrideByKongUpstreamPolicy(policy *kongv1beta1.KongUpstream
 	}
 }
 
+// overrideBySessionPersistence sets the Upstream's hashing settings so that requests of a session are routed
+// to the same target.
+func (u *Upstream) overrideBySessionPersistence(sessionPersistence *gatewayapi.SessionPersistence) {
+	if u == nil || sessionPersistence == nil {
+		return
+	}
+
+	overrides := TranslateSessionPersistence(sessionPersistence)
+	u.Algorithm = overrides.Algorithm
+	u.HashOn = overrides.HashOn
+	u.HashOnHeader = overrides.HashOnHeader
+	u.HashOnCookie = overrides.HashOnCookie
+	u.HashOnCookiePath = overrides.HashOnCookiePath
+	// Fallbacks configured by other means would not respect the session.
+	u.HashFallback = nil
+	u.HashFallbackHeader = nil
+	u.HashFallbackQueryArg = nil
+	u.HashFallbackURICapture = nil
+}
+
 // override sets Upstream fields by KongIngress first, then by k8s Service's annotations.
 func (u *Upstream) override(
 	kongIngress *kongv1.KongIngress,
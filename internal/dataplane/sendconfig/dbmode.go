@@ -2,10 +2,10 @@ package sendconfig
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
-	"sync"
 
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
@@ -24,14 +24,12 @@ import (
 // UpdateStrategyDBMode implements the UpdateStrategy interface. It updates Kong's data-plane
 // configuration using decK's syncer.
 type UpdateStrategyDBMode struct {
-	client            *kong.Client
-	dumpConfig        dump.Config
-	version           semver.Version
-	concurrency       int
-	isKonnect         bool
-	logger            logr.Logger
-	resourceErrors    []ResourceError
-	resourceErrorLock *sync.Mutex
+	client      *kong.Client
+	dumpConfig  dump.Config
+	version     semver.Version
+	concurrency int
+	isKonnect   bool
+	logger      logr.Logger
 }
 
 func NewUpdateStrategyDBMode(
@@ -42,13 +40,11 @@ func NewUpdateStrategyDBMode(
 	logger logr.Logger,
 ) UpdateStrategyDBMode {
 	return UpdateStrategyDBMode{
-		client:            client,
-		dumpConfig:        dumpConfig,
-		version:           version,
-		concurrency:       concurrency,
-		logger:            logger,
-		resourceErrors:    []ResourceError{},
-		resourceErrorLock: &sync.Mutex{},
+		client:      client,
+		dumpConfig:  dumpConfig,
+		version:     version,
+		concurrency: concurrency,
+		logger:      logger,
 	}
 }
 
@@ -88,19 +84,27 @@ func (s UpdateStrategyDBMode) Update(ctx context.Context, targetContent ContentW
 		return fmt.Errorf("creating a new syncer for %s: %w", s.client.BaseRootURL(), err)
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	go s.HandleEvents(ctx, syncer.GetResultChan())
+	// The syncer doesn't close its result channel when it refuses to run, so it has to be checked upfront.
+	if s.concurrency < 1 {
+		return fmt.Errorf("concurrency for %s has to be positive, got %d", s.client.BaseRootURL(), s.concurrency)
+	}
+
+	// Resource errors are collected from the entity actions reported by the syncer, so that failures can be mapped back
+	// to the Kubernetes objects the failed entities were translated from. The syncer closes the channel once it's done.
+	resourceErrorsCh := make(chan []ResourceError, 1)
+	go func() {
+		resourceErrorsCh <- s.handleEvents(syncer.GetResultChan())
+	}()
 
 	_, errs, _ := syncer.Solve(ctx, s.concurrency, false, false)
-	cancel()
-	s.resourceErrorLock.Lock()
-	defer s.resourceErrorLock.Unlock()
-	resourceFailures := resourceErrorsToResourceFailures(s.resourceErrors, s.logger)
+	resourceErrors := <-resourceErrorsCh
+	resourceFailures := resourceErrorsToResourceFailures(resourceErrors, s.logger)
+	entityFailures := resourceErrorsToEntityFailures(resourceErrors)
 	if errs != nil {
 		return NewUpdateError(
 			resourceFailures,
 			deckutils.ErrArray{Errors: errs},
-		)
+		).WithEntityFailures(entityFailures)
 	}
 
 	// as of GDR 1.8 we should always get a plain error set in addition to resourceErrors, so returning resourceErrors
@@ -109,35 +113,30 @@ func (s UpdateStrategyDBMode) Update(ctx context.Context, targetContent ContentW
 		return NewUpdateError(
 			resourceFailures,
 			errors.New("go-database-reconciler found resource errors"),
-		)
+		).WithEntityFailures(entityFailures)
 	}
 
 	return nil
 }
 
-// HandleEvents handles logging and error reporting for individual entity change events generated during a sync by
-// looping over an event channel. It terminates when its context dies.
-func (s *UpdateStrategyDBMode) HandleEvents(ctx context.Context, events chan diff.EntityAction) {
-	s.resourceErrorLock.Lock()
-	for {
-		select {
-		case event := <-events:
-			if event.Error == nil {
-				s.logger.V(util.DebugLevel).Info("updated gateway entity", "action", event.Action, "kind", event.Entity.Kind, "name", event.Entity.Name)
-			} else {
-				s.logger.Error(event.Error, "failed updating gateway entity", "action", event.Action, "kind", event.Entity.Kind, "name", event.Entity.Name)
-				parsed, err := resourceErrorFromEntityAction(event)
-				if err != nil {
-					s.logger.Error(err, "could not parse entity update error")
-				} else {
-					s.resourceErrors = append(s.resourceErrors, parsed)
-				}
-			}
-		case <-ctx.Done():
-			s.resourceErrorLock.Unlock()
-			return
+// handleEvents handles logging and error reporting for individual entity change events generated during a sync by
+// looping over an event channel until it's closed. It returns errors of entities that failed to be updated.
+func (s UpdateStrategyDBMode) handleEvents(events <-chan diff.EntityAction) []ResourceError {
+	var resourceErrors []ResourceError
+	for event := range events {
+		if event.Error == nil {
+			s.logger.V(util.DebugLevel).Info("updated gateway entity", "action", event.Action, "kind", event.Entity.Kind, "name", event.Entity.Name)
+			continue
+		}
+		s.logger.Error(event.Error, "failed updating gateway entity", "action", event.Action, "kind", event.Entity.Kind, "name", event.Entity.Name)
+		parsed, err := resourceErrorFromEntityAction(event)
+		if err != nil {
+			s.logger.Error(err, "could not parse entity update error")
+			continue
 		}
+		resourceErrors = append(resourceErrors, parsed)
 	}
+	return resourceErrors
 }
 
 func resourceErrorFromEntityAction(event diff.EntityAction) (ResourceError, error) {
@@ -180,20 +179,45 @@ func resourceErrorFromEntityAction(event diff.EntityAction) (ResourceError, erro
 	// it.
 	raw := rawResourceError{
 		Name: event.Entity.Name,
+		Type: event.Entity.Kind,
 		Tags: actualTags,
 		// /config flattened errors have a structured set of field to error reasons, whereas GDR errors are just plain
 		// un-parsed admin API endpoint strings. These will often mention a field within the string, e.g.
 		// schema violation (methods: cannot set 'methods' when 'protocols' is 'grpc' or 'grpcs')
 		// has "methods", but we'd need to do string parsing to extract it, and we may not catch all possible error types.
-		// This lazier approach just dumps the full error string as a single problem, which is probably good enough.
+		// This lazier approach just dumps the full error string as a single problem of the entity, which is probably
+		// good enough.
 		Problems: map[string]string{
-			"": fmt.Sprintf("%s", event.Error),
+			event.Entity.Kind: fmt.Sprintf("%s", event.Error),
 		},
 	}
+	if event.Entity.Kind == pluginEntityType {
+		// Plugins are identified by their fields rather than by their GDR names, so the entity is passed on in the same
+		// shape /config flattened errors have.
+		entity, err := rawEntityFromObject(subj)
+		if err != nil {
+			return ResourceError{}, fmt.Errorf("entity %s/%s could not be converted: %w",
+				event.Entity.Kind, event.Entity.Name, err)
+		}
+		raw.Entity = entity
+	}
 
 	return parseRawResourceError(raw)
 }
 
+// rawEntityFromObject converts an entity object to its raw JSON representation.
+func rawEntityFromObject(obj any) (map[string]any, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	entity := map[string]any{}
+	if err := json.Unmarshal(b, &entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
 func (s UpdateStrategyDBMode) MetricsProtocol() metrics.Protocol {
 	return metrics.ProtocolDeck
 }
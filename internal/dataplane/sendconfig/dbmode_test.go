@@ -0,0 +1,107 @@
+package sendconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/go-database-reconciler/pkg/diff"
+	"github.com/kong/go-database-reconciler/pkg/dump"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStrategyDBMode_HandleEvents(t *testing.T) {
+	s := NewUpdateStrategyDBMode(nil, dump.Config{}, semver.MustParse("3.9.0"), 1, logr.Discard())
+
+	events := make(chan diff.EntityAction, 4)
+	events <- diff.EntityAction{
+		Action: diff.CreateAction,
+		Entity: diff.Entity{
+			Name: "valid",
+			Kind: "route",
+			New:  &kong.Route{Name: kong.String("valid")},
+		},
+	}
+	events <- diff.EntityAction{
+		Action: diff.UpdateAction,
+		Entity: diff.Entity{
+			Name: "broken",
+			Kind: "route",
+			Old:  &kong.Route{Name: kong.String("broken")},
+			New: &kong.Route{
+				Name: kong.String("broken"),
+				Tags: kong.StringSlice(
+					"k8s-name:httpbin",
+					"k8s-namespace:default",
+					"k8s-kind:Ingress",
+					"k8s-uid:ea569579-f7e9-4d4e-973b-b207bfb848d8",
+					"k8s-group:networking.k8s.io",
+					"k8s-version:v1",
+				),
+			},
+		},
+		Error: errors.New("schema violation (methods: cannot set 'methods' when 'protocols' is 'grpc' or 'grpcs')"),
+	}
+	events <- diff.EntityAction{
+		Action: diff.DeleteAction,
+		Entity: diff.Entity{
+			Name: "untagged",
+			Kind: "route",
+			Old:  &kong.Route{Name: kong.String("untagged")},
+		},
+		Error: errors.New("not found"),
+	}
+	events <- diff.EntityAction{
+		Action: diff.CreateAction,
+		Entity: diff.Entity{
+			Name: "rate-limiting (route c1f1ba3a-5b8e-4f58-a7a7-63a0c5b4b7f2)",
+			Kind: "plugin",
+			New: &kong.Plugin{
+				Name:  kong.String("rate-limiting"),
+				Route: &kong.Route{ID: kong.String("c1f1ba3a-5b8e-4f58-a7a7-63a0c5b4b7f2")},
+				Tags: kong.StringSlice(
+					"k8s-name:rate-limiting",
+					"k8s-namespace:default",
+					"k8s-kind:KongPlugin",
+					"k8s-uid:0f2b9e4e-7c4c-4a0e-9a8e-2b4f3c1d5e6f",
+					"k8s-group:configuration.konghq.com",
+					"k8s-version:v1",
+				),
+			},
+		},
+		Error: errors.New("schema violation (config.minute: expected a number)"),
+	}
+	close(events)
+
+	require.Equal(t, []ResourceError{
+		{
+			Name:       "httpbin",
+			Namespace:  "default",
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+			UID:        "ea569579-f7e9-4d4e-973b-b207bfb848d8",
+			Problems: map[string]string{
+				"route": "schema violation (methods: cannot set 'methods' when 'protocols' is 'grpc' or 'grpcs')",
+			},
+			EntityType: "route",
+			EntityName: "broken",
+		},
+		{
+			Name:       "rate-limiting",
+			Namespace:  "default",
+			Kind:       "KongPlugin",
+			APIVersion: "configuration.konghq.com/v1",
+			UID:        "0f2b9e4e-7c4c-4a0e-9a8e-2b4f3c1d5e6f",
+			Problems: map[string]string{
+				"plugin": "schema violation (config.minute: expected a number)",
+			},
+			EntityType: "plugin",
+			EntityName: "rate-limiting",
+			EntityForeignKeys: map[string]string{
+				"route": "c1f1ba3a-5b8e-4f58-a7a7-63a0c5b4b7f2",
+			},
+		},
+	}, s.handleEvents(events), "only errors of entities with Kubernetes object tags should be returned")
+}
@@ -0,0 +1,234 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-database-reconciler/pkg/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/iter"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// UpdateStrategyDryRun implements the UpdateStrategy interface. Instead of applying the configuration, it only
+// validates it.
+//
+// When a shadow gateway is set with WithShadowGateway, the whole configuration is validated at once by applying it
+// to the shadow gateway using its `POST /config` endpoint. That catches conflicts between entities (e.g. duplicate
+// names or overlapping routes) the same way the gateways would when applying it.
+//
+// Otherwise, Kong's `POST /config` endpoint has no way to validate a configuration without applying it, so each
+// entity is validated on its own against the schemas of the Kong Gateway using `POST /schemas/{entity}/validate`
+// endpoints that are available in both DB-less and DB-backed modes. Conflicts between entities are not detected then.
+//
+// Entities failing validation are reported as resource failures of the Kubernetes objects they were translated from,
+// the same way as errors returned by Kong when applying the configuration.
+type UpdateStrategyDryRun struct {
+	schemaService kong.AbstractSchemaService
+	concurrency   int
+	logger        logr.Logger
+
+	// shadowGateway, when set, validates the whole configuration instead of schemaService.
+	shadowGateway UpdateStrategy
+}
+
+func NewUpdateStrategyDryRun(
+	schemaService kong.AbstractSchemaService,
+	concurrency int,
+	logger logr.Logger,
+) UpdateStrategyDryRun {
+	return UpdateStrategyDryRun{
+		schemaService: schemaService,
+		concurrency:   concurrency,
+		logger:        logger,
+	}
+}
+
+// WithShadowGateway returns a copy of the strategy validating the whole configuration by applying it to a shadow
+// gateway using the given service. The shadow gateway has to run in DB-less mode and must never serve traffic.
+func (s UpdateStrategyDryRun) WithShadowGateway(configService ConfigService) UpdateStrategyDryRun {
+	s.shadowGateway = NewUpdateStrategyInMemory(configService, DefaultContentToDBLessConfigConverter{}, s.logger)
+	return s
+}
+
+// entityToValidate is a Kong entity validated by UpdateStrategyDryRun.
+type entityToValidate struct {
+	entityType kong.EntityType
+	name       string
+	id         *string
+	tags       []*string
+	entity     any
+}
+
+func (s UpdateStrategyDryRun) Update(ctx context.Context, targetState ContentWithHash) error {
+	if s.shadowGateway != nil {
+		return s.shadowGateway.Update(ctx, targetState)
+	}
+
+	entities := entitiesToValidate(targetState.Content)
+
+	mapper := iter.Mapper[entityToValidate, *rawResourceError]{MaxGoroutines: max(s.concurrency, 1)}
+	rawErrors, err := mapper.MapErr(entities, func(e *entityToValidate) (*rawResourceError, error) {
+		valid, msg, err := s.schemaService.Validate(ctx, e.entityType, e.entity)
+		if err != nil {
+			return nil, fmt.Errorf("validating %s %q: %w", e.entityType, e.name, err)
+		}
+		if valid {
+			return nil, nil
+		}
+		s.logger.V(util.DebugLevel).Info("Entity failed validation", "type", e.entityType, "name", e.name, "reason", msg)
+		return &rawResourceError{
+			Name:     e.name,
+			ID:       lo.FromPtr(e.id),
+			Tags:     lo.Map(e.tags, func(t *string, _ int) string { return lo.FromPtr(t) }),
+			Problems: map[string]string{fmt.Sprintf("%s:%s", strings.TrimSuffix(string(e.entityType), "s"), e.name): msg},
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		invalidCount   int
+		resourceErrors []ResourceError
+	)
+	for _, raw := range rawErrors {
+		if raw == nil {
+			continue
+		}
+		invalidCount++
+		resourceErr, err := parseRawResourceError(*raw)
+		if err != nil {
+			s.logger.Error(err, "Could not parse validation error of entity", "name", raw.Name, "problems", raw.Problems)
+			continue
+		}
+		resourceErrors = append(resourceErrors, resourceErr)
+	}
+	if invalidCount > 0 {
+		return NewUpdateError(
+			resourceErrorsToResourceFailures(resourceErrors, s.logger),
+			fmt.Errorf("%d entities failed validation", invalidCount),
+		)
+	}
+	return nil
+}
+
+func (s UpdateStrategyDryRun) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolDryRun
+}
+
+func (s UpdateStrategyDryRun) Type() string {
+	return "DryRun"
+}
+
+// entitiesToValidate returns entities of the content that can be validated on their own. References to other entities
+// are dropped, as they're not resolved by the validation endpoints. Entities that can't exist without a reference
+// to another entity (e.g. targets or credentials) are not validated.
+func entitiesToValidate(content *file.Content) []entityToValidate {
+	var entities []entityToValidate
+	addPlugin := func(p *file.FPlugin) {
+		plugin := p.Plugin.DeepCopy()
+		plugin.Service, plugin.Route, plugin.Consumer, plugin.ConsumerGroup = nil, nil, nil, nil
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypePlugins,
+			name:       lo.FromPtr(plugin.Name),
+			id:         plugin.ID,
+			tags:       plugin.Tags,
+			entity:     plugin,
+		})
+	}
+	addRoute := func(r *file.FRoute) {
+		route := r.Route.DeepCopy()
+		route.Service = nil
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypeRoutes,
+			name:       lo.FromPtr(route.Name),
+			id:         route.ID,
+			tags:       route.Tags,
+			entity:     route,
+		})
+		for _, p := range r.Plugins {
+			addPlugin(p)
+		}
+	}
+
+	for _, s := range content.Services {
+		service := s.Service.DeepCopy()
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypeServices,
+			name:       lo.FromPtr(service.Name),
+			id:         service.ID,
+			tags:       service.Tags,
+			entity:     service,
+		})
+		for _, r := range s.Routes {
+			addRoute(r)
+		}
+		for _, p := range s.Plugins {
+			addPlugin(p)
+		}
+	}
+	for _, r := range content.Routes {
+		addRoute(&r)
+	}
+	for _, p := range content.Plugins {
+		addPlugin(&p)
+	}
+	for _, u := range content.Upstreams {
+		upstream := u.Upstream.DeepCopy()
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypeUpstreams,
+			name:       lo.FromPtr(upstream.Name),
+			id:         upstream.ID,
+			tags:       upstream.Tags,
+			entity:     upstream,
+		})
+	}
+	for _, c := range content.Consumers {
+		consumer := c.Consumer.DeepCopy()
+		name := lo.FromPtr(consumer.Username)
+		if name == "" {
+			name = lo.FromPtr(consumer.CustomID)
+		}
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypeConsumers,
+			name:       name,
+			id:         consumer.ID,
+			tags:       consumer.Tags,
+			entity:     consumer,
+		})
+		for _, p := range c.Plugins {
+			addPlugin(p)
+		}
+	}
+	for _, c := range content.Certificates {
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypeCertificates,
+			name:       lo.FromPtr(c.ID),
+			id:         c.ID,
+			tags:       c.Tags,
+			entity: &kong.Certificate{
+				ID:   c.ID,
+				Cert: c.Cert,
+				Key:  c.Key,
+				Tags: c.Tags,
+			},
+		})
+	}
+	for _, c := range content.CACertificates {
+		caCertificate := c.CACertificate.DeepCopy()
+		entities = append(entities, entityToValidate{
+			entityType: kong.EntityTypeCACertificates,
+			name:       lo.FromPtr(caCertificate.ID),
+			id:         caCertificate.ID,
+			tags:       caCertificate.Tags,
+			entity:     caCertificate,
+		})
+	}
+	return entities
+}
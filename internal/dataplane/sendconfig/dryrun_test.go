@@ -0,0 +1,200 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-database-reconciler/pkg/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+)
+
+// fakeSchemaService rejects entities of the configured types.
+type fakeSchemaService struct {
+	invalidEntityTypes map[kong.EntityType]string
+	err                error
+
+	lock      sync.Mutex
+	validated []kong.EntityType
+}
+
+func (f *fakeSchemaService) Get(context.Context, string) (kong.Schema, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeSchemaService) Validate(_ context.Context, entityType kong.EntityType, _ any) (bool, string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.validated = append(f.validated, entityType)
+	if f.err != nil {
+		return false, "", f.err
+	}
+	if msg, ok := f.invalidEntityTypes[entityType]; ok {
+		return false, msg, nil
+	}
+	return true, "", nil
+}
+
+// fakeShadowGateway counts configurations sent to it and responds with the configured error body.
+type fakeShadowGateway struct {
+	errBody []byte
+	calls   int
+}
+
+func (f *fakeShadowGateway) ReloadDeclarativeRawConfig(_ context.Context, config io.Reader, _, flattenErrors bool) ([]byte, error) {
+	f.calls++
+	if _, err := io.ReadAll(config); err != nil {
+		return nil, err
+	}
+	if !flattenErrors {
+		return nil, errors.New("flattened errors expected")
+	}
+	if f.errBody != nil {
+		return f.errBody, errors.New("HTTP status 400 (message: \"declarative config is invalid\")")
+	}
+	return nil, nil
+}
+
+func TestUpdateStrategyDryRun(t *testing.T) {
+	ingressTags := kong.StringSlice(
+		"k8s-name:ingress",
+		"k8s-namespace:default",
+		"k8s-kind:Ingress",
+		"k8s-group:networking.k8s.io",
+		"k8s-version:v1",
+		"k8s-uid:ingress-uid",
+	)
+	pluginTags := kong.StringSlice(
+		"k8s-name:rate-limit",
+		"k8s-namespace:default",
+		"k8s-kind:KongPlugin",
+		"k8s-group:configuration.konghq.com",
+		"k8s-version:v1",
+		"k8s-uid:plugin-uid",
+	)
+	content := &file.Content{
+		Services: []file.FService{
+			{
+				Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")},
+				Routes: []*file.FRoute{
+					{
+						Route: kong.Route{ID: kong.String("route-id"), Name: kong.String("route"), Paths: kong.StringSlice("/"), Tags: ingressTags},
+						Plugins: []*file.FPlugin{
+							{Plugin: kong.Plugin{
+								Name:  kong.String("rate-limiting"),
+								Route: &kong.Route{ID: kong.String("route-id"), Name: kong.String("route")},
+								Tags:  pluginTags,
+							}},
+						},
+					},
+				},
+			},
+		},
+		Consumers: []file.FConsumer{
+			{Consumer: kong.Consumer{Username: kong.String("consumer")}},
+		},
+		Upstreams: []file.FUpstream{
+			{Upstream: kong.Upstream{Name: kong.String("upstream")}},
+		},
+	}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		schemaService := &fakeSchemaService{}
+		strategy := NewUpdateStrategyDryRun(schemaService, 2, logr.Discard())
+		require.NoError(t, strategy.Update(context.Background(), ContentWithHash{Content: content}))
+		require.ElementsMatch(t, []kong.EntityType{
+			kong.EntityTypeServices,
+			kong.EntityTypeRoutes,
+			kong.EntityTypePlugins,
+			kong.EntityTypeConsumers,
+			kong.EntityTypeUpstreams,
+		}, schemaService.validated)
+		require.NotNil(t, content.Services[0].Routes[0].Plugins[0].Route, "content must not be modified")
+	})
+
+	t.Run("invalid entities are reported as resource failures", func(t *testing.T) {
+		schemaService := &fakeSchemaService{
+			invalidEntityTypes: map[kong.EntityType]string{
+				kong.EntityTypePlugins:   "schema violation (config.minute: expected a number)",
+				kong.EntityTypeConsumers: "schema violation (username: invalid)",
+			},
+		}
+		strategy := NewUpdateStrategyDryRun(schemaService, 2, logr.Discard())
+		err := strategy.Update(context.Background(), ContentWithHash{Content: content})
+
+		var updateErr UpdateError
+		require.ErrorAs(t, err, &updateErr)
+		require.EqualError(t, err, "2 entities failed validation")
+		// The consumer has no Kubernetes object tags, so it can't be reported as a resource failure.
+		require.Equal(t,
+			[]string{"invalid plugin:rate-limiting: schema violation (config.minute: expected a number)"},
+			lo.Map(updateErr.ResourceFailures(), func(f failures.ResourceFailure, _ int) string { return f.Message() }),
+		)
+		causingObjects := updateErr.ResourceFailures()[0].CausingObjects()
+		require.Len(t, causingObjects, 1)
+		require.Equal(t, "rate-limit", causingObjects[0].GetName())
+		require.Equal(t, "KongPlugin", causingObjects[0].GetObjectKind().GroupVersionKind().Kind)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		schemaService := &fakeSchemaService{err: errors.New("connection refused")}
+		strategy := NewUpdateStrategyDryRun(schemaService, 1, logr.Discard())
+		err := strategy.Update(context.Background(), ContentWithHash{Content: content})
+		require.ErrorContains(t, err, "connection refused")
+		require.False(t, errors.As(err, &UpdateError{}))
+	})
+
+	t.Run("whole configuration is validated by shadow gateway", func(t *testing.T) {
+		schemaService := &fakeSchemaService{}
+		shadowGateway := &fakeShadowGateway{}
+		strategy := NewUpdateStrategyDryRun(schemaService, 2, logr.Discard()).WithShadowGateway(shadowGateway)
+		require.NoError(t, strategy.Update(context.Background(), ContentWithHash{Content: content}))
+		require.Equal(t, 1, shadowGateway.calls, "whole configuration should be validated with a single request")
+		require.Empty(t, schemaService.validated, "entities should not be validated on their own")
+	})
+
+	t.Run("conflicts reported by shadow gateway are reported as resource failures", func(t *testing.T) {
+		shadowGateway := &fakeShadowGateway{
+			errBody: []byte(`{
+  "name": "invalid declarative configuration",
+  "flattened_errors": [
+    {
+      "entity_type": "route",
+      "entity_name": "route",
+      "entity_tags": [
+        "k8s-name:ingress",
+        "k8s-namespace:default",
+        "k8s-kind:Ingress",
+        "k8s-group:networking.k8s.io",
+        "k8s-version:v1",
+        "k8s-uid:ingress-uid"
+      ],
+      "errors": [
+        {
+          "type": "entity",
+          "message": "uniqueness violation: 'routes' entity with name set to 'route' already declared"
+        }
+      ]
+    }
+  ]
+}`),
+		}
+		strategy := NewUpdateStrategyDryRun(&fakeSchemaService{}, 2, logr.Discard()).WithShadowGateway(shadowGateway)
+		err := strategy.Update(context.Background(), ContentWithHash{Content: content})
+
+		var updateErr UpdateError
+		require.ErrorAs(t, err, &updateErr)
+		require.Len(t, updateErr.ResourceFailures(), 1)
+		require.Contains(t, updateErr.ResourceFailures()[0].Message(), "uniqueness violation")
+		causingObjects := updateErr.ResourceFailures()[0].CausingObjects()
+		require.Len(t, causingObjects, 1)
+		require.Equal(t, "ingress", causingObjects[0].GetName())
+	})
+}
@@ -28,6 +28,8 @@ func TestParseFlatEntityErrors(t *testing.T) {
 					Problems: map[string]string{
 						"methods": "cannot set methods when protocols is grpc or grpcs",
 					},
+					EntityType: "route",
+					EntityName: "67338dc2-31fd-47b6-85a9-9c11d347d090.httpbin.httpbin..80",
 				},
 				{
 					Name:       "httpbin",
@@ -39,6 +41,8 @@ func TestParseFlatEntityErrors(t *testing.T) {
 						"service:67338dc2-31fd-47b6-85a9-9c11d347d090.httpbin.httpbin.80": "failed conditional validation given value of field protocol",
 						"path": "value must be null",
 					},
+					EntityType: "service",
+					EntityName: "67338dc2-31fd-47b6-85a9-9c11d347d090.httpbin.httpbin.80",
 				},
 			},
 			wantErr: false,
@@ -146,6 +150,70 @@ func TestParseFlatEntityErrors(t *testing.T) {
   ],
   "message": "declarative config is invalid: {services={{[\"@entity\"]={\"failed conditional validation given value of field protocol\"},path=\"value must be null\"}}}",
   "code": 14
+}`),
+		},
+		{
+			name: "a plugin associated with a route",
+			want: []ResourceError{
+				{
+					Name:       "rate-limiting",
+					Namespace:  "default",
+					Kind:       "KongPlugin",
+					APIVersion: "configuration.konghq.com/v1",
+					UID:        "0f2b9e4e-7c4c-4a0e-9a8e-2b4f3c1d5e6f",
+					Problems: map[string]string{
+						"config.minute": "expected a number",
+					},
+					EntityType: "plugin",
+					EntityName: "rate-limiting",
+					EntityForeignKeys: map[string]string{
+						"route": "default.httpbin.httpbin..80",
+					},
+				},
+			},
+			wantErr: false,
+			body: []byte(`{
+  "name": "invalid declarative configuration",
+  "flattened_errors": [
+    {
+      "entity_type": "plugin",
+      "entity_name": "rate-limiting",
+      "entity": {
+        "name": "rate-limiting",
+        "route": {
+          "id": "default.httpbin.httpbin..80"
+        },
+        "config": {
+          "minute": "five"
+        },
+        "tags": [
+          "k8s-name:rate-limiting",
+          "k8s-namespace:default",
+          "k8s-kind:KongPlugin",
+          "k8s-uid:0f2b9e4e-7c4c-4a0e-9a8e-2b4f3c1d5e6f",
+          "k8s-group:configuration.konghq.com",
+          "k8s-version:v1"
+        ]
+      },
+      "errors": [
+        {
+          "message": "expected a number",
+          "type": "field",
+          "field": "config.minute"
+        }
+      ],
+      "entity_tags": [
+        "k8s-name:rate-limiting",
+        "k8s-namespace:default",
+        "k8s-kind:KongPlugin",
+        "k8s-uid:0f2b9e4e-7c4c-4a0e-9a8e-2b4f3c1d5e6f",
+        "k8s-group:configuration.konghq.com",
+        "k8s-version:v1"
+      ]
+    }
+  ],
+  "message": "declarative config is invalid",
+  "code": 14
 }`),
 		},
 		{
@@ -1,14 +1,32 @@
 package sendconfig
 
-import "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+)
 
 // UpdateError wraps several pieces of error information relevant to a failed Kong update attempt.
 type UpdateError struct {
 	rawResponseBody  []byte
 	resourceFailures []failures.ResourceFailure
+	entityFailures   []EntityFailure
 	err              error
 }
 
+// EntityFailure is a failure of a single Kong entity rejected by a gateway.
+type EntityFailure struct {
+	// Type is the type of the Kong entity, e.g. "plugin".
+	Type string
+	// Name is the name of the Kong entity. For plugins, it's the plugin name.
+	Name string
+	// ForeignKeys are IDs or names of entities the Kong entity is associated with, keyed by their type (e.g. "route").
+	// They're only populated for plugins.
+	ForeignKeys map[string]string
+	// CausingObject is the Kubernetes object the Kong entity was translated from.
+	CausingObject client.Object
+}
+
 func NewUpdateError(resourceFailures []failures.ResourceFailure, err error) UpdateError {
 	return UpdateError{
 		resourceFailures: resourceFailures,
@@ -24,6 +42,12 @@ func NewUpdateErrorWithResponseBody(rawResponseBody []byte, resourceFailures []f
 	}
 }
 
+// WithEntityFailures returns a copy of the UpdateError carrying failures of individual Kong entities.
+func (e UpdateError) WithEntityFailures(entityFailures []EntityFailure) UpdateError {
+	e.entityFailures = entityFailures
+	return e
+}
+
 // Error implements the Error interface. It returns the string value of the err field.
 func (e UpdateError) Error() string {
 	return e.err.Error()
@@ -39,6 +63,11 @@ func (e UpdateError) ResourceFailures() []failures.ResourceFailure {
 	return e.resourceFailures
 }
 
+// EntityFailures returns failures of individual Kong entities from a Kong configuration update attempt.
+func (e UpdateError) EntityFailures() []EntityFailure {
+	return e.entityFailures
+}
+
 func (e UpdateError) Unwrap() error {
 	return e.err
 }
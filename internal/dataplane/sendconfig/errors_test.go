@@ -36,4 +36,9 @@ func TestUpdateError(t *testing.T) {
 	require.Len(t, updateErr.ResourceFailures(), 1)
 	unwraps := errors.As(updateErr, &testError{})
 	require.True(t, unwraps, "UpdateError should unwrap to inner error")
+
+	withEntityFailures := updateErr.WithEntityFailures([]sendconfig.EntityFailure{{Type: "plugin", Name: "rate-limiting"}})
+	require.Len(t, withEntityFailures.EntityFailures(), 1)
+	require.Empty(t, updateErr.EntityFailures(), "original UpdateError should not be modified")
+	require.Len(t, withEntityFailures.ResourceFailures(), 1)
 }
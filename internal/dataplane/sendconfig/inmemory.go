@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-database-reconciler/pkg/file"
@@ -35,6 +36,22 @@ type UpdateStrategyInMemory struct {
 	configService   ConfigService
 	configConverter ContentToDBLessConfigConverter
 	logger          logr.Logger
+
+	// compressedConfigService, when set, is used to send configuration compressed with gzip instead of configService.
+	compressedConfigService CompressedConfigService
+	// compressionSupport tells whether the gateway was found not to accept compressed configuration. It's a pointer
+	// so that strategies resolved for the same gateway share it.
+	compressionSupport *compressionSupport
+	// largeConfigWarningThreshold is the size of a serialized configuration, in bytes, above which a warning is logged.
+	// 0 disables the warning.
+	largeConfigWarningThreshold int
+	// stats holds stats of the last payload sent. It's a pointer so that copies of the strategy share it.
+	stats *lastPayloadStats
+}
+
+type lastPayloadStats struct {
+	stats PayloadStats
+	set   bool
 }
 
 func NewUpdateStrategyInMemory(
@@ -46,11 +63,44 @@ func NewUpdateStrategyInMemory(
 		configService:   configService,
 		configConverter: configConverter,
 		logger:          logger,
+		stats:           &lastPayloadStats{},
 	}
 }
 
+// WithCompression returns a copy of the strategy sending configuration compressed with gzip using the given service.
+// When the gateway rejects a compressed payload without reporting invalid entities, the configuration is sent
+// uncompressed instead and, if it's accepted, compression is no longer attempted.
+func (s UpdateStrategyInMemory) WithCompression(service CompressedConfigService) UpdateStrategyInMemory {
+	return s.withCompression(service, &compressionSupport{})
+}
+
+func (s UpdateStrategyInMemory) withCompression(
+	service CompressedConfigService,
+	support *compressionSupport,
+) UpdateStrategyInMemory {
+	s.compressedConfigService = service
+	s.compressionSupport = support
+	return s
+}
+
+// WithLargeConfigWarningThreshold returns a copy of the strategy logging a warning listing namespaces contributing
+// the most entities when a serialized configuration exceeds the threshold, in bytes. 0 disables the warning.
+func (s UpdateStrategyInMemory) WithLargeConfigWarningThreshold(threshold int) UpdateStrategyInMemory {
+	s.largeConfigWarningThreshold = threshold
+	return s
+}
+
+// LastPayloadStats returns stats of the last payload sent. It returns false if no payload was sent yet.
+func (s UpdateStrategyInMemory) LastPayloadStats() (PayloadStats, bool) {
+	if s.stats == nil {
+		return PayloadStats{}, false
+	}
+	return s.stats.stats, s.stats.set
+}
+
 func (s UpdateStrategyInMemory) Update(ctx context.Context, targetState ContentWithHash) error {
 	dblessConfig := s.configConverter.Convert(targetState.Content)
+	serializationStart := time.Now()
 	config, err := json.Marshal(dblessConfig)
 	if err != nil {
 		return fmt.Errorf("constructing kong configuration: %w", err)
@@ -71,7 +121,35 @@ func (s UpdateStrategyInMemory) Update(ctx context.Context, targetState ContentW
 		}
 	}
 
-	if errBody, reloadConfigErr := s.configService.ReloadDeclarativeRawConfig(ctx, bytes.NewReader(config), true, true); reloadConfigErr != nil {
+	stats := PayloadStats{
+		Size:                  len(config),
+		SerializationDuration: time.Since(serializationStart),
+	}
+	warnAboutLargeConfig(s.logger, targetState, len(config), s.largeConfigWarningThreshold)
+
+	var (
+		errBody         []byte
+		reloadConfigErr error
+	)
+	if s.compressedConfigService != nil && !s.compressionSupport.unsupported.Load() {
+		errBody, stats.CompressedSize, reloadConfigErr = reloadCompressed(ctx, s.compressedConfigService, config)
+		if reloadConfigErr != nil && compressedConfigRejected(errBody) {
+			s.logger.V(util.DebugLevel).Info("Compressed configuration rejected, retrying uncompressed", "error", reloadConfigErr)
+			stats.CompressedSize = 0
+			errBody, reloadConfigErr = s.configService.ReloadDeclarativeRawConfig(ctx, bytes.NewReader(config), true, true)
+			if reloadConfigErr == nil {
+				s.compressionSupport.unsupported.Store(true)
+				s.logger.Info("Kong doesn't accept configuration compressed with gzip, sending it uncompressed from now on")
+			}
+		}
+	} else {
+		errBody, reloadConfigErr = s.configService.ReloadDeclarativeRawConfig(ctx, bytes.NewReader(config), true, true)
+	}
+	if s.stats != nil {
+		s.stats.stats, s.stats.set = stats, true
+	}
+
+	if reloadConfigErr != nil {
 		resourceErrors, parseErr := parseFlatEntityErrors(errBody, s.logger)
 		if parseErr != nil {
 			return fmt.Errorf("failed to parse flat entity errors from error response: %w", parseErr)
@@ -81,7 +159,7 @@ func (s UpdateStrategyInMemory) Update(ctx context.Context, targetState ContentW
 			errBody,
 			resourceErrorsToResourceFailures(resourceErrors, s.logger),
 			reloadConfigErr,
-		)
+		).WithEntityFailures(resourceErrorsToEntityFailures(resourceErrors))
 	}
 	return nil
 }
@@ -21,6 +21,8 @@ import (
 type rawResourceError struct {
 	Name     string
 	ID       string
+	Type     string
+	Entity   map[string]any
 	Tags     []string
 	Problems map[string]string
 }
@@ -50,6 +52,9 @@ type FlatEntityError struct {
 	// Type is the type of the Kong entity.
 	Type string `json:"entity_type,omitempty" yaml:"entity_type,omitempty"`
 
+	// Entity is the Kong entity as it was sent in the configuration.
+	Entity map[string]any `json:"entity,omitempty" yaml:"entity,omitempty"`
+
 	// Errors are the errors associated with the Kong entity.
 	Errors []FlatError `json:"errors,omitempty" yaml:"errors,omitempty"`
 }
@@ -117,6 +122,8 @@ func parseFlatEntityErrors(body []byte, logger logr.Logger) ([]ResourceError, er
 		raw := rawResourceError{
 			Name:     ee.Name,
 			ID:       ee.ID,
+			Type:     ee.Type,
+			Entity:   ee.Entity,
 			Tags:     ee.Tags,
 			Problems: map[string]string{},
 		}
@@ -157,6 +164,17 @@ func parseFlatEntityErrors(body []byte, logger logr.Logger) ([]ResourceError, er
 func parseRawResourceError(raw rawResourceError) (ResourceError, error) {
 	re := ResourceError{}
 	re.Problems = raw.Problems
+	if raw.Type != "" {
+		re.EntityType = raw.Type
+		re.EntityName = raw.Name
+	}
+	if raw.Type == pluginEntityType {
+		// Plugins are identified by their name and the entities they're associated with.
+		if name, ok := raw.Entity["name"].(string); ok {
+			re.EntityName = name
+		}
+		re.EntityForeignKeys = pluginForeignKeysFromRawEntity(raw.Entity)
+	}
 	var gvk schema.GroupVersionKind
 	for _, tag := range raw.Tags {
 		if strings.HasPrefix(tag, util.K8sNameTagPrefix) {
@@ -196,6 +214,31 @@ func parseRawResourceError(raw rawResourceError) (ResourceError, error) {
 	return re, nil
 }
 
+// pluginEntityType is the type of plugin entities in Kong error responses and go-database-reconciler events.
+const pluginEntityType = "plugin"
+
+// pluginForeignKeyFields are fields of a plugin entity referring to entities it's associated with.
+var pluginForeignKeyFields = []string{"service", "route", "consumer", "consumer_group"}
+
+// pluginForeignKeysFromRawEntity extracts references to associated entities from a raw plugin entity. References can
+// be either plain strings or objects with an ID or a name.
+func pluginForeignKeysFromRawEntity(entity map[string]any) map[string]string {
+	foreignKeys := map[string]string{}
+	for _, field := range pluginForeignKeyFields {
+		switch ref := entity[field].(type) {
+		case string:
+			foreignKeys[field] = ref
+		case map[string]any:
+			if id, ok := ref["id"].(string); ok {
+				foreignKeys[field] = id
+			} else if name, ok := ref["name"].(string); ok {
+				foreignKeys[field] = name
+			}
+		}
+	}
+	return foreignKeys
+}
+
 func gvkIsClusterScoped(gvk schema.GroupVersionKind) bool {
 	if gvk.Group == kongv1.GroupVersion.Group && gvk.Version == kongv1.GroupVersion.Version {
 		return gvk.Kind == "KongClusterPlugin" || gvk.Kind == "KongLicense"
@@ -210,22 +253,12 @@ func gvkIsClusterScoped(gvk schema.GroupVersionKind) bool {
 func resourceErrorsToResourceFailures(resourceErrors []ResourceError, logger logr.Logger) []failures.ResourceFailure {
 	var out []failures.ResourceFailure
 	for _, ee := range resourceErrors {
-		obj := metav1.PartialObjectMetadata{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       ee.Kind,
-				APIVersion: ee.APIVersion,
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: ee.Namespace,
-				Name:      ee.Name,
-				UID:       k8stypes.UID(ee.UID),
-			},
-		}
+		obj := resourceErrorObject(ee)
 		for problemSource, problem := range ee.Problems {
 			logger.V(util.DebugLevel).Info("Adding failure", "resource_name", ee.Name, "source", problemSource, "problem", problem)
 			resourceFailure, failureCreateErr := failures.NewResourceFailure(
 				fmt.Sprintf("invalid %s: %s", problemSource, problem),
-				&obj,
+				obj,
 			)
 			if failureCreateErr != nil {
 				logger.Error(failureCreateErr, "Could not create resource failure event")
@@ -237,3 +270,36 @@ func resourceErrorsToResourceFailures(resourceErrors []ResourceError, logger log
 
 	return out
 }
+
+// resourceErrorsToEntityFailures translates a slice of ResourceError to a slice of EntityFailure. Errors not associated
+// with a Kong entity type are skipped.
+func resourceErrorsToEntityFailures(resourceErrors []ResourceError) []EntityFailure {
+	var out []EntityFailure
+	for _, ee := range resourceErrors {
+		if ee.EntityType == "" {
+			continue
+		}
+		out = append(out, EntityFailure{
+			Type:          ee.EntityType,
+			Name:          ee.EntityName,
+			ForeignKeys:   ee.EntityForeignKeys,
+			CausingObject: resourceErrorObject(ee),
+		})
+	}
+	return out
+}
+
+// resourceErrorObject returns metadata of the Kubernetes object a ResourceError is associated with.
+func resourceErrorObject(ee ResourceError) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ee.Kind,
+			APIVersion: ee.APIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ee.Namespace,
+			Name:      ee.Name,
+			UID:       k8stypes.UID(ee.UID),
+		},
+	}
+}
@@ -0,0 +1,327 @@
+package sendconfig
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-database-reconciler/pkg/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// DefaultLargeDBLessConfigWarningThreshold is the default size of a serialized DB-less configuration, in bytes, above
+// which a warning listing namespaces contributing the most entities is logged.
+const DefaultLargeDBLessConfigWarningThreshold = 8 * 1024 * 1024
+
+// largeConfigTopNamespacesCount is the number of namespaces reported when a DB-less configuration is too large.
+const largeConfigTopNamespacesCount = 5
+
+// PayloadStats describes a DB-less configuration payload sent to Kong.
+type PayloadStats struct {
+	// Size is the size of the serialized configuration, in bytes.
+	Size int
+	// CompressedSize is the size of the payload compressed with gzip, in bytes. It's 0 when compression is disabled.
+	CompressedSize int
+	// SerializationDuration is how long it took to serialize the configuration.
+	SerializationDuration time.Duration
+}
+
+// PayloadStatsReporter is implemented by update strategies that can report stats of the last payload they've sent.
+type PayloadStatsReporter interface {
+	// LastPayloadStats returns stats of the last payload sent. It returns false if no payload was sent yet.
+	LastPayloadStats() (PayloadStats, bool)
+}
+
+// CompressedConfigService is a ConfigService that accepts configuration compressed with gzip.
+type CompressedConfigService interface {
+	// ReloadDeclarativeCompressedRawConfig sends configuration compressed with gzip to Kong's `POST /config` endpoint.
+	ReloadDeclarativeCompressedRawConfig(
+		ctx context.Context,
+		config io.Reader,
+		checkHash bool,
+		flattenErrors bool,
+	) ([]byte, error)
+}
+
+// KongCompressedConfigService implements CompressedConfigService using a Kong Admin API client.
+type KongCompressedConfigService struct {
+	client *kong.Client
+}
+
+// NewKongCompressedConfigService creates a KongCompressedConfigService.
+func NewKongCompressedConfigService(client *kong.Client) KongCompressedConfigService {
+	return KongCompressedConfigService{client: client}
+}
+
+// ReloadDeclarativeCompressedRawConfig sends configuration compressed with gzip to Kong's `POST /config` endpoint.
+// It mirrors kong.Client's ReloadDeclarativeRawConfig, setting the Content-Encoding header on top of it. The body
+// is streamed with chunked transfer encoding as its size isn't known upfront.
+func (s KongCompressedConfigService) ReloadDeclarativeCompressedRawConfig(
+	ctx context.Context,
+	config io.Reader,
+	checkHash bool,
+	flattenErrors bool,
+) ([]byte, error) {
+	type sendConfigParams struct {
+		CheckHash     int `url:"check_hash,omitempty"`
+		FlattenErrors int `url:"flatten_errors,omitempty"`
+	}
+	var params sendConfigParams
+	if checkHash {
+		params.CheckHash = 1
+	}
+	if flattenErrors {
+		params.FlattenErrors = 1
+	}
+	req, err := s.client.NewRequest(http.MethodPost, "/config", params, config)
+	if err != nil {
+		return nil, fmt.Errorf("creating new HTTP request for /config: %w", err)
+	}
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := s.client.DoRAW(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed posting new config to /config: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read /config %d status response body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return b, fmt.Errorf("failed posting new config to /config: got status code %d", resp.StatusCode)
+	}
+	return b, nil
+}
+
+// compressionSupport tracks whether a gateway accepts configuration compressed with gzip.
+type compressionSupport struct {
+	unsupported atomic.Bool
+}
+
+// compressionSupportByURL holds compressionSupport of gateways by their Admin API URLs, so that it's kept across
+// strategies resolved for every configuration push.
+type compressionSupportByURL struct {
+	lock    sync.Mutex
+	support map[string]*compressionSupport
+}
+
+func (c *compressionSupportByURL) get(url string) *compressionSupport {
+	if c == nil {
+		return &compressionSupport{}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.support == nil {
+		c.support = map[string]*compressionSupport{}
+	}
+	support, ok := c.support[url]
+	if !ok {
+		support = &compressionSupport{}
+		c.support[url] = support
+	}
+	return support
+}
+
+// compressedConfigRejected tells whether an error response to a compressed configuration indicates that the body
+// couldn't be processed, e.g. because Kong, or a proxy in front of it, doesn't accept gzip-encoded bodies. Kong reports
+// invalid configuration with per-entity flattened errors, which it can only do after decompressing the body, so such
+// responses aren't treated as rejections of compression. A nil body means no response was received at all.
+func compressedConfigRejected(errBody []byte) bool {
+	if errBody == nil {
+		return false
+	}
+	var configError ConfigError
+	if err := json.Unmarshal(errBody, &configError); err != nil {
+		return true
+	}
+	return len(configError.Flattened) == 0
+}
+
+// reloadCompressed compresses the configuration with gzip while streaming it to the CompressedConfigService, so
+// the compressed payload is never held in memory as a whole. It returns the response body, the size of the
+// compressed payload and an error.
+func reloadCompressed(ctx context.Context, service CompressedConfigService, config []byte) ([]byte, int, error) {
+	pr, pw := io.Pipe()
+	compressed := &countingWriter{w: pw}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		gw := gzip.NewWriter(compressed)
+		if _, err := gw.Write(config); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(gw.Close())
+	}()
+
+	body, err := service.ReloadDeclarativeCompressedRawConfig(ctx, pr, true, true)
+	// Unblock the compressing goroutine in case the body wasn't read in full, e.g. when Kong responded early.
+	pr.CloseWithError(io.ErrClosedPipe)
+	<-done
+	return body, compressed.n, err
+}
+
+// countingWriter counts bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// namespaceEntitiesCount is a number of Kong entities translated from objects in a namespace.
+type namespaceEntitiesCount struct {
+	Namespace string
+	Count     int
+}
+
+// countEntitiesByNamespace counts Kong entities of a configuration by the namespace of objects they were translated
+// from, as indicated by their tags. It returns up to limit namespaces with the most entities. Entities are counted
+// in the content before it's serialized, so that large configurations don't have to be unmarshaled again.
+func countEntitiesByNamespace(content *file.Content, customEntities CustomEntitiesByType, limit int) []namespaceEntitiesCount {
+	counts := map[string]int{}
+	count := func(tags []*string) {
+		for _, tag := range tags {
+			if ns, ok := strings.CutPrefix(lo.FromPtr(tag), util.K8sNamespaceTagPrefix); ok {
+				counts[ns]++
+				return
+			}
+		}
+	}
+	countPlugins := func(plugins []*file.FPlugin) {
+		for _, p := range plugins {
+			count(p.Tags)
+		}
+	}
+	countRoute := func(r *file.FRoute) {
+		count(r.Tags)
+		countPlugins(r.Plugins)
+	}
+
+	if content != nil {
+		for _, s := range content.Services {
+			count(s.Tags)
+			for _, r := range s.Routes {
+				countRoute(r)
+			}
+			countPlugins(s.Plugins)
+		}
+		for i := range content.Routes {
+			countRoute(&content.Routes[i])
+		}
+		for _, p := range content.Plugins {
+			count(p.Tags)
+		}
+		for _, u := range content.Upstreams {
+			count(u.Tags)
+			for _, t := range u.Targets {
+				count(t.Tags)
+			}
+		}
+		for _, c := range content.Consumers {
+			count(c.Tags)
+			countPlugins(c.Plugins)
+			for _, cred := range c.KeyAuths {
+				count(cred.Tags)
+			}
+			for _, cred := range c.HMACAuths {
+				count(cred.Tags)
+			}
+			for _, cred := range c.JWTAuths {
+				count(cred.Tags)
+			}
+			for _, cred := range c.BasicAuths {
+				count(cred.Tags)
+			}
+			for _, cred := range c.Oauth2Creds {
+				count(cred.Tags)
+			}
+			for _, cred := range c.ACLGroups {
+				count(cred.Tags)
+			}
+			for _, cred := range c.MTLSAuths {
+				count(cred.Tags)
+			}
+		}
+		for _, cg := range content.ConsumerGroups {
+			count(cg.Tags)
+		}
+		for _, c := range content.Certificates {
+			count(c.Tags)
+			for _, sni := range c.SNIs {
+				count(sni.Tags)
+			}
+		}
+		for _, c := range content.CACertificates {
+			count(c.Tags)
+		}
+		for _, v := range content.Vaults {
+			count(v.Tags)
+		}
+	}
+	for _, entities := range customEntities {
+		for _, entity := range entities {
+			switch tags := entity["tags"].(type) {
+			case []string:
+				count(lo.ToSlicePtr(tags))
+			case []any:
+				count(lo.FilterMap(tags, func(tag any, _ int) (*string, bool) {
+					s, ok := tag.(string)
+					return &s, ok
+				}))
+			}
+		}
+	}
+
+	result := make([]namespaceEntitiesCount, 0, len(counts))
+	for ns, count := range counts {
+		result = append(result, namespaceEntitiesCount{Namespace: ns, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Namespace < result[j].Namespace
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// warnAboutLargeConfig logs a warning listing namespaces contributing the most entities to a configuration
+// whose serialized size exceeds the threshold.
+func warnAboutLargeConfig(logger logr.Logger, targetState ContentWithHash, size int, threshold int) {
+	if threshold <= 0 || size <= threshold {
+		return
+	}
+	topNamespaces := countEntitiesByNamespace(targetState.Content, targetState.CustomEntities, largeConfigTopNamespacesCount)
+	namespaces := make([]string, 0, len(topNamespaces))
+	for _, ns := range topNamespaces {
+		namespaces = append(namespaces, fmt.Sprintf("%s=%d", ns.Namespace, ns.Count))
+	}
+	logger.Info(
+		"DB-less configuration exceeds the size warning threshold, consider splitting it across controllers",
+		"size_bytes", size,
+		"threshold_bytes", threshold,
+		"top_namespaces_by_entities", namespaces,
+	)
+}
@@ -0,0 +1,257 @@
+package sendconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-database-reconciler/pkg/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+)
+
+type configServiceMock struct {
+	config []byte
+}
+
+func (m *configServiceMock) ReloadDeclarativeRawConfig(_ context.Context, config io.Reader, _, _ bool) ([]byte, error) {
+	b, err := io.ReadAll(config)
+	m.config = b
+	return nil, err
+}
+
+func testContentWithNamespaces(namespaces ...string) ContentWithHash {
+	content := &file.Content{}
+	for i, ns := range namespaces {
+		content.Services = append(content.Services, file.FService{
+			Service: kong.Service{
+				Name: kong.String(ns + "." + string(rune('a'+i))),
+				Host: kong.String("example.com"),
+				Tags: kong.StringSlice("k8s-namespace:"+ns, "k8s-kind:Service"),
+			},
+		})
+	}
+	return ContentWithHash{Content: content}
+}
+
+func TestUpdateStrategyInMemory_Compression(t *testing.T) {
+	var (
+		receivedEncoding string
+		receivedQuery    string
+		receivedConfig   map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedEncoding = r.Header.Get("Content-Encoding")
+		receivedQuery = r.URL.RawQuery
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(gr).Decode(&receivedConfig); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+	kongClient, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+
+	plain := &configServiceMock{}
+	s := NewUpdateStrategyInMemory(plain, DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+		WithCompression(NewKongCompressedConfigService(kongClient))
+	_, ok := s.LastPayloadStats()
+	require.False(t, ok, "no stats should be reported before the first update")
+
+	require.NoError(t, s.Update(context.Background(), testContentWithNamespaces("default", "other")))
+	require.Nil(t, plain.config, "uncompressed config service shouldn't be used")
+	require.Equal(t, "gzip", receivedEncoding)
+	require.Equal(t, "check_hash=1&flatten_errors=1", receivedQuery)
+	require.Len(t, receivedConfig["services"], 2)
+
+	stats, ok := s.LastPayloadStats()
+	require.True(t, ok)
+	require.Positive(t, stats.Size)
+	require.Positive(t, stats.CompressedSize)
+}
+
+func TestUpdateStrategyInMemory_CompressionFallback(t *testing.T) {
+	flattenedErrorsBody := []byte(`{"code":14,"message":"declarative config is invalid","flattened_errors":[{"entity_type":"service","entity_name":"default.a","errors":[{"type":"entity","message":"invalid"}]}]}`)
+
+	testCases := []struct {
+		name string
+		// compressedBody and compressedErr are returned by the compressed config service.
+		compressedBody []byte
+		compressedErr  error
+		// uncompressedErr is returned by the uncompressed config service.
+		uncompressedErr error
+
+		expectFallback          bool
+		expectErr               bool
+		expectCompressionOnNext bool
+	}{
+		{
+			name:                    "rejected with an empty body",
+			compressedBody:          []byte{},
+			compressedErr:           errors.New("got status code 415"),
+			expectFallback:          true,
+			expectCompressionOnNext: false,
+		},
+		{
+			name:                    "rejected with a body that isn't a config error",
+			compressedBody:          []byte(`failed parsing declarative configuration`),
+			compressedErr:           errors.New("got status code 400"),
+			expectFallback:          true,
+			expectCompressionOnNext: false,
+		},
+		{
+			name:                    "rejected uncompressed too",
+			compressedBody:          []byte(`{"message":"unexpected error"}`),
+			compressedErr:           errors.New("got status code 500"),
+			uncompressedErr:         errors.New("got status code 500"),
+			expectFallback:          true,
+			expectErr:               true,
+			expectCompressionOnNext: true,
+		},
+		{
+			name:                    "rejected with entity errors",
+			compressedBody:          flattenedErrorsBody,
+			compressedErr:           errors.New("got status code 400"),
+			expectErr:               true,
+			expectCompressionOnNext: true,
+		},
+		{
+			name:                    "no response",
+			compressedErr:           errors.New("connection refused"),
+			expectErr:               true,
+			expectCompressionOnNext: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			compressedCalls := 0
+			compressed := compressedConfigServiceFunc(func(_ context.Context, config io.Reader, _, _ bool) ([]byte, error) {
+				compressedCalls++
+				_, _ = io.Copy(io.Discard, config)
+				return tc.compressedBody, tc.compressedErr
+			})
+			uncompressedCalls := 0
+			uncompressed := configServiceFunc(func(_ context.Context, config io.Reader, _, _ bool) ([]byte, error) {
+				uncompressedCalls++
+				_, _ = io.Copy(io.Discard, config)
+				return nil, tc.uncompressedErr
+			})
+			s := NewUpdateStrategyInMemory(uncompressed, DefaultContentToDBLessConfigConverter{}, logr.Discard()).
+				WithCompression(compressed)
+
+			err := s.Update(context.Background(), testContentWithNamespaces("default"))
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, 1, compressedCalls)
+			if tc.expectFallback {
+				require.Equal(t, 1, uncompressedCalls, "configuration should be sent uncompressed")
+				stats, ok := s.LastPayloadStats()
+				require.True(t, ok)
+				require.Zero(t, stats.CompressedSize, "compressed size shouldn't be reported for an uncompressed payload")
+			} else {
+				require.Zero(t, uncompressedCalls, "configuration shouldn't be sent uncompressed")
+			}
+
+			// The next update is sent compressed unless the gateway was found not to accept it.
+			_ = s.Update(context.Background(), testContentWithNamespaces("default"))
+			if tc.expectCompressionOnNext {
+				require.Equal(t, 2, compressedCalls)
+			} else {
+				require.Equal(t, 1, compressedCalls)
+				require.Equal(t, 2, uncompressedCalls)
+			}
+		})
+	}
+}
+
+func TestCompressionSupportByURL(t *testing.T) {
+	var supportByURL compressionSupportByURL
+	a := supportByURL.get("http://a:8001")
+	a.unsupported.Store(true)
+	require.Same(t, a, supportByURL.get("http://a:8001"), "support should be shared for the same URL")
+	require.False(t, supportByURL.get("http://b:8001").unsupported.Load())
+}
+
+func TestUpdateStrategyInMemory_PayloadStatsWithoutCompression(t *testing.T) {
+	plain := &configServiceMock{}
+	s := NewUpdateStrategyInMemory(plain, DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+	require.NoError(t, s.Update(context.Background(), testContentWithNamespaces("default")))
+	stats, ok := s.LastPayloadStats()
+	require.True(t, ok)
+	require.Equal(t, len(plain.config), stats.Size)
+	require.Zero(t, stats.CompressedSize)
+}
+
+func TestCountEntitiesByNamespace(t *testing.T) {
+	content := testContentWithNamespaces("a", "b", "b", "c", "c", "c").Content
+	content.Services[0].Routes = []*file.FRoute{{
+		Route: kong.Route{Name: kong.String("a.route"), Tags: kong.StringSlice("k8s-namespace:a")},
+	}}
+	content.Consumers = []file.FConsumer{{
+		Consumer: kong.Consumer{Username: kong.String("a.consumer"), Tags: kong.StringSlice("k8s-namespace:a")},
+		KeyAuths: []*kong.KeyAuth{{Key: kong.String("key"), Tags: kong.StringSlice("k8s-namespace:a")}},
+	}}
+	customEntities := CustomEntitiesByType{
+		"key-auth-enc": {
+			{"key": "key", "tags": []string{"k8s-namespace:d"}},
+			{"key": "other", "tags": []any{"k8s-kind:Secret", "k8s-namespace:d"}},
+		},
+	}
+
+	require.Equal(t, []namespaceEntitiesCount{
+		{Namespace: "a", Count: 4},
+		{Namespace: "c", Count: 3},
+		{Namespace: "b", Count: 2},
+	}, countEntitiesByNamespace(content, customEntities, 3))
+	require.Equal(t, []namespaceEntitiesCount{
+		{Namespace: "a", Count: 4},
+		{Namespace: "c", Count: 3},
+		{Namespace: "b", Count: 2},
+		{Namespace: "d", Count: 2},
+	}, countEntitiesByNamespace(content, customEntities, 10))
+}
+
+func TestReloadCompressed_ServiceNotReadingBody(t *testing.T) {
+	// Compressing a payload larger than the pipe buffers mustn't block when the service returns without reading it.
+	config := bytes.Repeat([]byte(`{"services":[]}`), 1<<16)
+	_, _, err := reloadCompressed(context.Background(), compressedConfigServiceFunc(
+		func(context.Context, io.Reader, bool, bool) ([]byte, error) {
+			return []byte(`{"message":"too large"}`), io.ErrUnexpectedEOF
+		},
+	), config)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+type configServiceFunc func(ctx context.Context, config io.Reader, checkHash, flattenErrors bool) ([]byte, error)
+
+func (f configServiceFunc) ReloadDeclarativeRawConfig(
+	ctx context.Context, config io.Reader, checkHash, flattenErrors bool,
+) ([]byte, error) {
+	return f(ctx, config, checkHash, flattenErrors)
+}
+
+type compressedConfigServiceFunc func(ctx context.Context, config io.Reader, checkHash, flattenErrors bool) ([]byte, error)
+
+func (f compressedConfigServiceFunc) ReloadDeclarativeCompressedRawConfig(
+	ctx context.Context, config io.Reader, checkHash, flattenErrors bool,
+) ([]byte, error) {
+	return f(ctx, config, checkHash, flattenErrors)
+}
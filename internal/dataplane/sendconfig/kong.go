@@ -41,4 +41,23 @@ type Config struct {
 	// UseLastValidConfigForFallback indicates whether to use the last valid config cache to backfill broken objects
 	// when recovering from a config push failure.
 	UseLastValidConfigForFallback bool
+
+	// UseEntityLevelExclusionForFallback indicates whether to exclude only broken Kong entities instead of whole
+	// broken objects with their dependants when recovering from a config push failure, when it's safe to do so.
+	UseEntityLevelExclusionForFallback bool
+
+	// CompressDBLessConfig indicates whether DB-less configuration should be sent to Kong compressed with gzip.
+	CompressDBLessConfig bool
+
+	// LargeDBLessConfigWarningThreshold is the size of a serialized DB-less configuration, in bytes, above which
+	// a warning listing namespaces contributing the most entities is logged. 0 disables the warning.
+	LargeDBLessConfigWarningThreshold int
+
+	// DryRun indicates that configuration should only be validated against Kong Gateways' schemas instead of being
+	// applied. It's not relevant for Konnect client.
+	DryRun bool
+
+	// StagedRollout configures rolling out configuration to a subset of gateways first. It's not relevant for Konnect
+	// client.
+	StagedRollout StagedRolloutConfig
 }
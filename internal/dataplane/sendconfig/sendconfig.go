@@ -79,6 +79,12 @@ func PerformUpdate(
 	})
 	duration := time.Since(timeStart)
 
+	if reporter, ok := updateStrategy.(PayloadStatsReporter); ok {
+		if stats, ok := reporter.LastPayloadStats(); ok {
+			promMetrics.RecordPushPayload(client.BaseRootURL(), stats.Size, stats.CompressedSize, stats.SerializationDuration)
+		}
+	}
+
 	metricsProtocol := updateStrategy.MetricsProtocol()
 	if err != nil {
 		// For UpdateError, record the failure and return the error.
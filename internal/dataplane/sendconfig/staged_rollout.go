@@ -0,0 +1,430 @@
+package sendconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/iter"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+const (
+	// DefaultStagedRolloutSoakPeriod is the default time canary gateways are observed before configuration is rolled out
+	// to the rest of gateways.
+	DefaultStagedRolloutSoakPeriod = 30 * time.Second
+
+	// DefaultStagedRolloutHealthCheckInterval is the default interval of health checks of canary gateways.
+	DefaultStagedRolloutHealthCheckInterval = 5 * time.Second
+
+	// kongHTTPRequestsMetric is the counter of requests proxied by a gateway, labeled with their status code. It's
+	// exposed by the Prometheus plugin configured with `status_code_metrics` enabled.
+	kongHTTPRequestsMetric = "kong_http_requests_total"
+)
+
+// StagedRolloutConfig configures rolling out configuration to gateways in stages.
+type StagedRolloutConfig struct {
+	// Canaries is the number (e.g. 1) or the percentage (e.g. 10%) of gateways that get new configuration first.
+	// Percentages are rounded up. Zero disables staged rollout.
+	Canaries intstr.IntOrString
+
+	// SoakPeriod is the time canary gateways are observed before configuration is rolled out to the rest of gateways.
+	SoakPeriod time.Duration
+
+	// HealthCheckInterval is the interval of health checks of canary gateways during the soak period.
+	HealthCheckInterval time.Duration
+
+	// MaxHealthCheckFailures is the number of failed health checks of a single canary gateway that is tolerated
+	// during the soak period.
+	MaxHealthCheckFailures int
+
+	// MaxErrorRate is the ratio (between 0 and 1) of requests proxied by a canary gateway during the soak period
+	// that may fail with a 5xx status code. Zero disables checking the error rate.
+	MaxErrorRate float64
+}
+
+// Enabled tells whether staged rollout is enabled.
+func (c StagedRolloutConfig) Enabled() bool {
+	return c.Canaries != intstr.IntOrString{}
+}
+
+// canaryCount returns the number of canary gateways out of total gateways.
+func (c StagedRolloutConfig) canaryCount(total int) (int, error) {
+	return intstr.GetScaledValueFromIntOrPercent(&c.Canaries, total, true)
+}
+
+// PushFunc pushes configuration to a single gateway and returns the SHA of the configuration it pushed.
+type PushFunc func(ctx context.Context, client *adminapi.Client) (string, error)
+
+// ConfigSHAFunc returns the SHA of the configuration PushFunc would push to a single gateway, without pushing it.
+type ConfigSHAFunc func(ctx context.Context, client *adminapi.Client) (string, error)
+
+var (
+	// ErrStagedRolloutInProgress is returned by StagedRollout.Push when canary gateways are being observed.
+	// The rest of gateways get the configuration in one of the next pushes, once the soak period elapsed.
+	ErrStagedRolloutInProgress = errors.New("staged rollout in progress, canary gateways are being observed")
+
+	// ErrConfigRejectedByCanaries is returned by StagedRollout.Push when the configuration was already rejected by
+	// canary gateways. It's not pushed to them again until it changes.
+	ErrConfigRejectedByCanaries = errors.New("configuration was rejected by canary gateways")
+)
+
+// StagedRollout pushes configuration to a subset of gateways (canaries) first. Canaries are then health checked
+// using the Admin API `/status` endpoint for a soak period and only if they stay healthy, the configuration is pushed
+// to the rest of gateways. If pushing configuration to canaries fails or canaries turn unhealthy, they're rolled back,
+// the rest of gateways are left untouched and the configuration is not pushed to canaries again until it changes.
+// Canaries are the first gateways sorted by their Admin API URLs and they stay the same for the whole rollout,
+// even when gateways join or leave in the meantime (unless a canary leaves). When none of the canaries got new
+// configuration (because it didn't change), there's nothing to observe and the rest of gateways are pushed right away.
+//
+// The soak period is spread across pushes, so that callers aren't blocked for its whole duration: Push returns
+// ErrStagedRolloutInProgress while canaries are observed and has to be called again (e.g. on the next sync) to
+// health check them and eventually push the rest of gateways. Canaries are health checked at most once per health
+// check interval, but only as often as Push is called. When the configuration changes during the soak period,
+// the new configuration is pushed to canaries and the soak period starts over.
+//
+// A health check fails when the `/status` endpoint can't be reached, when the gateway reports it has no configuration
+// or when its request counter goes down, which means the gateway was restarted. With MaxErrorRate set, it also fails
+// when the ratio of 5xx responses among requests proxied since the soak period started exceeds it. The error rate
+// is computed from the kong_http_requests_total counter exposed by the Admin API `/metrics` endpoint, which requires
+// the Prometheus plugin with `status_code_metrics` enabled.
+//
+// StagedRollout is not safe for concurrent use.
+type StagedRollout struct {
+	config StagedRolloutConfig
+	logger logr.Logger
+
+	// soaking is the configuration of canaries that are being observed. It's nil when no canaries are observed.
+	soaking *canaryRollout
+
+	// rejected is the configuration canaries failed with last. It's nil when canaries didn't fail since
+	// the configuration changed.
+	rejected *canaryRollout
+}
+
+// canaryRollout is configuration pushed to canary gateways.
+type canaryRollout struct {
+	canaryURLs []string
+	shas       []string
+
+	startedAt     time.Time
+	lastCheckedAt time.Time
+	checkers      []*canaryHealthChecker
+}
+
+// isFor tells whether the rollout is of configuration with the given SHAs to the canaries with the given URLs.
+func (r *canaryRollout) isFor(canaryURLs []string, shas []string) bool {
+	return r != nil && slices.Equal(r.canaryURLs, canaryURLs) && slices.Equal(r.shas, shas)
+}
+
+func NewStagedRollout(config StagedRolloutConfig, logger logr.Logger) *StagedRollout {
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = DefaultStagedRolloutHealthCheckInterval
+	}
+	return &StagedRollout{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Push pushes configuration to clients using push. configSHA tells the SHAs of the configuration push would push
+// to canaries. Canaries that failed are rolled back using rollback, which can be nil when there's no configuration
+// to roll back to. SHAs of the pushed configuration are returned in the order of clients once it's pushed to all
+// clients. ErrStagedRolloutInProgress is returned while canaries are observed.
+func (r *StagedRollout) Push(
+	ctx context.Context,
+	clients []*adminapi.Client,
+	configSHA ConfigSHAFunc,
+	push PushFunc,
+	rollback PushFunc,
+) ([]string, error) {
+	canaryCount, err := r.config.canaryCount(len(clients))
+	if err != nil {
+		return nil, fmt.Errorf("invalid number of canaries: %w", err)
+	}
+	// There's nothing to stage when every gateway would be a canary.
+	if canaryCount <= 0 || canaryCount >= len(clients) {
+		r.soaking, r.rejected = nil, nil
+		return pushToClients(ctx, clients, push)
+	}
+
+	canaries, rest := r.selectCanaries(clients, canaryCount)
+	canaryURLs := lo.Map(canaries, func(c *adminapi.Client, _ int) string { return c.BaseRootURL() })
+	logger := r.logger.WithValues("canaries", canaryURLs)
+
+	canarySHAs, err := pushToClients(ctx, canaries, PushFunc(configSHA))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate configuration SHA for canary gateways: %w", err)
+	}
+	if r.rejected.isFor(canaryURLs, canarySHAs) {
+		logger.V(util.DebugLevel).Info("Configuration was rejected by canary gateways, waiting for it to change")
+		return nil, fmt.Errorf("%w, waiting for it to change", ErrConfigRejectedByCanaries)
+	}
+	r.rejected = nil
+
+	currentCanarySHAs := lo.Map(canaries, func(c *adminapi.Client, _ int) string { return string(c.LastConfigSHA()) })
+	// Canaries are observed as long as they run the configuration that is being rolled out.
+	if !r.soaking.isFor(canaryURLs, canarySHAs) || !slices.Equal(currentCanarySHAs, canarySHAs) {
+		r.soaking = nil
+		logger.V(util.DebugLevel).Info("Pushing configuration to canary gateways")
+		if _, err := pushToClients(ctx, canaries, push); err != nil {
+			return nil, r.reject(ctx, logger, canaries, canarySHAs, rollback,
+				fmt.Errorf("pushing configuration to canary gateways failed: %w", err))
+		}
+
+		// Pushes are skipped when configuration didn't change, so there's nothing to observe. Soaking would only
+		// hold up the update for the soak period.
+		if slices.Equal(currentCanarySHAs, canarySHAs) {
+			logger.V(util.DebugLevel).Info("Configuration of canary gateways didn't change, skipping the soak period")
+		} else {
+			logger.V(util.DebugLevel).Info("Observing canary gateways", "soakPeriod", r.config.SoakPeriod)
+			now := time.Now()
+			r.soaking = &canaryRollout{
+				canaryURLs: canaryURLs,
+				shas:       canarySHAs,
+				startedAt:  now,
+				checkers: lo.Map(canaries, func(c *adminapi.Client, _ int) *canaryHealthChecker {
+					return &canaryHealthChecker{client: c, maxErrorRate: r.config.MaxErrorRate}
+				}),
+			}
+			// Check canaries right away to get their baseline request counters.
+			if err := r.check(ctx, r.soaking, now); err != nil {
+				r.soaking = nil
+				return nil, r.reject(ctx, logger, canaries, canarySHAs, rollback, err)
+			}
+			return nil, ErrStagedRolloutInProgress
+		}
+	} else {
+		now := time.Now()
+		soakDone := now.Sub(r.soaking.startedAt) >= r.config.SoakPeriod
+		// The soak period always ends with a health check.
+		if soakDone || now.Sub(r.soaking.lastCheckedAt) >= r.config.HealthCheckInterval {
+			if err := r.check(ctx, r.soaking, now); err != nil {
+				r.soaking = nil
+				return nil, r.reject(ctx, logger, canaries, canarySHAs, rollback, err)
+			}
+		}
+		if !soakDone {
+			return nil, ErrStagedRolloutInProgress
+		}
+		r.soaking = nil
+	}
+
+	logger.V(util.DebugLevel).Info("Canary gateways are healthy, pushing configuration to the rest of gateways")
+	restSHAs, err := pushToClients(ctx, rest, push)
+	if err != nil {
+		return nil, err
+	}
+	// Canaries aren't necessarily the first clients, so SHAs are put back in the order of clients.
+	shasByClient := make(map[*adminapi.Client]string, len(clients))
+	for i, c := range canaries {
+		shasByClient[c] = canarySHAs[i]
+	}
+	for i, c := range rest {
+		shasByClient[c] = restSHAs[i]
+	}
+	return lo.Map(clients, func(c *adminapi.Client, _ int) string { return shasByClient[c] }), nil
+}
+
+// selectCanaries splits clients into canaries and the rest of gateways. Canaries of the ongoing rollout (observed
+// or rejected) are kept as long as all of them are still among clients, so that gateways joining or leaving don't
+// change canaries in the middle of a rollout. Otherwise, the first canaryCount clients sorted by their URLs are
+// chosen, so that the same gateways are canaries regardless of the order clients are given in.
+func (r *StagedRollout) selectCanaries(clients []*adminapi.Client, canaryCount int) (canaries, rest []*adminapi.Client) {
+	for _, ongoing := range []*canaryRollout{r.soaking, r.rejected} {
+		if ongoing == nil {
+			continue
+		}
+		canaries = lo.Filter(clients, func(c *adminapi.Client, _ int) bool {
+			return slices.Contains(ongoing.canaryURLs, c.BaseRootURL())
+		})
+		if len(canaries) == len(ongoing.canaryURLs) {
+			sortClientsByURL(canaries)
+			rest = lo.Filter(clients, func(c *adminapi.Client, _ int) bool {
+				return !slices.Contains(ongoing.canaryURLs, c.BaseRootURL())
+			})
+			return canaries, rest
+		}
+	}
+
+	sorted := slices.Clone(clients)
+	sortClientsByURL(sorted)
+	return sorted[:canaryCount], sorted[canaryCount:]
+}
+
+// sortClientsByURL sorts clients by their Admin API URLs.
+func sortClientsByURL(clients []*adminapi.Client) {
+	slices.SortFunc(clients, func(a, b *adminapi.Client) int {
+		return strings.Compare(a.BaseRootURL(), b.BaseRootURL())
+	})
+}
+
+// reject rolls back canaries after err occurred, records that canaries failed with configuration of the given SHAs
+// and returns err, joined with rollback errors if any.
+func (r *StagedRollout) reject(
+	ctx context.Context,
+	logger logr.Logger,
+	canaries []*adminapi.Client,
+	canarySHAs []string,
+	rollback PushFunc,
+	err error,
+) error {
+	r.rejected = &canaryRollout{
+		canaryURLs: lo.Map(canaries, func(c *adminapi.Client, _ int) string { return c.BaseRootURL() }),
+		shas:       canarySHAs,
+	}
+	if rollback == nil {
+		logger.Error(err, "Staged rollout failed, no configuration to roll canary gateways back to")
+		return err
+	}
+	logger.Error(err, "Staged rollout failed, rolling canary gateways back")
+	if _, rollbackErr := pushToClients(ctx, canaries, rollback); rollbackErr != nil {
+		return errors.Join(err, fmt.Errorf("rolling back canary gateways failed: %w", rollbackErr))
+	}
+	return err
+}
+
+// check health checks canaries of the rollout. It returns an error as soon as any canary exceeds the number
+// of tolerated failed health checks.
+func (r *StagedRollout) check(ctx context.Context, rollout *canaryRollout, now time.Time) error {
+	rollout.lastCheckedAt = now
+	for _, checker := range rollout.checkers {
+		err := checker.check(ctx)
+		if err == nil {
+			continue
+		}
+		checker.failures++
+		r.logger.V(util.DebugLevel).Info("Canary gateway failed health check",
+			"url", checker.client.BaseRootURL(), "failures", checker.failures, "reason", err.Error())
+		if checker.failures > r.config.MaxHealthCheckFailures {
+			return fmt.Errorf("canary gateway %s failed %d health checks, last failure: %w",
+				checker.client.BaseRootURL(), checker.failures, err)
+		}
+	}
+	return nil
+}
+
+// canaryHealthChecker checks health of a single canary gateway.
+type canaryHealthChecker struct {
+	client       *adminapi.Client
+	maxErrorRate float64
+	failures     int
+
+	// lastTotalRequests is the request counter reported by the gateway in the last successful health check.
+	lastTotalRequests int
+
+	// baseline holds the proxied requests counters of the gateway when the soak period started. It's nil until
+	// they're fetched for the first time.
+	baseline *proxiedRequests
+}
+
+func (c *canaryHealthChecker) check(ctx context.Context) error {
+	status, err := c.client.AdminAPIClient().Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+	if status.ConfigurationHash == WellKnownInitialHash {
+		return errors.New("gateway has no configuration")
+	}
+	totalRequests := status.Server.TotalRequests
+	if totalRequests < c.lastTotalRequests {
+		c.lastTotalRequests = totalRequests
+		return errors.New("gateway request counter went down, gateway was restarted")
+	}
+	c.lastTotalRequests = totalRequests
+
+	if c.maxErrorRate > 0 {
+		return c.checkErrorRate(ctx)
+	}
+	return nil
+}
+
+// checkErrorRate fails when the ratio of 5xx responses among requests proxied since the baseline exceeds
+// the maximum error rate.
+func (c *canaryHealthChecker) checkErrorRate(ctx context.Context) error {
+	current, err := fetchProxiedRequests(ctx, c.client)
+	if err != nil {
+		return err
+	}
+	// Counters going down mean the gateway was restarted, so start over from them.
+	if c.baseline == nil || current.total < c.baseline.total || current.serverErrors < c.baseline.serverErrors {
+		c.baseline = &current
+		return nil
+	}
+
+	total := current.total - c.baseline.total
+	if total == 0 {
+		return nil
+	}
+	serverErrors := current.serverErrors - c.baseline.serverErrors
+	if errorRate := serverErrors / total; errorRate > c.maxErrorRate {
+		return fmt.Errorf("error rate %.4f (%.0f of %.0f requests failed with 5xx) exceeds %.4f",
+			errorRate, serverErrors, total, c.maxErrorRate)
+	}
+	return nil
+}
+
+// proxiedRequests holds counters of requests proxied by a gateway.
+type proxiedRequests struct {
+	total        float64
+	serverErrors float64
+}
+
+// fetchProxiedRequests sums up the kong_http_requests_total counter exposed by the Admin API `/metrics` endpoint
+// of the gateway across all its series.
+func fetchProxiedRequests(ctx context.Context, client *adminapi.Client) (proxiedRequests, error) {
+	kongClient := client.AdminAPIClient()
+	req, err := kongClient.NewRequestRaw(http.MethodGet, kongClient.BaseRootURL(), "/metrics", nil, nil)
+	if err != nil {
+		return proxiedRequests{}, fmt.Errorf("creating request for /metrics: %w", err)
+	}
+	resp, err := kongClient.DoRAW(ctx, req)
+	if err != nil {
+		return proxiedRequests{}, fmt.Errorf("failed to get metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return proxiedRequests{}, fmt.Errorf("failed to get metrics: got status code %d, "+
+			"is the Prometheus plugin enabled?", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return proxiedRequests{}, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	family, ok := families[kongHTTPRequestsMetric]
+	if !ok {
+		return proxiedRequests{}, fmt.Errorf("gateway doesn't expose %s metric, "+
+			"is the Prometheus plugin configured with status_code_metrics enabled?", kongHTTPRequestsMetric)
+	}
+
+	var requests proxiedRequests
+	for _, m := range family.GetMetric() {
+		value := m.GetCounter().GetValue()
+		requests.total += value
+		for _, label := range m.GetLabel() {
+			if label.GetName() == "code" && strings.HasPrefix(label.GetValue(), "5") {
+				requests.serverErrors += value
+			}
+		}
+	}
+	return requests, nil
+}
+
+// pushToClients pushes configuration to all clients concurrently.
+func pushToClients(ctx context.Context, clients []*adminapi.Client, push PushFunc) ([]string, error) {
+	return iter.MapErr(clients, func(client **adminapi.Client) (string, error) {
+		return push(ctx, *client)
+	})
+}
@@ -0,0 +1,396 @@
+package sendconfig_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+)
+
+// statusServer serves the Admin API `/status` and `/metrics` endpoints of a gateway.
+type statusServer struct {
+	healthy       atomic.Bool
+	totalRequests atomic.Int64
+
+	// okRequests and failedRequests are the proxied requests reported by the `/metrics` endpoint.
+	okRequests     atomic.Int64
+	failedRequests atomic.Int64
+}
+
+func newStatusServerClient(t *testing.T) (*statusServer, *adminapi.Client) {
+	s := &statusServer{}
+	s.healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		switch r.URL.Path {
+		case "/status":
+			_, _ = fmt.Fprintf(w, `{"configuration_hash":"8f2e8ee8bd6e3e3c6e3a2f4f15f6d1a7","server":{"total_requests":%d}}`, s.totalRequests.Load())
+		case "/metrics":
+			_, _ = fmt.Fprintf(w, "# TYPE kong_http_requests_total counter\n"+
+				"kong_http_requests_total{service=\"s\",route=\"r\",code=\"200\"} %d\n"+
+				"kong_http_requests_total{service=\"s\",route=\"r\",code=\"503\"} %d\n",
+				s.okRequests.Load(), s.failedRequests.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+	return s, client
+}
+
+// gatewaysByURL sorts gateways by URLs of their clients.
+type gatewaysByURL struct {
+	servers []*statusServer
+	clients []*adminapi.Client
+}
+
+func (g gatewaysByURL) Len() int { return len(g.clients) }
+
+func (g gatewaysByURL) Less(i, j int) bool {
+	return g.clients[i].BaseRootURL() < g.clients[j].BaseRootURL()
+}
+
+func (g gatewaysByURL) Swap(i, j int) {
+	g.servers[i], g.servers[j] = g.servers[j], g.servers[i]
+	g.clients[i], g.clients[j] = g.clients[j], g.clients[i]
+}
+
+// pushRecorder records pushes to gateways in their order.
+type pushRecorder struct {
+	lock   sync.Mutex
+	pushed []string
+	failOn map[string]error
+
+	// version is a part of SHAs of the pushed configuration, changing it changes the configuration.
+	version string
+}
+
+func (r *pushRecorder) push(ctx context.Context, client *adminapi.Client) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pushed = append(r.pushed, client.BaseRootURL())
+	if err := r.failOn[client.BaseRootURL()]; err != nil {
+		return "", err
+	}
+	sha, _ := r.sha(ctx, client)
+	client.SetLastConfigSHA([]byte(sha))
+	return sha, nil
+}
+
+func (r *pushRecorder) sha(_ context.Context, client *adminapi.Client) (string, error) {
+	return "sha-" + r.version + client.BaseRootURL(), nil
+}
+
+func (r *pushRecorder) setVersion(version string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.version = version
+}
+
+func (r *pushRecorder) pushedURLs() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.pushed...)
+}
+
+// pushUntilDone pushes configuration until canaries are no longer observed, as it's done on consecutive syncs.
+func pushUntilDone(
+	t *testing.T,
+	rollout *sendconfig.StagedRollout,
+	clients []*adminapi.Client,
+	push *pushRecorder,
+	rollback *pushRecorder,
+) ([]string, error) {
+	var rollbackFunc sendconfig.PushFunc
+	if rollback != nil {
+		rollbackFunc = rollback.push
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		shas, err := rollout.Push(context.Background(), clients, push.sha, push.push, rollbackFunc)
+		if !errors.Is(err, sendconfig.ErrStagedRolloutInProgress) {
+			return shas, err
+		}
+		require.True(t, time.Now().Before(deadline), "staged rollout should finish")
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStagedRollout_Push(t *testing.T) {
+	ctx := context.Background()
+	config := sendconfig.StagedRolloutConfig{
+		Canaries:            intstr.FromString("25%"),
+		SoakPeriod:          50 * time.Millisecond,
+		HealthCheckInterval: 10 * time.Millisecond,
+	}
+	// setupGateways returns gateways sorted by their URLs, so that the first ones are canaries.
+	setupGateways := func(t *testing.T, count int) ([]*statusServer, []*adminapi.Client) {
+		var (
+			servers []*statusServer
+			clients []*adminapi.Client
+		)
+		for range count {
+			server, client := newStatusServerClient(t)
+			servers = append(servers, server)
+			clients = append(clients, client)
+		}
+		sort.Sort(gatewaysByURL{servers: servers, clients: clients})
+		return servers, clients
+	}
+
+	t.Run("healthy canaries get configuration before the rest of gateways", func(t *testing.T) {
+		_, clients := setupGateways(t, 4)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+
+		shas, err := pushUntilDone(t, sendconfig.NewStagedRollout(config, logr.Discard()), clients, push, rollback)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"sha-" + clients[0].BaseRootURL(),
+			"sha-" + clients[1].BaseRootURL(),
+			"sha-" + clients[2].BaseRootURL(),
+			"sha-" + clients[3].BaseRootURL(),
+		}, shas)
+		pushed := push.pushedURLs()
+		require.Len(t, pushed, 4)
+		require.Equal(t, clients[0].BaseRootURL(), pushed[0], "canary should get configuration first")
+		require.Empty(t, rollback.pushedURLs())
+	})
+
+	t.Run("canaries are chosen by their URLs regardless of the order of clients", func(t *testing.T) {
+		_, clients := setupGateways(t, 4)
+		reversed := []*adminapi.Client{clients[3], clients[2], clients[1], clients[0]}
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+
+		shas, err := pushUntilDone(t, sendconfig.NewStagedRollout(config, logr.Discard()), reversed, push, rollback)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"sha-" + clients[3].BaseRootURL(),
+			"sha-" + clients[2].BaseRootURL(),
+			"sha-" + clients[1].BaseRootURL(),
+			"sha-" + clients[0].BaseRootURL(),
+		}, shas, "SHAs should be returned in the order of clients")
+		require.Equal(t, clients[0].BaseRootURL(), push.pushedURLs()[0], "gateway with the first URL should be the canary")
+	})
+
+	t.Run("canaries stay the same for the whole rollout when gateways join", func(t *testing.T) {
+		_, clients := setupGateways(t, 4)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		rollout := sendconfig.NewStagedRollout(config, logr.Discard())
+
+		_, err := rollout.Push(ctx, clients[1:], push.sha, push.push, rollback.push)
+		require.ErrorIs(t, err, sendconfig.ErrStagedRolloutInProgress)
+		require.Equal(t, []string{clients[1].BaseRootURL()}, push.pushedURLs())
+
+		// A gateway that would be a canary joins during the soak period.
+		_, err = pushUntilDone(t, rollout, clients, push, rollback)
+		require.NoError(t, err)
+		pushed := push.pushedURLs()
+		require.Len(t, pushed, 4, "canary should not be pushed again")
+		require.Equal(t, clients[1].BaseRootURL(), pushed[0])
+		require.ElementsMatch(t, []string{clients[0].BaseRootURL(), clients[2].BaseRootURL(), clients[3].BaseRootURL()}, pushed[1:])
+	})
+
+	t.Run("soak period is spread across pushes", func(t *testing.T) {
+		_, clients := setupGateways(t, 4)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		rollout := sendconfig.NewStagedRollout(config, logr.Discard())
+
+		start := time.Now()
+		_, err := rollout.Push(ctx, clients, push.sha, push.push, rollback.push)
+		require.ErrorIs(t, err, sendconfig.ErrStagedRolloutInProgress)
+		require.Less(t, time.Since(start), config.SoakPeriod, "push should not wait for the soak period")
+		require.Equal(t, []string{clients[0].BaseRootURL()}, push.pushedURLs(), "only canary should be pushed")
+
+		_, err = rollout.Push(ctx, clients, push.sha, push.push, rollback.push)
+		require.ErrorIs(t, err, sendconfig.ErrStagedRolloutInProgress)
+		require.Equal(t, []string{clients[0].BaseRootURL()}, push.pushedURLs(), "canary should not be pushed again")
+
+		_, err = pushUntilDone(t, rollout, clients, push, rollback)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), config.SoakPeriod)
+		require.Len(t, push.pushedURLs(), 4)
+	})
+
+	t.Run("configuration changed during the soak period is pushed to canaries and observed again", func(t *testing.T) {
+		_, clients := setupGateways(t, 4)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		rollout := sendconfig.NewStagedRollout(config, logr.Discard())
+
+		_, err := rollout.Push(ctx, clients, push.sha, push.push, rollback.push)
+		require.ErrorIs(t, err, sendconfig.ErrStagedRolloutInProgress)
+
+		push.setVersion("changed-")
+		_, err = rollout.Push(ctx, clients, push.sha, push.push, rollback.push)
+		require.ErrorIs(t, err, sendconfig.ErrStagedRolloutInProgress)
+		require.Equal(t, []string{clients[0].BaseRootURL(), clients[0].BaseRootURL()}, push.pushedURLs())
+
+		shas, err := pushUntilDone(t, rollout, clients, push, rollback)
+		require.NoError(t, err)
+		require.Equal(t, "sha-changed-"+clients[3].BaseRootURL(), shas[3], "the rest of gateways should get the changed configuration")
+	})
+
+	t.Run("canary failing to apply configuration is rolled back", func(t *testing.T) {
+		_, clients := setupGateways(t, 4)
+		push := &pushRecorder{failOn: map[string]error{clients[0].BaseRootURL(): errors.New("rejected")}}
+		rollback := &pushRecorder{version: "rollback"}
+
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(config, logr.Discard()), clients, push, rollback)
+		require.ErrorContains(t, err, "pushing configuration to canary gateways failed: rejected")
+		require.Equal(t, []string{clients[0].BaseRootURL()}, push.pushedURLs())
+		require.Equal(t, []string{clients[0].BaseRootURL()}, rollback.pushedURLs())
+	})
+
+	t.Run("unhealthy canary is rolled back", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].healthy.Store(false)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(config, logr.Discard()), clients, push, rollback)
+		require.ErrorContains(t, err, fmt.Sprintf("canary gateway %s failed 1 health checks", clients[0].BaseRootURL()))
+		require.Equal(t, []string{clients[0].BaseRootURL()}, push.pushedURLs())
+		require.Equal(t, []string{clients[0].BaseRootURL()}, rollback.pushedURLs())
+	})
+
+	t.Run("configuration rejected by canaries is not pushed again until it changes", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].healthy.Store(false)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		rollout := sendconfig.NewStagedRollout(config, logr.Discard())
+
+		_, err := pushUntilDone(t, rollout, clients, push, rollback)
+		require.ErrorContains(t, err, "failed 1 health checks")
+		servers[0].healthy.Store(true)
+
+		_, err = pushUntilDone(t, rollout, clients, push, rollback)
+		require.ErrorIs(t, err, sendconfig.ErrConfigRejectedByCanaries)
+		require.Equal(t, []string{clients[0].BaseRootURL()}, push.pushedURLs(), "rejected configuration should not be pushed again")
+		require.Equal(t, []string{clients[0].BaseRootURL()}, rollback.pushedURLs(), "canary should not be rolled back again")
+
+		push.setVersion("changed-")
+		_, err = pushUntilDone(t, rollout, clients, push, rollback)
+		require.NoError(t, err)
+		require.Len(t, push.pushedURLs(), 5, "changed configuration should be pushed to all gateways")
+	})
+
+	t.Run("restarted canary is rolled back", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].totalRequests.Store(100)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		go func() {
+			time.Sleep(config.SoakPeriod / 2)
+			servers[0].totalRequests.Store(1)
+		}()
+
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(config, logr.Discard()), clients, push, rollback)
+		require.ErrorContains(t, err, "gateway was restarted")
+		require.Equal(t, []string{clients[0].BaseRootURL()}, rollback.pushedURLs())
+	})
+
+	t.Run("failed health checks are tolerated up to the configured number", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].healthy.Store(false)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		go func() {
+			time.Sleep(config.HealthCheckInterval / 2)
+			servers[0].healthy.Store(true)
+		}()
+
+		tolerantConfig := config
+		tolerantConfig.MaxHealthCheckFailures = 2
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(tolerantConfig, logr.Discard()), clients, push, rollback)
+		require.NoError(t, err)
+		require.Len(t, push.pushedURLs(), 4)
+		require.Empty(t, rollback.pushedURLs())
+	})
+
+	t.Run("soak period is skipped when configuration of canaries didn't change", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		// Canaries would fail health checks if they were observed.
+		servers[0].healthy.Store(false)
+		clients[0].SetLastConfigSHA([]byte("sha-" + clients[0].BaseRootURL()))
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+
+		_, err := sendconfig.NewStagedRollout(config, logr.Discard()).Push(ctx, clients, push.sha, push.push, rollback.push)
+		require.NoError(t, err)
+		require.Len(t, push.pushedURLs(), 4)
+		require.Empty(t, rollback.pushedURLs())
+	})
+
+	t.Run("canary with error rate above the maximum is rolled back", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].okRequests.Store(1000)
+		servers[0].failedRequests.Store(10)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		go func() {
+			time.Sleep(config.SoakPeriod / 2)
+			servers[0].okRequests.Add(90)
+			servers[0].failedRequests.Add(10)
+		}()
+
+		errorRateConfig := config
+		errorRateConfig.MaxErrorRate = 0.05
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(errorRateConfig, logr.Discard()), clients, push, rollback)
+		require.ErrorContains(t, err, "error rate 0.1000 (10 of 100 requests failed with 5xx) exceeds 0.0500")
+		require.Equal(t, []string{clients[0].BaseRootURL()}, rollback.pushedURLs())
+	})
+
+	t.Run("canary with error rate within the maximum is healthy", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].failedRequests.Store(500)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+		go func() {
+			time.Sleep(config.SoakPeriod / 2)
+			servers[0].okRequests.Add(99)
+			servers[0].failedRequests.Add(1)
+		}()
+
+		errorRateConfig := config
+		errorRateConfig.MaxErrorRate = 0.05
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(errorRateConfig, logr.Discard()), clients, push, rollback)
+		require.NoError(t, err)
+		require.Len(t, push.pushedURLs(), 4)
+		require.Empty(t, rollback.pushedURLs())
+	})
+
+	t.Run("canary without configuration to roll back to", func(t *testing.T) {
+		servers, clients := setupGateways(t, 4)
+		servers[0].healthy.Store(false)
+		push := &pushRecorder{}
+
+		_, err := pushUntilDone(t, sendconfig.NewStagedRollout(config, logr.Discard()), clients, push, nil)
+		require.ErrorContains(t, err, "failed 1 health checks")
+		require.Equal(t, []string{clients[0].BaseRootURL()}, push.pushedURLs())
+	})
+
+	t.Run("all gateways are pushed at once when every gateway would be a canary", func(t *testing.T) {
+		servers, clients := setupGateways(t, 2)
+		// Gateways aren't health checked, so they can be unhealthy.
+		servers[0].healthy.Store(false)
+		push, rollback := &pushRecorder{}, &pushRecorder{version: "rollback"}
+
+		allCanariesConfig := config
+		allCanariesConfig.Canaries = intstr.FromInt32(2)
+		_, err := sendconfig.NewStagedRollout(allCanariesConfig, logr.Discard()).Push(ctx, clients, push.sha, push.push, rollback.push)
+		require.NoError(t, err)
+		require.Len(t, push.pushedURLs(), 2)
+		require.Empty(t, rollback.pushedURLs())
+	})
+}
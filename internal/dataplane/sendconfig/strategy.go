@@ -56,23 +56,46 @@ type ResourceError struct {
 	APIVersion string
 	UID        string
 	Problems   map[string]string
+
+	// EntityType is the type of the Kong entity the error is associated with, e.g. "plugin".
+	EntityType string
+	// EntityName is the name of the Kong entity the error is associated with. For plugins, it's the plugin name.
+	EntityName string
+	// EntityForeignKeys are IDs or names of entities the Kong entity is associated with, keyed by their type (e.g.
+	// "route"). They're only populated for plugins.
+	EntityForeignKeys map[string]string
 }
 
 type DefaultUpdateStrategyResolver struct {
 	config Config
 	logger logr.Logger
+
+	// dryRunShadowGateway, when set, is used by UpdateStrategyDryRun to validate the whole configuration at once.
+	dryRunShadowGateway ConfigService
+
+	// compressionSupport holds whether gateways accept compressed configuration, shared by copies of the resolver.
+	compressionSupport *compressionSupportByURL
 }
 
 func NewDefaultUpdateStrategyResolver(config Config, logger logr.Logger) DefaultUpdateStrategyResolver {
 	return DefaultUpdateStrategyResolver{
-		config: config,
-		logger: logger,
+		config:             config,
+		logger:             logger,
+		compressionSupport: &compressionSupportByURL{},
 	}
 }
 
+// WithDryRunShadowGateway returns a copy of the resolver whose dry-run strategies validate the whole configuration
+// by applying it to a shadow DB-less gateway using the given service.
+func (r DefaultUpdateStrategyResolver) WithDryRunShadowGateway(configService ConfigService) DefaultUpdateStrategyResolver {
+	r.dryRunShadowGateway = configService
+	return r
+}
+
 // ResolveUpdateStrategy returns an UpdateStrategy based on the client and configuration.
 // The UpdateStrategy can be either UpdateStrategyDBMode or UpdateStrategyInMemory. Both
 // of them implement different ways to populate Kong instances with data-plane configuration.
+// In dry-run mode, UpdateStrategyDryRun is used for Kong Gateway clients instead.
 // If the client implements UpdateClientWithBackoff interface, its strategy will be decorated
 // with the backoff strategy it provides.
 func (r DefaultUpdateStrategyResolver) ResolveUpdateStrategy(
@@ -104,6 +127,18 @@ func (r DefaultUpdateStrategyResolver) resolveUpdateStrategy(client UpdateClient
 		)
 	}
 
+	if r.config.DryRun {
+		updateStrategy := NewUpdateStrategyDryRun(
+			adminAPIClient.Schemas,
+			r.config.Concurrency,
+			r.logger,
+		)
+		if r.dryRunShadowGateway != nil {
+			updateStrategy = updateStrategy.WithShadowGateway(r.dryRunShadowGateway)
+		}
+		return updateStrategy
+	}
+
 	if !r.config.InMemory {
 		return NewUpdateStrategyDBMode(
 			adminAPIClient,
@@ -118,9 +153,16 @@ func (r DefaultUpdateStrategyResolver) resolveUpdateStrategy(client UpdateClient
 		)
 	}
 
-	return NewUpdateStrategyInMemory(
+	updateStrategy := NewUpdateStrategyInMemory(
 		adminAPIClient,
 		DefaultContentToDBLessConfigConverter{},
 		r.logger,
-	)
+	).WithLargeConfigWarningThreshold(r.config.LargeDBLessConfigWarningThreshold)
+	if r.config.CompressDBLessConfig {
+		updateStrategy = updateStrategy.withCompression(
+			NewKongCompressedConfigService(adminAPIClient),
+			r.compressionSupport.get(adminAPIClient.BaseRootURL()),
+		)
+	}
+	return updateStrategy
 }
@@ -0,0 +1,418 @@
+package translator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	netv1 "k8s.io/api/networking/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Translator - Ingress Incremental Translation
+// -----------------------------------------------------------------------------
+
+// ingressIncrementalTranslation keeps translation results of Ingresses from the previous BuildKongConfig() call
+// along with the versions of objects in the cache they were translated from. Ingresses changed since then (added,
+// updated or deleted) or depending (directly or transitively, as resolved by fallback.ResolveDependencies) on
+// changed objects are affected by the changes and get translated again, while translation results of the other
+// Ingresses are reused.
+//
+// Only Ingresses are translated incrementally, as translation of every one of them is independent of the others
+// (with the exception of canary Ingresses, whose presence disables it). All other objects are always translated.
+// Dependencies are resolved for Ingresses only instead of building the dependency graph of the whole cache, as
+// that would cost more than translating all Ingresses again (see BenchmarkTranslator_IngressIncrementalTranslation).
+//
+// Its methods are safe to call with a nil receiver, which is the case when the feature is disabled.
+type ingressIncrementalTranslation struct {
+	logger logr.Logger
+
+	// cache is the cache the next translation is made from. Changes can only be detected in caches passed to
+	// the translator with UpdateCache, so it's nil until then.
+	cache *store.CacheStores
+
+	// objects are versions of objects in the cache the previous translation was made from.
+	objects map[fallback.ObjectHash]objectVersion
+	// dependencies are hashes of objects every Ingress in the cache the previous translation was made from
+	// depends on, by hashes of the Ingresses.
+	dependencies map[fallback.ObjectHash]objectHashes
+
+	// changes are changes in the cache since the previous translation.
+	changes cacheChanges
+
+	// ingresses are results of the previous translation of Ingresses by their UIDs.
+	ingresses map[k8stypes.UID]ingressTranslation
+}
+
+func newIngressIncrementalTranslation(logger logr.Logger) *ingressIncrementalTranslation {
+	return &ingressIncrementalTranslation{
+		logger: logger,
+	}
+}
+
+// objectHashes is a set of object hashes.
+type objectHashes map[fallback.ObjectHash]struct{}
+
+// intersects returns true if any of the hashes is in the other set.
+func (h objectHashes) intersects(other objectHashes) bool {
+	for hash := range h {
+		if _, ok := other[hash]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// objectVersion is a version of an object in the cache.
+type objectVersion struct {
+	resourceVersion string
+	// ingressClass is true for IngressClasses and IngressClassParameters, which affect translation of all Ingresses.
+	ingressClass bool
+	// mayBeDependency is false for objects no Ingress can depend on (Ingresses and EndpointSlices), whose changes
+	// don't change dependencies of other Ingresses.
+	mayBeDependency bool
+}
+
+// changed returns true if the object may have changed between the versions. Objects without a resource version
+// (e.g. objects not coming from the API server) are always considered changed.
+func (v objectVersion) changed(previous objectVersion) bool {
+	return v.resourceVersion == "" || v.resourceVersion != previous.resourceVersion
+}
+
+// cacheChanges are changes in the cache since the previous translation.
+type cacheChanges struct {
+	// all is true when all objects have to be translated again, e.g. when there was no previous translation.
+	all bool
+	// ingressClasses is true when IngressClasses or IngressClassParameters changed.
+	ingressClasses bool
+	// affected are hashes of changed objects and Ingresses depending on them.
+	affected objectHashes
+}
+
+// affects returns true if the object has to be translated again.
+func (c cacheChanges) affects(obj client.Object) bool {
+	if c.all {
+		return true
+	}
+	_, ok := c.affected[fallback.GetObjectHash(obj)]
+	return ok
+}
+
+// UpdateCache sets the cache the next translation is made from.
+func (i *ingressIncrementalTranslation) UpdateCache(c store.CacheStores) {
+	if i == nil {
+		return
+	}
+	i.cache = &c
+}
+
+// DetectChanges determines objects affected by changes in the cache since the previous translation. It must be
+// called once before every translation.
+func (i *ingressIncrementalTranslation) DetectChanges() {
+	if i == nil {
+		return
+	}
+	if i.cache == nil {
+		i.reset()
+		return
+	}
+	// Results of this translation won't be reused by the next one when there are no Ingresses or there are canary
+	// Ingresses, so there's no need to resolve dependencies and track versions of objects.
+	if !hasIncrementallyTranslatableIngresses(*i.cache) {
+		i.reset()
+		return
+	}
+
+	objects := cacheObjectVersions(*i.cache)
+	changes := cacheChanges{
+		all:      i.objects == nil,
+		affected: make(objectHashes),
+	}
+	// dependenciesChanged is true when objects Ingresses may depend on changed, so that dependencies of all
+	// Ingresses have to be resolved again.
+	dependenciesChanged := changes.all
+	markChanged := func(hash fallback.ObjectHash, version objectVersion) {
+		changes.ingressClasses = changes.ingressClasses || version.ingressClass
+		dependenciesChanged = dependenciesChanged || version.mayBeDependency
+		changes.affected[hash] = struct{}{}
+	}
+	if !changes.all {
+		// Objects that were added or updated.
+		for hash, version := range objects {
+			if previous, ok := i.objects[hash]; !ok || version.changed(previous) {
+				markChanged(hash, version)
+			}
+		}
+		// Objects that were deleted.
+		for hash, version := range i.objects {
+			if _, ok := objects[hash]; !ok {
+				markChanged(hash, version)
+			}
+		}
+	}
+
+	previousDependencies := i.dependencies
+	if dependenciesChanged {
+		previousDependencies = nil
+	}
+	dependencies, err := ingressDependencies(*i.cache, previousDependencies, changes.affected)
+	if err != nil {
+		i.logger.Error(err, "Failed to resolve dependencies of Ingresses, translating all objects")
+		i.reset()
+		return
+	}
+
+	if !changes.all {
+		// Ingresses depending on changed objects in the current cache (e.g. on added objects) or in the previous one
+		// (e.g. on deleted objects).
+		for _, deps := range []map[fallback.ObjectHash]objectHashes{dependencies, i.dependencies} {
+			for ingress, ingressDeps := range deps {
+				if ingressDeps.intersects(changes.affected) {
+					changes.affected[ingress] = struct{}{}
+				}
+			}
+		}
+	}
+
+	i.objects = objects
+	i.dependencies = dependencies
+	i.changes = changes
+}
+
+// reset drops the state of the previous translation, so that all objects are translated again.
+func (i *ingressIncrementalTranslation) reset() {
+	i.objects = nil
+	i.dependencies = nil
+	i.ingresses = nil
+	i.changes = cacheChanges{all: true}
+}
+
+// hasIncrementallyTranslatableIngresses returns true if the cache holds Ingresses and none of them is a canary.
+func hasIncrementallyTranslatableIngresses(c store.CacheStores) bool {
+	ingresses := c.IngressV1.List()
+	return len(ingresses) > 0 && !lo.ContainsBy(ingresses, func(o any) bool {
+		ingress, ok := o.(*netv1.Ingress)
+		return ok && annotations.ExtractCanary(ingress.Annotations)
+	})
+}
+
+// ingressDependencies returns hashes of objects every Ingress in the cache depends on directly or transitively,
+// by hashes of the Ingresses. Dependencies of objects shared by multiple Ingresses are resolved only once.
+// Dependencies of Ingresses found in previous and not changed are reused instead of being resolved again.
+func ingressDependencies(
+	c store.CacheStores,
+	previous map[fallback.ObjectHash]objectHashes,
+	changed objectHashes,
+) (map[fallback.ObjectHash]objectHashes, error) {
+	resolved := make(map[fallback.ObjectHash][]client.Object)
+	resolve := func(obj client.Object) ([]client.Object, error) {
+		hash := fallback.GetObjectHash(obj)
+		if deps, ok := resolved[hash]; ok {
+			return deps, nil
+		}
+		deps, err := fallback.ResolveDependencies(c, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve dependencies of %s: %w", hash, err)
+		}
+		resolved[hash] = deps
+		return deps, nil
+	}
+
+	dependencies := make(map[fallback.ObjectHash]objectHashes)
+	for _, o := range c.IngressV1.List() {
+		ingress, ok := o.(*netv1.Ingress)
+		if !ok {
+			continue
+		}
+		ingressHash := fallback.GetObjectHash(ingress)
+		if deps, ok := previous[ingressHash]; ok {
+			if _, ingressChanged := changed[ingressHash]; !ingressChanged {
+				dependencies[ingressHash] = deps
+				continue
+			}
+		}
+
+		ingressDeps := make(objectHashes)
+		toResolve := []client.Object{ingress}
+		for len(toResolve) > 0 {
+			obj := toResolve[len(toResolve)-1]
+			toResolve = toResolve[:len(toResolve)-1]
+			deps, err := resolve(obj)
+			if err != nil {
+				return nil, err
+			}
+			for _, dep := range deps {
+				depHash := fallback.GetObjectHash(dep)
+				if _, ok := ingressDeps[depHash]; ok {
+					continue
+				}
+				ingressDeps[depHash] = struct{}{}
+				toResolve = append(toResolve, dep)
+			}
+		}
+		dependencies[ingressHash] = ingressDeps
+	}
+	return dependencies, nil
+}
+
+// cacheObjectVersions returns versions of all objects in the cache by their hashes.
+func cacheObjectVersions(c store.CacheStores) map[fallback.ObjectHash]objectVersion {
+	ingressClassStores := []cache.Store{c.IngressClassV1, c.IngressClassParametersV1alpha1}
+	nonDependencyStores := []cache.Store{c.IngressV1, c.EndpointSlice}
+	versions := make(map[fallback.ObjectHash]objectVersion)
+	for _, s := range c.ListAllStores() {
+		ingressClass := slices.Contains(ingressClassStores, s)
+		mayBeDependency := !slices.Contains(nonDependencyStores, s)
+		for _, o := range s.List() {
+			obj, ok := o.(client.Object)
+			if !ok {
+				continue
+			}
+			versions[fallback.GetObjectHash(obj)] = objectVersion{
+				resourceVersion: obj.GetResourceVersion(),
+				ingressClass:    ingressClass,
+				mayBeDependency: mayBeDependency,
+			}
+		}
+	}
+	return versions
+}
+
+// -----------------------------------------------------------------------------
+// Translator - Ingress Incremental Translation - Ingresses
+// -----------------------------------------------------------------------------
+
+// ingressTranslation is a result of translating a single Ingress.
+type ingressTranslation struct {
+	// services are Kong Services with Kong Routes translated from the Ingress.
+	services map[string]kongstate.Service
+	// recorder holds translation failures and successfully translated objects reported during the translation.
+	recorder *translationRecorder
+}
+
+// ForgetIngresses drops results of the previous translation of Ingresses. It must be called when Ingresses
+// were translated without using TranslateIngresses.
+func (i *ingressIncrementalTranslation) ForgetIngresses() {
+	if i == nil {
+		return
+	}
+	i.ingresses = nil
+}
+
+// TranslateIngresses translates Ingresses affected by changes since the previous translation one by one using
+// translate and reuses results of the previous translation for the others. Failures and translated objects
+// recorded during translation of every Ingress are reported to the given collectors. Kong Services translated
+// from different Ingresses are merged by their names.
+func (i *ingressIncrementalTranslation) TranslateIngresses(
+	ingresses []*netv1.Ingress,
+	translate func(*netv1.Ingress, *translationRecorder) map[string]kongstate.Service,
+	failuresCollector subtranslator.FailuresCollector,
+	translatedObjectsCollector *ObjectsCollector,
+) map[string]kongstate.Service {
+	translateAll := i.changes.all || i.changes.ingressClasses
+	previous := i.ingresses
+	i.ingresses = make(map[k8stypes.UID]ingressTranslation, len(ingresses))
+
+	var translatedCount int
+	result := make(map[string]kongstate.Service)
+	for _, ingress := range ingresses {
+		translation, ok := previous[ingress.UID]
+		if !ok || translateAll || i.changes.affects(ingress) {
+			recorder := &translationRecorder{}
+			translation = ingressTranslation{
+				services: translate(ingress, recorder),
+				recorder: recorder,
+			}
+			translatedCount++
+		}
+		i.ingresses[ingress.UID] = translation
+
+		translation.recorder.replay(failuresCollector, translatedObjectsCollector)
+		mergeTranslatedServices(result, translation.services)
+	}
+
+	i.logger.V(util.DebugLevel).Info("Translated Ingresses incrementally",
+		"translated", translatedCount, "reused", len(ingresses)-translatedCount,
+	)
+	return result
+}
+
+// mergeTranslatedServices adds copies of Kong Services to result. Kong Routes of Kong Services already in result
+// are appended to the existing ones.
+func mergeTranslatedServices(result map[string]kongstate.Service, services map[string]kongstate.Service) {
+	for name, service := range services {
+		service := copyTranslatedService(service)
+		if existing, ok := result[name]; ok {
+			existing.Routes = append(existing.Routes, service.Routes...)
+			result[name] = existing
+			continue
+		}
+		result[name] = service
+	}
+}
+
+// copyTranslatedService returns a copy of the Kong Service that can be modified by further translation steps
+// without affecting the original. Kubernetes objects it refers to are not copied, as they're never modified.
+func copyTranslatedService(service kongstate.Service) kongstate.Service {
+	copyPlugins := func(plugins []kong.Plugin) []kong.Plugin {
+		return lo.Map(plugins, func(p kong.Plugin, _ int) kong.Plugin { return *p.DeepCopy() })
+	}
+
+	serviceCopy := service
+	serviceCopy.Service = *service.Service.DeepCopy()
+	serviceCopy.Plugins = copyPlugins(service.Plugins)
+	serviceCopy.Backends = slices.Clone(service.Backends)
+	serviceCopy.K8sServices = maps.Clone(service.K8sServices)
+	serviceCopy.Routes = lo.Map(service.Routes, func(route kongstate.Route, _ int) kongstate.Route {
+		route.Route = *route.Route.DeepCopy()
+		route.Plugins = copyPlugins(route.Plugins)
+		route.ParentGateways = slices.Clone(route.ParentGateways)
+		return route
+	})
+	return serviceCopy
+}
+
+// translationRecorder records translation failures and successfully translated objects, so that they can be
+// reported again when the translation result is reused.
+type translationRecorder struct {
+	failures          []recordedFailure
+	translatedObjects []client.Object
+}
+
+type recordedFailure struct {
+	reason         string
+	causingObjects []client.Object
+}
+
+// PushResourceFailure records a translation failure.
+func (r *translationRecorder) PushResourceFailure(reason string, causingObjects ...client.Object) {
+	r.failures = append(r.failures, recordedFailure{reason: reason, causingObjects: causingObjects})
+}
+
+// Add records a successfully translated object.
+func (r *translationRecorder) Add(obj client.Object) {
+	r.translatedObjects = append(r.translatedObjects, obj)
+}
+
+// replay reports the recorded failures and translated objects to the given collectors.
+func (r *translationRecorder) replay(failuresCollector subtranslator.FailuresCollector, translatedObjectsCollector *ObjectsCollector) {
+	for _, f := range r.failures {
+		failuresCollector.PushResourceFailure(f.reason, f.causingObjects...)
+	}
+	for _, obj := range r.translatedObjects {
+		translatedObjectsCollector.Add(obj)
+	}
+}
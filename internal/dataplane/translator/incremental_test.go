@@ -0,0 +1,346 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	incubatorv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/incubator/v1alpha1"
+)
+
+func TestTranslator_IncrementalTranslation(t *testing.T) {
+	ingressClassName := annotations.DefaultIngressClass
+	newService := func(name string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				UID:             k8stypes.UID("service-" + name),
+				ResourceVersion: "1",
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+			},
+		}
+	}
+	newServiceFacade := func(name, serviceName string, port int32) *incubatorv1alpha1.KongServiceFacade {
+		return &incubatorv1alpha1.KongServiceFacade{
+			TypeMeta: metav1.TypeMeta{Kind: incubatorv1alpha1.KongServiceFacadeKind, APIVersion: incubatorv1alpha1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				UID:             k8stypes.UID("facade-" + name),
+				ResourceVersion: "1",
+				Annotations:     map[string]string{annotations.IngressClassKey: ingressClassName},
+			},
+			Spec: incubatorv1alpha1.KongServiceFacadeSpec{
+				Backend: incubatorv1alpha1.KongServiceFacadeBackend{Name: serviceName, Port: port},
+			},
+		}
+	}
+	newIngress := func(name, path string, backend netv1.IngressBackend) *netv1.Ingress {
+		return &netv1.Ingress{
+			TypeMeta: metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				UID:             k8stypes.UID("ingress-" + name),
+				ResourceVersion: "1",
+			},
+			Spec: netv1.IngressSpec{
+				IngressClassName: &ingressClassName,
+				Rules: []netv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: netv1.IngressRuleValue{
+						HTTP: &netv1.HTTPIngressRuleValue{
+							Paths: []netv1.HTTPIngressPath{{
+								Path:     path,
+								PathType: lo.ToPtr(netv1.PathTypePrefix),
+								Backend:  backend,
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+	serviceBackend := func(name string) netv1.IngressBackend {
+		return netv1.IngressBackend{
+			Service: &netv1.IngressServiceBackend{Name: name, Port: netv1.ServiceBackendPort{Number: 80}},
+		}
+	}
+	facadeBackend := func(name string) netv1.IngressBackend {
+		return netv1.IngressBackend{
+			Resource: &corev1.TypedLocalObjectReference{
+				APIGroup: lo.ToPtr(incubatorv1alpha1.GroupVersion.Group),
+				Kind:     incubatorv1alpha1.KongServiceFacadeKind,
+				Name:     name,
+			},
+		}
+	}
+	updated := func(obj client.Object) client.Object {
+		obj.SetResourceVersion(obj.GetResourceVersion() + "1")
+		return obj
+	}
+
+	// Ingresses "a" and "b" share the same Kong Service, "c" uses a KongServiceFacade.
+	ingressA := newIngress("a", "/a", serviceBackend("svc"))
+	ingressB := newIngress("b", "/b", serviceBackend("svc"))
+	ingressC := newIngress("c", "/c", facadeBackend("facade"))
+	objects := map[string]client.Object{
+		"svc":       newService("svc"),
+		"other-svc": newService("other-svc"),
+		"facade":    newServiceFacade("facade", "svc", 80),
+		"a":         ingressA,
+		"b":         ingressB,
+		"c":         ingressC,
+	}
+
+	type step struct {
+		name   string
+		change func()
+		// expectedTranslated are names of Ingresses expected to be translated again in the step.
+		expectedTranslated []string
+	}
+	steps := []step{
+		{
+			name:               "all Ingresses are translated initially",
+			change:             func() {},
+			expectedTranslated: []string{"a", "b", "c"},
+		},
+		{
+			name:   "nothing changed",
+			change: func() {},
+		},
+		{
+			name: "Ingress updated",
+			change: func() {
+				ingress := updated(ingressA.DeepCopy()).(*netv1.Ingress)
+				ingress.Spec.Rules[0].HTTP.Paths[0].Path = "/a-updated"
+				objects["a"] = ingress
+			},
+			expectedTranslated: []string{"a"},
+		},
+		{
+			name: "KongServiceFacade updated",
+			change: func() {
+				facade := updated(objects["facade"].DeepCopyObject().(client.Object)).(*incubatorv1alpha1.KongServiceFacade)
+				facade.Spec.Backend.Port = 8080
+				objects["facade"] = facade
+			},
+			expectedTranslated: []string{"c"},
+		},
+		{
+			name: "Ingress added",
+			change: func() {
+				objects["d"] = newIngress("d", "/d", serviceBackend("other-svc"))
+			},
+			expectedTranslated: []string{"d"},
+		},
+		{
+			name: "Ingress deleted",
+			change: func() {
+				delete(objects, "b")
+			},
+		},
+		{
+			name: "KongServiceFacade deleted",
+			change: func() {
+				delete(objects, "facade")
+			},
+			expectedTranslated: []string{"c"},
+		},
+		{
+			name: "canary Ingress added",
+			change: func() {
+				canary := newIngress("canary", "/d", serviceBackend("svc"))
+				canary.Annotations = map[string]string{
+					annotations.AnnotationPrefix + annotations.CanaryKey:       "true",
+					annotations.AnnotationPrefix + annotations.CanaryWeightKey: "10",
+				}
+				objects["canary"] = canary
+			},
+		},
+		{
+			name: "canary Ingress deleted",
+			change: func() {
+				delete(objects, "canary")
+			},
+			expectedTranslated: []string{"a", "c", "d"},
+		},
+		{
+			name: "Ingress referring to a missing Service added",
+			change: func() {
+				objects["e"] = newIngress("e", "/e", serviceBackend("missing-svc"))
+			},
+			expectedTranslated: []string{"e"},
+		},
+		{
+			name: "missing Service added",
+			change: func() {
+				objects["missing-svc"] = newService("missing-svc")
+			},
+			expectedTranslated: []string{"e"},
+		},
+	}
+
+	newTranslator := func(incremental bool) *Translator {
+		translator, err := NewTranslator(logr.Discard(), store.New(store.NewCacheStores(), ingressClassName, logr.Discard()), "", FeatureFlags{
+			FillIDs:                           true,
+			ReportConfiguredKubernetesObjects: true,
+			KongServiceFacade:                 true,
+			IngressIncrementalTranslation:     incremental,
+		}, fakeSchemaServiceProvier{})
+		require.NoError(t, err)
+		return translator
+	}
+	incrementalTranslator := newTranslator(true)
+
+	for _, s := range steps {
+		t.Run(s.name, func(t *testing.T) {
+			s.change()
+			cacheStores, err := store.NewCacheStoresFromObjs(lo.Map(lo.Values(objects), func(obj client.Object, _ int) runtime.Object {
+				return obj.DeepCopyObject()
+			})...)
+			require.NoError(t, err)
+
+			previous := incrementalTranslator.ingressIncrementalTranslation.ingresses
+			incrementalTranslator.UpdateCache(cacheStores)
+			incrementalResult := incrementalTranslator.BuildKongConfig()
+
+			fullTranslator := newTranslator(false)
+			fullTranslator.UpdateCache(cacheStores)
+			fullResult := fullTranslator.BuildKongConfig()
+
+			var translated []string
+			for uid, translation := range incrementalTranslator.ingressIncrementalTranslation.ingresses {
+				if previousTranslation, ok := previous[uid]; !ok || previousTranslation.recorder != translation.recorder {
+					translated = append(translated, string(uid)[len("ingress-"):])
+				}
+			}
+			require.ElementsMatch(t, s.expectedTranslated, translated)
+
+			toDeckContent := func(result KongConfigBuildingResult) string {
+				content := deckgen.ToDeckContent(context.Background(), logr.Discard(), result.KongState, deckgen.GenerateDeckContentParams{})
+				b, err := json.Marshal(content)
+				require.NoError(t, err)
+				return string(b)
+			}
+			require.NotEmpty(t, incrementalResult.KongState.Services)
+			require.Equal(t, toDeckContent(fullResult), toDeckContent(incrementalResult))
+			require.ElementsMatch(t, fullResult.ConfiguredKubernetesObjects, incrementalResult.ConfiguredKubernetesObjects)
+			require.ElementsMatch(t,
+				lo.Map(fullResult.TranslationFailures, func(f failures.ResourceFailure, _ int) string { return f.Message() }),
+				lo.Map(incrementalResult.TranslationFailures, func(f failures.ResourceFailure, _ int) string { return f.Message() }),
+			)
+		})
+	}
+}
+
+// BenchmarkTranslator_IngressIncrementalTranslation compares building Kong configuration with and without
+// the incremental translation of Ingresses when a single Ingress changes between translations. The gain grows with
+// the cost of translating a single Ingress, hence Ingresses with different numbers of paths are used.
+func BenchmarkTranslator_IngressIncrementalTranslation(b *testing.B) {
+	ingressClassName := annotations.DefaultIngressClass
+	const ingressesCount = 1000
+
+	// newObjects returns Ingresses with the given number of paths, each with its own Service. Ingresses are
+	// at odd indexes.
+	newObjects := func(pathsCount int) []client.Object {
+		objects := make([]client.Object, 0, 2*ingressesCount)
+		for i := range ingressesCount {
+			name := fmt.Sprintf("name-%d", i)
+			paths := make([]netv1.HTTPIngressPath, 0, pathsCount)
+			for j := range pathsCount {
+				paths = append(paths, netv1.HTTPIngressPath{
+					Path:     fmt.Sprintf("/path-%d", j),
+					PathType: lo.ToPtr(netv1.PathTypePrefix),
+					Backend: netv1.IngressBackend{
+						Service: &netv1.IngressServiceBackend{Name: name, Port: netv1.ServiceBackendPort{Number: 80}},
+					},
+				})
+			}
+			objects = append(objects,
+				&corev1.Service{
+					TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       "default",
+						UID:             k8stypes.UID("service-" + name),
+						ResourceVersion: "1",
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+					},
+				},
+				&netv1.Ingress{
+					TypeMeta: metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       "default",
+						UID:             k8stypes.UID("ingress-" + name),
+						ResourceVersion: "1",
+					},
+					Spec: netv1.IngressSpec{
+						IngressClassName: &ingressClassName,
+						Rules: []netv1.IngressRule{{
+							Host:             name + ".example.com",
+							IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{Paths: paths}},
+						}},
+					},
+				},
+			)
+		}
+		return objects
+	}
+
+	for _, pathsCount := range []int{1, 10} {
+		objects := newObjects(pathsCount)
+		for _, incremental := range []bool{false, true} {
+			b.Run(fmt.Sprintf("paths=%d/incremental=%t", pathsCount, incremental), func(b *testing.B) {
+				translator, err := NewTranslator(logr.Discard(), store.New(store.NewCacheStores(), ingressClassName, logr.Discard()), "", FeatureFlags{
+					FillIDs:                       true,
+					KongServiceFacade:             true,
+					IngressIncrementalTranslation: incremental,
+				}, fakeSchemaServiceProvier{})
+				require.NoError(b, err)
+				cacheStores, err := store.NewCacheStoresFromObjs(lo.Map(objects, func(obj client.Object, _ int) runtime.Object {
+					return obj.DeepCopyObject()
+				})...)
+				require.NoError(b, err)
+				translator.UpdateCache(cacheStores)
+				translator.BuildKongConfig()
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					// Update a single Ingress between translations.
+					ingress := objects[2*(i%ingressesCount)+1].DeepCopyObject().(*netv1.Ingress)
+					ingress.ResourceVersion = strconv.Itoa(i + 2)
+					require.NoError(b, cacheStores.IngressV1.Update(ingress))
+					translator.UpdateCache(cacheStores)
+
+					result := translator.BuildKongConfig()
+					require.Len(b, result.KongState.Services, ingressesCount)
+				}
+			})
+		}
+	}
+}
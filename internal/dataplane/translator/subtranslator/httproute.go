@@ -24,7 +24,11 @@ import (
 type KongServiceTranslation struct {
 	Name        string
 	BackendRefs []gatewayapi.HTTPBackendRef
-	KongRoutes  []KongRouteTranslation
+	// Timeouts are the timeouts shared by all the rules translated into the service.
+	Timeouts *gatewayapi.HTTPRouteTimeouts
+	// SessionPersistence is the session persistence shared by all the rules translated into the service.
+	SessionPersistence *gatewayapi.SessionPersistence
+	KongRoutes         []KongRouteTranslation
 }
 
 // KongRouteTranslation is a translation of a single HTTPRoute rule into metadata
@@ -33,13 +37,15 @@ type KongRouteTranslation struct {
 	Name    string
 	Matches []gatewayapi.HTTPRouteMatch
 	Filters []gatewayapi.HTTPRouteFilter
+	// RuleNumber is the index of the first rule the matches come from.
+	RuleNumber int
 }
 
 // TranslateHTTPRoute translates a list of HTTPRoutes into a list of HTTPRouteTranslationMeta
 // objects that can be used to instantiate Kong routes and services.
-// The translation is done by grouping the HTTPRoutes by their backendRefs.
+// The translation is done by grouping the HTTPRoutes by their backendRefs, timeouts and session persistence.
 // This means that all the rules of a single HTTPRoute will be grouped together
-// if they share the same backendRefs.
+// if they share the same backendRefs, timeouts and session persistence.
 func TranslateHTTPRoute(route *gatewayapi.HTTPRoute) []*KongServiceTranslation {
 	index := httpRouteTranslationIndex{}
 	index.setRoute(route)
@@ -73,11 +79,12 @@ func (i *httpRouteTranslationIndex) extractRulesMeta(route *gatewayapi.HTTPRoute
 }
 
 func (i *httpRouteTranslationIndex) translate() []*KongServiceTranslation {
-	rulesGroupedByBackendRed := groupRulesByBackendRefs(i.rulesMeta)
+	rulesGroupedByBackendRed := groupRulesByKongServiceSettings(i.rulesMeta)
 	translations := make([]*KongServiceTranslation, 0, len(rulesGroupedByBackendRed))
 
 	for _, rulesByBackends := range rulesGroupedByBackendRed {
-		// each backend refs group is a separate Kong service, not eligible for consolidation
+		// each backend refs, timeouts and session persistence group is a separate Kong service,
+		// not eligible for consolidation
 		kongServiceTranslation := i.translateToKongService(rulesByBackends)
 		i.translateToKongServiceRoutes(kongServiceTranslation, rulesByBackends)
 		translations = append(translations, kongServiceTranslation)
@@ -88,9 +95,11 @@ func (i *httpRouteTranslationIndex) translate() []*KongServiceTranslation {
 
 func (i *httpRouteTranslationIndex) translateToKongService(rulesMeta []httpRouteRuleMeta) *KongServiceTranslation {
 	return &KongServiceTranslation{
-		Name:        i.translateToKongServiceName(rulesMeta),
-		BackendRefs: i.translateToKongServiceBackends(rulesMeta),
-		KongRoutes:  nil,
+		Name:               i.translateToKongServiceName(rulesMeta),
+		BackendRefs:        i.translateToKongServiceBackends(rulesMeta),
+		Timeouts:           i.translateToKongServiceTimeouts(rulesMeta),
+		SessionPersistence: i.translateToKongServiceSessionPersistence(rulesMeta),
+		KongRoutes:         nil,
 	}
 }
 
@@ -119,6 +128,24 @@ func (i *httpRouteTranslationIndex) translateToKongServiceBackends(rulesMeta []h
 	return rulesMeta[0].Rule.BackendRefs
 }
 
+func (i *httpRouteTranslationIndex) translateToKongServiceTimeouts(rulesMeta []httpRouteRuleMeta) *gatewayapi.HTTPRouteTimeouts {
+	if len(rulesMeta) == 0 {
+		return nil
+	}
+	// get the timeouts from any rule, as they are all the same,
+	// because the rules are processed in groups with the same backendRefs and timeouts.
+	return rulesMeta[0].Rule.Timeouts
+}
+
+func (i *httpRouteTranslationIndex) translateToKongServiceSessionPersistence(rulesMeta []httpRouteRuleMeta) *gatewayapi.SessionPersistence {
+	if len(rulesMeta) == 0 {
+		return nil
+	}
+	// get the session persistence from any rule, as they are all the same,
+	// because the rules are processed in groups with the same session persistence.
+	return rulesMeta[0].Rule.SessionPersistence
+}
+
 func (i *httpRouteTranslationIndex) translateToKongServiceRoutes(s *KongServiceTranslation, rulesMeta []httpRouteRuleMeta) {
 	for _, rulesByFilter := range groupRulesByFilter(rulesMeta) {
 		// each filter group must be a separate Kong route, not eligible for consolidation
@@ -156,9 +183,10 @@ func (i *httpRouteTranslationIndex) translateToKongRoutes(rulesMeta []httpRouteR
 		kongRouteName := i.translateToKongRouteName(matchGroup)
 
 		kongRoutes = append(kongRoutes, KongRouteTranslation{
-			Name:    kongRouteName,
-			Matches: matchGroup.httpRouteMatches(),
-			Filters: filters,
+			Name:       kongRouteName,
+			Matches:    matchGroup.httpRouteMatches(),
+			Filters:    filters,
+			RuleNumber: matchGroup[0].RuleNumber,
 		})
 	}
 
@@ -194,11 +222,14 @@ func (i *httpRouteTranslationIndex) translateToKongRouteName(matchesMeta httpRou
 	)
 }
 
-// groupRulesByBackendRefs groups the rules by their backendRefs.
-// The backendRefs are grouped by their key function.
+// groupRulesByKongServiceSettings groups the rules by their backendRefs, timeouts and session persistence.
+// Rules with different timeouts can't share a Kong service, as timeouts are configured on the service level.
+// Similarly, session persistence is configured on the level of the service's upstream.
 // The elements in the groups have the order of the original slice, but the groups themselves are not ordered.
-func groupRulesByBackendRefs(ruleEntries []httpRouteRuleMeta) map[string][]httpRouteRuleMeta {
-	return groupSliceByKeyFn(ruleEntries, httpRouteRuleMeta.getHTTPBackendRefsKey)
+func groupRulesByKongServiceSettings(ruleEntries []httpRouteRuleMeta) map[string][]httpRouteRuleMeta {
+	return groupSliceByKeyFn(ruleEntries, func(m httpRouteRuleMeta) string {
+		return m.getHTTPBackendRefsKey() + m.getTimeoutsKey() + m.getSessionPersistenceKey()
+	})
 }
 
 // groupRulesByFilter groups the rules by their filters.
@@ -241,6 +272,22 @@ func (m httpRouteRuleMeta) getHTTPBackendRefsKey() string {
 	return getSortedItemsString(m.Rule.BackendRefs)
 }
 
+// getTimeoutsKey computes a key from the rule's timeouts.
+func (m httpRouteRuleMeta) getTimeoutsKey() string {
+	if m.Rule.Timeouts == nil {
+		return ""
+	}
+	return mustMarshalJSON(m.Rule.Timeouts)
+}
+
+// getSessionPersistenceKey computes a key from the rule's session persistence.
+func (m httpRouteRuleMeta) getSessionPersistenceKey() string {
+	if m.Rule.SessionPersistence == nil {
+		return ""
+	}
+	return mustMarshalJSON(m.Rule.SessionPersistence)
+}
+
 func (m *httpRouteRuleMeta) matches() httpRouteMatchMetaList {
 	matches := make([]httpRouteMatchMeta, 0, len(m.Rule.Matches))
 
@@ -447,8 +494,8 @@ func generatePluginsFromHTTPRouteFilters(
 			kongRouteModifiers = append(kongRouteModifiers, routeModifiers...)
 
 		case gatewayapi.HTTPRouteFilterRequestMirror:
-			// not supported
-			return httpRouteFiltersOriginatedPlugins{}, fmt.Errorf("httpFilter %s unsupported", filter.Type)
+			// RequestMirror filters are translated by the translator, as the plugin mirroring requests
+			// depends on the protocol of the mirror backends.
 		}
 	}
 
@@ -903,3 +950,31 @@ func normalizePath(path string) string {
 func isPathRoot(path string) bool {
 	return path == "/"
 }
+
+// ValidateHTTPRouteRequestMirrors checks whether RequestMirror filters of the HTTPRoute can be translated.
+// They have to be enabled with the HTTPRouteRequestMirror feature gate. They're implemented with a pre-function
+// plugin running Lua code that Kong allows only with untrusted_lua set to on, and a route can't have another
+// pre-function plugin attached. routePlugins are the names of Kong plugins (e.g. "pre-function") attached to
+// the HTTPRoute with the konghq.com/plugins annotation.
+func ValidateHTTPRouteRequestMirrors(httproute *gatewayapi.HTTPRoute, enabled, untrustedLua bool, routePlugins []string) error {
+	for ruleIndex, rule := range httproute.Spec.Rules {
+		for filterIndex, filter := range rule.Filters {
+			if filter.Type != gatewayapi.HTTPRouteFilterRequestMirror {
+				continue
+			}
+			var err error
+			switch {
+			case !enabled:
+				err = ErrRouteValidationRequestMirrorDisabled
+			case !untrustedLua:
+				err = ErrRouteValidationRequestMirrorUntrustedLua
+			case lo.Contains(routePlugins, "pre-function"):
+				err = ErrRouteValidationRequestMirrorPreFunction
+			default:
+				continue
+			}
+			return fmt.Errorf("rules[%d].filters[%d]: %w", ruleIndex, filterIndex, err)
+		}
+	}
+	return nil
+}
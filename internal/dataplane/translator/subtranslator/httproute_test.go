@@ -7,6 +7,7 @@ import (
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
@@ -758,3 +759,157 @@ func TestMergePluginsOfTheSameType(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateHTTPRoute_RulesWithDifferentTimeoutsAreNotCombined(t *testing.T) {
+	rule := func(path string, timeout *gatewayapi.Duration) gatewayapi.HTTPRouteRule {
+		return gatewayapi.HTTPRouteRule{
+			Matches: []gatewayapi.HTTPRouteMatch{{
+				Path: &gatewayapi.HTTPPathMatch{
+					Type:  lo.ToPtr(gatewayapi.PathMatchPathPrefix),
+					Value: lo.ToPtr(path),
+				},
+			}},
+			BackendRefs: []gatewayapi.HTTPBackendRef{{
+				BackendRef: gatewayapi.BackendRef{
+					BackendObjectReference: gatewayapi.BackendObjectReference{
+						Name: "service",
+						Port: lo.ToPtr(gatewayapi.PortNumber(80)),
+					},
+				},
+			}},
+			Timeouts: &gatewayapi.HTTPRouteTimeouts{BackendRequest: timeout},
+		}
+	}
+	route := &gatewayapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gatewayapi.HTTPRouteSpec{
+			Rules: []gatewayapi.HTTPRouteRule{
+				rule("/one", lo.ToPtr(gatewayapi.Duration("1s"))),
+				rule("/two", lo.ToPtr(gatewayapi.Duration("2s"))),
+				rule("/three", lo.ToPtr(gatewayapi.Duration("1s"))),
+			},
+		},
+	}
+
+	translations := TranslateHTTPRoute(route)
+	require.Len(t, translations, 2, "rules with different timeouts should be translated into separate services")
+
+	servicesByName := lo.SliceToMap(translations, func(s *KongServiceTranslation) (string, *KongServiceTranslation) {
+		return s.Name, s
+	})
+	require.Contains(t, servicesByName, "httproute.default.route.0")
+	require.Contains(t, servicesByName, "httproute.default.route.1")
+	require.Equal(t, gatewayapi.Duration("1s"), *servicesByName["httproute.default.route.0"].Timeouts.BackendRequest)
+	require.Len(t, servicesByName["httproute.default.route.0"].KongRoutes, 1)
+	require.Len(t, servicesByName["httproute.default.route.0"].KongRoutes[0].Matches, 2)
+	require.Equal(t, gatewayapi.Duration("2s"), *servicesByName["httproute.default.route.1"].Timeouts.BackendRequest)
+	require.Len(t, servicesByName["httproute.default.route.1"].KongRoutes, 1)
+}
+
+func TestTranslateHTTPRoute_RulesWithDifferentSessionPersistenceAreNotCombined(t *testing.T) {
+	rule := func(path string, sessionPersistence *gatewayapi.SessionPersistence) gatewayapi.HTTPRouteRule {
+		return gatewayapi.HTTPRouteRule{
+			Matches: []gatewayapi.HTTPRouteMatch{{
+				Path: &gatewayapi.HTTPPathMatch{
+					Type:  lo.ToPtr(gatewayapi.PathMatchPathPrefix),
+					Value: lo.ToPtr(path),
+				},
+			}},
+			BackendRefs: []gatewayapi.HTTPBackendRef{{
+				BackendRef: gatewayapi.BackendRef{
+					BackendObjectReference: gatewayapi.BackendObjectReference{
+						Name: "service",
+						Port: lo.ToPtr(gatewayapi.PortNumber(80)),
+					},
+				},
+			}},
+			SessionPersistence: sessionPersistence,
+		}
+	}
+	sessionPersistence := &gatewayapi.SessionPersistence{SessionName: lo.ToPtr("session")}
+	route := &gatewayapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gatewayapi.HTTPRouteSpec{
+			Rules: []gatewayapi.HTTPRouteRule{
+				rule("/one", sessionPersistence),
+				rule("/two", nil),
+				rule("/three", sessionPersistence),
+			},
+		},
+	}
+
+	translations := TranslateHTTPRoute(route)
+	require.Len(t, translations, 2, "rules with different session persistence should be translated into separate services")
+
+	servicesByName := lo.SliceToMap(translations, func(s *KongServiceTranslation) (string, *KongServiceTranslation) {
+		return s.Name, s
+	})
+	require.Contains(t, servicesByName, "httproute.default.route.0")
+	require.Contains(t, servicesByName, "httproute.default.route.1")
+	require.Equal(t, sessionPersistence, servicesByName["httproute.default.route.0"].SessionPersistence)
+	require.Len(t, servicesByName["httproute.default.route.0"].KongRoutes[0].Matches, 2)
+	require.Nil(t, servicesByName["httproute.default.route.1"].SessionPersistence)
+}
+
+func TestValidateHTTPRouteRequestMirrors(t *testing.T) {
+	routeWithFilters := func(filters ...gatewayapi.HTTPRouteFilter) *gatewayapi.HTTPRoute {
+		return &gatewayapi.HTTPRoute{
+			Spec: gatewayapi.HTTPRouteSpec{
+				Rules: []gatewayapi.HTTPRouteRule{{Filters: filters}},
+			},
+		}
+	}
+	mirrorFilter := gatewayapi.HTTPRouteFilter{Type: gatewayapi.HTTPRouteFilterRequestMirror}
+	headerFilter := gatewayapi.HTTPRouteFilter{Type: gatewayapi.HTTPRouteFilterRequestHeaderModifier}
+
+	testCases := []struct {
+		name          string
+		route         *gatewayapi.HTTPRoute
+		disabled      bool
+		untrustedLua  bool
+		routePlugins  []string
+		expectedError error
+	}{
+		{
+			name:         "route without mirror filters is valid regardless of untrusted_lua and plugins",
+			route:        routeWithFilters(headerFilter),
+			routePlugins: []string{"pre-function"},
+		},
+		{
+			name:          "mirror filter requires the feature gate",
+			route:         routeWithFilters(mirrorFilter),
+			disabled:      true,
+			untrustedLua:  true,
+			expectedError: ErrRouteValidationRequestMirrorDisabled,
+		},
+		{
+			name:          "mirror filter requires untrusted_lua",
+			route:         routeWithFilters(headerFilter, mirrorFilter),
+			expectedError: ErrRouteValidationRequestMirrorUntrustedLua,
+		},
+		{
+			name:         "mirror filter with untrusted_lua and other plugins is valid",
+			route:        routeWithFilters(mirrorFilter),
+			untrustedLua: true,
+			routePlugins: []string{"key-auth", "post-function"},
+		},
+		{
+			name:          "mirror filter conflicts with a pre-function plugin",
+			route:         routeWithFilters(mirrorFilter),
+			untrustedLua:  true,
+			routePlugins:  []string{"key-auth", "pre-function"},
+			expectedError: ErrRouteValidationRequestMirrorPreFunction,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateHTTPRouteRequestMirrors(tc.route, !tc.disabled, tc.untrustedLua, tc.routePlugins)
+			if tc.expectedError == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
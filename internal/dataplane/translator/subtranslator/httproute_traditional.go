@@ -0,0 +1,206 @@
+package subtranslator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+)
+
+// -----------------------------------------------------------------------------
+// HTTPRoute Translation - Traditional Router
+// -----------------------------------------------------------------------------
+
+// The traditional router (both traditional and traditional_compatible flavors) orders routes by the number of
+// fields they match on (hosts, paths, methods, headers), then by the number of header matches and only then by
+// regex_priority. Gateway API precedence (path, then method, then the number of header matches, then the age of
+// the route and the order of rules) is encoded into regex_priority, which makes it effective among routes matching
+// on the same fields and the same number of headers.
+const (
+	// traditionalRegexPriorityOrdinalBits is the number of bits used for the order of HTTPRoute rules.
+	traditionalRegexPriorityOrdinalBits = 19
+	// traditionalRegexPriorityMaxOrdinal is the maximum order of an HTTPRoute rule that can be represented.
+	traditionalRegexPriorityMaxOrdinal = 1<<traditionalRegexPriorityOrdinalBits - 1
+	// traditionalRegexPriorityMaxPathLength is the maximum path length taken into account.
+	traditionalRegexPriorityMaxPathLength = 1<<10 - 1
+)
+
+// TraditionalHTTPRouteRegexPriority returns the regex_priority of a Kong route translated from the HTTPRoute
+// matches for the traditional router. ruleOrdinal is the position of the rule the matches come from among
+// rules of all HTTPRoutes, ordered by the Gateway API precedence (age of the route, its namespace and name, index
+// of the rule). The highest path precedence of the matches is used as all of them are translated into a single
+// Kong route.
+func TraditionalHTTPRouteRegexPriority(matches []gatewayapi.HTTPRouteMatch, ruleOrdinal int) int {
+	var (
+		exact     bool
+		length    int
+		hasMethod bool
+	)
+	for _, match := range matches {
+		matchExact, matchLength := httpRouteMatchPathPrecedence(match)
+		if matchExact && !exact || matchExact == exact && matchLength > length {
+			exact, length = matchExact, matchLength
+		}
+		hasMethod = hasMethod || match.Method != nil
+	}
+
+	priority := min(length, traditionalRegexPriorityMaxPathLength)
+	if exact {
+		priority |= 1 << 10
+	}
+	priority <<= 1
+	if hasMethod {
+		priority |= 1
+	}
+	return priority<<traditionalRegexPriorityOrdinalBits |
+		(traditionalRegexPriorityMaxOrdinal - min(ruleOrdinal, traditionalRegexPriorityMaxOrdinal))
+}
+
+// ValidateHTTPRouteForTraditionalRouter checks whether all matches of the HTTPRoute can be expressed
+// with the traditional router. It returns an error describing the first match that can't be.
+func ValidateHTTPRouteForTraditionalRouter(httproute *gatewayapi.HTTPRoute) error {
+	type indexedMatch struct {
+		match      gatewayapi.HTTPRouteMatch
+		ruleIndex  int
+		matchIndex int
+	}
+	var matches []indexedMatch
+	for ruleIndex, rule := range httproute.Spec.Rules {
+		for matchIndex, match := range rule.Matches {
+			if len(match.QueryParams) > 0 {
+				return fmt.Errorf("rules[%d].matches[%d]: %w", ruleIndex, matchIndex, ErrRouteValidationQueryParamMatchesUnsupported)
+			}
+			matches = append(matches, indexedMatch{match: match, ruleIndex: ruleIndex, matchIndex: matchIndex})
+		}
+	}
+
+	// The traditional router prefers matches with more fields and header matches regardless of their paths and
+	// methods, so matches overlapping with a match that takes precedence over them by Gateway API rules, but that
+	// has fewer header matches, would be wrongly preferred.
+	for _, preferred := range matches {
+		for _, other := range matches {
+			if !httpRouteMatchPrecedes(preferred.match, other.match) ||
+				!traditionalRouterPrecedes(other.match, preferred.match) ||
+				!httpRouteMatchesOverlap(preferred.match, other.match) {
+				continue
+			}
+			return fmt.Errorf("rules[%d].matches[%d] takes precedence over rules[%d].matches[%d]: %w",
+				preferred.ruleIndex, preferred.matchIndex, other.ruleIndex, other.matchIndex,
+				ErrRouteValidationMatchPrecedenceUnsupported,
+			)
+		}
+	}
+	return nil
+}
+
+// httpRouteMatchPathPrecedence returns whether the match's path is exact and the length of the path
+// that determine the precedence of the match. Matches without a path match all paths.
+func httpRouteMatchPathPrecedence(match gatewayapi.HTTPRouteMatch) (exact bool, length int) {
+	if match.Path == nil || match.Path.Value == nil {
+		return false, len("/")
+	}
+	return match.Path.Type != nil && *match.Path.Type == gatewayapi.PathMatchExact, len(*match.Path.Value)
+}
+
+// httpRouteMatchPrecedes returns true if a takes precedence over b by its path or method according
+// to the Gateway API.
+func httpRouteMatchPrecedes(a, b gatewayapi.HTTPRouteMatch) bool {
+	aExact, aLength := httpRouteMatchPathPrecedence(a)
+	bExact, bLength := httpRouteMatchPathPrecedence(b)
+	if aExact != bExact {
+		return aExact
+	}
+	if aLength != bLength {
+		return aLength > bLength
+	}
+	return a.Method != nil && b.Method == nil
+}
+
+// traditionalRouterPrecedes returns true if the traditional router prefers a over b regardless of regex_priority.
+func traditionalRouterPrecedes(a, b gatewayapi.HTTPRouteMatch) bool {
+	fieldsCount := func(m gatewayapi.HTTPRouteMatch) int {
+		count := 0
+		if m.Path != nil {
+			count++
+		}
+		if m.Method != nil {
+			count++
+		}
+		if len(m.Headers) > 0 {
+			count++
+		}
+		return count
+	}
+	if fieldsCount(a) != fieldsCount(b) {
+		return fieldsCount(a) > fieldsCount(b)
+	}
+	return len(uniqueHeaderMatches(a.Headers)) > len(uniqueHeaderMatches(b.Headers))
+}
+
+// httpRouteMatchesOverlap returns false if there can't be a request matching both a and b.
+func httpRouteMatchesOverlap(a, b gatewayapi.HTTPRouteMatch) bool {
+	if a.Method != nil && b.Method != nil && *a.Method != *b.Method {
+		return false
+	}
+
+	bHeaders := uniqueHeaderMatches(b.Headers)
+	for name, aHeader := range uniqueHeaderMatches(a.Headers) {
+		bHeader, ok := bHeaders[name]
+		if ok && isExactHeaderMatch(aHeader) && isExactHeaderMatch(bHeader) && aHeader.Value != bHeader.Value {
+			return false
+		}
+	}
+
+	return httpRouteMatchPathsOverlap(a.Path, b.Path)
+}
+
+func httpRouteMatchPathsOverlap(a, b *gatewayapi.HTTPPathMatch) bool {
+	pathOrRoot := func(p *gatewayapi.HTTPPathMatch) (gatewayapi.PathMatchType, string) {
+		if p == nil || p.Value == nil {
+			return gatewayapi.PathMatchPathPrefix, "/"
+		}
+		if p.Type == nil {
+			return gatewayapi.PathMatchPathPrefix, *p.Value
+		}
+		return *p.Type, *p.Value
+	}
+	aType, aValue := pathOrRoot(a)
+	bType, bValue := pathOrRoot(b)
+
+	switch {
+	case aType == gatewayapi.PathMatchRegularExpression || bType == gatewayapi.PathMatchRegularExpression:
+		// Regular expressions are not analyzed, assume they may overlap.
+		return true
+	case aType == gatewayapi.PathMatchExact && bType == gatewayapi.PathMatchExact:
+		return aValue == bValue
+	case aType == gatewayapi.PathMatchExact:
+		return pathHasPrefix(aValue, bValue)
+	case bType == gatewayapi.PathMatchExact:
+		return pathHasPrefix(bValue, aValue)
+	default:
+		return pathHasPrefix(aValue, bValue) || pathHasPrefix(bValue, aValue)
+	}
+}
+
+// pathHasPrefix returns true if the path matches the prefix element-wise, as Gateway API PathPrefix matches do.
+func pathHasPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// uniqueHeaderMatches returns header matches by their lowercase names. Only the first match for a header
+// name is considered, as required by the Gateway API.
+func uniqueHeaderMatches(headers []gatewayapi.HTTPHeaderMatch) map[string]gatewayapi.HTTPHeaderMatch {
+	unique := make(map[string]gatewayapi.HTTPHeaderMatch, len(headers))
+	for _, header := range headers {
+		name := strings.ToLower(string(header.Name))
+		if _, ok := unique[name]; !ok {
+			unique[name] = header
+		}
+	}
+	return unique
+}
+
+func isExactHeaderMatch(header gatewayapi.HTTPHeaderMatch) bool {
+	return header.Type == nil || *header.Type == gatewayapi.HeaderMatchExact
+}
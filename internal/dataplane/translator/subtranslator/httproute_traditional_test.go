@@ -0,0 +1,170 @@
+package subtranslator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/builder"
+)
+
+func TestTraditionalHTTPRouteRegexPriority(t *testing.T) {
+	testCases := []struct {
+		name     string
+		higher   []gatewayapi.HTTPRouteMatch
+		lower    []gatewayapi.HTTPRouteMatch
+		ordinals [2]int
+	}{
+		{
+			name:   "exact path takes precedence over longer prefix",
+			higher: builder.NewHTTPRouteMatch().WithPathExact("/foo").ToSlice(),
+			lower:  builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar/baz").ToSlice(),
+		},
+		{
+			name:   "longer prefix takes precedence over shorter prefix",
+			higher: builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar").ToSlice(),
+			lower:  builder.NewHTTPRouteMatch().WithPathPrefix("/foo").ToSlice(),
+		},
+		{
+			name:   "method takes precedence when paths are equal",
+			higher: builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithMethod(gatewayapi.HTTPMethodGet).ToSlice(),
+			lower:  builder.NewHTTPRouteMatch().WithPathPrefix("/foo").ToSlice(),
+		},
+		{
+			name:   "path takes precedence over method",
+			higher: builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar").ToSlice(),
+			lower:  builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithMethod(gatewayapi.HTTPMethodGet).ToSlice(),
+		},
+		{
+			name:     "earlier rule takes precedence when matches are equal",
+			higher:   builder.NewHTTPRouteMatch().WithPathPrefix("/foo").ToSlice(),
+			lower:    builder.NewHTTPRouteMatch().WithPathPrefix("/foo").ToSlice(),
+			ordinals: [2]int{3, 4},
+		},
+		{
+			name: "the most specific path of the matches is used",
+			higher: []gatewayapi.HTTPRouteMatch{
+				builder.NewHTTPRouteMatch().WithPathPrefix("/").Build(),
+				builder.NewHTTPRouteMatch().WithPathExact("/foo").Build(),
+			},
+			lower:    builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar").ToSlice(),
+			ordinals: [2]int{1, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			higher := TraditionalHTTPRouteRegexPriority(tc.higher, tc.ordinals[0])
+			lower := TraditionalHTTPRouteRegexPriority(tc.lower, tc.ordinals[1])
+			require.Greater(t, higher, lower)
+			require.Less(t, higher, 1<<31, "regex_priority must fit into a 32-bit signed integer")
+		})
+	}
+}
+
+func TestValidateHTTPRouteForTraditionalRouter(t *testing.T) {
+	httpRouteWithRules := func(rules ...gatewayapi.HTTPRouteRule) *gatewayapi.HTTPRoute {
+		return &gatewayapi.HTTPRoute{Spec: gatewayapi.HTTPRouteSpec{Rules: rules}}
+	}
+	ruleWithMatches := func(matches ...*builder.HTTPRouteMatchBuilder) gatewayapi.HTTPRouteRule {
+		rule := gatewayapi.HTTPRouteRule{}
+		for _, m := range matches {
+			rule.Matches = append(rule.Matches, m.Build())
+		}
+		return rule
+	}
+
+	testCases := []struct {
+		name        string
+		httpRoute   *gatewayapi.HTTPRoute
+		expectedErr error
+	}{
+		{
+			name: "path, method and header matches",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(
+					builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithMethod(gatewayapi.HTTPMethodGet),
+					builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithMethod(gatewayapi.HTTPMethodGet).WithHeaderRegex("version", "v[12]"),
+				),
+				ruleWithMatches(
+					builder.NewHTTPRouteMatch().WithPathExact("/bar").WithHeader("version", "one").WithHeader("color", "orange"),
+				),
+			),
+		},
+		{
+			name: "query param matches are not supported",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo")),
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithQueryParam("animal", "whale")),
+			),
+			expectedErr: ErrRouteValidationQueryParamMatchesUnsupported,
+		},
+		{
+			name: "longer path overlapping with a match with more header matches is not supported",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar")),
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithHeader("version", "one")),
+			),
+			expectedErr: ErrRouteValidationMatchPrecedenceUnsupported,
+		},
+		{
+			name: "method overlapping with a match with more header matches is not supported",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(
+					builder.NewHTTPRouteMatch().WithPathPrefix("/").WithMethod(gatewayapi.HTTPMethodDelete),
+					builder.NewHTTPRouteMatch().WithPathPrefix("/").WithHeader("version", "one").WithHeader("color", "orange"),
+				),
+			),
+			expectedErr: ErrRouteValidationMatchPrecedenceUnsupported,
+		},
+		{
+			name: "longer path not overlapping with a match with more header matches",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar")),
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo/baz").WithHeader("version", "one")),
+			),
+		},
+		{
+			name: "path prefixes are matched element-wise",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foobar")),
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithHeader("version", "one")),
+			),
+		},
+		{
+			name: "different methods don't overlap",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/").WithMethod(gatewayapi.HTTPMethodDelete)),
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/").WithMethod(gatewayapi.HTTPMethodGet).WithHeader("version", "one")),
+			),
+		},
+		{
+			name: "different exact header values don't overlap",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo/bar").WithHeader("version", "two")),
+				ruleWithMatches(builder.NewHTTPRouteMatch().WithPathPrefix("/foo").WithHeader("version", "one").WithHeader("color", "orange")),
+			),
+		},
+		{
+			name: "duplicated header matches are counted once",
+			httpRoute: httpRouteWithRules(
+				ruleWithMatches(
+					builder.NewHTTPRouteMatch().WithPathPrefix("/").WithHeader("version", "one").WithMethod(gatewayapi.HTTPMethodGet),
+					builder.NewHTTPRouteMatch().WithPathPrefix("/").WithHeader("version", "one").WithHeader("Version", "two").WithMethod(gatewayapi.HTTPMethodGet),
+				),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateHTTPRouteForTraditionalRouter(tc.httpRoute)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -57,11 +57,23 @@ func TranslateIngresses(
 	storer store.Storer,
 ) map[string]kongstate.Service {
 	index := newIngressTranslationIndex(flags, failuresCollector, storer)
+	// Canary Ingresses are indexed first, so that their paths can be merged into the same paths of primary Ingresses.
 	for _, ingress := range ingresses {
+		if annotations.ExtractCanary(ingress.Annotations) {
+			index.AddCanary(ingress)
+		}
+	}
+	for _, ingress := range ingresses {
+		if annotations.ExtractCanary(ingress.Annotations) {
+			continue
+		}
 		prependRegexPrefix := MaybePrependRegexPrefixForIngressV1Fn(ingress, icp.EnableLegacyRegexDetection)
 		index.Add(ingress, prependRegexPrefix)
 		translatedObjectsCollector.Add(ingress)
 	}
+	for _, canary := range index.ReportCanaries() {
+		translatedObjectsCollector.Add(canary)
+	}
 
 	return index.Translate()
 }
@@ -113,6 +125,8 @@ func defaultServiceTimeoutInKongFormat() *int {
 // for each unique combination.
 type ingressTranslationIndex struct {
 	cache             map[string]*ingressTranslationMeta
+	canaries          map[ingressCanaryKey]*ingressCanary
+	canariesOrder     []*ingressCanary
 	featureFlags      TranslateIngressFeatureFlags
 	failuresCollector FailuresCollector
 	storer            store.Storer
@@ -121,6 +135,7 @@ type ingressTranslationIndex struct {
 func newIngressTranslationIndex(flags TranslateIngressFeatureFlags, failuresCollector FailuresCollector, storer store.Storer) *ingressTranslationIndex {
 	return &ingressTranslationIndex{
 		cache:             make(map[string]*ingressTranslationMeta),
+		canaries:          make(map[ingressCanaryKey]*ingressCanary),
 		featureFlags:      flags,
 		failuresCollector: failuresCollector,
 		storer:            storer,
@@ -136,16 +151,7 @@ func (i *ingressTranslationIndex) Add(ingress *netv1.Ingress, addRegexPrefix add
 		}
 
 		for _, httpIngressPath := range ingressRule.HTTP.Paths {
-			httpIngressPath := httpIngressPath
-			httpIngressPath.Path = flattenMultipleSlashes(httpIngressPath.Path)
-
-			if httpIngressPath.Path == "" {
-				httpIngressPath.Path = "/"
-			}
-
-			if httpIngressPath.PathType == nil {
-				httpIngressPath.PathType = &defaultHTTPIngressPathType
-			}
+			httpIngressPath := normalizeIngressPath(httpIngressPath)
 
 			backend, err := i.getIngressPathBackend(ingress.Namespace, httpIngressPath)
 			if err != nil {
@@ -153,17 +159,31 @@ func (i *ingressTranslationIndex) Add(ingress *netv1.Ingress, addRegexPrefix add
 				continue
 			}
 
-			kongRouteName := backend.intoKongRouteName(k8stypes.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}, ingressRule.Host)
+			ingressNN := k8stypes.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+			kongRouteName := backend.intoKongRouteName(ingressNN, ingressRule.Host)
+			// '_' is not allowed in host, so we use '_' to replace '*' since '*' is not allowed in Kong.
+			routeName := backend.intoKongRouteName(ingressNN, strings.ReplaceAll(ingressRule.Host, "*", "_"))
+
+			// Paths having a canary are translated into separate Kong Routes.
+			canary := i.lookupCanary(ingress, ingressRule.Host, httpIngressPath, backend)
+			if canary != nil {
+				kongRouteName = canary.intoKongRouteName(kongRouteName)
+				routeName = canary.intoKongRouteName(routeName)
+			}
+
 			meta, ok := i.cache[kongRouteName]
 			if !ok {
 				meta = &ingressTranslationMeta{
-					ingressNamespace: ingress.Namespace,
-					ingressName:      ingress.Name,
-					ingressUID:       string(ingress.UID),
-					ingressHost:      ingressRule.Host,
-					ingressTags:      util.GenerateTagsForObject(ingress),
-					backend:          backend,
-					addRegexPrefixFn: addRegexPrefix,
+					ingressNamespace:   ingress.Namespace,
+					ingressName:        ingress.Name,
+					ingressUID:         string(ingress.UID),
+					ingressHost:        ingressRule.Host,
+					ingressTags:        util.GenerateTagsForObject(ingress),
+					ingressAnnotations: ingress.GetAnnotations(),
+					routeName:          routeName,
+					backend:            backend,
+					canary:             canary,
+					addRegexPrefixFn:   addRegexPrefix,
 				}
 			}
 
@@ -218,29 +238,43 @@ func IsKongServiceFacade(resource *corev1.TypedLocalObjectReference) bool {
 func (i *ingressTranslationIndex) Translate() map[string]kongstate.Service {
 	kongStateServiceCache := make(map[string]kongstate.Service)
 	for _, meta := range i.cache {
-		kongServiceName := meta.generateKongServiceName()
-		kongStateService, ok := kongStateServiceCache[kongServiceName]
-		if !ok {
-			var err error
-			kongStateService, err = meta.translateIntoKongStateService(kongServiceName, meta.backend.port)
-			if err != nil {
-				i.failuresCollector.PushResourceFailure(fmt.Sprintf("failed to translate Ingress into Kong Service: %s", err), meta.parentIngress)
-				continue
-			}
+		if meta.canary != nil {
+			i.translateCanary(kongStateServiceCache, meta)
+			continue
 		}
+		i.translateRoute(kongStateServiceCache, meta, meta.generateKongServiceName(), meta.translateIntoKongStateService)
+	}
 
-		if i.featureFlags.ExpressionRoutes {
-			route := meta.translateIntoKongExpressionRoute()
-			kongStateService.Routes = append(kongStateService.Routes, *route)
-		} else {
-			route := meta.translateIntoKongRoute()
-			kongStateService.Routes = append(kongStateService.Routes, *route)
+	return kongStateServiceCache
+}
+
+// translateRoute translates the ingressTranslationMeta into a Kong Route and adds it to the Kong Service
+// of the given name in the cache. The Kong Service is translated using translateService if it's not in the cache yet.
+func (i *ingressTranslationIndex) translateRoute(
+	kongStateServiceCache map[string]kongstate.Service,
+	meta *ingressTranslationMeta,
+	kongServiceName string,
+	translateService func(kongServiceName string) (kongstate.Service, error),
+) {
+	kongStateService, ok := kongStateServiceCache[kongServiceName]
+	if !ok {
+		var err error
+		kongStateService, err = translateService(kongServiceName)
+		if err != nil {
+			i.failuresCollector.PushResourceFailure(fmt.Sprintf("failed to translate Ingress into Kong Service: %s", err), meta.parentIngress)
+			return
 		}
+	}
 
-		kongStateServiceCache[kongServiceName] = kongStateService
+	if i.featureFlags.ExpressionRoutes {
+		route := meta.translateIntoKongExpressionRoute()
+		kongStateService.Routes = append(kongStateService.Routes, *route)
+	} else {
+		route := meta.translateIntoKongRoute()
+		kongStateService.Routes = append(kongStateService.Routes, *route)
 	}
 
-	return kongStateServiceCache
+	kongStateServiceCache[kongServiceName] = kongStateService
 }
 
 // -----------------------------------------------------------------------------
@@ -248,15 +282,18 @@ func (i *ingressTranslationIndex) Translate() map[string]kongstate.Service {
 // -----------------------------------------------------------------------------
 
 type ingressTranslationMeta struct {
-	parentIngress    client.Object
-	ingressNamespace string
-	ingressName      string
-	ingressUID       string
-	ingressHost      string
-	ingressTags      []*string
-	backend          ingressTranslationMetaBackend
-	paths            []netv1.HTTPIngressPath
-	addRegexPrefixFn addRegexPrefixFn
+	parentIngress      client.Object
+	ingressNamespace   string
+	ingressName        string
+	ingressUID         string
+	ingressHost        string
+	ingressTags        []*string
+	ingressAnnotations map[string]string
+	routeName          string
+	backend            ingressTranslationMetaBackend
+	canary             *ingressCanary
+	paths              []netv1.HTTPIngressPath
+	addRegexPrefixFn   addRegexPrefixFn
 }
 
 type ingressPathBackendType string
@@ -325,10 +362,8 @@ func (b ingressTranslationMetaBackend) isServiceFacade() bool {
 	return b.backendType == ingressPathBackendTypeKongServiceFacade
 }
 
-func (m *ingressTranslationMeta) translateIntoKongStateService(
-	kongServiceName string,
-	portDef kongstate.PortDef,
-) (kongstate.Service, error) {
+func (m *ingressTranslationMeta) translateIntoKongStateService(kongServiceName string) (kongstate.Service, error) {
+	portDef := m.backend.port
 	if m.backend.isServiceFacade() {
 		serviceBackend, err := kongstate.NewServiceBackendForServiceFacade(
 			k8stypes.NamespacedName{
@@ -407,21 +442,14 @@ func (m *ingressTranslationMeta) generateKongServiceName() string {
 }
 
 func (m *ingressTranslationMeta) translateIntoKongRoute() *kongstate.Route {
-	ingressHost := m.ingressHost
-	if strings.Contains(ingressHost, "*") {
-		// '_' is not allowed in host, so we use '_' to replace '*' since '*' is not allowed in Kong.
-		ingressHost = strings.ReplaceAll(ingressHost, "*", "_")
-	}
-	routeName := m.backend.intoKongRouteName(k8stypes.NamespacedName{Namespace: m.ingressNamespace, Name: m.ingressName}, ingressHost)
-
 	route := &kongstate.Route{
 		Ingress: util.K8sObjectInfo{
 			Namespace:   m.parentIngress.GetNamespace(),
 			Name:        m.parentIngress.GetName(),
-			Annotations: m.parentIngress.GetAnnotations(),
+			Annotations: m.ingressAnnotations,
 		},
 		Route: kong.Route{
-			Name:              kong.String(routeName),
+			Name:              kong.String(m.routeName),
 			StripPath:         kong.Bool(false),
 			PreserveHost:      kong.Bool(true),
 			Protocols:         kong.StringSlice("http", "https"),
@@ -451,6 +479,18 @@ func (m *ingressTranslationMeta) translateIntoKongRoute() *kongstate.Route {
 // Ingress Translation - Private - Helper Functions
 // -----------------------------------------------------------------------------
 
+// normalizeIngressPath returns the Ingress path with multiple slashes flattened and defaults applied.
+func normalizeIngressPath(httpIngressPath netv1.HTTPIngressPath) netv1.HTTPIngressPath {
+	httpIngressPath.Path = flattenMultipleSlashes(httpIngressPath.Path)
+	if httpIngressPath.Path == "" {
+		httpIngressPath.Path = "/"
+	}
+	if httpIngressPath.PathType == nil {
+		httpIngressPath.PathType = &defaultHTTPIngressPathType
+	}
+	return httpIngressPath
+}
+
 // TODO this is exported because most of the translator translate functions are still in the translator package. if/when we
 // refactor to move them here, this should become private.
 
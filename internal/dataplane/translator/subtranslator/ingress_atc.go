@@ -7,7 +7,6 @@ import (
 
 	"github.com/kong/go-kong/kong"
 	netv1 "k8s.io/api/networking/v1"
-	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
@@ -29,23 +28,14 @@ var (
 const IngressDefaultBackendPriority RoutePriorityType = 0
 
 func (m *ingressTranslationMeta) translateIntoKongExpressionRoute() *kongstate.Route {
-	// '_' is not allowed in a host, so use '_' to replace a possible occurrence of  '*' since '*' is not allowed in Kong.
-	ingressHost := strings.ReplaceAll(m.ingressHost, "*", "_")
-	routeName := m.backend.intoKongRouteName(k8stypes.NamespacedName{
-		Namespace: m.parentIngress.GetNamespace(),
-		Name:      m.parentIngress.GetName(),
-	},
-		ingressHost,
-	)
-
 	route := &kongstate.Route{
 		Ingress: util.K8sObjectInfo{
 			Namespace:   m.parentIngress.GetNamespace(),
 			Name:        m.parentIngress.GetName(),
-			Annotations: m.parentIngress.GetAnnotations(),
+			Annotations: m.ingressAnnotations,
 		},
 		Route: kong.Route{
-			Name:              kong.String(routeName),
+			Name:              kong.String(m.routeName),
 			StripPath:         kong.Bool(false),
 			PreserveHost:      kong.Bool(true),
 			RequestBuffering:  kong.Bool(true),
@@ -55,7 +45,7 @@ func (m *ingressTranslationMeta) translateIntoKongExpressionRoute() *kongstate.R
 		ExpressionRoutes: true,
 	}
 
-	ingressAnnotations := m.parentIngress.GetAnnotations()
+	ingressAnnotations := m.ingressAnnotations
 
 	routeMatcher := atc.And()
 	// translate hosts.
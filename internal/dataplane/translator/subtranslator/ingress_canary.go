@@ -0,0 +1,308 @@
+package subtranslator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+
+	"github.com/samber/mo"
+	netv1 "k8s.io/api/networking/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// -----------------------------------------------------------------------------
+// Ingress Translation - Private - Canaries
+// -----------------------------------------------------------------------------
+
+// An Ingress annotated with konghq.com/canary: "true" is not translated on its own. Each of its paths is merged into
+// the same path (same namespace, host, path type and path) of primary Ingresses, which get a separate Kong Route
+// for it. Depending on the canary annotations:
+//
+//   - konghq.com/canary-weight: the primary Kong Route points to a Kong Service splitting requests between
+//     the primary and the canary backends by the given percentage.
+//   - konghq.com/canary-by-header: an additional Kong Route matching the header with the value "always" (or the
+//     konghq.com/canary-by-header-value value) points to the canary backend. When the weight is set and no custom
+//     value is used, another Kong Route matching the header with the value "never" points to the primary backend.
+//   - konghq.com/canary-by-cookie: the same as konghq.com/canary-by-header, but matching the cookie's value.
+//
+// Routes matching the header or the cookie take precedence over the primary one, as they match on more fields.
+// The order of the header and the cookie Routes is not defined when a request matches both of them.
+
+const (
+	// canaryAlways is the header or cookie value routing requests to the canary backend.
+	canaryAlways = "always"
+	// canaryNever is the header or cookie value routing requests to the primary backend only.
+	canaryNever = "never"
+
+	canaryMaxWeight = 100
+)
+
+// ingressCanaryKey identifies Ingress paths that canary paths are merged into.
+type ingressCanaryKey struct {
+	namespace string
+	host      string
+	pathType  netv1.PathType
+	path      string
+}
+
+func newIngressCanaryKey(namespace, host string, httpIngressPath netv1.HTTPIngressPath) ingressCanaryKey {
+	return ingressCanaryKey{
+		namespace: namespace,
+		host:      host,
+		pathType:  *httpIngressPath.PathType,
+		path:      httpIngressPath.Path,
+	}
+}
+
+// ingressCanaryConfig is the canary configuration of an Ingress, parsed from its annotations.
+type ingressCanaryConfig struct {
+	// weight is the percentage of requests routed to the canary backend.
+	weight        mo.Option[int]
+	byHeader      string
+	byHeaderValue string
+	byCookie      string
+}
+
+func parseIngressCanaryConfig(anns map[string]string) (ingressCanaryConfig, error) {
+	config := ingressCanaryConfig{
+		byHeader:      annotations.ExtractCanaryByHeader(anns),
+		byHeaderValue: annotations.ExtractCanaryByHeaderValue(anns),
+		byCookie:      annotations.ExtractCanaryByCookie(anns),
+	}
+	if value, ok := annotations.ExtractCanaryWeight(anns); ok {
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 || weight > canaryMaxWeight {
+			return ingressCanaryConfig{}, fmt.Errorf("invalid %s annotation value %q: must be an integer between 0 and %d",
+				annotations.AnnotationPrefix+annotations.CanaryWeightKey, value, canaryMaxWeight,
+			)
+		}
+		if weight > 0 {
+			config.weight = mo.Some(weight)
+		}
+	}
+	if config.byHeaderValue != "" && config.byHeader == "" {
+		return ingressCanaryConfig{}, fmt.Errorf("%s annotation requires %s annotation to be set",
+			annotations.AnnotationPrefix+annotations.CanaryByHeaderValueKey,
+			annotations.AnnotationPrefix+annotations.CanaryByHeaderKey,
+		)
+	}
+	if config.weight.IsAbsent() && config.byHeader == "" && config.byCookie == "" {
+		return ingressCanaryConfig{}, errors.New("canary Ingress must route some requests to its backends using a non-zero " +
+			"weight, a header or a cookie annotation")
+	}
+	return config, nil
+}
+
+// ingressCanary is a path of a canary Ingress.
+type ingressCanary struct {
+	ingress *netv1.Ingress
+	backend ingressTranslationMetaBackend
+	config  ingressCanaryConfig
+	path    string
+	// merged is true when the canary path has been merged into a path of a primary Ingress.
+	merged bool
+}
+
+// intoKongRouteName returns the name of a Kong Route of the primary Ingress path the canary was merged into.
+func (c *ingressCanary) intoKongRouteName(primaryRouteName string) string {
+	return fmt.Sprintf("%s.canary.%s", primaryRouteName, c.ingress.Name)
+}
+
+// AddCanary indexes paths of the canary Ingress, so that they can be merged into primary Ingresses' paths
+// added afterwards. When multiple canary Ingresses use the same path, the first one added is used.
+func (i *ingressTranslationIndex) AddCanary(ingress *netv1.Ingress) {
+	config, err := parseIngressCanaryConfig(ingress.Annotations)
+	if err != nil {
+		i.failuresCollector.PushResourceFailure(fmt.Sprintf("invalid canary Ingress: %s", err), ingress)
+		return
+	}
+
+	for _, ingressRule := range ingress.Spec.Rules {
+		if ingressRule.HTTP == nil {
+			continue
+		}
+		for _, httpIngressPath := range ingressRule.HTTP.Paths {
+			httpIngressPath := normalizeIngressPath(httpIngressPath)
+
+			backend, err := i.getIngressPathBackend(ingress.Namespace, httpIngressPath)
+			if err != nil {
+				i.failuresCollector.PushResourceFailure(fmt.Sprintf("failed to get backend for ingress path %q: %s", httpIngressPath.Path, err), ingress)
+				continue
+			}
+			if backend.isServiceFacade() {
+				i.failuresCollector.PushResourceFailure(
+					fmt.Sprintf("canary ingress path %q: KongServiceFacade backends are not supported for canaries", httpIngressPath.Path), ingress,
+				)
+				continue
+			}
+
+			key := newIngressCanaryKey(ingress.Namespace, ingressRule.Host, httpIngressPath)
+			if existing, ok := i.canaries[key]; ok {
+				i.failuresCollector.PushResourceFailure(
+					fmt.Sprintf("canary ingress path %q: Ingress %s is already a canary for the path", httpIngressPath.Path, existing.ingress.Name), ingress,
+				)
+				continue
+			}
+			canary := &ingressCanary{
+				ingress: ingress,
+				backend: backend,
+				config:  config,
+				path:    httpIngressPath.Path,
+			}
+			i.canaries[key] = canary
+			i.canariesOrder = append(i.canariesOrder, canary)
+		}
+	}
+}
+
+// lookupCanary returns the canary for the path of the primary Ingress if there's one that can be merged into it.
+func (i *ingressTranslationIndex) lookupCanary(
+	ingress *netv1.Ingress,
+	host string,
+	httpIngressPath netv1.HTTPIngressPath,
+	backend ingressTranslationMetaBackend,
+) *ingressCanary {
+	canary, ok := i.canaries[newIngressCanaryKey(ingress.Namespace, host, httpIngressPath)]
+	if !ok {
+		return nil
+	}
+	if backend.isServiceFacade() {
+		i.failuresCollector.PushResourceFailure(
+			fmt.Sprintf("canary ingress path %q: Ingress %s uses a KongServiceFacade backend for the path, which is not supported for canaries",
+				canary.path, ingress.Name,
+			), canary.ingress,
+		)
+		return nil
+	}
+	canary.merged = true
+	return canary
+}
+
+// ReportCanaries returns canary Ingresses with at least one path merged into a primary Ingress. Paths that haven't
+// been merged are reported as failures.
+func (i *ingressTranslationIndex) ReportCanaries() []*netv1.Ingress {
+	var merged []*netv1.Ingress
+	seen := make(map[k8stypes.NamespacedName]struct{})
+	for _, canary := range i.canariesOrder {
+		if !canary.merged {
+			i.failuresCollector.PushResourceFailure(
+				fmt.Sprintf("canary ingress path %q: no primary Ingress with the same host and path found", canary.path), canary.ingress,
+			)
+			continue
+		}
+		nn := k8stypes.NamespacedName{Namespace: canary.ingress.Namespace, Name: canary.ingress.Name}
+		if _, ok := seen[nn]; ok {
+			continue
+		}
+		seen[nn] = struct{}{}
+		merged = append(merged, canary.ingress)
+	}
+	return merged
+}
+
+// translateCanary translates the primary Ingress paths having a canary into Kong Routes.
+func (i *ingressTranslationIndex) translateCanary(kongStateServiceCache map[string]kongstate.Service, meta *ingressTranslationMeta) {
+	config := meta.canary.config
+	canaryMeta := meta.canaryBackendMeta()
+
+	// Requests not matching the canary header nor cookie are split by the weight, if it's set.
+	if config.weight.IsPresent() {
+		i.translateRoute(kongStateServiceCache, meta, meta.generateWeightedKongServiceName(), meta.translateIntoWeightedKongStateService)
+	} else {
+		i.translateRoute(kongStateServiceCache, meta, meta.generateKongServiceName(), meta.translateIntoKongStateService)
+	}
+
+	if config.byHeader != "" {
+		value := config.byHeaderValue
+		if value == "" {
+			value = canaryAlways
+		}
+		headerMeta := canaryMeta.withHeaderMatch("header", config.byHeader, value)
+		i.translateRoute(kongStateServiceCache, headerMeta, canaryMeta.generateKongServiceName(), canaryMeta.translateIntoKongStateService)
+		if config.byHeaderValue == "" && config.weight.IsPresent() {
+			neverMeta := meta.withHeaderMatch("header-never", config.byHeader, canaryNever)
+			i.translateRoute(kongStateServiceCache, neverMeta, meta.generateKongServiceName(), meta.translateIntoKongStateService)
+		}
+	}
+
+	if config.byCookie != "" {
+		cookieMeta := canaryMeta.withHeaderMatch("cookie", "cookie", cookieHeaderRegex(config.byCookie, canaryAlways))
+		i.translateRoute(kongStateServiceCache, cookieMeta, canaryMeta.generateKongServiceName(), canaryMeta.translateIntoKongStateService)
+		if config.weight.IsPresent() {
+			neverMeta := meta.withHeaderMatch("cookie-never", "cookie", cookieHeaderRegex(config.byCookie, canaryNever))
+			i.translateRoute(kongStateServiceCache, neverMeta, meta.generateKongServiceName(), meta.translateIntoKongStateService)
+		}
+	}
+}
+
+// cookieHeaderRegex returns a header match value matching the Cookie header containing the cookie with the value.
+// The semicolon separating cookies is escaped, so that it's not taken for a separator of header values.
+func cookieHeaderRegex(cookie, value string) string {
+	return fmt.Sprintf(`%s(^|\x3b)\s*%s=%s\s*(\x3b|$)`, headerAnnotationRegexPrefix, regexp.QuoteMeta(cookie), regexp.QuoteMeta(value))
+}
+
+// canaryBackendMeta returns a copy of the ingressTranslationMeta with the canary Ingress as the parent
+// and the canary backend. Kong Routes translated from it are configured with the primary Ingress' annotations.
+func (m *ingressTranslationMeta) canaryBackendMeta() *ingressTranslationMeta {
+	canaryMeta := *m
+	canaryMeta.parentIngress = m.canary.ingress
+	canaryMeta.ingressNamespace = m.canary.ingress.Namespace
+	canaryMeta.ingressName = m.canary.ingress.Name
+	canaryMeta.ingressUID = string(m.canary.ingress.UID)
+	canaryMeta.ingressTags = util.GenerateTagsForObject(m.canary.ingress)
+	canaryMeta.backend = m.canary.backend
+	return &canaryMeta
+}
+
+// withHeaderMatch returns a copy of the ingressTranslationMeta matching also on the header. The header match is
+// added the same way as the konghq.com/headers.* annotations, so that it's translated for both traditional
+// and expression routes.
+func (m *ingressTranslationMeta) withHeaderMatch(routeNameSuffix, header, value string) *ingressTranslationMeta {
+	matchMeta := *m
+	matchMeta.routeName = fmt.Sprintf("%s.%s", m.routeName, routeNameSuffix)
+	matchMeta.ingressAnnotations = maps.Clone(m.ingressAnnotations)
+	if matchMeta.ingressAnnotations == nil {
+		matchMeta.ingressAnnotations = make(map[string]string, 1)
+	}
+	matchMeta.ingressAnnotations[annotations.AnnotationPrefix+annotations.HeadersKey+"."+header] = value
+	return &matchMeta
+}
+
+// generateWeightedKongServiceName returns the name of the Kong Service splitting requests between the primary
+// and the canary backends.
+func (m *ingressTranslationMeta) generateWeightedKongServiceName() string {
+	return fmt.Sprintf("%s.canary.%s", m.generateKongServiceName(), m.canary.ingress.Name)
+}
+
+// translateIntoWeightedKongStateService translates the primary and the canary backends into a Kong Service
+// splitting requests between them by the canary weight.
+func (m *ingressTranslationMeta) translateIntoWeightedKongStateService(kongServiceName string) (kongstate.Service, error) {
+	service, err := m.translateIntoKongStateService(kongServiceName)
+	if err != nil {
+		return kongstate.Service{}, err
+	}
+	canaryBackend, err := kongstate.NewServiceBackendForService(
+		k8stypes.NamespacedName{
+			Namespace: m.canary.ingress.Namespace,
+			Name:      m.canary.backend.name,
+		},
+		m.canary.backend.port,
+	)
+	if err != nil {
+		return kongstate.Service{}, fmt.Errorf("failed to create ServiceBackend for Kubernetes Service %q: %w", m.canary.backend.name, err)
+	}
+
+	weight := m.canary.config.weight.MustGet()
+	service.Backends[0].SetWeight(int32(canaryMaxWeight - weight))
+	canaryBackend.SetWeight(int32(weight))
+	service.Backends = append(service.Backends, canaryBackend)
+	// The Kong Upstream is named after the Kong Service's host, it has to be different from the primary backend's one.
+	service.Host = &kongServiceName
+	return service, nil
+}
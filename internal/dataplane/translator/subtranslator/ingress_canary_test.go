@@ -0,0 +1,300 @@
+package subtranslator
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/builder"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
+)
+
+type objectsCollector struct {
+	objects []client.Object
+}
+
+func (c *objectsCollector) Add(obj client.Object) {
+	c.objects = append(c.objects, obj)
+}
+
+func TestParseIngressCanaryConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		expectedConfig ingressCanaryConfig
+		expectedErr    string
+	}{
+		{
+			name: "weight",
+			annotations: map[string]string{
+				"konghq.com/canary-weight": "30",
+			},
+			expectedConfig: ingressCanaryConfig{weight: mo.Some(30)},
+		},
+		{
+			name: "header with value and cookie",
+			annotations: map[string]string{
+				"konghq.com/canary-by-header":       "x-canary",
+				"konghq.com/canary-by-header-value": "yes",
+				"konghq.com/canary-by-cookie":       "canary",
+			},
+			expectedConfig: ingressCanaryConfig{byHeader: "x-canary", byHeaderValue: "yes", byCookie: "canary"},
+		},
+		{
+			name: "zero weight is ignored",
+			annotations: map[string]string{
+				"konghq.com/canary-weight":    "0",
+				"konghq.com/canary-by-header": "x-canary",
+			},
+			expectedConfig: ingressCanaryConfig{byHeader: "x-canary"},
+		},
+		{
+			name: "weight out of range",
+			annotations: map[string]string{
+				"konghq.com/canary-weight": "101",
+			},
+			expectedErr: `invalid konghq.com/canary-weight annotation value "101": must be an integer between 0 and 100`,
+		},
+		{
+			name: "weight not a number",
+			annotations: map[string]string{
+				"konghq.com/canary-weight": "10%",
+			},
+			expectedErr: `invalid konghq.com/canary-weight annotation value "10%": must be an integer between 0 and 100`,
+		},
+		{
+			name: "header value without header",
+			annotations: map[string]string{
+				"konghq.com/canary-by-header-value": "yes",
+			},
+			expectedErr: "konghq.com/canary-by-header-value annotation requires konghq.com/canary-by-header annotation to be set",
+		},
+		{
+			name:        "no canary routing",
+			annotations: map[string]string{},
+			expectedErr: "canary Ingress must route some requests to its backends using a non-zero weight, a header or a cookie annotation",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := parseIngressCanaryConfig(tc.annotations)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedConfig, config)
+		})
+	}
+}
+
+func TestTranslateIngress_Canary(t *testing.T) {
+	ingressRule := func(host, path, serviceName string) netv1.IngressRule {
+		return netv1.IngressRule{
+			Host: host,
+			IngressRuleValue: netv1.IngressRuleValue{
+				HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Path:     path,
+						PathType: &pathTypePrefix,
+						Backend: netv1.IngressBackend{
+							Service: &netv1.IngressServiceBackend{
+								Name: serviceName,
+								Port: netv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+	primary := builder.NewIngress("primary", "kong").
+		WithNamespace("default").
+		WithRules(ingressRule("example.com", "/api", "primary-svc")).
+		Build()
+
+	testCases := []struct {
+		name                 string
+		ingresses            []*netv1.Ingress
+		expressionRoutes     bool
+		expectedRoutes       map[string][]string
+		expectedWeights      map[string][]int
+		expectedHeaders      map[string]map[string]string
+		expectedTranslated   []string
+		expectedFailures     []string
+		expectedExpressionIn map[string]string
+	}{
+		{
+			name: "weighted canary",
+			ingresses: []*netv1.Ingress{
+				primary,
+				builder.NewIngress("canary", "kong").
+					WithNamespace("default").
+					WithAnnotations(map[string]string{
+						"konghq.com/canary":        "true",
+						"konghq.com/canary-weight": "20",
+					}).
+					WithRules(ingressRule("example.com", "/api", "canary-svc")).
+					Build(),
+			},
+			expectedRoutes: map[string][]string{
+				"default.primary-svc.80.canary.canary": {"default.primary.primary-svc.example.com.80.canary.canary"},
+			},
+			expectedWeights: map[string][]int{
+				"default.primary-svc.80.canary.canary": {80, 20},
+			},
+			expectedTranslated: []string{"primary", "canary"},
+		},
+		{
+			name: "canary by header with a custom value",
+			ingresses: []*netv1.Ingress{
+				primary,
+				builder.NewIngress("canary", "kong").
+					WithNamespace("default").
+					WithAnnotations(map[string]string{
+						"konghq.com/canary":                 "true",
+						"konghq.com/canary-by-header":       "x-canary",
+						"konghq.com/canary-by-header-value": "v2",
+					}).
+					WithRules(ingressRule("example.com", "/api", "canary-svc")).
+					Build(),
+			},
+			expectedRoutes: map[string][]string{
+				"default.primary-svc.80": {"default.primary.primary-svc.example.com.80.canary.canary"},
+				"default.canary-svc.80":  {"default.primary.primary-svc.example.com.80.canary.canary.header"},
+			},
+			expectedHeaders: map[string]map[string]string{
+				"default.primary.primary-svc.example.com.80.canary.canary.header": {"x-canary": "v2"},
+			},
+			expectedTranslated: []string{"primary", "canary"},
+		},
+		{
+			name:             "canary by cookie with expression routes",
+			expressionRoutes: true,
+			ingresses: []*netv1.Ingress{
+				primary,
+				builder.NewIngress("canary", "kong").
+					WithNamespace("default").
+					WithAnnotations(map[string]string{
+						"konghq.com/canary":           "true",
+						"konghq.com/canary-by-cookie": "canary",
+					}).
+					WithRules(ingressRule("example.com", "/api", "canary-svc")).
+					Build(),
+			},
+			expectedRoutes: map[string][]string{
+				"default.primary-svc.80": {"default.primary.primary-svc.example.com.80.canary.canary"},
+				"default.canary-svc.80":  {"default.primary.primary-svc.example.com.80.canary.canary.cookie"},
+			},
+			expectedExpressionIn: map[string]string{
+				"default.primary.primary-svc.example.com.80.canary.canary.cookie": `http.headers.cookie ~ "(^|\\x3b)\\s*canary=always\\s*(\\x3b|$)"`,
+			},
+			expectedTranslated: []string{"primary", "canary"},
+		},
+		{
+			name: "canary without a primary Ingress path",
+			ingresses: []*netv1.Ingress{
+				primary,
+				builder.NewIngress("canary", "kong").
+					WithNamespace("default").
+					WithAnnotations(map[string]string{
+						"konghq.com/canary":        "true",
+						"konghq.com/canary-weight": "20",
+					}).
+					WithRules(ingressRule("example.com", "/other", "canary-svc")).
+					Build(),
+			},
+			expectedRoutes: map[string][]string{
+				"default.primary-svc.80": {"default.primary.primary-svc.example.com.80"},
+			},
+			expectedTranslated: []string{"primary"},
+			expectedFailures:   []string{`canary ingress path "/other": no primary Ingress with the same host and path found`},
+		},
+		{
+			name: "second canary for the same path",
+			ingresses: []*netv1.Ingress{
+				primary,
+				builder.NewIngress("canary", "kong").
+					WithNamespace("default").
+					WithAnnotations(map[string]string{
+						"konghq.com/canary":        "true",
+						"konghq.com/canary-weight": "20",
+					}).
+					WithRules(ingressRule("example.com", "/api", "canary-svc")).
+					Build(),
+				builder.NewIngress("canary-2", "kong").
+					WithNamespace("default").
+					WithAnnotations(map[string]string{
+						"konghq.com/canary":        "true",
+						"konghq.com/canary-weight": "50",
+					}).
+					WithRules(ingressRule("example.com", "/api", "canary-svc-2")).
+					Build(),
+			},
+			expectedRoutes: map[string][]string{
+				"default.primary-svc.80.canary.canary": {"default.primary.primary-svc.example.com.80.canary.canary"},
+			},
+			expectedWeights: map[string][]int{
+				"default.primary-svc.80.canary.canary": {80, 20},
+			},
+			expectedTranslated: []string{"primary", "canary"},
+			expectedFailures:   []string{`canary ingress path "/api": Ingress canary is already a canary for the path`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			failuresCollector := failures.NewResourceFailuresCollector(logr.Discard())
+			translatedObjects := &objectsCollector{}
+			services := TranslateIngresses(
+				tc.ingresses,
+				kongv1alpha1.IngressClassParametersSpec{},
+				TranslateIngressFeatureFlags{ExpressionRoutes: tc.expressionRoutes},
+				translatedObjects,
+				failuresCollector,
+				lo.Must(store.NewFakeStore(store.FakeObjects{})),
+			)
+
+			routes := make(map[string][]string)
+			for name, service := range services {
+				for _, route := range service.Routes {
+					routes[name] = append(routes[name], *route.Name)
+					if expectedHeaders, ok := tc.expectedHeaders[*route.Name]; ok {
+						for header, value := range expectedHeaders {
+							require.Equal(t, value, route.Ingress.Annotations["konghq.com/headers."+header])
+						}
+					}
+					if expression, ok := tc.expectedExpressionIn[*route.Name]; ok {
+						require.Contains(t, *route.Expression, expression)
+					}
+				}
+			}
+			require.Equal(t, tc.expectedRoutes, routes)
+
+			for name, expectedWeights := range tc.expectedWeights {
+				weights := lo.Map(services[name].Backends, func(b kongstate.ServiceBackend, _ int) int {
+					return b.Weight().MustGet()
+				})
+				require.Equal(t, expectedWeights, weights)
+			}
+
+			require.Equal(t, tc.expectedTranslated, lo.Map(translatedObjects.objects, func(o client.Object, _ int) string {
+				return o.GetName()
+			}))
+
+			collectedFailures := lo.Map(failuresCollector.PopResourceFailures(), func(f failures.ResourceFailure, _ int) string {
+				return f.Message()
+			})
+			require.ElementsMatch(t, tc.expectedFailures, collectedFailures)
+		})
+	}
+}
@@ -1,18 +1,29 @@
 package subtranslator
 
 import (
+	"strings"
+
 	"github.com/samber/lo"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/atc"
 )
 
+const (
+	// l4RoutePriority is the priority of L4 routes.
+	l4RoutePriority RoutePriorityType = 1
+	// l4RouteExactSNIsPriority is the priority of L4 routes matching exact SNIs only. It's higher than
+	// l4RoutePriority so that they take precedence over routes with wildcard SNIs covering them.
+	l4RouteExactSNIsPriority RoutePriorityType = 2
+)
+
 // ApplyExpressionToL4KongRoute convert route flavor from traditional to expressions
 // against protocols, snis and dest ports.
 func ApplyExpressionToL4KongRoute(r *kongstate.Route) {
 	matchers := []atc.Matcher{}
 
-	sniMatcher := sniMatcherFromSNIs(lo.Map(r.Route.SNIs, func(item *string, _ int) string { return *item }))
+	snis := lo.Map(r.Route.SNIs, func(item *string, _ int) string { return *item })
+	sniMatcher := sniMatcherFromL4RouteSNIs(snis)
 	matchers = append(matchers, sniMatcher)
 
 	// TODO(rodman10): replace with helper function.
@@ -32,6 +43,29 @@ func ApplyExpressionToL4KongRoute(r *kongstate.Route) {
 	}
 	matchers = append(matchers, atc.Or(portMatchers...))
 
+	priority := l4RoutePriority
+	if len(snis) > 0 && !lo.ContainsBy(snis, func(sni string) bool { return strings.HasPrefix(sni, "*") }) {
+		priority = l4RouteExactSNIsPriority
+	}
+
 	r.ExpressionRoutes = true
-	atc.ApplyExpression(&r.Route, atc.And(matchers...), 1)
+	atc.ApplyExpression(&r.Route, atc.And(matchers...), priority)
+}
+
+// sniMatcherFromL4RouteSNIs generates matchers to match TLS SNIs of L4 routes. Unlike sniMatcherFromSNIs,
+// it matches wildcard SNIs (like *.foo.com) by their suffix, as TLSRoutes' hostnames may contain them.
+func sniMatcherFromL4RouteSNIs(snis []string) atc.Matcher {
+	matchers := make([]atc.Matcher, 0, len(snis))
+	for _, sni := range snis {
+		if domain, isWildcard := strings.CutPrefix(sni, "*."); isWildcard {
+			if validSNIs.MatchString(domain) {
+				matchers = append(matchers, atc.NewPredicateTLSSNI(atc.OpSuffixMatch, "."+domain))
+			}
+			continue
+		}
+		if validSNIs.MatchString(sni) {
+			matchers = append(matchers, atc.NewPredicateTLSSNI(atc.OpEqual, sni))
+		}
+	}
+	return atc.Or(matchers...)
 }
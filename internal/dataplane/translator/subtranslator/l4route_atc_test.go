@@ -92,6 +92,19 @@ func TestApplyExpressionToL4KongRoute(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "wildcard SNI host",
+			subExpr: "(tls.sni =^ \".example.com\") || (tls.sni == \"example.net\")",
+			route: kong.Route{
+				SNIs: []*string{
+					lo.ToPtr("*.example.com"),
+					lo.ToPtr("example.net"),
+				},
+				Protocols: []*string{
+					lo.ToPtr("tls_passthrough"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7,4 +7,16 @@ var (
 	ErrRouteValidationQueryParamMatchesUnsupported     = errors.New("query param matches are not yet supported")
 	ErrRouteValidationNoMatchRulesOrHostnamesSpecified = errors.New("no match rules or hostnames specified")
 	ErrRotueValidationRuleNoBackendRef                 = errors.New("no backendRefs in rule")
+	ErrRouteValidationRequestMirrorDisabled            = errors.New(
+		"filter type RequestMirror requires the HTTPRouteRequestMirror feature gate to be enabled",
+	)
+	ErrRouteValidationRequestMirrorUntrustedLua = errors.New(
+		"filter type RequestMirror requires Kong Gateway to be configured with untrusted_lua set to on",
+	)
+	ErrRouteValidationRequestMirrorPreFunction = errors.New(
+		"filter type RequestMirror can't be used with a pre-function plugin attached to the route",
+	)
+	ErrRouteValidationMatchPrecedenceUnsupported = errors.New(
+		"the traditional router prefers matches with more header matches, use the expressions router instead",
+	)
 )
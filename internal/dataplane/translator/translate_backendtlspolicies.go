@@ -0,0 +1,258 @@
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+// backendTLSPolicyCACertificateKey is the key of a ConfigMap's or Secret's data holding a PEM-encoded CA certificate
+// referenced by a BackendTLSPolicy.
+const backendTLSPolicyCACertificateKey = "ca.crt"
+
+// backendTLSProtocols maps Kong Service protocols to their TLS counterparts used when a BackendTLSPolicy applies.
+var backendTLSProtocols = map[string]string{
+	"http":  "https",
+	"https": "https",
+	"grpc":  "grpcs",
+	"grpcs": "grpcs",
+	"ws":    "wss",
+	"wss":   "wss",
+	"tcp":   "tls",
+	"tls":   "tls",
+}
+
+// applyBackendTLSPolicies configures TLS towards the upstreams of Kong Services whose Kubernetes Service backends
+// are targeted by BackendTLSPolicies. For every such Kong Service it enables TLS certificate verification using
+// the CA certificates referenced by the policy.
+//
+// Kong sends the upstream Host header as the SNI and verifies upstream certificates against it, and Kong Services
+// have no field to set them apart. A policy is therefore only applied when Kong sends its hostname as the Host
+// header: the upstream's host_header (the Service's konghq.com/host-header annotation) is set to it and none of
+// the Kong Service's routes preserves the client's Host header. Otherwise, verification would fail for every
+// certificate issued for the hostname, so the policy is reported as invalid instead.
+func (t *Translator) applyBackendTLSPolicies(result *kongstate.KongState) {
+	policies, err := t.storer.ListBackendTLSPolicies()
+	if err != nil {
+		t.logger.Error(err, "Failed to list BackendTLSPolicies")
+		return
+	}
+	if len(policies) == 0 {
+		return
+	}
+	// The oldest policy targeting a Service takes precedence as required by the Gateway API.
+	sortBackendTLSPolicies(policies)
+
+	caCertIDs := sets.New(lo.FilterMap(result.CACertificates, func(c kong.CACertificate, _ int) (string, bool) {
+		return lo.FromPtr(c.ID), c.ID != nil
+	})...)
+	for i := range result.Services {
+		service := &result.Services[i]
+		policy, err := backendTLSPolicyForService(policies, service)
+		if err != nil {
+			t.registerTranslationFailure(err.Error(), lo.Map(lo.Values(service.K8sServices), func(s *corev1.Service, _ int) client.Object {
+				return s
+			})...)
+			continue
+		}
+		if policy == nil {
+			continue
+		}
+
+		protocol, ok := backendTLSProtocols[lo.FromPtr(service.Protocol)]
+		if !ok {
+			t.registerTranslationFailure(
+				fmt.Sprintf("BackendTLSPolicy cannot be applied to service %s using %q protocol", lo.FromPtr(service.Name), lo.FromPtr(service.Protocol)),
+				policy,
+			)
+			continue
+		}
+		caCerts, err := t.getBackendTLSPolicyCACertificates(policy)
+		if err != nil {
+			t.registerTranslationFailure(fmt.Sprintf("invalid BackendTLSPolicy: %s", err), policy)
+			continue
+		}
+		upstream, _ := lo.Find(result.Upstreams, func(u kongstate.Upstream) bool {
+			return lo.FromPtr(u.Name) == lo.FromPtr(service.Host)
+		})
+		if err := validateBackendTLSPolicyHostname(policy, service, upstream); err != nil {
+			t.registerTranslationFailure(fmt.Sprintf("invalid BackendTLSPolicy: %s", err), append(
+				[]client.Object{policy},
+				lo.Map(lo.Values(service.K8sServices), func(s *corev1.Service, _ int) client.Object { return s })...,
+			)...)
+			continue
+		}
+
+		service.Protocol = kong.String(protocol)
+		service.TLSVerify = kong.Bool(true)
+		service.CACertificates = nil
+		for _, caCert := range caCerts {
+			service.CACertificates = append(service.CACertificates, caCert.ID)
+			if !caCertIDs.Has(*caCert.ID) {
+				caCertIDs.Insert(*caCert.ID)
+				result.CACertificates = append(result.CACertificates, caCert)
+			}
+		}
+	}
+}
+
+// validateBackendTLSPolicyHostname returns an error if Kong wouldn't send the policy's hostname as the SNI and verify
+// the upstream certificate against it when proxying to the given Kong Service's upstream.
+func validateBackendTLSPolicyHostname(
+	policy *gatewayapi.BackendTLSPolicy, service *kongstate.Service, upstream kongstate.Upstream,
+) error {
+	hostname := string(policy.Spec.Validation.Hostname)
+	if lo.FromPtr(upstream.HostHeader) != hostname {
+		return fmt.Errorf(
+			"hostname %q is not the Host header sent to the upstream of service %s which Kong uses as the SNI "+
+				"and to verify its certificate, set the targeted Service's %s annotation to it",
+			hostname, lo.FromPtr(service.Name), annotations.AnnotationPrefix+annotations.HostHeaderKey,
+		)
+	}
+	preservingHost := lo.FilterMap(service.Routes, func(r kongstate.Route, _ int) (string, bool) {
+		return lo.FromPtr(r.Name), lo.FromPtr(r.PreserveHost)
+	})
+	if len(preservingHost) > 0 {
+		sort.Strings(preservingHost)
+		return fmt.Errorf(
+			"routes %s of service %s preserve the client's Host header which Kong uses as the SNI and to verify "+
+				"the upstream certificate instead of hostname %q, set their %s annotation to \"false\"",
+			strings.Join(preservingHost, ", "), lo.FromPtr(service.Name), hostname,
+			annotations.AnnotationPrefix+annotations.PreserveHostKey,
+		)
+	}
+	return nil
+}
+
+// backendTLSPolicyForService returns the BackendTLSPolicy that applies to all Kubernetes Service backends of the
+// given Kong Service. It returns nil if there's none and an error if the backends are targeted by different policies.
+func backendTLSPolicyForService(policies []*gatewayapi.BackendTLSPolicy, service *kongstate.Service) (*gatewayapi.BackendTLSPolicy, error) {
+	var (
+		result      *gatewayapi.BackendTLSPolicy
+		hasBackends bool
+	)
+	for _, backend := range service.Backends {
+		if backend.IsServiceFacade() {
+			continue
+		}
+		k8sService, ok := service.K8sServices[fmt.Sprintf("%s/%s", backend.Namespace(), backend.Name())]
+		if !ok {
+			continue
+		}
+		portName := servicePortName(k8sService, backend.PortDef())
+		policy, _ := lo.Find(policies, func(p *gatewayapi.BackendTLSPolicy) bool {
+			return backendTLSPolicyTargetsService(p, k8sService.Namespace, k8sService.Name, portName)
+		})
+		if hasBackends && policy != result {
+			serviceKeys := lo.Keys(service.K8sServices)
+			sort.Strings(serviceKeys)
+			return nil, fmt.Errorf("inconsistent BackendTLSPolicy configuration for services %s", strings.Join(serviceKeys, ", "))
+		}
+		result, hasBackends = policy, true
+	}
+	return result, nil
+}
+
+// backendTLSPolicyTargetsService returns true if the given BackendTLSPolicy targets the Service with the given
+// namespace and name. If a policy's target reference specifies a section name, it has to match the given port name.
+func backendTLSPolicyTargetsService(policy *gatewayapi.BackendTLSPolicy, namespace, name, portName string) bool {
+	if policy.Namespace != namespace {
+		return false
+	}
+	return lo.ContainsBy(policy.Spec.TargetRefs, func(ref gatewayapi.LocalPolicyTargetReferenceWithSectionName) bool {
+		return (ref.Group == "" || ref.Group == "core") && ref.Kind == "Service" && string(ref.Name) == name &&
+			(ref.SectionName == nil || string(*ref.SectionName) == portName)
+	})
+}
+
+// servicePortName returns the name of the Service's port the given PortDef refers to.
+func servicePortName(service *corev1.Service, portDef kongstate.PortDef) string {
+	switch portDef.Mode {
+	case kongstate.PortModeByName:
+		return portDef.Name
+	case kongstate.PortModeByNumber:
+		if port, ok := lo.Find(service.Spec.Ports, func(p corev1.ServicePort) bool { return p.Port == portDef.Number }); ok {
+			return port.Name
+		}
+	case kongstate.PortModeImplicit:
+		if len(service.Spec.Ports) == 1 {
+			return service.Spec.Ports[0].Name
+		}
+	}
+	return ""
+}
+
+// getBackendTLSPolicyCACertificates translates ConfigMaps and Secrets referenced by the BackendTLSPolicy to
+// kong.CACertificates. UIDs of the referenced objects are used as the certificates' IDs.
+func (t *Translator) getBackendTLSPolicyCACertificates(policy *gatewayapi.BackendTLSPolicy) ([]kong.CACertificate, error) {
+	validation := policy.Spec.Validation
+	if len(validation.CACertificateRefs) == 0 && validation.WellKnownCACertificates == nil {
+		return nil, errors.New("either caCertificateRefs or wellKnownCACertificates must be specified")
+	}
+	if validation.WellKnownCACertificates != nil && *validation.WellKnownCACertificates != gatewayapi.WellKnownCACertificatesSystem {
+		return nil, fmt.Errorf("unsupported wellKnownCACertificates %q", *validation.WellKnownCACertificates)
+	}
+
+	caCerts := make([]kong.CACertificate, 0, len(validation.CACertificateRefs))
+	for _, ref := range validation.CACertificateRefs {
+		if ref.Group != "" && ref.Group != "core" {
+			return nil, fmt.Errorf("unsupported caCertificateRef group %q", ref.Group)
+		}
+
+		var (
+			obj  client.Object
+			data []byte
+		)
+		switch ref.Kind {
+		case "ConfigMap":
+			configMap, err := t.storer.GetConfigMap(policy.Namespace, string(ref.Name))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", policy.Namespace, ref.Name, err)
+			}
+			obj, data = configMap, []byte(configMap.Data[backendTLSPolicyCACertificateKey])
+		case "Secret":
+			secret, err := t.storer.GetSecret(policy.Namespace, string(ref.Name))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Secret %s/%s: %w", policy.Namespace, ref.Name, err)
+			}
+			obj, data = secret, secret.Data[backendTLSPolicyCACertificateKey]
+		default:
+			return nil, fmt.Errorf("unsupported caCertificateRef kind %q", ref.Kind)
+		}
+
+		if len(data) == 0 {
+			return nil, fmt.Errorf("%s %s/%s is missing %q key", ref.Kind, policy.Namespace, ref.Name, backendTLSPolicyCACertificateKey)
+		}
+		if err := validateCACertificate(data); err != nil {
+			return nil, fmt.Errorf("invalid CA certificate in %s %s/%s: %w", ref.Kind, policy.Namespace, ref.Name, err)
+		}
+		caCerts = append(caCerts, kong.CACertificate{
+			ID:   kong.String(string(obj.GetUID())),
+			Cert: kong.String(string(data)),
+			Tags: util.GenerateTagsForObject(obj),
+		})
+	}
+	return caCerts, nil
+}
+
+// sortBackendTLSPolicies sorts BackendTLSPolicies by their creation timestamp and then by namespace/name.
+func sortBackendTLSPolicies(policies []*gatewayapi.BackendTLSPolicy) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		if !policies[i].CreationTimestamp.Equal(&policies[j].CreationTimestamp) {
+			return policies[i].CreationTimestamp.Before(&policies[j].CreationTimestamp)
+		}
+		return client.ObjectKeyFromObject(policies[i]).String() < client.ObjectKeyFromObject(policies[j]).String()
+	})
+}
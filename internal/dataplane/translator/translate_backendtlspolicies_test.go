@@ -0,0 +1,293 @@
+package translator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v3/test/helpers/certificate"
+)
+
+func TestApplyBackendTLSPolicies(t *testing.T) {
+	caCert, _ := certificate.MustGenerateSelfSignedCertPEMFormat(certificate.WithCATrue())
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ca", UID: "ca-configmap-uid"},
+		Data:       map[string]string{"ca.crt": string(caCert)},
+	}
+	newService := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "https", Port: 443}, {Name: "http", Port: 80}}},
+		}
+	}
+	newKongService := func(name string, port int32) kongstate.Service {
+		backend, err := kongstate.NewServiceBackendForService(
+			k8stypes.NamespacedName{Namespace: "default", Name: name},
+			kongstate.PortDef{Mode: kongstate.PortModeByNumber, Number: port},
+		)
+		require.NoError(t, err)
+		return kongstate.Service{
+			Service: kong.Service{
+				Name:     kong.String(name),
+				Host:     kong.String(name + ".default.svc"),
+				Protocol: kong.String("http"),
+			},
+			Backends:    []kongstate.ServiceBackend{backend},
+			K8sServices: map[string]*corev1.Service{"default/" + name: newService(name)},
+		}
+	}
+	policy := &gatewayapi.BackendTLSPolicy{
+		TypeMeta:   gatewayapi.BackendTLSPolicyTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+		Spec: gatewayapi.BackendTLSPolicySpec{
+			TargetRefs: []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "tls"},
+					SectionName:                lo.ToPtr(gatewayapi.SectionName("https")),
+				},
+			},
+			Validation: gatewayapi.BackendTLSPolicyValidation{
+				CACertificateRefs: []gatewayapi.LocalObjectReference{{Kind: "ConfigMap", Name: "ca"}},
+				Hostname:          "backend.example.com",
+			},
+		},
+	}
+	missingCAPolicy := &gatewayapi.BackendTLSPolicy{
+		TypeMeta:   gatewayapi.BackendTLSPolicyTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing-ca-policy", CreationTimestamp: metav1.Now()},
+		Spec: gatewayapi.BackendTLSPolicySpec{
+			TargetRefs: []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+				{LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "missing-ca"}},
+			},
+			Validation: gatewayapi.BackendTLSPolicyValidation{
+				CACertificateRefs: []gatewayapi.LocalObjectReference{{Kind: "ConfigMap", Name: "missing"}},
+				Hostname:          "backend.example.com",
+			},
+		},
+	}
+	fakeStore, err := store.NewFakeStore(store.FakeObjects{
+		BackendTLSPolicies: []*gatewayapi.BackendTLSPolicy{policy, missingCAPolicy},
+		ConfigMaps:         []*corev1.ConfigMap{caConfigMap},
+	})
+	require.NoError(t, err)
+	translator := mustNewTranslator(t, fakeStore)
+
+	state := &kongstate.KongState{
+		Services: []kongstate.Service{
+			newKongService("tls", 443),
+			newKongService("tls", 80),
+			newKongService("missing-ca", 443),
+		},
+		Upstreams: []kongstate.Upstream{
+			{Upstream: kong.Upstream{Name: kong.String("tls.default.svc"), HostHeader: kong.String("backend.example.com")}},
+		},
+	}
+	state.Services[0].Routes = []kongstate.Route{{Route: kong.Route{Name: kong.String("route"), PreserveHost: kong.Bool(false)}}}
+	translator.applyBackendTLSPolicies(state)
+
+	t.Log("Verifying the policy is applied to the targeted port of the Service")
+	tlsService := state.Services[0]
+	assert.Equal(t, "https", *tlsService.Protocol)
+	assert.True(t, *tlsService.TLSVerify)
+	assert.Equal(t, []*string{kong.String("ca-configmap-uid")}, tlsService.CACertificates)
+	require.Len(t, state.CACertificates, 1)
+	assert.Equal(t, string(caCert), *state.CACertificates[0].Cert)
+
+	t.Log("Verifying the policy is not applied to other ports of the Service")
+	assert.Equal(t, "http", *state.Services[1].Protocol)
+	assert.Nil(t, state.Services[1].TLSVerify)
+
+	t.Log("Verifying an invalid CA certificate reference is reported")
+	assert.Equal(t, "http", *state.Services[2].Protocol)
+	assert.Nil(t, state.Services[2].TLSVerify)
+	failures := translator.failuresCollector.PopResourceFailures()
+	require.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Message(), "invalid BackendTLSPolicy")
+}
+
+func TestApplyBackendTLSPolicies_Hostname(t *testing.T) {
+	caCert, _ := certificate.MustGenerateSelfSignedCertPEMFormat(certificate.WithCATrue())
+	fakeStore, err := store.NewFakeStore(store.FakeObjects{
+		BackendTLSPolicies: []*gatewayapi.BackendTLSPolicy{
+			{
+				TypeMeta:   gatewayapi.BackendTLSPolicyTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+				Spec: gatewayapi.BackendTLSPolicySpec{
+					TargetRefs: []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+						{LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "tls"}},
+					},
+					Validation: gatewayapi.BackendTLSPolicyValidation{
+						CACertificateRefs: []gatewayapi.LocalObjectReference{{Kind: "ConfigMap", Name: "ca"}},
+						Hostname:          "backend.example.com",
+					},
+				},
+			},
+		},
+		ConfigMaps: []*corev1.ConfigMap{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ca", UID: "ca-configmap-uid"},
+				Data:       map[string]string{"ca.crt": string(caCert)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		hostHeader      *string
+		preserveHost    *bool
+		expectedFailure string
+	}{
+		{
+			name:         "upstream Host header set to hostname and Host not preserved",
+			hostHeader:   kong.String("backend.example.com"),
+			preserveHost: kong.Bool(false),
+		},
+		{
+			name:            "upstream Host header not set",
+			preserveHost:    kong.Bool(false),
+			expectedFailure: `hostname "backend.example.com" is not the Host header sent to the upstream of service tls`,
+		},
+		{
+			name:            "upstream Host header set to another host",
+			hostHeader:      kong.String("other.example.com"),
+			preserveHost:    kong.Bool(false),
+			expectedFailure: "konghq.com/host-header annotation",
+		},
+		{
+			name:            "route preserving Host header",
+			hostHeader:      kong.String("backend.example.com"),
+			preserveHost:    kong.Bool(true),
+			expectedFailure: "routes route of service tls preserve the client's Host header",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			translator := mustNewTranslator(t, fakeStore)
+			backend, err := kongstate.NewServiceBackendForService(
+				k8stypes.NamespacedName{Namespace: "default", Name: "tls"},
+				kongstate.PortDef{Mode: kongstate.PortModeImplicit},
+			)
+			require.NoError(t, err)
+			state := &kongstate.KongState{
+				Services: []kongstate.Service{
+					{
+						Service:  kong.Service{Name: kong.String("tls"), Host: kong.String("tls.default.svc"), Protocol: kong.String("http")},
+						Backends: []kongstate.ServiceBackend{backend},
+						K8sServices: map[string]*corev1.Service{
+							"default/tls": {
+								TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+								ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls"},
+							},
+						},
+						Routes: []kongstate.Route{{Route: kong.Route{Name: kong.String("route"), PreserveHost: tc.preserveHost}}},
+					},
+				},
+				Upstreams: []kongstate.Upstream{
+					{Upstream: kong.Upstream{Name: kong.String("tls.default.svc"), HostHeader: tc.hostHeader}},
+				},
+			}
+			translator.applyBackendTLSPolicies(state)
+
+			failures := translator.failuresCollector.PopResourceFailures()
+			if tc.expectedFailure == "" {
+				require.Empty(t, failures)
+				require.True(t, *state.Services[0].TLSVerify)
+				require.Equal(t, "https", *state.Services[0].Protocol)
+				return
+			}
+			require.Len(t, failures, 1)
+			require.Contains(t, failures[0].Message(), tc.expectedFailure)
+			require.Len(t, failures[0].CausingObjects(), 2, "both the policy and the Service should be reported")
+			require.Nil(t, state.Services[0].TLSVerify)
+			require.Equal(t, "http", *state.Services[0].Protocol)
+		})
+	}
+}
+
+// TestApplyBackendTLSPolicies_KongServiceSchema verifies Kong Services with a BackendTLSPolicy applied conform to
+// Kong's JSON schema of Services (testdata/kong_service_schema.json, as served by Kong), which rejects unknown fields.
+func TestApplyBackendTLSPolicies_KongServiceSchema(t *testing.T) {
+	caCert, _ := certificate.MustGenerateSelfSignedCertPEMFormat(certificate.WithCATrue())
+	fakeStore, err := store.NewFakeStore(store.FakeObjects{
+		BackendTLSPolicies: []*gatewayapi.BackendTLSPolicy{
+			{
+				TypeMeta:   gatewayapi.BackendTLSPolicyTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+				Spec: gatewayapi.BackendTLSPolicySpec{
+					TargetRefs: []gatewayapi.LocalPolicyTargetReferenceWithSectionName{
+						{LocalPolicyTargetReference: gatewayapi.LocalPolicyTargetReference{Kind: "Service", Name: "tls"}},
+					},
+					Validation: gatewayapi.BackendTLSPolicyValidation{
+						CACertificateRefs: []gatewayapi.LocalObjectReference{{Kind: "ConfigMap", Name: "ca"}},
+						Hostname:          "backend.example.com",
+					},
+				},
+			},
+		},
+		ConfigMaps: []*corev1.ConfigMap{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ca", UID: "5b1c8a52-8f0e-4c38-9d8f-3e1f6f1f2a7b"},
+				Data:       map[string]string{"ca.crt": string(caCert)},
+			},
+		},
+	})
+	require.NoError(t, err)
+	translator := mustNewTranslator(t, fakeStore)
+
+	backend, err := kongstate.NewServiceBackendForService(
+		k8stypes.NamespacedName{Namespace: "default", Name: "tls"},
+		kongstate.PortDef{Mode: kongstate.PortModeImplicit},
+	)
+	require.NoError(t, err)
+	state := &kongstate.KongState{
+		Services: []kongstate.Service{
+			{
+				Service: kong.Service{
+					ID:             kong.String("0f6fcd4e-1c4b-4e5c-9e0f-7f2d1a4a6c1e"),
+					Name:           kong.String("default.tls.pnum-443"),
+					Host:           kong.String("default.tls.443.svc"),
+					Port:           kong.Int(443),
+					Protocol:       kong.String("http"),
+					ConnectTimeout: kong.Int(60000),
+					ReadTimeout:    kong.Int(60000),
+					WriteTimeout:   kong.Int(60000),
+					Retries:        kong.Int(5),
+				},
+				Backends: []kongstate.ServiceBackend{backend},
+				Routes:   []kongstate.Route{{Route: kong.Route{Name: kong.String("route"), PreserveHost: kong.Bool(false)}}},
+				K8sServices: map[string]*corev1.Service{
+					"default/tls": {
+						ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls"},
+						Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "https", Port: 443}}},
+					},
+				},
+			},
+		},
+	}
+	state.Upstreams = []kongstate.Upstream{
+		{Upstream: kong.Upstream{Name: kong.String("default.tls.443.svc"), HostHeader: kong.String("backend.example.com")}},
+	}
+	translator.applyBackendTLSPolicies(state)
+	require.True(t, *state.Services[0].TLSVerify, "policy should be applied")
+
+	serviceJSON, err := json.Marshal(state.Services[0].Service)
+	require.NoError(t, err)
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewReferenceLoader("file://./testdata/kong_service_schema.json"),
+		gojsonschema.NewBytesLoader(serviceJSON),
+	)
+	require.NoError(t, err)
+	assert.True(t, result.Valid(), "service %s doesn't conform to Kong's schema: %v", serviceJSON, result.Errors())
+}
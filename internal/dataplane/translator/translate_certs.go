@@ -9,8 +9,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
@@ -41,6 +44,7 @@ type certWrapper struct {
 	identifier        string
 	cert              kong.Certificate
 	snis              []string
+	gateways          []k8stypes.NamespacedName
 	CreationTimestamp metav1.Time
 }
 
@@ -132,6 +136,7 @@ func (t *Translator) getGatewayCerts() []certWrapper {
 						},
 						CreationTimestamp: secret.CreationTimestamp,
 						snis:              []string{hostname},
+						gateways:          []k8stypes.NamespacedName{client.ObjectKeyFromObject(gateway)},
 					})
 				}
 			}
@@ -193,6 +198,11 @@ func mergeCerts(logger logr.Logger, certLists ...[]certWrapper) []kongstate.Cert
 					current.CreationTimestamp = cw.CreationTimestamp
 				}
 				current.snis = append(current.snis, cw.snis...)
+				for _, gateway := range cw.gateways {
+					if !lo.Contains(current.gateways, gateway) {
+						current.gateways = append(current.gateways, gateway)
+					}
+				}
 			}
 
 			// although we use current in the end, we only warn/exclude on new ones here. SNIs already in the slice
@@ -221,7 +231,7 @@ func mergeCerts(logger logr.Logger, certLists ...[]certWrapper) []kongstate.Cert
 		sort.SliceStable(cw.cert.SNIs, func(i, j int) bool {
 			return strings.Compare(*cw.cert.SNIs[i], *cw.cert.SNIs[j]) < 0
 		})
-		res = append(res, kongstate.Certificate{Certificate: cw.cert})
+		res = append(res, kongstate.Certificate{Certificate: cw.cert, ParentGateways: cw.gateways})
 	}
 	return res
 }
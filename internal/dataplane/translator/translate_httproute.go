@@ -1,8 +1,10 @@
 package translator
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/samber/lo"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
@@ -34,7 +37,12 @@ func (t *Translator) ingressRulesFromHTTPRoutes() ingressRules {
 	httpRoutesToTranslate := make([]*gatewayapi.HTTPRoute, 0, len(httpRouteList))
 	for _, httproute := range httpRouteList {
 		// Validate each HTTPRoute before translating and register translation failures if an HTTPRoute is invalid.
-		if err := validateHTTPRoute(httproute, t.featureFlags); err != nil {
+		if err := validateHTTPRoute(httproute, t.featureFlags, t.routePluginNames(httproute)); err != nil {
+			t.registerTranslationFailure(fmt.Sprintf("HTTPRoute can't be routed: %v", err), httproute)
+			continue
+		}
+		httproute, err := t.resolveHTTPRouteRequestMirrors(httproute)
+		if err != nil {
 			t.registerTranslationFailure(fmt.Sprintf("HTTPRoute can't be routed: %v", err), httproute)
 			continue
 		}
@@ -46,22 +54,54 @@ func (t *Translator) ingressRulesFromHTTPRoutes() ingressRules {
 		return result
 	}
 
+	firstRuleOrdinals := httpRoutesFirstRuleOrdinals(httpRoutesToTranslate)
 	for _, httproute := range httpRoutesToTranslate {
-		if err := t.ingressRulesFromHTTPRoute(&result, httproute); err != nil {
+		if err := t.ingressRulesFromHTTPRoute(&result, httproute, firstRuleOrdinals[httproute]); err != nil {
 			t.registerTranslationFailure(fmt.Sprintf("HTTPRoute can't be routed: %s", err), httproute)
 		} else {
 			// at this point the object has been configured and can be
 			// reported as successfully translated.
 			t.registerSuccessfullyTranslatedObject(httproute)
+			if duplicates := duplicateHTTPRouteHeaderMatches(httproute); len(duplicates) > 0 {
+				t.registerTranslationWarning(fmt.Sprintf(
+					"header matches ignored as an earlier match refers to the same header: %s", strings.Join(duplicates, ", "),
+				), httproute)
+			}
 		}
 	}
 
 	return result
 }
 
+// httpRoutesFirstRuleOrdinals orders rules of all the HTTPRoutes by the Gateway API precedence rules: the oldest
+// HTTPRoute first, then alphabetically by namespace and name, then in the order of rules. It returns the ordinal of
+// the first rule of each HTTPRoute.
+func httpRoutesFirstRuleOrdinals(httpRoutes []*gatewayapi.HTTPRoute) map[*gatewayapi.HTTPRoute]int {
+	sorted := slices.Clone(httpRoutes)
+	slices.SortStableFunc(sorted, func(a, b *gatewayapi.HTTPRoute) int {
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			if a.CreationTimestamp.Before(&b.CreationTimestamp) {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	ordinals := make(map[*gatewayapi.HTTPRoute]int, len(sorted))
+	next := 0
+	for _, httproute := range sorted {
+		ordinals[httproute] = next
+		next += len(httproute.Spec.Rules)
+	}
+	return ordinals
+}
+
 // ingressRulesFromHTTPRoute validates and generates a set of proto-Kong routes (ingress rules) from an HTTPRoute.
 // If multiple rules in the HTTPRoute use the same Service, it combines them into a single Kong route.
-func (t *Translator) ingressRulesFromHTTPRoute(result *ingressRules, httproute *gatewayapi.HTTPRoute) error {
+// firstRuleOrdinal is the position of the HTTPRoute's first rule among rules of all HTTPRoutes ordered by the
+// Gateway API precedence rules, used to prioritize Kong routes.
+func (t *Translator) ingressRulesFromHTTPRoute(result *ingressRules, httproute *gatewayapi.HTTPRoute, firstRuleOrdinal int) error {
 	for _, kongServiceTranslation := range subtranslator.TranslateHTTPRoute(httproute) {
 		// HTTPRoute uses a wrapper HTTPBackendRef to add optional filters to its BackendRefs
 		backendRefs := httpBackendRefsToBackendRefs(kongServiceTranslation.BackendRefs)
@@ -74,12 +114,34 @@ func (t *Translator) ingressRulesFromHTTPRoute(result *ingressRules, httproute *
 			return err
 		}
 
+		applyTimeoutsToService(&service, kongServiceTranslation.Timeouts)
+		service.SessionPersistence = kongServiceTranslation.SessionPersistence
+
 		// generate the routes for the service and attach them to the service
 		for _, kongRouteTranslation := range kongServiceTranslation.KongRoutes {
 			routes, err := GenerateKongRouteFromTranslation(httproute, kongRouteTranslation, t.featureFlags.ExpressionRoutes)
 			if err != nil {
 				return err
 			}
+			mirrorPlugin, err := t.requestMirrorPlugin(httproute, kongRouteTranslation.Filters)
+			if err != nil {
+				return err
+			}
+			if mirrorPlugin != nil {
+				for i := range routes {
+					routes[i].Plugins = append(routes[i].Plugins, *mirrorPlugin.DeepCopy())
+				}
+			}
+			if !t.featureFlags.ExpressionRoutes {
+				regexPriority := subtranslator.TraditionalHTTPRouteRegexPriority(
+					kongRouteTranslation.Matches, firstRuleOrdinal+kongRouteTranslation.RuleNumber,
+				)
+				for i := range routes {
+					if len(routes[i].Paths) > 0 {
+						routes[i].RegexPriority = kong.Int(regexPriority)
+					}
+				}
+			}
 			service.Routes = append(service.Routes, routes...)
 		}
 
@@ -87,54 +149,66 @@ func (t *Translator) ingressRulesFromHTTPRoute(result *ingressRules, httproute *
 		result.ServiceNameToServices[*service.Service.Name] = service
 		result.ServiceNameToParent[serviceName] = httproute
 	}
-	applyTimeoutsToService(httproute, result)
 	return nil
 }
 
-// applyTimeoutsToService applies timeouts from HTTPRoute to the service.
-// If the HTTPRoute has multiple rules, the timeout from the last rule which has specific timeout will be applied to the service.
-// If the HTTPRoute has multiple rules and the first rule doesn't have timeout, the default timeout will be applied to the service.
-func applyTimeoutsToService(httpRoute *gatewayapi.HTTPRoute, rules *ingressRules) {
-	// If the HTTPRoute doesn't have rules, we don't need to apply timeouts to the service.
-	if httpRoute.Spec.Rules == nil {
+// applyTimeoutsToService applies timeouts of an HTTPRoute rule to the Kong service the rule is translated into.
+// Due to only connect, read and write timeouts being available in Kong services, both timeouts
+// are approximated with them:
+//   - backendRequest is used as the connect, read and write timeout,
+//   - request caps the above and limits the number of retries, so that the attempts roughly fit within it.
+//     It's not a total deadline of the request: each phase of each attempt can take up to the timeout.
+//
+// See https://github.com/Kong/kubernetes-ingress-controller/issues/4914#issuecomment-1813964669.
+func applyTimeoutsToService(service *kongstate.Service, timeouts *gatewayapi.HTTPRouteTimeouts) {
+	if timeouts == nil {
+		return
+	}
+	// We ignore the parsing errors and zero values (meaning timeout is disabled) because the durations are validated
+	// to be a strict subset of Golang time.ParseDuration.
+	backendRequestTimeout, hasBackendRequestTimeout := parseHTTPRouteTimeout(timeouts.BackendRequest)
+	requestTimeout, hasRequestTimeout := parseHTTPRouteTimeout(timeouts.Request)
+
+	var timeout int
+	switch {
+	case hasBackendRequestTimeout && hasRequestTimeout:
+		timeout = min(backendRequestTimeout, requestTimeout)
+	case hasBackendRequestTimeout:
+		timeout = backendRequestTimeout
+	case hasRequestTimeout:
+		timeout = requestTimeout
+	default:
 		return
 	}
 
-	backendRequestTimeout := DefaultServiceTimeout
-	for _, rule := range httpRoute.Spec.Rules {
-		if rule.Timeouts != nil && rule.Timeouts.BackendRequest != nil {
-			duration, err := time.ParseDuration(string(*rule.Timeouts.BackendRequest))
-			// We ignore the error here because the rule.Timeouts.BackendRequest is validated
-			// to be a strict subset of Golang time.ParseDuration so it should never happen
-			if err != nil {
-				continue
-			}
-			backendRequestTimeout = int(duration.Milliseconds())
+	service.Service.ReadTimeout = kong.Int(timeout)
+	service.Service.ConnectTimeout = kong.Int(timeout)
+	service.Service.WriteTimeout = kong.Int(timeout)
+	if hasRequestTimeout {
+		retries := DefaultRetries
+		if service.Service.Retries != nil {
+			retries = *service.Service.Retries
 		}
+		// Limit retries so that the first attempt and all the retries, each timing out, fit within the request timeout.
+		service.Service.Retries = kong.Int(max(0, min(retries, requestTimeout/timeout-1)))
 	}
+}
 
-	// if the backendRequestTimeout is the same as the default timeout, we don't need to apply it to the service.
-	if backendRequestTimeout == DefaultServiceTimeout {
-		return
+// parseHTTPRouteTimeout returns the timeout in milliseconds and true if the timeout is set and enabled (non-zero).
+func parseHTTPRouteTimeout(timeout *gatewayapi.Duration) (int, bool) {
+	if timeout == nil {
+		return 0, false
 	}
-
-	// due rules.ServiceNameToServices is a map, we need to iterate over the map to find the service
-	// which has the same parent as the HTTPRoute.
-	for serviceName, service := range rules.ServiceNameToServices {
-		if service.Parent.GetObjectKind() == httpRoute.GetObjectKind() && service.Parent.GetName() == httpRoute.Name && service.Parent.GetNamespace() == httpRoute.Namespace {
-			// Due to only one field being available in the Gateway API to control this behavior,
-			// when users set `spec.rules[].timeouts` in HTTPRoute,
-			// KIC will also set ReadTimeout, WriteTimeout and ConnectTimeout for the service to this value
-			// https://github.com/Kong/kubernetes-ingress-controller/issues/4914#issuecomment-1813964669
-			service.Service.ReadTimeout = kong.Int(backendRequestTimeout)
-			service.Service.ConnectTimeout = kong.Int(backendRequestTimeout)
-			service.Service.WriteTimeout = kong.Int(backendRequestTimeout)
-			rules.ServiceNameToServices[serviceName] = service
-		}
+	duration, err := time.ParseDuration(string(*timeout))
+	if err != nil || duration <= 0 {
+		return 0, false
 	}
+	return int(duration.Milliseconds()), true
 }
 
-func validateHTTPRoute(httproute *gatewayapi.HTTPRoute, featureFlags FeatureFlags) error {
+// validateHTTPRoute checks whether the HTTPRoute can be translated. routePlugins are the names of Kong plugins
+// attached to the HTTPRoute, as returned by routePluginNames.
+func validateHTTPRoute(httproute *gatewayapi.HTTPRoute, featureFlags FeatureFlags, routePlugins []string) error {
 	spec := httproute.Spec
 
 	// validation for HTTPRoutes will happen at a higher layer, but in spite of that we run
@@ -145,21 +219,190 @@ func validateHTTPRoute(httproute *gatewayapi.HTTPRoute, featureFlags FeatureFlag
 		return subtranslator.ErrRouteValidationNoRules
 	}
 
-	// Kong supports query parameter match only with expression router,
-	// so we return error when query param match is specified and expression router is not enabled in the translator.
+	if err := subtranslator.ValidateHTTPRouteRequestMirrors(
+		httproute, featureFlags.HTTPRouteRequestMirror, featureFlags.UntrustedLua, routePlugins,
+	); err != nil {
+		return err
+	}
+
+	// Kong supports query parameter matches and the full Gateway API match precedence only with expression router,
+	// so we return error when the traditional router can't express the matches.
 	if !featureFlags.ExpressionRoutes {
-		for _, rule := range spec.Rules {
-			for _, match := range rule.Matches {
-				if len(match.QueryParams) > 0 {
-					return subtranslator.ErrRouteValidationQueryParamMatchesUnsupported
-				}
-			}
+		if err := subtranslator.ValidateHTTPRouteForTraditionalRouter(httproute); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// resolveHTTPRouteRequestMirrors resolves backendRefs of RequestMirror filters of the HTTPRoute using the same rules
+// as for regular backendRefs (existence of the Service, supported group and kind, ReferenceGrants).
+// It returns the HTTPRoute unchanged if it has no RequestMirror filters. Otherwise, it returns a copy of the HTTPRoute
+// where RequestMirror filters with unresolvable backendRefs are dropped and the remaining ones have their namespace set.
+func (t *Translator) resolveHTTPRouteRequestMirrors(httproute *gatewayapi.HTTPRoute) (*gatewayapi.HTTPRoute, error) {
+	hasMirrorFilter := lo.ContainsBy(httproute.Spec.Rules, func(rule gatewayapi.HTTPRouteRule) bool {
+		return lo.ContainsBy(rule.Filters, isRequestMirrorFilter)
+	})
+	if !hasMirrorFilter {
+		return httproute, nil
+	}
+
+	grants, err := t.storer.ListReferenceGrants()
+	if err != nil {
+		return httproute, fmt.Errorf("could not retrieve ReferenceGrants: %w", err)
+	}
+	allowed := GetPermittedForReferenceGrantFrom(gatewayapi.ReferenceGrantFrom{
+		Group:     gatewayapi.Group(httproute.GetObjectKind().GroupVersionKind().Group),
+		Kind:      gatewayapi.Kind(httproute.GetObjectKind().GroupVersionKind().Kind),
+		Namespace: gatewayapi.Namespace(httproute.Namespace),
+	}, grants)
+
+	resolved := httproute.DeepCopy()
+	for i, rule := range resolved.Spec.Rules {
+		resolved.Spec.Rules[i].Filters = lo.Filter(
+			withRequestMirrorNamespacesDefaulted(rule.Filters, httproute.Namespace),
+			func(filter gatewayapi.HTTPRouteFilter, _ int) bool {
+				if !isRequestMirrorFilter(filter) {
+					return true
+				}
+				backendRef := gatewayapi.BackendRef{BackendObjectReference: filter.RequestMirror.BackendRef}
+				// Group is defaulted by the API server, but let's not rely on that as it's required to check grants.
+				if backendRef.Group == nil {
+					backendRef.Group = lo.ToPtr(gatewayapi.Group(""))
+				}
+				backends := backendRefsToKongStateBackends(t.logger, t.storer, httproute, []gatewayapi.BackendRef{backendRef}, allowed)
+				return len(backends) > 0
+			},
+		)
+	}
+	return resolved, nil
+}
+
+// routePluginNames returns names of Kong plugins (e.g. "pre-function") of the KongPlugins and KongClusterPlugins
+// attached to the HTTPRoute with the konghq.com/plugins annotation. References which can't be resolved are skipped.
+func (t *Translator) routePluginNames(httproute *gatewayapi.HTTPRoute) []string {
+	var names []string
+	for _, ref := range annotations.ExtractNamespacedKongPluginsFromAnnotations(httproute.Annotations) {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = httproute.Namespace
+		}
+		if plugin, err := t.storer.GetKongPlugin(namespace, ref.Name); err == nil {
+			names = append(names, plugin.PluginName)
+			continue
+		}
+		if plugin, err := t.storer.GetKongClusterPlugin(ref.Name); err == nil {
+			names = append(names, plugin.PluginName)
+		}
+	}
+	return names
+}
+
+// withRequestMirrorNamespacesDefaulted returns a copy of the filters where the backendRefs of RequestMirror
+// filters without an explicit namespace point to the given namespace of the route.
+func withRequestMirrorNamespacesDefaulted(filters []gatewayapi.HTTPRouteFilter, namespace string) []gatewayapi.HTTPRouteFilter {
+	return lo.Map(filters, func(filter gatewayapi.HTTPRouteFilter, _ int) gatewayapi.HTTPRouteFilter {
+		if !isRequestMirrorFilter(filter) || filter.RequestMirror.BackendRef.Namespace != nil {
+			return filter
+		}
+		mirror := filter.RequestMirror.DeepCopy()
+		mirror.BackendRef.Namespace = lo.ToPtr(gatewayapi.Namespace(namespace))
+		filter.RequestMirror = mirror
+		return filter
+	})
+}
+
+func isRequestMirrorFilter(filter gatewayapi.HTTPRouteFilter) bool {
+	return filter.Type == gatewayapi.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil
+}
+
+// requestMirrorLuaCodeTemplate is the Lua code run by the pre-function plugin in the access phase to implement
+// the RequestMirror filter. Each request is copied asynchronously to every mirror URL and the responses are ignored,
+// so mirroring never affects the response returned to the client. The Host header of the original request is
+// dropped, so that the HTTP client sets it to the host of the mirror URL. Certificates of HTTPS mirrors are
+// verified against lua_ssl_trusted_certificate of Kong and have to be valid for <name>.<namespace>.svc of the
+// mirror Service. The only placeholder is the comma-separated list of quoted mirror URLs.
+//
+// The code uses ngx.timer and resty.http, which Kong allows only with untrusted_lua set to on, as the default Lua
+// sandbox blocks them. RequestMirror filters are therefore translated only with the HTTPRouteRequestMirror feature
+// gate (disabled by default) enabled and are rejected unless Kong Gateway runs with untrusted_lua=on.
+const requestMirrorLuaCodeTemplate = `local mirrors = { %s }
+local method = kong.request.get_method()
+local path = kong.request.get_path_with_query()
+local headers = kong.request.get_headers()
+headers["host"] = nil
+local body = kong.request.get_raw_body()
+for _, mirror in ipairs(mirrors) do
+  ngx.timer.at(0, function(premature)
+    if premature then
+      return
+    end
+    local httpc = require("resty.http").new()
+    local _, err = httpc:request_uri(mirror .. path, {
+      method = method, headers = headers, body = body, ssl_verify = true,
+    })
+    if err then
+      kong.log.warn("failed to mirror request to ", mirror, ": ", err)
+    end
+  end)
+end
+`
+
+// requestMirrorPlugin generates a pre-function plugin copying requests to the backends referenced by
+// the RequestMirror filters among the given filters of the HTTPRoute. It returns nil if there are none.
+// Multiple RequestMirror filters are allowed in a single rule, they're all translated into a single plugin.
+// The filters' backendRefs are expected to be resolved by resolveHTTPRouteRequestMirrors.
+func (t *Translator) requestMirrorPlugin(httproute *gatewayapi.HTTPRoute, filters []gatewayapi.HTTPRouteFilter) (*kong.Plugin, error) {
+	var mirrorURLs []string
+	for _, filter := range filters {
+		if !isRequestMirrorFilter(filter) {
+			continue
+		}
+		mirrorURL, err := t.requestMirrorURL(filter.RequestMirror.BackendRef)
+		if err != nil {
+			return nil, err
+		}
+		mirrorURLs = append(mirrorURLs, fmt.Sprintf("%q", mirrorURL))
+	}
+	if len(mirrorURLs) == 0 {
+		return nil, nil
+	}
+
+	return &kong.Plugin{
+		Name: kong.String("pre-function"),
+		Config: kong.Configuration{
+			"access": []string{fmt.Sprintf(requestMirrorLuaCodeTemplate, strings.Join(mirrorURLs, ", "))},
+		},
+		// This plugin is derived from an HTTPRoute filter, not a KongPlugin, so we apply tags indicating that
+		// HTTPRoute as the parent Kubernetes resource.
+		Tags: util.GenerateTagsForObject(httproute),
+	}, nil
+}
+
+// requestMirrorURL returns the in-cluster URL of the Service referenced by a RequestMirror filter. The scheme is
+// https if the Service's konghq.com/protocol annotation is https, and http otherwise, as for regular backends.
+func (t *Translator) requestMirrorURL(ref gatewayapi.BackendObjectReference) (string, error) {
+	if ref.Namespace == nil || *ref.Namespace == "" {
+		return "", fmt.Errorf("%s filter: namespace of backendRef %s is not resolved",
+			gatewayapi.HTTPRouteFilterRequestMirror, ref.Name)
+	}
+	if ref.Port == nil {
+		return "", fmt.Errorf("%s filter: port of backendRef %s/%s is required",
+			gatewayapi.HTTPRouteFilterRequestMirror, *ref.Namespace, ref.Name)
+	}
+	service, err := t.storer.GetService(string(*ref.Namespace), string(ref.Name))
+	if err != nil {
+		return "", fmt.Errorf("%s filter: failed to get Service %s/%s: %w",
+			gatewayapi.HTTPRouteFilterRequestMirror, *ref.Namespace, ref.Name, err)
+	}
+	scheme := "http"
+	if annotations.ExtractProtocolName(service.Annotations) == "https" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%d", scheme, ref.Name, *ref.Namespace, *ref.Port), nil
+}
+
 // ingressRulesFromHTTPRoutesUsingExpressionRoutes translates HTTPRoutes to expression based routes
 // when ExpressionRoutes feature flag is enabled.
 // Because we need to assign different priorities based on the hostname and match in the specification of HTTPRoutes,
@@ -193,9 +436,7 @@ func (t *Translator) ingressRulesFromHTTPRoutesUsingExpressionRoutes(httpRoutes
 			Namespace: httproute.Namespace,
 			Name:      httproute.Name,
 		}
-		if translationFailures, ok := httpRouteNameToTranslationFailure[nsName]; !ok {
-			applyTimeoutsToService(httproute, result)
-		} else {
+		if translationFailures, ok := httpRouteNameToTranslationFailure[nsName]; ok {
 			t.registerTranslationFailure(
 				fmt.Sprintf("HTTPRoute can't be routed: %v", errors.Join(translationFailures...)),
 				httproute,
@@ -497,11 +738,20 @@ func (t *Translator) ingressRulesFromSplitHTTPRouteMatchWithPriority(
 	if err != nil {
 		return err
 	}
+	applyTimeoutsToService(&kongService, rule.Timeouts)
+	kongService.SessionPersistence = rule.SessionPersistence
 
 	additionalRoutes, err := subtranslator.KongExpressionRouteFromHTTPRouteMatchWithPriority(httpRouteMatchWithPriority)
 	if err != nil {
 		return err
 	}
+	mirrorPlugin, err := t.requestMirrorPlugin(httpRoute, rule.Filters)
+	if err != nil {
+		return err
+	}
+	if mirrorPlugin != nil {
+		additionalRoutes.Plugins = append(additionalRoutes.Plugins, *mirrorPlugin)
+	}
 
 	kongService.Routes = append(
 		kongService.Routes,
@@ -1,6 +1,7 @@
 package translator
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/go-logr/zapr"
@@ -23,6 +24,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/builder"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1"
 )
 
 // httprouteGVK is the GVK for HTTPRoutes, needed in unit tests because
@@ -47,6 +49,8 @@ func TestValidateHTTPRoute(t *testing.T) {
 		name             string
 		httpRoute        *gatewayapi.HTTPRoute
 		expressionRoutes bool
+		requestMirror    bool
+		untrustedLua     bool
 		expectedError    error
 	}{
 		{
@@ -138,15 +142,95 @@ func TestValidateHTTPRoute(t *testing.T) {
 			expressionRoutes: false,
 			expectedError:    subtranslator.ErrRouteValidationQueryParamMatchesUnsupported,
 		},
+		{
+			name: "HTTPRoute with request timeout should pass validation",
+			httpRoute: &gatewayapi.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "httproute-request-timeout",
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: gatewayapi.HTTPRouteSpec{
+					CommonRouteSpec: commonRouteSpecMock("fake-gateway-1"),
+					Rules: []gatewayapi.HTTPRouteRule{{
+						BackendRefs: []gatewayapi.HTTPBackendRef{
+							builder.NewHTTPBackendRef("fake-service").WithPort(80).Build(),
+						},
+						Timeouts: &gatewayapi.HTTPRouteTimeouts{Request: lo.ToPtr(gatewayapi.Duration("5s"))},
+					}},
+				},
+			},
+		},
+		{
+			name: "HTTPRoute with RequestMirror filter should pass validation when the feature is enabled",
+			httpRoute: &gatewayapi.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "httproute-request-mirror",
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: gatewayapi.HTTPRouteSpec{
+					CommonRouteSpec: commonRouteSpecMock("fake-gateway-1"),
+					Rules: []gatewayapi.HTTPRouteRule{{
+						BackendRefs: []gatewayapi.HTTPBackendRef{
+							builder.NewHTTPBackendRef("fake-service").WithPort(80).Build(),
+						},
+						Filters: []gatewayapi.HTTPRouteFilter{{Type: gatewayapi.HTTPRouteFilterRequestMirror}},
+					}},
+				},
+			},
+			requestMirror: true,
+			untrustedLua:  true,
+			expectedError: nil,
+		},
+		{
+			name: "HTTPRoute with RequestMirror filter should not pass validation when untrusted Lua is not allowed",
+			httpRoute: &gatewayapi.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "httproute-request-mirror",
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: gatewayapi.HTTPRouteSpec{
+					CommonRouteSpec: commonRouteSpecMock("fake-gateway-1"),
+					Rules: []gatewayapi.HTTPRouteRule{{
+						BackendRefs: []gatewayapi.HTTPBackendRef{
+							builder.NewHTTPBackendRef("fake-service").WithPort(80).Build(),
+						},
+						Filters: []gatewayapi.HTTPRouteFilter{{Type: gatewayapi.HTTPRouteFilterRequestMirror}},
+					}},
+				},
+			},
+			requestMirror: true,
+			expectedError: subtranslator.ErrRouteValidationRequestMirrorUntrustedLua,
+		},
+		{
+			name: "HTTPRoute with RequestMirror filter should not pass validation when the feature is disabled",
+			httpRoute: &gatewayapi.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "httproute-request-mirror",
+					Namespace: corev1.NamespaceDefault,
+				},
+				Spec: gatewayapi.HTTPRouteSpec{
+					CommonRouteSpec: commonRouteSpecMock("fake-gateway-1"),
+					Rules: []gatewayapi.HTTPRouteRule{{
+						BackendRefs: []gatewayapi.HTTPBackendRef{
+							builder.NewHTTPBackendRef("fake-service").WithPort(80).Build(),
+						},
+						Filters: []gatewayapi.HTTPRouteFilter{{Type: gatewayapi.HTTPRouteFilterRequestMirror}},
+					}},
+				},
+			},
+			expectedError: subtranslator.ErrRouteValidationRequestMirrorDisabled,
+		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			featureFlags := FeatureFlags{
-				ExpressionRoutes: tc.expressionRoutes,
+				ExpressionRoutes:       tc.expressionRoutes,
+				HTTPRouteRequestMirror: tc.requestMirror,
+				UntrustedLua:           tc.untrustedLua,
 			}
-			err := validateHTTPRoute(tc.httpRoute, featureFlags)
+			err := validateHTTPRoute(tc.httpRoute, featureFlags, nil)
 			if tc.expectedError == nil {
 				require.NoError(t, err, "should pass the validation")
 			} else {
@@ -156,6 +240,267 @@ func TestValidateHTTPRoute(t *testing.T) {
 	}
 }
 
+func TestResolveHTTPRouteRequestMirrors(t *testing.T) {
+	mirrorFilter := func(name string, namespace *string) gatewayapi.HTTPRouteFilter {
+		return gatewayapi.HTTPRouteFilter{
+			Type: gatewayapi.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayapi.HTTPRequestMirrorFilter{
+				BackendRef: gatewayapi.BackendObjectReference{
+					Name:      gatewayapi.ObjectName(name),
+					Kind:      util.StringToGatewayAPIKindPtr("Service"),
+					Namespace: (*gatewayapi.Namespace)(namespace),
+					Port:      lo.ToPtr(gatewayapi.PortNumber(80)),
+				},
+			},
+		}
+	}
+	service := func(name, namespace string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	routeWithFilters := func(filters ...gatewayapi.HTTPRouteFilter) *gatewayapi.HTTPRoute {
+		route := &gatewayapi.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+			Spec: gatewayapi.HTTPRouteSpec{
+				Rules: []gatewayapi.HTTPRouteRule{{Filters: filters}},
+			},
+		}
+		route.SetGroupVersionKind(httprouteGVK)
+		return route
+	}
+
+	testCases := []struct {
+		name            string
+		route           *gatewayapi.HTTPRoute
+		storeObjects    store.FakeObjects
+		expectedFilters []gatewayapi.HTTPRouteFilter
+	}{
+		{
+			name: "route without mirror filters is not changed",
+			route: routeWithFilters(gatewayapi.HTTPRouteFilter{
+				Type: gatewayapi.HTTPRouteFilterRequestHeaderModifier,
+			}),
+			expectedFilters: []gatewayapi.HTTPRouteFilter{{
+				Type: gatewayapi.HTTPRouteFilterRequestHeaderModifier,
+			}},
+		},
+		{
+			name:  "mirror to a Service in the same namespace gets its namespace set",
+			route: routeWithFilters(mirrorFilter("mirror", nil)),
+			storeObjects: store.FakeObjects{
+				Services: []*corev1.Service{service("mirror", "default")},
+			},
+			expectedFilters: []gatewayapi.HTTPRouteFilter{mirrorFilter("mirror", lo.ToPtr("default"))},
+		},
+		{
+			name:            "mirror to a non-existent Service is dropped",
+			route:           routeWithFilters(mirrorFilter("mirror", nil)),
+			expectedFilters: []gatewayapi.HTTPRouteFilter{},
+		},
+		{
+			name:  "mirror to a Service in another namespace without ReferenceGrant is dropped",
+			route: routeWithFilters(mirrorFilter("mirror", lo.ToPtr("other"))),
+			storeObjects: store.FakeObjects{
+				Services: []*corev1.Service{service("mirror", "other")},
+			},
+			expectedFilters: []gatewayapi.HTTPRouteFilter{},
+		},
+		{
+			name:  "mirror to a Service in another namespace permitted by ReferenceGrant is kept",
+			route: routeWithFilters(mirrorFilter("mirror", lo.ToPtr("other"))),
+			storeObjects: store.FakeObjects{
+				Services: []*corev1.Service{service("mirror", "other")},
+				ReferenceGrants: []*gatewayapi.ReferenceGrant{{
+					ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "other"},
+					Spec: gatewayapi.ReferenceGrantSpec{
+						From: []gatewayapi.ReferenceGrantFrom{{
+							Group:     gatewayapi.Group(httprouteGVK.Group),
+							Kind:      gatewayapi.Kind(httprouteGVK.Kind),
+							Namespace: "default",
+						}},
+						To: []gatewayapi.ReferenceGrantTo{{
+							Kind: "Service",
+						}},
+					},
+				}},
+			},
+			expectedFilters: []gatewayapi.HTTPRouteFilter{mirrorFilter("mirror", lo.ToPtr("other"))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakestore, err := store.NewFakeStore(tc.storeObjects)
+			require.NoError(t, err)
+			p := mustNewTranslator(t, fakestore)
+
+			resolved, err := p.resolveHTTPRouteRequestMirrors(tc.route)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFilters, resolved.Spec.Rules[0].Filters)
+		})
+	}
+}
+
+func TestRoutePluginNames(t *testing.T) {
+	route := &gatewayapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"konghq.com/plugins": "mirror-lua, other:remote, global, missing",
+			},
+		},
+	}
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		KongPlugins: []*kongv1.KongPlugin{
+			{ObjectMeta: metav1.ObjectMeta{Name: "mirror-lua", Namespace: "default"}, PluginName: "pre-function"},
+			{ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "other"}, PluginName: "key-auth"},
+		},
+		KongClusterPlugins: []*kongv1.KongClusterPlugin{
+			{ObjectMeta: metav1.ObjectMeta{Name: "global"}, PluginName: "cors"},
+		},
+	})
+	require.NoError(t, err)
+	p := mustNewTranslator(t, fakestore)
+
+	require.Equal(t, []string{"pre-function", "key-auth", "cors"}, p.routePluginNames(route))
+}
+
+func TestRequestMirrorPlugin(t *testing.T) {
+	mirrorFilter := func(name, namespace string, port int) gatewayapi.HTTPRouteFilter {
+		return gatewayapi.HTTPRouteFilter{
+			Type: gatewayapi.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayapi.HTTPRequestMirrorFilter{
+				BackendRef: gatewayapi.BackendObjectReference{
+					Name:      gatewayapi.ObjectName(name),
+					Namespace: lo.ToPtr(gatewayapi.Namespace(namespace)),
+					Port:      lo.ToPtr(gatewayapi.PortNumber(port)),
+				},
+			},
+		}
+	}
+	route := &gatewayapi.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"}}
+	route.SetGroupVersionKind(httprouteGVK)
+
+	fakestore, err := store.NewFakeStore(store.FakeObjects{
+		Services: []*corev1.Service{
+			{ObjectMeta: metav1.ObjectMeta{Name: "mirror-http", Namespace: "default"}},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "mirror-https",
+					Namespace:   "other",
+					Annotations: map[string]string{"konghq.com/protocol": "https"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	p := mustNewTranslator(t, fakestore)
+
+	t.Run("no mirror filters", func(t *testing.T) {
+		plugin, err := p.requestMirrorPlugin(route, []gatewayapi.HTTPRouteFilter{
+			{Type: gatewayapi.HTTPRouteFilterRequestHeaderModifier},
+		})
+		require.NoError(t, err)
+		require.Nil(t, plugin)
+	})
+
+	t.Run("mirrors use schemes of their Services", func(t *testing.T) {
+		plugin, err := p.requestMirrorPlugin(route, []gatewayapi.HTTPRouteFilter{
+			mirrorFilter("mirror-http", "default", 80),
+			mirrorFilter("mirror-https", "other", 8443),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, plugin)
+		require.Equal(t, kong.Plugin{
+			Name: kong.String("pre-function"),
+			Config: kong.Configuration{
+				"access": []string{fmt.Sprintf(requestMirrorLuaCodeTemplate,
+					`"http://mirror-http.default.svc:80", "https://mirror-https.other.svc:8443"`,
+				)},
+			},
+			Tags: util.GenerateTagsForObject(route),
+		}, *plugin)
+	})
+
+	t.Run("mirror to a non-existent Service", func(t *testing.T) {
+		_, err := p.requestMirrorPlugin(route, []gatewayapi.HTTPRouteFilter{mirrorFilter("missing", "default", 80)})
+		require.ErrorContains(t, err, "RequestMirror filter: failed to get Service default/missing")
+	})
+
+	t.Run("mirror with unresolved namespace", func(t *testing.T) {
+		filter := mirrorFilter("mirror-http", "", 80)
+		_, err := p.requestMirrorPlugin(route, []gatewayapi.HTTPRouteFilter{filter})
+		require.EqualError(t, err, "RequestMirror filter: namespace of backendRef mirror-http is not resolved")
+	})
+}
+
+func TestApplyTimeoutsToService(t *testing.T) {
+	duration := func(d string) *gatewayapi.Duration {
+		return lo.ToPtr(gatewayapi.Duration(d))
+	}
+
+	testCases := []struct {
+		name            string
+		timeouts        *gatewayapi.HTTPRouteTimeouts
+		expectedTimeout int
+		expectedRetries int
+	}{
+		{
+			name:            "no timeouts",
+			expectedTimeout: DefaultServiceTimeout,
+			expectedRetries: DefaultRetries,
+		},
+		{
+			name:            "zero timeouts disable timeouts",
+			timeouts:        &gatewayapi.HTTPRouteTimeouts{Request: duration("0s"), BackendRequest: duration("0s")},
+			expectedTimeout: DefaultServiceTimeout,
+			expectedRetries: DefaultRetries,
+		},
+		{
+			name:            "backendRequest timeout",
+			timeouts:        &gatewayapi.HTTPRouteTimeouts{BackendRequest: duration("500ms")},
+			expectedTimeout: 500,
+			expectedRetries: DefaultRetries,
+		},
+		{
+			name:            "request timeout disables retries",
+			timeouts:        &gatewayapi.HTTPRouteTimeouts{Request: duration("2s")},
+			expectedTimeout: 2000,
+			expectedRetries: 0,
+		},
+		{
+			name:            "request timeout limits retries of backendRequest timeout",
+			timeouts:        &gatewayapi.HTTPRouteTimeouts{Request: duration("2s"), BackendRequest: duration("500ms")},
+			expectedTimeout: 500,
+			expectedRetries: 3,
+		},
+		{
+			name:            "request timeout caps backendRequest timeout",
+			timeouts:        &gatewayapi.HTTPRouteTimeouts{Request: duration("1s"), BackendRequest: duration("5s")},
+			expectedTimeout: 1000,
+			expectedRetries: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := kongstate.Service{
+				Service: kong.Service{
+					ConnectTimeout: kong.Int(DefaultServiceTimeout),
+					ReadTimeout:    kong.Int(DefaultServiceTimeout),
+					WriteTimeout:   kong.Int(DefaultServiceTimeout),
+					Retries:        kong.Int(DefaultRetries),
+				},
+			}
+			applyTimeoutsToService(&service, tc.timeouts)
+			require.Equal(t, tc.expectedTimeout, *service.ConnectTimeout)
+			require.Equal(t, tc.expectedTimeout, *service.ReadTimeout)
+			require.Equal(t, tc.expectedTimeout, *service.WriteTimeout)
+			require.Equal(t, tc.expectedRetries, *service.Retries)
+		})
+	}
+}
+
 func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 	testCases := []testCaseIngressRulesFromHTTPRoutes{
 		{
@@ -377,7 +722,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 										kong.String("~/httpbin$"),
 										kong.String("/httpbin/"),
 									},
-									PreserveHost: kong.Bool(true),
+									RegexPriority: kong.Int(8912895),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -455,7 +801,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 									Paths: []*string{
 										kong.String("~/httpbin$"),
 									},
-									PreserveHost: kong.Bool(true),
+									RegexPriority: kong.Int(9961471),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -535,7 +882,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 									Paths: []*string{
 										kong.String("~/httpbin$"),
 									},
-									PreserveHost: kong.Bool(true),
+									RegexPriority: kong.Int(1082654719),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -625,7 +973,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/httpbin-2$"),
 											kong.String("/httpbin-2/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(11010047),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -719,7 +1068,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 										kong.String("~/httpbin-1$"),
 										kong.String("/httpbin-1/"),
 									},
-									PreserveHost: kong.Bool(true),
+									RegexPriority: kong.Int(11010047),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -759,7 +1109,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 										kong.String("~/httpbin-2$"),
 										kong.String("/httpbin-2/"),
 									},
-									PreserveHost: kong.Bool(true),
+									RegexPriority: kong.Int(11010046),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -879,7 +1230,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/httpbin-2$"),
 											kong.String("/httpbin-2/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(11010047),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -922,7 +1274,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/httpbin-2$"),
 											kong.String("/httpbin-2/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(11010045),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1036,7 +1389,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-0$"),
 											kong.String("/path-0/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(7864319),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1069,7 +1423,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-1$"),
 											kong.String("/path-1/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(7864318),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1191,7 +1546,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-1$"),
 											kong.String("/path-1/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(7864319),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1217,7 +1573,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-3$"),
 											kong.String("/path-3/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(8388607),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1244,7 +1601,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-5$"),
 											kong.String("/path-5/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(7864319),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1392,7 +1750,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-5$"),
 											kong.String("/path-5/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(7864319),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1418,7 +1777,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-3$"),
 											kong.String("/path-3/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(8388607),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1446,7 +1806,8 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 											kong.String("~/path-7$"),
 											kong.String("/path-7/"),
 										},
-										PreserveHost: kong.Bool(true),
+										RegexPriority: kong.Int(7864317),
+										PreserveHost:  kong.Bool(true),
 										Protocols: []*string{
 											kong.String("http"),
 											kong.String("https"),
@@ -1581,7 +1942,7 @@ func TestIngressRulesFromHTTPRoutes(t *testing.T) {
 				httproute.SetGroupVersionKind(httprouteGVK)
 
 				// generate the ingress rules
-				err := p.ingressRulesFromHTTPRoute(&ingressRules, httproute)
+				err := p.ingressRulesFromHTTPRoute(&ingressRules, httproute, 0)
 				if err != nil {
 					errs = append(errs, err)
 				}
@@ -1711,7 +2072,8 @@ func TestIngressRulesFromHTTPRoutes_RegexPrefix(t *testing.T) {
 									Paths: []*string{
 										kong.String("~/httpbin$"),
 									},
-									PreserveHost: kong.Bool(true),
+									RegexPriority: kong.Int(9961471),
+									PreserveHost:  kong.Bool(true),
 									Protocols: []*string{
 										kong.String("http"),
 										kong.String("https"),
@@ -1744,7 +2106,7 @@ func TestIngressRulesFromHTTPRoutes_RegexPrefix(t *testing.T) {
 					httproute.SetGroupVersionKind(httprouteGVK)
 
 					// generate the ingress rules
-					err := tran.ingressRulesFromHTTPRoute(&ingressRules, httproute)
+					err := tran.ingressRulesFromHTTPRoute(&ingressRules, httproute, 0)
 					if err != nil {
 						errs = append(errs, err)
 					}
@@ -6,10 +6,12 @@ import (
 	"sort"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/atc"
@@ -17,6 +19,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/featuregates"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v3/pkg/apis/configuration/v1alpha1"
 )
 
 func (t *Translator) ingressRulesFromIngressV1() ingressRules {
@@ -40,24 +43,15 @@ func (t *Translator) ingressRulesFromIngressV1() ingressRules {
 	var allDefaultBackends []netv1.Ingress
 	for _, ingress := range ingressList {
 		ingressSpec := ingress.Spec
-		if ingressSpec.DefaultBackend != nil {
+		// Canary Ingresses only affect paths of their primary Ingresses.
+		if ingressSpec.DefaultBackend != nil && !annotations.ExtractCanary(ingress.Annotations) {
 			allDefaultBackends = append(allDefaultBackends, *ingress)
 		}
 		result.SecretNameToSNIs.addFromIngressV1TLS(ingressSpec.TLS, ingress)
 	}
 
 	// Translate Ingress objects into Kong Services.
-	servicesCache := subtranslator.TranslateIngresses(
-		ingressList,
-		icp,
-		subtranslator.TranslateIngressFeatureFlags{
-			ExpressionRoutes:  t.featureFlags.ExpressionRoutes,
-			KongServiceFacade: t.featureFlags.KongServiceFacade,
-		},
-		t.translatedObjectsCollector,
-		t.failuresCollector,
-		t.storer,
-	)
+	servicesCache := t.translateIngresses(ingressList, icp)
 	for i := range servicesCache {
 		service := servicesCache[i]
 		if err := subtranslator.MaybeRewriteURI(&service, t.featureFlags.RewriteURIs); err != nil {
@@ -84,6 +78,36 @@ func (t *Translator) ingressRulesFromIngressV1() ingressRules {
 	return result
 }
 
+// translateIngresses translates Ingresses into Kong Services. With incremental translation enabled, only Ingresses
+// affected by changes since the previous translation are translated, unless there are canary Ingresses, which
+// are merged into other Ingresses.
+func (t *Translator) translateIngresses(
+	ingressList []*netv1.Ingress,
+	icp kongv1alpha1.IngressClassParametersSpec,
+) map[string]kongstate.Service {
+	flags := subtranslator.TranslateIngressFeatureFlags{
+		ExpressionRoutes:  t.featureFlags.ExpressionRoutes,
+		KongServiceFacade: t.featureFlags.KongServiceFacade,
+	}
+
+	hasCanaries := lo.ContainsBy(ingressList, func(ingress *netv1.Ingress) bool {
+		return annotations.ExtractCanary(ingress.Annotations)
+	})
+	if t.ingressIncrementalTranslation == nil || hasCanaries {
+		t.ingressIncrementalTranslation.ForgetIngresses()
+		return subtranslator.TranslateIngresses(ingressList, icp, flags, t.translatedObjectsCollector, t.failuresCollector, t.storer)
+	}
+
+	return t.ingressIncrementalTranslation.TranslateIngresses(
+		ingressList,
+		func(ingress *netv1.Ingress, recorder *translationRecorder) map[string]kongstate.Service {
+			return subtranslator.TranslateIngresses([]*netv1.Ingress{ingress}, icp, flags, recorder, recorder, t.storer)
+		},
+		t.failuresCollector,
+		t.translatedObjectsCollector,
+	)
+}
+
 // getDefaultBackendService picks the oldest Ingress with a DefaultBackend defined and returns a Kong Service for it.
 func getDefaultBackendService(
 	storer store.Storer,
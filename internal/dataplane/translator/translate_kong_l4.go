@@ -33,22 +33,7 @@ func (t *Translator) ingressRulesFromTCPIngressV1beta1() ingressRules {
 
 		var objectSuccessfullyTranslated bool
 		for i, rule := range ingress.Spec.Rules {
-			r := kongstate.Route{
-				Ingress: util.FromK8sObject(ingress),
-				Route: kong.Route{
-					Name:      kong.String(ingress.Namespace + "." + ingress.Name + "." + strconv.Itoa(i)),
-					Protocols: kong.StringSlice("tcp", "tls"),
-					Destinations: []*kong.CIDRPort{
-						{
-							Port: kong.Int(rule.Port),
-						},
-					},
-					Tags: util.GenerateTagsForObject(ingress),
-				},
-			}
-			if host := rule.Host; host != "" {
-				r.SNIs = kong.StringSlice(host)
-			}
+			r := tcpIngressRuleToKongRoute(ingress, i, rule)
 
 			serviceBackend, err := kongstate.NewServiceBackendForService(
 				k8stypes.NamespacedName{
@@ -121,15 +106,7 @@ func (t *Translator) ingressRulesFromUDPIngressV1beta1() ingressRules {
 		var objectSuccessfullyTranslated bool
 		for i, rule := range ingress.Spec.Rules {
 			// generate the kong Route based on the listen port
-			route := kongstate.Route{
-				Ingress: util.FromK8sObject(ingress),
-				Route: kong.Route{
-					Name:         kong.String(ingress.Namespace + "." + ingress.Name + "." + strconv.Itoa(i) + ".udp"),
-					Protocols:    kong.StringSlice("udp"),
-					Destinations: []*kong.CIDRPort{{Port: kong.Int(rule.Port)}},
-					Tags:         util.GenerateTagsForObject(ingress),
-				},
-			}
+			route := udpIngressRuleToKongRoute(ingress, i, rule)
 
 			serviceBackend, err := kongstate.NewServiceBackendForService(
 				k8stypes.NamespacedName{
@@ -182,6 +159,68 @@ func (t *Translator) ingressRulesFromUDPIngressV1beta1() ingressRules {
 	return result
 }
 
+// GenerateKongRoutesFromTCPIngress generates Kong routes from all rules of the TCPIngress.
+// It is used for both traditional and expression based routes.
+func GenerateKongRoutesFromTCPIngress(ingress *kongv1beta1.TCPIngress, expressionRoutes bool) []kongstate.Route {
+	routes := make([]kongstate.Route, 0, len(ingress.Spec.Rules))
+	for i, rule := range ingress.Spec.Rules {
+		routes = append(routes, tcpIngressRuleToKongRoute(ingress, i, rule))
+	}
+	if expressionRoutes {
+		for i := range routes {
+			applyExpressionToL4Route(&routes[i])
+		}
+	}
+	return routes
+}
+
+// GenerateKongRoutesFromUDPIngress generates Kong routes from all rules of the UDPIngress.
+// It is used for both traditional and expression based routes.
+func GenerateKongRoutesFromUDPIngress(ingress *kongv1beta1.UDPIngress, expressionRoutes bool) []kongstate.Route {
+	routes := make([]kongstate.Route, 0, len(ingress.Spec.Rules))
+	for i, rule := range ingress.Spec.Rules {
+		routes = append(routes, udpIngressRuleToKongRoute(ingress, i, rule))
+	}
+	if expressionRoutes {
+		for i := range routes {
+			applyExpressionToL4Route(&routes[i])
+		}
+	}
+	return routes
+}
+
+func tcpIngressRuleToKongRoute(ingress *kongv1beta1.TCPIngress, ruleNumber int, rule kongv1beta1.IngressRule) kongstate.Route {
+	r := kongstate.Route{
+		Ingress: util.FromK8sObject(ingress),
+		Route: kong.Route{
+			Name:      kong.String(ingress.Namespace + "." + ingress.Name + "." + strconv.Itoa(ruleNumber)),
+			Protocols: kong.StringSlice("tcp", "tls"),
+			Destinations: []*kong.CIDRPort{
+				{
+					Port: kong.Int(rule.Port),
+				},
+			},
+			Tags: util.GenerateTagsForObject(ingress),
+		},
+	}
+	if host := rule.Host; host != "" {
+		r.SNIs = kong.StringSlice(host)
+	}
+	return r
+}
+
+func udpIngressRuleToKongRoute(ingress *kongv1beta1.UDPIngress, ruleNumber int, rule kongv1beta1.UDPIngressRule) kongstate.Route {
+	return kongstate.Route{
+		Ingress: util.FromK8sObject(ingress),
+		Route: kong.Route{
+			Name:         kong.String(ingress.Namespace + "." + ingress.Name + "." + strconv.Itoa(ruleNumber) + ".udp"),
+			Protocols:    kong.StringSlice("udp"),
+			Destinations: []*kong.CIDRPort{{Port: kong.Int(rule.Port)}},
+			Tags:         util.GenerateTagsForObject(ingress),
+		},
+	}
+}
+
 func tcpIngressToNetworkingTLS(tls []kongv1beta1.IngressTLS) []netv1.IngressTLS {
 	var result []netv1.IngressTLS
 
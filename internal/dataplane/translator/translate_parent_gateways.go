@@ -0,0 +1,146 @@
+package translator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+)
+
+// fillRoutesParentGateways sets ParentGateways of all the Routes in the given KongState that were translated from
+// Gateway API routes to the Gateways that accepted them. It allows splitting the configuration per Gateway when each of them has its own data-plane.
+func (t *Translator) fillRoutesParentGateways(state *kongstate.KongState) {
+	parentGateways := t.routesParentGateways()
+	if len(parentGateways) == 0 {
+		return
+	}
+
+	for i := range state.Services {
+		for j := range state.Services[i].Routes {
+			route := &state.Services[i].Routes[j]
+			key := routeParentGatewaysKey(route.Ingress.GroupVersionKind.Kind, route.Ingress.Namespace, route.Ingress.Name)
+			route.ParentGateways = parentGateways[key]
+		}
+	}
+}
+
+// fillDedicatedGateways sets DedicatedGateways of the given KongState to Gateways which have data-planes of their
+// own, i.e. managed Gateways and unmanaged Gateways annotated with konghq.com/admin-service. They're recognized by
+// the finalizer the Gateway controller sets on them before registering their data-planes' Admin API endpoints.
+func (t *Translator) fillDedicatedGateways(state *kongstate.KongState) {
+	if !t.featureFlags.ManagedGateways {
+		return
+	}
+	gateways, err := t.storer.ListGateways()
+	if err != nil {
+		t.logger.Error(err, "Failed to list Gateways")
+		return
+	}
+	for _, gateway := range gateways {
+		if controllerutil.ContainsFinalizer(gateway, gatewayapi.ManagedGatewayFinalizer) {
+			state.DedicatedGateways = append(state.DedicatedGateways, client.ObjectKeyFromObject(gateway))
+		}
+	}
+	slices.SortFunc(state.DedicatedGateways, func(a, b k8stypes.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+}
+
+// routesParentGateways returns Gateways that accepted Gateway API routes indexed by the routes' keys built with
+// routeParentGatewaysKey. Gateways referenced by parentRefs that haven't accepted a route (e.g. because of
+// listeners' allowedRoutes or hostnames) are not included, so the route isn't configured in their data-planes.
+func (t *Translator) routesParentGateways() map[string][]k8stypes.NamespacedName {
+	result := make(map[string][]k8stypes.NamespacedName)
+	collect := func(kind string, route client.Object, parentRefs []gatewayapi.ParentReference, parents []gatewayapi.RouteParentStatus) {
+		var gateways []k8stypes.NamespacedName
+		for _, parentRef := range parentRefs {
+			if !parentRefIsGateway(parentRef) {
+				continue
+			}
+			gateway := parentRefGateway(route.GetNamespace(), parentRef)
+			if !slices.Contains(gateways, gateway) && routeAcceptedByGateway(route.GetNamespace(), gateway, parents) {
+				gateways = append(gateways, gateway)
+			}
+		}
+		if len(gateways) > 0 {
+			result[routeParentGatewaysKey(kind, route.GetNamespace(), route.GetName())] = gateways
+		}
+	}
+
+	if httpRoutes, err := t.storer.ListHTTPRoutes(); err != nil {
+		t.logger.Error(err, "Failed to list HTTPRoutes")
+	} else {
+		for _, r := range httpRoutes {
+			collect("HTTPRoute", r, r.Spec.ParentRefs, r.Status.Parents)
+		}
+	}
+	if grpcRoutes, err := t.storer.ListGRPCRoutes(); err != nil {
+		t.logger.Error(err, "Failed to list GRPCRoutes")
+	} else {
+		for _, r := range grpcRoutes {
+			collect("GRPCRoute", r, r.Spec.ParentRefs, r.Status.Parents)
+		}
+	}
+	if tcpRoutes, err := t.storer.ListTCPRoutes(); err != nil {
+		t.logger.Error(err, "Failed to list TCPRoutes")
+	} else {
+		for _, r := range tcpRoutes {
+			collect("TCPRoute", r, r.Spec.ParentRefs, r.Status.Parents)
+		}
+	}
+	if udpRoutes, err := t.storer.ListUDPRoutes(); err != nil {
+		t.logger.Error(err, "Failed to list UDPRoutes")
+	} else {
+		for _, r := range udpRoutes {
+			collect("UDPRoute", r, r.Spec.ParentRefs, r.Status.Parents)
+		}
+	}
+	if tlsRoutes, err := t.storer.ListTLSRoutes(); err != nil {
+		t.logger.Error(err, "Failed to list TLSRoutes")
+	} else {
+		for _, r := range tlsRoutes {
+			collect("TLSRoute", r, r.Spec.ParentRefs, r.Status.Parents)
+		}
+	}
+
+	return result
+}
+
+// parentRefGateway returns the namespaced name of the Gateway referenced by the parentRef of a route in the given
+// namespace.
+func parentRefGateway(routeNamespace string, parentRef gatewayapi.ParentReference) k8stypes.NamespacedName {
+	namespace := routeNamespace
+	if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+		namespace = string(*parentRef.Namespace)
+	}
+	return k8stypes.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)}
+}
+
+// routeAcceptedByGateway returns true if a route in the given namespace has the Accepted condition set to True
+// in its status for the given Gateway.
+func routeAcceptedByGateway(routeNamespace string, gateway k8stypes.NamespacedName, parents []gatewayapi.RouteParentStatus) bool {
+	return lo.ContainsBy(parents, func(parent gatewayapi.RouteParentStatus) bool {
+		return parentRefIsGateway(parent.ParentRef) &&
+			parentRefGateway(routeNamespace, parent.ParentRef) == gateway &&
+			lo.ContainsBy(parent.Conditions, func(c metav1.Condition) bool {
+				return c.Type == string(gatewayapi.RouteConditionAccepted) && c.Status == metav1.ConditionTrue
+			})
+	})
+}
+
+func routeParentGatewaysKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// parentRefIsGateway returns true if the group/kind of ParentReference is empty or gateway.networking.k8s.io/Gateway.
+func parentRefIsGateway(parentRef gatewayapi.ParentReference) bool {
+	return (parentRef.Group == nil || *parentRef.Group == "" || *parentRef.Group == gatewayapi.V1Group) &&
+		(parentRef.Kind == nil || *parentRef.Kind == "" || *parentRef.Kind == KindGateway)
+}
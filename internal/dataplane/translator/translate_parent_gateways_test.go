@@ -0,0 +1,118 @@
+package translator
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
+)
+
+func TestFillRoutesParentGateways(t *testing.T) {
+	httpRoute := &gatewayapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route"},
+		Spec: gatewayapi.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi.CommonRouteSpec{
+				ParentRefs: []gatewayapi.ParentReference{
+					{Name: "same-namespace"},
+					{Name: "other-namespace", Namespace: lo.ToPtr(gatewayapi.Namespace("other"))},
+					{Name: "not-a-gateway", Kind: lo.ToPtr(gatewayapi.Kind("Service")), Group: lo.ToPtr(gatewayapi.Group(""))},
+					{Name: "not-accepting"},
+					{Name: "no-status"},
+				},
+			},
+		},
+		Status: gatewayapi.HTTPRouteStatus{
+			RouteStatus: gatewayapi.RouteStatus{
+				Parents: []gatewayapi.RouteParentStatus{
+					routeParentStatus(gatewayapi.ParentReference{Name: "same-namespace"}, metav1.ConditionTrue),
+					routeParentStatus(gatewayapi.ParentReference{Name: "other-namespace", Namespace: lo.ToPtr(gatewayapi.Namespace("other"))}, metav1.ConditionTrue),
+					routeParentStatus(gatewayapi.ParentReference{Name: "not-a-gateway", Kind: lo.ToPtr(gatewayapi.Kind("Service")), Group: lo.ToPtr(gatewayapi.Group(""))}, metav1.ConditionTrue),
+					routeParentStatus(gatewayapi.ParentReference{Name: "not-accepting"}, metav1.ConditionFalse),
+				},
+			},
+		},
+	}
+	fakeStore, err := store.NewFakeStore(store.FakeObjects{HTTPRoutes: []*gatewayapi.HTTPRoute{httpRoute}})
+	require.NoError(t, err)
+	translator := mustNewTranslator(t, fakeStore)
+
+	state := &kongstate.KongState{
+		Services: []kongstate.Service{
+			{
+				Routes: []kongstate.Route{
+					{
+						Route: kong.Route{Name: kong.String("httproute")},
+						Ingress: util.K8sObjectInfo{
+							Namespace:        "default",
+							Name:             "route",
+							GroupVersionKind: schema.GroupVersionKind{Group: string(gatewayapi.V1Group), Version: "v1", Kind: "HTTPRoute"},
+						},
+					},
+					{
+						Route: kong.Route{Name: kong.String("ingress")},
+						Ingress: util.K8sObjectInfo{
+							Namespace:        "default",
+							Name:             "route",
+							GroupVersionKind: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+						},
+					},
+				},
+			},
+		},
+	}
+	translator.fillRoutesParentGateways(state)
+
+	assert.Equal(t, []k8stypes.NamespacedName{
+		{Namespace: "default", Name: "same-namespace"},
+		{Namespace: "other", Name: "other-namespace"},
+	}, state.Services[0].Routes[0].ParentGateways)
+	assert.Empty(t, state.Services[0].Routes[1].ParentGateways, "Ingress routes should not be attached to any Gateway")
+}
+
+func routeParentStatus(parentRef gatewayapi.ParentReference, accepted metav1.ConditionStatus) gatewayapi.RouteParentStatus {
+	return gatewayapi.RouteParentStatus{
+		ParentRef: parentRef,
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayapi.RouteConditionAccepted), Status: accepted},
+		},
+	}
+}
+
+func TestFillDedicatedGateways(t *testing.T) {
+	fakeStore, err := store.NewFakeStore(store.FakeObjects{
+		Gateways: []*gatewayapi.Gateway{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unmanaged"}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "managed", Finalizers: []string{gatewayapi.ManagedGatewayFinalizer}}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "managed", Finalizers: []string{gatewayapi.ManagedGatewayFinalizer}}},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("managed gateways disabled", func(t *testing.T) {
+		translator := mustNewTranslator(t, fakeStore)
+		state := &kongstate.KongState{}
+		translator.fillDedicatedGateways(state)
+		assert.Empty(t, state.DedicatedGateways)
+	})
+
+	t.Run("managed gateways enabled", func(t *testing.T) {
+		translator := mustNewTranslator(t, fakeStore)
+		translator.featureFlags.ManagedGateways = true
+		state := &kongstate.KongState{}
+		translator.fillDedicatedGateways(state)
+		assert.Equal(t, []k8stypes.NamespacedName{
+			{Namespace: "default", Name: "managed"},
+			{Namespace: "other", Name: "managed"},
+		}, state.DedicatedGateways)
+	})
+}
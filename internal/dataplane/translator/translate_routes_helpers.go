@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 )
@@ -73,6 +74,33 @@ func generateKongRoutesFromRouteRule[T tRoute, TRule tRouteRule](
 	}, nil
 }
 
+// generateKongRoutesFromRouteRules converts all rules of a Gateway Route (TCP, UDP or TLS) to Kong Route objects
+// matching the provided Gateway listening ports. It's used for both traditional and expression based routes.
+func generateKongRoutesFromRouteRules[T tRoute, TRule tRouteRule](
+	route T,
+	gwPorts []gatewayapi.PortNumber,
+	rules []TRule,
+	expressionRoutes bool,
+) ([]kongstate.Route, error) {
+	if len(rules) == 0 {
+		return nil, subtranslator.ErrRouteValidationNoRules
+	}
+	var routes []kongstate.Route
+	for ruleNumber, rule := range rules {
+		ruleRoutes, err := generateKongRoutesFromRouteRule(route, gwPorts, ruleNumber, rule)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, ruleRoutes...)
+	}
+	if expressionRoutes {
+		for i := range routes {
+			applyExpressionToL4Route(&routes[i])
+		}
+	}
+	return routes, nil
+}
+
 // routeToKongRoute converts Gateway Route to kong.Route.
 func routeToKongRoute[TRoute tTCPorUDPorTLSRoute](
 	r TRoute,
@@ -93,7 +121,7 @@ func routeToKongRoute[TRoute tTCPorUDPorTLSRoute](
 	case *gatewayapi.TCPRoute:
 		kr = tcpRouteToKongRoute(rr, destinations, ruleNumber)
 	case *gatewayapi.TLSRoute:
-		kr = tlsRouteToKongRoute(rr, ruleNumber)
+		kr = tlsRouteToKongRoute(rr, destinations, ruleNumber)
 	default:
 		kr = kong.Route{}
 	}
@@ -130,18 +158,27 @@ func tcpRouteToKongRoute(
 	}
 }
 
-func tlsRouteToKongRoute(r *gatewayapi.TLSRoute, ruleNumber int) kong.Route {
+func tlsRouteToKongRoute(
+	r *gatewayapi.TLSRoute,
+	destinations []*kong.CIDRPort,
+	ruleNumber int,
+) kong.Route {
 	hostnames := make([]*string, 0, len(r.Spec.Hostnames))
 	for _, hostname := range r.Spec.Hostnames {
 		hostnames = append(hostnames, kong.String(string(hostname)))
 	}
 
-	return kong.Route{
+	kr := kong.Route{
 		Name: kong.String(
 			generateRouteName(tlsRouteType, r.Namespace, r.Name, ruleNumber)),
 		Protocols: kong.StringSlice("tls"),
 		SNIs:      hostnames,
 	}
+	// TLSRoutes are matched by SNIs, destinations are only needed to tell apart listeners they're attached to.
+	if len(destinations) > 0 {
+		kr.Destinations = destinations
+	}
+	return kr
 }
 
 type routeType string
@@ -187,7 +224,9 @@ func (t *Translator) getGatewayListeningPorts(
 		// "When unspecified (empty string), this will reference the entire resource." - see
 		// https://github.com/kubernetes-sigs/gateway-api/blob/ebe9f31ef27819c3b29f698a3e9b91d279453c59/apis/v1/shared_types.go#L107).
 		gwPorts = append(gwPorts, lo.FilterMap(gw.Spec.Listeners, func(l gatewayapi.Listener, _ int) (gatewayapi.PortNumber, bool) {
-			if (pr.SectionName == nil || *pr.SectionName == l.Name) && protocol == l.Protocol {
+			if (pr.SectionName == nil || *pr.SectionName == l.Name) &&
+				(pr.Port == nil || *pr.Port == l.Port) &&
+				protocol == l.Protocol {
 				return l.Port, true
 			}
 			return 0, false
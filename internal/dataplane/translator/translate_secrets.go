@@ -54,26 +54,34 @@ func toKongCACertificate(certSecret *corev1.Secret, secretID string) (kong.CACer
 	if !certExists {
 		return kong.CACertificate{}, errors.New("missing 'cert' field in data")
 	}
-	pemBlock, _ := pem.Decode(caCertbytes)
+	if err := validateCACertificate(caCertbytes); err != nil {
+		return kong.CACertificate{}, err
+	}
+
+	return kong.CACertificate{
+		ID:   kong.String(secretID),
+		Cert: kong.String(string(caCertbytes)),
+		Tags: util.GenerateTagsForObject(certSecret),
+	}, nil
+}
+
+// validateCACertificate ensures the given PEM-encoded certificate can be used as a CA certificate in Kong.
+func validateCACertificate(caCertBytes []byte) error {
+	pemBlock, _ := pem.Decode(caCertBytes)
 	if pemBlock == nil {
-		return kong.CACertificate{}, errors.New("invalid PEM block")
+		return errors.New("invalid PEM block")
 	}
 	x509Cert, err := x509.ParseCertificate(pemBlock.Bytes)
 	if err != nil {
-		return kong.CACertificate{}, errors.New("failed to parse certificate")
+		return errors.New("failed to parse certificate")
 	}
 	if !x509Cert.IsCA {
-		return kong.CACertificate{}, errors.New("certificate is missing the 'CA' basic constraint")
+		return errors.New("certificate is missing the 'CA' basic constraint")
 	}
 	if time.Now().After(x509Cert.NotAfter) {
-		return kong.CACertificate{}, errors.New("expired")
+		return errors.New("expired")
 	}
-
-	return kong.CACertificate{
-		ID:   kong.String(secretID),
-		Cert: kong.String(string(caCertbytes)),
-		Tags: util.GenerateTagsForObject(certSecret),
-	}, nil
+	return nil
 }
 
 func getPluginsAssociatedWithCACertSecret(secretID string, storer store.Storer) []client.Object {
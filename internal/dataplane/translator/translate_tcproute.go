@@ -3,6 +3,7 @@ package translator
 import (
 	"fmt"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 )
@@ -77,3 +78,13 @@ func (t *Translator) ingressRulesFromTCPRoute(result *ingressRules, tcproute *ga
 
 	return nil
 }
+
+// GenerateKongRoutesFromTCPRoute generates Kong routes from all rules of the TCPRoute, matching the provided
+// ports of Gateway listeners the route is attached to. It is used for both traditional and expression based routes.
+func GenerateKongRoutesFromTCPRoute(
+	tcproute *gatewayapi.TCPRoute,
+	gwPorts []gatewayapi.PortNumber,
+	expressionRoutes bool,
+) ([]kongstate.Route, error) {
+	return generateKongRoutesFromRouteRules(tcproute, gwPorts, tcproute.Spec.Rules, expressionRoutes)
+}
@@ -3,10 +3,13 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
@@ -60,25 +63,42 @@ func (t *Translator) ingressRulesFromTLSRoute(result *ingressRules, tlsroute *ga
 		return subtranslator.ErrRouteValidationNoRules
 	}
 
-	tlsPassthrough, err := t.isTLSRoutePassthrough(tlsroute)
+	listenerPortsByMode, err := t.getTLSRouteListenerPortsByMode(tlsroute)
 	if err != nil {
 		return err
 	}
+	// When the route isn't attached to any known listener, fall back to terminating TLS without matching
+	// destination ports.
+	modes := lo.Filter([]gatewayapi.TLSModeType{gatewayapi.TLSModePassthrough, gatewayapi.TLSModeTerminate},
+		func(mode gatewayapi.TLSModeType, _ int) bool {
+			_, ok := listenerPortsByMode[mode]
+			return ok
+		},
+	)
+	if len(modes) == 0 {
+		modes = []gatewayapi.TLSModeType{gatewayapi.TLSModeTerminate}
+	}
 
 	// Each rule may represent a different set of backend services that will be accepting
 	// traffic, so we make separate routes and Kong services for every present rule.
 	for ruleNumber, rule := range spec.Rules {
-		// Determine the routes needed to route traffic to services for this rule.
-		// TLSRoute matches based on hostname with Gateway listener thus passing gwPorts is pointless.
-		routes, err := generateKongRoutesFromRouteRule(tlsroute, nil, ruleNumber, rule)
-		// Change protocols in route to tls_passthrough.
-		if tlsPassthrough {
-			for i := range routes {
-				routes[i].Protocols = kong.StringSlice("tls_passthrough")
+		// Determine the routes needed to route traffic to services for this rule. A separate route is
+		// generated for each TLS mode of the listeners the TLSRoute is attached to, matching their ports.
+		var routes []kongstate.Route
+		for _, mode := range modes {
+			modeRoutes, err := generateKongRoutesFromRouteRule(tlsroute, listenerPortsByMode[mode], ruleNumber, rule)
+			if err != nil {
+				return err
 			}
-		}
-		if err != nil {
-			return err
+			for i := range modeRoutes {
+				if mode == gatewayapi.TLSModePassthrough {
+					modeRoutes[i].Protocols = kong.StringSlice("tls_passthrough")
+				}
+				if len(modes) > 1 {
+					modeRoutes[i].Name = kong.String(*modeRoutes[i].Name + "." + strings.ToLower(string(mode)))
+				}
+			}
+			routes = append(routes, modeRoutes...)
 		}
 
 		// create a service and attach the routes to it
@@ -96,16 +116,28 @@ func (t *Translator) ingressRulesFromTLSRoute(result *ingressRules, tlsroute *ga
 	return nil
 }
 
-// isTLSRoutePassthrough returns true if we need to configure TLS passthrough to kong
-// for the tlsroute object.
-// returns a non-nil error if we failed to get the supported gateway.
-func (t *Translator) isTLSRoutePassthrough(tlsroute *gatewayapi.TLSRoute) (bool, error) {
-	// reconcile loop will push TLSRoute object with updated status when
-	// gateway is ready and TLSRoute object becomes stable.
-	// so we get the supported gateways from status.parents.
-	for _, parentStatus := range tlsroute.Status.Parents {
-		parentRef := parentStatus.ParentRef
+// GenerateKongRoutesFromTLSRoute generates Kong routes from all rules of the TLSRoute, matching the provided
+// ports of Gateway listeners the route is attached to. Routes are generated as if the listeners terminated TLS.
+// It is used for both traditional and expression based routes.
+func GenerateKongRoutesFromTLSRoute(
+	tlsroute *gatewayapi.TLSRoute,
+	gwPorts []gatewayapi.PortNumber,
+	expressionRoutes bool,
+) ([]kongstate.Route, error) {
+	if len(tlsroute.Spec.Hostnames) == 0 {
+		return nil, fmt.Errorf("no hostnames provided")
+	}
+	return generateKongRoutesFromRouteRules(tlsroute, gwPorts, tlsroute.Spec.Rules, expressionRoutes)
+}
 
+// getTLSRouteListenerPortsByMode returns ports of the TLS listeners the TLSRoute is attached to,
+// grouped by the listeners' TLS mode (Terminate when not specified).
+// returns a non-nil error if we failed to get the supported gateway.
+func (t *Translator) getTLSRouteListenerPortsByMode(
+	tlsroute *gatewayapi.TLSRoute,
+) (map[gatewayapi.TLSModeType][]gatewayapi.PortNumber, error) {
+	portsByMode := make(map[gatewayapi.TLSModeType][]gatewayapi.PortNumber)
+	for _, parentRef := range tlsroute.Spec.ParentRefs {
 		if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1.GroupName {
 			continue
 		}
@@ -130,20 +162,24 @@ func (t *Translator) isTLSRoutePassthrough(tlsroute *gatewayapi.TLSRoute) (bool,
 					"tlsroute_name", tlsroute.Name)
 				continue
 			}
-			return false, err
+			return nil, err
 		}
 
-		// If any of the gateway's listeners is configured to passthrough
-		// TLS requests, we return true.
 		for _, listener := range gateway.Spec.Listeners {
-			if parentRef.SectionName == nil || listener.Name == *parentRef.SectionName {
-				if listener.TLS != nil && listener.TLS.Mode != nil &&
-					*listener.TLS.Mode == gatewayapi.TLSModePassthrough {
-					return true, nil
-				}
+			if listener.Protocol != gatewayapi.TLSProtocolType ||
+				(parentRef.SectionName != nil && listener.Name != *parentRef.SectionName) ||
+				(parentRef.Port != nil && listener.Port != *parentRef.Port) {
+				continue
+			}
+			mode := gatewayapi.TLSModeTerminate
+			if listener.TLS != nil && listener.TLS.Mode != nil {
+				mode = *listener.TLS.Mode
+			}
+			if !lo.Contains(portsByMode[mode], listener.Port) {
+				portsByMode[mode] = append(portsByMode[mode], listener.Port)
 			}
 		}
 	}
 
-	return false, nil
+	return portsByMode, nil
 }
@@ -257,3 +257,127 @@ func TestIngressRulesFromTLSRoutesUsingExpressionRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressRulesFromTLSRoutesWithListenerTLSModes(t *testing.T) {
+	gateway := &gatewayapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "gateway",
+		},
+		Spec: gatewayapi.GatewaySpec{
+			Listeners: []gatewayapi.Listener{
+				builder.NewListener("passthrough").TLS().WithPort(8443).
+					WithTLSConfig(&gatewayapi.GatewayTLSConfig{Mode: lo.ToPtr(gatewayapi.TLSModePassthrough)}).Build(),
+				builder.NewListener("terminate").TLS().WithPort(9443).
+					WithTLSConfig(&gatewayapi.GatewayTLSConfig{Mode: lo.ToPtr(gatewayapi.TLSModeTerminate)}).Build(),
+				builder.NewListener("http").HTTP().WithPort(80).Build(),
+			},
+		},
+	}
+	newTLSRoute := func(hostnames []gatewayapi.Hostname, parentRefs ...gatewayapi.ParentReference) *gatewayapi.TLSRoute {
+		return &gatewayapi.TLSRoute{
+			TypeMeta: gatewayapi.TLSRouteTypeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "tlsroute",
+			},
+			Spec: gatewayapi.TLSRouteSpec{
+				CommonRouteSpec: gatewayapi.CommonRouteSpec{ParentRefs: parentRefs},
+				Hostnames:       hostnames,
+				Rules: []gatewayapi.TLSRouteRule{
+					{
+						BackendRefs: []gatewayapi.BackendRef{
+							builder.NewBackendRef("service").WithPort(443).Build(),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		tlsRoute           *gatewayapi.TLSRoute
+		expressionRoutes   bool
+		expectedKongRoutes []kong.Route
+	}{
+		{
+			name: "attached to passthrough listener",
+			tlsRoute: newTLSRoute([]gatewayapi.Hostname{"foo.com"},
+				gatewayapi.ParentReference{Name: "gateway", SectionName: lo.ToPtr(gatewayapi.SectionName("passthrough"))},
+			),
+			expectedKongRoutes: []kong.Route{
+				{
+					Name:         kong.String("tlsroute.default.tlsroute.0.0"),
+					Protocols:    kong.StringSlice("tls_passthrough"),
+					SNIs:         kong.StringSlice("foo.com"),
+					Destinations: []*kong.CIDRPort{{Port: kong.Int(8443)}},
+				},
+			},
+		},
+		{
+			name: "attached to terminate listener with expression routes",
+			tlsRoute: newTLSRoute([]gatewayapi.Hostname{"foo.com"},
+				gatewayapi.ParentReference{Name: "gateway", SectionName: lo.ToPtr(gatewayapi.SectionName("terminate"))},
+			),
+			expressionRoutes: true,
+			expectedKongRoutes: []kong.Route{
+				{
+					Name:       kong.String("tlsroute.default.tlsroute.0.0"),
+					Protocols:  kong.StringSlice("tls"),
+					Expression: kong.String(`(tls.sni == "foo.com") && (net.dst.port == 9443)`),
+					Priority:   kong.Uint64(2),
+				},
+			},
+		},
+		{
+			name:             "attached to listeners with both modes and a wildcard hostname with expression routes",
+			tlsRoute:         newTLSRoute([]gatewayapi.Hostname{"*.foo.com"}, gatewayapi.ParentReference{Name: "gateway"}),
+			expressionRoutes: true,
+			expectedKongRoutes: []kong.Route{
+				{
+					Name:       kong.String("tlsroute.default.tlsroute.0.0.passthrough"),
+					Protocols:  kong.StringSlice("tls_passthrough"),
+					Expression: kong.String(`(tls.sni =^ ".foo.com") && (net.dst.port == 8443)`),
+					Priority:   kong.Uint64(1),
+				},
+				{
+					Name:       kong.String("tlsroute.default.tlsroute.0.0.terminate"),
+					Protocols:  kong.StringSlice("tls"),
+					Expression: kong.String(`(tls.sni =^ ".foo.com") && (net.dst.port == 9443)`),
+					Priority:   kong.Uint64(1),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fakestore, err := store.NewFakeStore(store.FakeObjects{
+				Gateways:  []*gatewayapi.Gateway{gateway},
+				TLSRoutes: []*gatewayapi.TLSRoute{tc.tlsRoute},
+				Services: []*corev1.Service{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "service"}},
+				},
+			})
+			require.NoError(t, err)
+			translator := mustNewTranslator(t, fakestore)
+			translator.featureFlags.ExpressionRoutes = tc.expressionRoutes
+
+			result := translator.ingressRulesFromTLSRoutes()
+			kongService, ok := result.ServiceNameToServices["tlsroute.default.tlsroute.0"]
+			require.True(t, ok)
+			require.Len(t, kongService.Routes, len(tc.expectedKongRoutes))
+			for i, expectedRoute := range tc.expectedKongRoutes {
+				route := kongService.Routes[i].Route
+				require.Equal(t, expectedRoute.Name, route.Name)
+				require.Equal(t, expectedRoute.Protocols, route.Protocols)
+				require.Equal(t, expectedRoute.SNIs, route.SNIs)
+				require.Equal(t, expectedRoute.Destinations, route.Destinations)
+				require.Equal(t, expectedRoute.Expression, route.Expression)
+				require.Equal(t, expectedRoute.Priority, route.Priority)
+			}
+		})
+	}
+}
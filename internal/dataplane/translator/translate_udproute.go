@@ -3,6 +3,7 @@ package translator
 import (
 	"fmt"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator/subtranslator"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/gatewayapi"
 )
@@ -99,3 +100,13 @@ func validateUDPRoute(udproute *gatewayapi.UDPRoute) error {
 	}
 	return nil
 }
+
+// GenerateKongRoutesFromUDPRoute generates Kong routes from all rules of the UDPRoute, matching the provided
+// ports of Gateway listeners the route is attached to. It is used for both traditional and expression based routes.
+func GenerateKongRoutesFromUDPRoute(
+	udproute *gatewayapi.UDPRoute,
+	gwPorts []gatewayapi.PortNumber,
+	expressionRoutes bool,
+) ([]kongstate.Route, error) {
+	return generateKongRoutesFromRouteRules(udproute, gwPorts, udproute.Spec.Rules, expressionRoutes)
+}
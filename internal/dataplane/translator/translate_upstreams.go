@@ -2,11 +2,13 @@ package translator
 
 import (
 	"fmt"
+	"math"
 	"net"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
+	"github.com/samber/mo"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -36,6 +38,9 @@ func (t *Translator) getUpstreams(serviceMap map[string]kongstate.Service) ([]ko
 			// routes may, for example, use the same Service twice or may use two Services with the same selector and same
 			// endpoints.
 			targetMap := map[string]kongstate.Target{}
+			// backends collects the targets of every backend together with the backend weight, so that the weights
+			// can be distributed among targets once the targets of all backends are known.
+			backends := make([]backendTargets, 0, len(service.Backends))
 			// populate all the kong targets for the upstream given all the backends
 			for _, backend := range service.Backends {
 				// gather the Kubernetes service for the backend
@@ -83,38 +88,34 @@ func (t *Translator) getUpstreams(serviceMap map[string]kongstate.Service) ([]ko
 						"namespace", k8sService.Namespace, "name", k8sService.Name, "kong_service", *service.Name)
 				}
 
-				// if weights were set for the backend then that weight needs to be
-				// distributed equally among all the targets.
-				if weight, weightPresent := backend.Weight().Get(); weightPresent && len(newTargets) != 0 {
-					// initialize the weight of the target based on the weight of the backend
-					// which governs that target (and potentially more). If the weight of the
-					// backend is 0 then this indicates an intention to drop all targets from
-					// this backend from the load-balancer and is a special situation where
-					// all derived targets will receive a weight of 0.
-					targetWeight := weight
-
-					// if the backend governing this target is not set to a weight of 0,
-					// all targets derived from the backend split the weight, therefore
-					// equally splitting the traffic load.
-					if weight != 0 {
-						targetWeight = weight / len(newTargets)
-						// minimum weight of 1 if weight zero was not specifically set.
-						if targetWeight == 0 {
-							targetWeight = 1
-						}
-					}
-
-					for i := range newTargets {
-						newTargets[i].Weight = &targetWeight
-					}
-				}
+				backends = append(backends, backendTargets{
+					weight:  backend.Weight(),
+					targets: newTargets,
+				})
+			}
 
-				for _, t := range newTargets {
+			// if weights were set for the backends then they need to be distributed among
+			// all the targets of the backends, keeping the traffic ratio between the backends.
+			exactWeights := setBackendTargetsWeights(backends)
+			for _, b := range backends {
+				for _, t := range b.targets {
 					targetMap = updateTargetMap(targetMap, t)
 				}
 			}
 
 			targets := lo.Values(targetMap)
+			// targets shared by multiple backends sum up their weights, which may push them over the limit.
+			exactWeights = fitTargetWeightsIntoKongRange(targets) && exactWeights
+			if !exactWeights {
+				reason := fmt.Sprintf("weights of backends of Kong service %s can't be represented exactly with target weights, "+
+					"traffic is split between backends approximately", *service.Name)
+				// Services not originating from a Kubernetes object have nothing to attach the warning to.
+				if service.Parent != nil {
+					t.registerTranslationWarning(reason, service.Parent)
+				} else {
+					t.logger.V(util.InfoLevel).Info(reason)
+				}
+			}
 			// warn if an upstream was created with 0 targets
 			if len(targets) == 0 {
 				t.logger.V(util.InfoLevel).Info("No targets found to create upstream", "service_name", *service.Name)
@@ -137,6 +138,99 @@ func (t *Translator) getUpstreams(serviceMap map[string]kongstate.Service) ([]ko
 	return upstreams, serviceMap
 }
 
+// maxKongTargetWeight is the maximum weight of a target accepted by Kong.
+const maxKongTargetWeight = 65535
+
+// backendTargets groups targets derived from a single backend with the weight of the backend.
+type backendTargets struct {
+	weight  mo.Option[int]
+	targets []kongstate.Target
+}
+
+// setBackendTargetsWeights sets weights of targets derived from weighted backends, so that the weight of each backend
+// is split equally among its targets while the traffic ratio between backends is preserved regardless of the number
+// of targets each backend has. A backend with a weight of 0 gets all its targets weighted 0, which drops them from
+// load-balancing. It returns false when the weights could only be approximated because representing them exactly
+// would require exceeding the maximum target weight.
+func setBackendTargetsWeights(backends []backendTargets) bool {
+	// scale is the smallest multiplier for which each backend weight is divisible by the number of its targets,
+	// so that every target gets an integer weight of weight * scale / len(targets).
+	scale := 1
+	maxTargetWeight := 0.0
+	for _, b := range backends {
+		weight, ok := b.weight.Get()
+		if !ok || weight == 0 || len(b.targets) == 0 {
+			continue
+		}
+		maxTargetWeight = max(maxTargetWeight, float64(weight)/float64(len(b.targets)))
+		// Stop growing the scale once it can't produce valid weights anyway to avoid overflowing.
+		if scale <= maxKongTargetWeight {
+			scale = lcm(scale, len(b.targets)/gcd(weight, len(b.targets)))
+		}
+	}
+	exact := scale <= maxKongTargetWeight
+	for _, b := range backends {
+		if weight, ok := b.weight.Get(); ok && weight != 0 && len(b.targets) != 0 && exact {
+			exact = scale*weight/len(b.targets) <= maxKongTargetWeight
+		}
+	}
+
+	for _, b := range backends {
+		weight, ok := b.weight.Get()
+		if !ok || len(b.targets) == 0 {
+			continue
+		}
+		var targetWeight int
+		switch {
+		case weight == 0:
+			targetWeight = 0
+		case exact:
+			targetWeight = scale * weight / len(b.targets)
+		default:
+			// Scale the weights so that the heaviest target gets the maximum weight, rounding the rest.
+			// The minimum weight is 1 as the weight of 0 was not specifically set.
+			targetWeight = max(1, int(math.Round(float64(weight)/float64(len(b.targets))/maxTargetWeight*maxKongTargetWeight)))
+		}
+		for i := range b.targets {
+			b.targets[i].Weight = lo.ToPtr(targetWeight)
+		}
+	}
+	return exact
+}
+
+// fitTargetWeightsIntoKongRange scales down weights of the targets proportionally if any of them exceeds the maximum
+// target weight. It returns false when the weights had to be scaled down and therefore became approximate.
+func fitTargetWeightsIntoKongRange(targets []kongstate.Target) bool {
+	heaviest := 0
+	for _, t := range targets {
+		heaviest = max(heaviest, targetWeightOrDefault(t.Weight))
+	}
+	if heaviest <= maxKongTargetWeight {
+		return true
+	}
+
+	for i, t := range targets {
+		weight := targetWeightOrDefault(t.Weight)
+		if weight == 0 {
+			continue
+		}
+		scaled := max(1, int(math.Round(float64(weight)*maxKongTargetWeight/float64(heaviest))))
+		targets[i].Weight = lo.ToPtr(scaled)
+	}
+	return false
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
+
 // findPort finds a port matching the specified definition in a Kubernetes Service.
 func findPort(svc *corev1.Service, wantPort kongstate.PortDef) (*corev1.ServicePort, error) {
 	switch wantPort.Mode {
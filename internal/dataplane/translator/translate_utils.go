@@ -3,6 +3,7 @@ package translator
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
@@ -24,11 +25,15 @@ func convertGatewayMatchHeadersToKongRouteMatchHeaders(headers []gatewayapi.HTTP
 	// iterate through each provided header match checking for invalid
 	// options and otherwise converting to kong type format.
 	convertedHeaders := make(map[string][]string)
+	seenHeaders := make(map[string]struct{}, len(headers))
 	for _, header := range headers {
-		if _, exists := convertedHeaders[string(header.Name)]; exists {
-			return nil, fmt.Errorf("multiple header matches for the same header are not allowed: %s",
-				string(header.Name))
+		// header names are case-insensitive and only the first match for a header
+		// must be considered, subsequent ones are ignored as the Gateway API requires.
+		name := strings.ToLower(string(header.Name))
+		if _, seen := seenHeaders[name]; seen {
+			continue
 		}
+		seenHeaders[name] = struct{}{}
 		switch {
 		case header.Type != nil && *header.Type == gatewayapi.HeaderMatchRegularExpression:
 			convertedHeaders[string(header.Name)] = []string{kongHeaderRegexPrefix + header.Value}
@@ -42,6 +47,27 @@ func convertGatewayMatchHeadersToKongRouteMatchHeaders(headers []gatewayapi.HTTP
 	return convertedHeaders, nil
 }
 
+// duplicateHTTPRouteHeaderMatches returns the paths of header matches of the HTTPRoute that are ignored, because
+// an earlier match in the same HTTPRouteMatch refers to the same (case-insensitive) header name.
+func duplicateHTTPRouteHeaderMatches(httproute *gatewayapi.HTTPRoute) []string {
+	var duplicates []string
+	for ruleIndex, rule := range httproute.Spec.Rules {
+		for matchIndex, match := range rule.Matches {
+			seenHeaders := make(map[string]struct{}, len(match.Headers))
+			for headerIndex, header := range match.Headers {
+				name := strings.ToLower(string(header.Name))
+				if _, seen := seenHeaders[name]; seen {
+					duplicates = append(duplicates, fmt.Sprintf("rules[%d].matches[%d].headers[%d] (%s)",
+						ruleIndex, matchIndex, headerIndex, header.Name))
+					continue
+				}
+				seenHeaders[name] = struct{}{}
+			}
+		}
+	}
+	return duplicates
+}
+
 // GetPermittedForReferenceGrantFrom takes a ReferenceGrant From (a namespace, group, and kind) and returns a map
 // from a namespace to a slice of ReferenceGrant Tos. When a To is included in the slice, the key namespace has a
 // ReferenceGrant with those Tos and the input From.
@@ -162,9 +188,14 @@ func generateKongServiceFromBackendRefWithRuleNumber(
 func applyExpressionToIngressRules(result *ingressRules) {
 	for _, svc := range result.ServiceNameToServices {
 		for i := range svc.Routes {
-			subtranslator.ApplyExpressionToL4KongRoute(&svc.Routes[i])
-			svc.Routes[i].Destinations = nil
-			svc.Routes[i].SNIs = nil
+			applyExpressionToL4Route(&svc.Routes[i])
 		}
 	}
 }
+
+// applyExpressionToL4Route translates the L4 route's destinations and SNIs into an expression.
+func applyExpressionToL4Route(route *kongstate.Route) {
+	subtranslator.ApplyExpressionToL4KongRoute(route)
+	route.Destinations = nil
+	route.SNIs = nil
+}
@@ -1,7 +1,6 @@
 package translator
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -60,19 +59,20 @@ func TestConvertGatewayMatchHeadersToKongRouteMatchHeaders(t *testing.T) {
 			},
 		},
 		{
-			msg: "multiple header matches for the same header are rejected",
+			msg: "only the first of multiple header matches for the same header is considered",
 			input: []gatewayapi.HTTPHeaderMatch{
 				{
 					Name:  "Content-Type",
 					Value: "audio/vorbis",
 				},
 				{
-					Name:  "Content-Type",
+					Name:  "content-type",
 					Value: "audio/flac",
 				},
 			},
-			output: nil,
-			err:    fmt.Errorf("multiple header matches for the same header are not allowed: Content-Type"),
+			output: map[string][]string{
+				"Content-Type": {"audio/vorbis"},
+			},
 		},
 		{
 			msg: "multiple header matches convert properly",
@@ -108,6 +108,47 @@ func TestConvertGatewayMatchHeadersToKongRouteMatchHeaders(t *testing.T) {
 	}
 }
 
+func TestDuplicateHTTPRouteHeaderMatches(t *testing.T) {
+	httproute := &gatewayapi.HTTPRoute{
+		Spec: gatewayapi.HTTPRouteSpec{
+			Rules: []gatewayapi.HTTPRouteRule{
+				{
+					Matches: []gatewayapi.HTTPRouteMatch{
+						{
+							Headers: []gatewayapi.HTTPHeaderMatch{
+								{Name: "Content-Type", Value: "audio/vorbis"},
+								{Name: "Content-Length", Value: "999999999"},
+							},
+						},
+						{
+							Headers: []gatewayapi.HTTPHeaderMatch{
+								{Name: "Content-Type", Value: "audio/vorbis"},
+								{Name: "content-type", Value: "audio/flac"},
+							},
+						},
+					},
+				},
+				{
+					Matches: []gatewayapi.HTTPRouteMatch{
+						{
+							Headers: []gatewayapi.HTTPHeaderMatch{
+								{Name: "X-Foo", Value: "foo"},
+								{Name: "X-Bar", Value: "bar"},
+								{Name: "X-FOO", Value: "bar"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"rules[0].matches[1].headers[1] (content-type)",
+		"rules[1].matches[0].headers[2] (X-FOO)",
+	}, duplicateHTTPRouteHeaderMatches(httproute))
+}
+
 func TestGetPermittedForReferenceGrantFrom(t *testing.T) {
 	grants := []*gatewayapi.ReferenceGrant{
 		{
@@ -8,6 +8,7 @@ import (
 	"github.com/kong/go-kong/kong"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
 	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
@@ -52,13 +53,36 @@ type FeatureFlags struct {
 
 	// KongCustomEntity indicates whether we should support translating custom entities from KongCustomEntity CRs.
 	KongCustomEntity bool
+
+	// IngressIncrementalTranslation indicates whether translation results of Ingresses not affected by changes since
+	// the previous BuildKongConfig() call should be reused instead of translating the Ingresses again. It's disabled
+	// for all Ingresses whenever any canary Ingress exists.
+	IngressIncrementalTranslation bool
+
+	// SchemaBasedCredentials indicates whether KongConsumer credentials of schema-based types should be translated.
+	// They're sent to Kong as generic entities, which only DB-less Kong Gateways accept.
+	SchemaBasedCredentials bool
+
+	// HTTPRouteRequestMirror indicates whether HTTPRoute RequestMirror filters should be translated. They're translated
+	// to pre-function plugins, which Kong accepts only with untrusted_lua set to on.
+	HTTPRouteRequestMirror bool
+
+	// UntrustedLua indicates whether Kong Gateway is configured with untrusted_lua set to on, which HTTPRoute
+	// RequestMirror filters require.
+	UntrustedLua bool
+
+	// ManagedGateways indicates whether managed Gateways have data-planes of their own, so that their part of
+	// the configuration is not configured in data-planes shared by all Gateways.
+	ManagedGateways bool
 }
 
 func NewFeatureFlags(
 	featureGates featuregates.FeatureGates,
 	routerFlavor dpconf.RouterFlavor,
+	dbMode dpconf.DBMode,
 	updateStatusFlag bool,
 	enterpriseEdition bool,
+	untrustedLua bool,
 ) FeatureFlags {
 	return FeatureFlags{
 		ReportConfiguredKubernetesObjects: updateStatusFlag,
@@ -68,6 +92,11 @@ func NewFeatureFlags(
 		RewriteURIs:                       featureGates.Enabled(featuregates.RewriteURIsFeature),
 		KongServiceFacade:                 featureGates.Enabled(featuregates.KongServiceFacade),
 		KongCustomEntity:                  featureGates.Enabled(featuregates.KongCustomEntity),
+		IngressIncrementalTranslation:     featureGates.Enabled(featuregates.IngressIncrementalTranslation),
+		SchemaBasedCredentials:            dbMode.IsDBLessMode(),
+		HTTPRouteRequestMirror:            featureGates.Enabled(featuregates.HTTPRouteRequestMirror),
+		UntrustedLua:                      untrustedLua,
+		ManagedGateways:                   featureGates.Enabled(featuregates.ManagedGateways),
 	}
 }
 
@@ -89,8 +118,16 @@ type Translator struct {
 	// schemaServiceProvider provides the schema service required for fetching schemas of custom entities.
 	schemaServiceProvider SchemaServiceProvider
 
+	// credentialTypes holds the credential types of KongConsumer credentials that are translated.
+	credentialTypes *credentials.Registry
+
 	failuresCollector          *failures.ResourceFailuresCollector
+	warningsCollector          *failures.ResourceWarningsCollector
 	translatedObjectsCollector *ObjectsCollector
+
+	// ingressIncrementalTranslation keeps results of the previous translation of Ingresses for reuse. It's nil when
+	// the IngressIncrementalTranslation feature flag is disabled.
+	ingressIncrementalTranslation *ingressIncrementalTranslation
 }
 
 // NewTranslator produces a new Translator object provided a logging mechanism
@@ -110,14 +147,22 @@ func NewTranslator(
 		translatedObjectsCollector = NewObjectsCollector()
 	}
 
+	var incremental *ingressIncrementalTranslation
+	if featureFlags.IngressIncrementalTranslation {
+		incremental = newIngressIncrementalTranslation(logger)
+	}
+
 	return &Translator{
-		logger:                     logger,
-		storer:                     storer,
-		workspace:                  workspace,
-		featureFlags:               featureFlags,
-		schemaServiceProvider:      schemaServiceProvider,
-		failuresCollector:          failuresCollector,
-		translatedObjectsCollector: translatedObjectsCollector,
+		logger:                        logger,
+		storer:                        storer,
+		workspace:                     workspace,
+		featureFlags:                  featureFlags,
+		schemaServiceProvider:         schemaServiceProvider,
+		credentialTypes:               credentials.NewDefaultRegistry(),
+		failuresCollector:             failuresCollector,
+		warningsCollector:             failures.NewResourceWarningsCollector(logger),
+		translatedObjectsCollector:    translatedObjectsCollector,
+		ingressIncrementalTranslation: incremental,
 	}, nil
 }
 
@@ -134,6 +179,10 @@ type KongConfigBuildingResult struct {
 	// They should be used to provide users with feedback on Kubernetes objects validity.
 	TranslationFailures []failures.ResourceFailure
 
+	// TranslationWarnings is a list of issues that occurred during parsing which didn't prevent the objects
+	// from being translated, but made the resulting configuration differ from what was requested.
+	TranslationWarnings []failures.ResourceFailure
+
 	// ConfiguredKubernetesObjects is a list of Kubernetes objects that were successfully translated.
 	ConfiguredKubernetesObjects []client.Object
 }
@@ -142,11 +191,16 @@ type KongConfigBuildingResult struct {
 // This method can be used to swap the cache with another one (e.g. the last valid snapshot).
 func (t *Translator) UpdateCache(c store.CacheStores) {
 	t.storer.UpdateCache(c)
+	t.ingressIncrementalTranslation.UpdateCache(c)
 }
 
 // BuildKongConfig creates a Kong configuration from Ingress and Custom resources
 // defined in Kubernetes.
 func (t *Translator) BuildKongConfig() KongConfigBuildingResult {
+	// Determine objects affected by changes since the previous translation, so that translation results
+	// of the others can be reused.
+	t.ingressIncrementalTranslation.DetectChanges()
+
 	// Translate and merge all rules together from all Kubernetes API sources
 	ingressRules := mergeIngressRules(
 		t.ingressRulesFromIngressV1(),
@@ -179,11 +233,17 @@ func (t *Translator) BuildKongConfig() KongConfigBuildingResult {
 		}
 	}
 
+	// associate Routes with Gateways they're attached to
+	t.fillRoutesParentGateways(&result)
+	t.fillDedicatedGateways(&result)
+
 	// merge KongIngress with Routes, Services and Upstream
 	result.FillOverrides(t.logger, t.storer, t.failuresCollector)
 
 	// generate consumers and credentials
-	result.FillConsumersAndCredentials(t.logger, t.storer, t.failuresCollector)
+	result.FillConsumersAndCredentials(
+		t.logger, t.storer, t.failuresCollector, t.credentialTypes, t.schemaServiceProvider.GetSchemaService(), t.featureFlags.SchemaBasedCredentials, t.workspace,
+	)
 	for i := range result.Consumers {
 		t.registerSuccessfullyTranslatedObject(&result.Consumers[i].K8sKongConsumer)
 	}
@@ -226,6 +286,9 @@ func (t *Translator) BuildKongConfig() KongConfigBuildingResult {
 	// populate CA certificates in Kong
 	result.CACertificates = t.getCACerts()
 
+	// configure TLS towards upstreams targeted by BackendTLSPolicies
+	t.applyBackendTLSPolicies(&result)
+
 	if t.licenseGetter != nil && t.featureFlags.EnterpriseEdition {
 		optionalLicense := t.licenseGetter.GetLicense()
 		if l, ok := optionalLicense.Get(); ok {
@@ -241,6 +304,7 @@ func (t *Translator) BuildKongConfig() KongConfigBuildingResult {
 	return KongConfigBuildingResult{
 		KongState:                   &result,
 		TranslationFailures:         t.popTranslationFailures(),
+		TranslationWarnings:         t.popTranslationWarnings(),
 		ConfiguredKubernetesObjects: t.popConfiguredKubernetesObjects(),
 	}
 }
@@ -254,6 +318,12 @@ func (t *Translator) InjectLicenseGetter(licenseGetter license.Getter) {
 	t.licenseGetter = licenseGetter
 }
 
+// InjectCredentialTypes sets the registry of credential types of KongConsumer credentials to be translated,
+// replacing the types supported by the controller out of the box.
+func (t *Translator) InjectCredentialTypes(credentialTypes *credentials.Registry) {
+	t.credentialTypes = credentialTypes
+}
+
 // -----------------------------------------------------------------------------
 // Translator - Private Methods
 // -----------------------------------------------------------------------------
@@ -267,6 +337,16 @@ func (t *Translator) popTranslationFailures() []failures.ResourceFailure {
 	return t.failuresCollector.PopResourceFailures()
 }
 
+// registerTranslationWarning should be called when a Kubernetes object is translated, but the resulting
+// configuration doesn't exactly match what the object requested.
+func (t *Translator) registerTranslationWarning(reason string, causingObjects ...client.Object) {
+	t.warningsCollector.PushResourceWarning(reason, causingObjects...)
+}
+
+func (t *Translator) popTranslationWarnings() []failures.ResourceFailure {
+	return t.warningsCollector.PopResourceWarnings()
+}
+
 // registerSuccessfullyTranslatedObject should be called when any Kubernetes object is successfully translated.
 // It collects the object for reporting purposes.
 func (t *Translator) registerSuccessfullyTranslatedObject(obj client.Object) {
@@ -23,9 +23,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/labels"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/featuregates"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
@@ -4674,8 +4676,10 @@ func TestNewFeatureFlags(t *testing.T) {
 
 		featureGates      map[string]bool
 		routerFlavor      dpconf.RouterFlavor
+		dbMode            dpconf.DBMode
 		updateStatusFlag  bool
 		enterpriseEdition bool
+		untrustedLua      bool
 
 		expectedFeatureFlags FeatureFlags
 	}{
@@ -4684,6 +4688,7 @@ func TestNewFeatureFlags(t *testing.T) {
 			featureGates: map[string]bool{},
 
 			routerFlavor:     dpconf.RouterFlavorTraditionalCompatible,
+			dbMode:           dpconf.DBModePostgres,
 			updateStatusFlag: true,
 			expectedFeatureFlags: FeatureFlags{
 				ReportConfiguredKubernetesObjects: true,
@@ -4692,6 +4697,7 @@ func TestNewFeatureFlags(t *testing.T) {
 		{
 			name:         "expression router and update status disabled",
 			routerFlavor: dpconf.RouterFlavorExpressions,
+			dbMode:       dpconf.DBModePostgres,
 			expectedFeatureFlags: FeatureFlags{
 				ExpressionRoutes: true,
 			},
@@ -4701,17 +4707,37 @@ func TestNewFeatureFlags(t *testing.T) {
 			featureGates: map[string]bool{
 				featuregates.KongServiceFacade: true,
 			},
+			dbMode:            dpconf.DBModePostgres,
 			enterpriseEdition: true,
 			expectedFeatureFlags: FeatureFlags{
 				EnterpriseEdition: true,
 				KongServiceFacade: true,
 			},
 		},
+		{
+			name:   "DB-less mode",
+			dbMode: dpconf.DBModeOff,
+			expectedFeatureFlags: FeatureFlags{
+				SchemaBasedCredentials: true,
+			},
+		},
+		{
+			name: "RequestMirror enabled and untrusted Lua allowed",
+			featureGates: map[string]bool{
+				featuregates.HTTPRouteRequestMirror: true,
+			},
+			dbMode:       dpconf.DBModePostgres,
+			untrustedLua: true,
+			expectedFeatureFlags: FeatureFlags{
+				HTTPRouteRequestMirror: true,
+				UntrustedLua:           true,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualFlags := NewFeatureFlags(tc.featureGates, tc.routerFlavor, tc.updateStatusFlag, tc.enterpriseEdition)
+			actualFlags := NewFeatureFlags(tc.featureGates, tc.routerFlavor, tc.dbMode, tc.updateStatusFlag, tc.enterpriseEdition, tc.untrustedLua)
 
 			require.Equal(t, tc.expectedFeatureFlags, actualFlags)
 		})
@@ -4770,6 +4796,60 @@ func TestTranslator_License(t *testing.T) {
 	})
 }
 
+func TestTranslator_CredentialTypes(t *testing.T) {
+	s, err := store.NewFakeStore(store.FakeObjects{
+		Secrets: []*corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "key-auth-secret",
+					Namespace: "default",
+					Labels: map[string]string{
+						labels.CredentialTypeLabel: "key-auth",
+					},
+				},
+				Data: map[string][]byte{
+					"key": []byte("key"),
+				},
+			},
+		},
+		KongConsumers: []*kongv1.KongConsumer{
+			{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "KongConsumer",
+					APIVersion: kongv1.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "consumer",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.IngressClassKey: annotations.DefaultIngressClass,
+					},
+				},
+				Username:    "consumer",
+				Credentials: []string{"key-auth-secret"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("credential types supported out of the box are translated by default", func(t *testing.T) {
+		p := mustNewTranslator(t, s)
+		result := p.BuildKongConfig()
+		require.Empty(t, result.TranslationFailures)
+		require.Len(t, result.KongState.Consumers, 1)
+		require.Len(t, result.KongState.Consumers[0].KeyAuths, 1)
+	})
+
+	t.Run("only injected credential types are translated", func(t *testing.T) {
+		p := mustNewTranslator(t, s)
+		p.InjectCredentialTypes(credentials.NewRegistry(credentials.Type{Name: "basic-auth", EntityType: "basicauth_credentials"}))
+		result := p.BuildKongConfig()
+		require.Len(t, result.TranslationFailures, 1)
+		require.Len(t, result.KongState.Consumers, 1)
+		require.Empty(t, result.KongState.Consumers[0].KeyAuths)
+	})
+}
+
 func TestTranslator_ConfiguredKubernetesObjects(t *testing.T) {
 	testCases := []struct {
 		name                          string
@@ -5170,6 +5250,110 @@ func TestUpdateTargetMap(t *testing.T) {
 	}
 }
 
+func TestSetBackendTargetsWeights(t *testing.T) {
+	targets := func(n int) []kongstate.Target {
+		return lo.Times(n, func(i int) kongstate.Target {
+			return kongstate.Target{Target: kong.Target{Target: lo.ToPtr(fmt.Sprintf("10.0.0.%d:80", i))}}
+		})
+	}
+	weights := func(targets []kongstate.Target) []*int {
+		return lo.Map(targets, func(t kongstate.Target, _ int) *int { return t.Weight })
+	}
+
+	testCases := []struct {
+		name            string
+		backends        []backendTargets
+		expectedWeights [][]*int
+		expectedExact   bool
+	}{
+		{
+			name: "weights divisible by the number of targets are split equally",
+			backends: []backendTargets{
+				{weight: mo.Some(90), targets: targets(3)},
+				{weight: mo.Some(10), targets: targets(2)},
+			},
+			expectedWeights: [][]*int{
+				{lo.ToPtr(30), lo.ToPtr(30), lo.ToPtr(30)},
+				{lo.ToPtr(5), lo.ToPtr(5)},
+			},
+			expectedExact: true,
+		},
+		{
+			name: "ratio between backends is preserved regardless of the number of targets",
+			backends: []backendTargets{
+				{weight: mo.Some(1), targets: targets(3)},
+				{weight: mo.Some(99), targets: targets(2)},
+			},
+			// 3 * 2 : 2 * 297 = 1 : 99
+			expectedWeights: [][]*int{
+				{lo.ToPtr(2), lo.ToPtr(2), lo.ToPtr(2)},
+				{lo.ToPtr(297), lo.ToPtr(297)},
+			},
+			expectedExact: true,
+		},
+		{
+			name: "zero weight, unweighted backends and backends without targets",
+			backends: []backendTargets{
+				{weight: mo.Some(0), targets: targets(2)},
+				{weight: mo.None[int](), targets: targets(1)},
+				{weight: mo.Some(10), targets: targets(0)},
+				{weight: mo.Some(10), targets: targets(4)},
+			},
+			expectedWeights: [][]*int{
+				{lo.ToPtr(0), lo.ToPtr(0)},
+				{nil},
+				{},
+				{lo.ToPtr(5), lo.ToPtr(5), lo.ToPtr(5), lo.ToPtr(5)},
+			},
+			expectedExact: true,
+		},
+		{
+			name: "weights exceeding the maximum target weight are approximated",
+			backends: []backendTargets{
+				{weight: mo.Some(1_000_000), targets: targets(1)},
+				{weight: mo.Some(500_000), targets: targets(2)},
+				{weight: mo.Some(1), targets: targets(1)},
+			},
+			expectedWeights: [][]*int{
+				{lo.ToPtr(65535)},
+				{lo.ToPtr(16384), lo.ToPtr(16384)},
+				{lo.ToPtr(1)},
+			},
+			expectedExact: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exact := setBackendTargetsWeights(tc.backends)
+			require.Equal(t, tc.expectedExact, exact)
+			for i, b := range tc.backends {
+				require.Equal(t, tc.expectedWeights[i], weights(b.targets), "backend %d", i)
+			}
+		})
+	}
+}
+
+func TestFitTargetWeightsIntoKongRange(t *testing.T) {
+	target := func(weight *int) kongstate.Target {
+		return kongstate.Target{Target: kong.Target{Target: lo.ToPtr("10.0.0.1:80"), Weight: weight}}
+	}
+
+	t.Run("weights within range are not changed", func(t *testing.T) {
+		targets := []kongstate.Target{target(lo.ToPtr(65535)), target(nil), target(lo.ToPtr(0))}
+		require.True(t, fitTargetWeightsIntoKongRange(targets))
+		require.Equal(t, []kongstate.Target{target(lo.ToPtr(65535)), target(nil), target(lo.ToPtr(0))}, targets)
+	})
+
+	t.Run("weights out of range are scaled down proportionally", func(t *testing.T) {
+		targets := []kongstate.Target{target(lo.ToPtr(131070)), target(nil), target(lo.ToPtr(0)), target(lo.ToPtr(1))}
+		require.False(t, fitTargetWeightsIntoKongRange(targets))
+		require.Equal(t, []kongstate.Target{
+			target(lo.ToPtr(65535)), target(lo.ToPtr(50)), target(lo.ToPtr(0)), target(lo.ToPtr(1)),
+		}, targets)
+	})
+}
+
 func TestTranslator_UpdateStore(t *testing.T) {
 	originalStore, err := store.NewFakeStore(store.FakeObjects{})
 	require.NoError(t, err)
@@ -1,6 +1,10 @@
 package diagnostics
 
-import "github.com/kong/go-database-reconciler/pkg/file"
+import (
+	"time"
+
+	"github.com/kong/go-database-reconciler/pkg/file"
+)
 
 // ConfigDumpResponse is the GET /debug/config/[successful|failed] response schema.
 type ConfigDumpResponse struct {
@@ -18,6 +22,9 @@ type FallbackResponse struct {
 	ExcludedObjects []FallbackAffectedObjectMeta `json:"excludedObjects,omitempty"`
 	// BackfilledObjects is the list of objects that were backfilled from the last valid cache state.
 	BackfilledObjects []FallbackAffectedObjectMeta `json:"backfilledObjects,omitempty"`
+	// ExcludedEntities is the list of broken Kong entities that were excluded from the fallback configuration
+	// individually, without excluding objects they were translated from.
+	ExcludedEntities []FallbackExcludedEntity `json:"excludedEntities,omitempty"`
 }
 
 // FallbackStatus describes whether the fallback configuration generation was triggered or not.
@@ -49,3 +56,125 @@ type FallbackAffectedObjectMeta struct {
 	// CausingObjects is the object that triggered this
 	CausingObjects []string `json:"causingObjects,omitempty"`
 }
+
+// FallbackExcludedEntity is a Kong entity excluded from the fallback configuration.
+type FallbackExcludedEntity struct {
+	// Type is the Kong entity type.
+	Type string `json:"type"`
+	// Name is the Kong entity name. For plugins, it's the plugin name.
+	Name string `json:"name"`
+	// ForeignKeys are IDs or names of entities the Kong entity is associated with, keyed by their type.
+	ForeignKeys map[string]string `json:"foreignKeys,omitempty"`
+	// CausingObject is the object the Kong entity was translated from.
+	CausingObject string `json:"causingObject"`
+}
+
+// ConfigDiffResponse is the GET /debug/config/diff response schema.
+type ConfigDiffResponse struct {
+	// Timestamp is the time the diff was computed at. It's nil if no diff was computed yet.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Failed indicates the configuration was not accepted by Kong, so the changes were not applied.
+	Failed bool `json:"failed"`
+	// Summary is the number of changes by entity type.
+	Summary map[string]ConfigDiffSummary `json:"summary"`
+	// Changes is the list of changed entities.
+	Changes []ConfigDiffEntityChange `json:"changes"`
+}
+
+// ConfigDiffSummary is the number of entities of a type by the type of change.
+type ConfigDiffSummary struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Delete int `json:"delete"`
+}
+
+// ConfigDiffEntityChange is a change of a single Kong entity.
+type ConfigDiffEntityChange struct {
+	// Type is the entity type (service, route, plugin or consumer).
+	Type string `json:"type"`
+	// Action is the type of change (create, update or delete).
+	Action string `json:"action"`
+	// Name identifies the entity among entities of the same type.
+	Name string `json:"name"`
+	// Fields are names of the entity's fields that changed. It's only set for updates.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// DryRunResponse is the GET /debug/config/dry-run response schema.
+type DryRunResponse struct {
+	// Timestamp is the time the configuration was validated at. It's nil if no configuration was validated yet.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Status is the result of the most recent configuration validation.
+	Status DryRunStatus `json:"status"`
+	// Error is the error returned when validating the configuration.
+	Error string `json:"error,omitempty"`
+	// TranslationFailures are failures that occurred when translating Kubernetes objects.
+	TranslationFailures []DryRunObjectFailure `json:"translationFailures"`
+	// BrokenObjects are failures of objects that would be reported as broken if the configuration was applied.
+	BrokenObjects []DryRunObjectFailure `json:"brokenObjects"`
+}
+
+// DryRunStatus describes the result of validating a configuration in dry-run mode.
+type DryRunStatus string
+
+const (
+	// DryRunStatusNotRun indicates that no configuration was validated yet.
+	DryRunStatusNotRun DryRunStatus = "not-run"
+
+	// DryRunStatusValid indicates that the configuration passed validation.
+	DryRunStatusValid DryRunStatus = "valid"
+
+	// DryRunStatusInvalid indicates that the configuration failed validation.
+	DryRunStatusInvalid DryRunStatus = "invalid"
+)
+
+// DryRunObjectFailure is a failure affecting Kubernetes objects.
+type DryRunObjectFailure struct {
+	// Message describes the failure.
+	Message string `json:"message"`
+	// Objects are the objects causing the failure.
+	Objects []string `json:"objects"`
+}
+
+// ConfigHistoryResponse is the GET /debug/config/history response schema.
+type ConfigHistoryResponse struct {
+	// PinnedID is the ID of the configuration gateways are pinned to. It's nil if gateways are not pinned.
+	PinnedID *uint64 `json:"pinnedID,omitempty"`
+	// Entries are configurations pushed to gateways, the most recent one first.
+	Entries []ConfigHistoryEntryResponse `json:"entries"`
+}
+
+// ConfigHistoryEntryResponse is a single configuration pushed to gateways.
+type ConfigHistoryEntryResponse struct {
+	// ID identifies the configuration.
+	ID uint64 `json:"id"`
+	// Timestamp is the time the configuration was pushed at.
+	Timestamp time.Time `json:"timestamp"`
+	// SHAs are the hashes of the configuration reported by gateways.
+	SHAs []string `json:"shas"`
+	// Fallback tells whether the configuration was pushed to recover from a rejection of another configuration.
+	Fallback bool `json:"fallback"`
+	// ObjectChanges are changes of Kubernetes objects that triggered the push.
+	ObjectChanges []ConfigHistoryObjectChange `json:"objectChanges"`
+	// ObjectChangesCount is the number of changes of Kubernetes objects. It can be greater than the number of
+	// ObjectChanges, which are truncated.
+	ObjectChangesCount int `json:"objectChangesCount"`
+}
+
+// ConfigHistoryObjectChange is a change of a Kubernetes object.
+type ConfigHistoryObjectChange struct {
+	// Kind is the object's kind.
+	Kind string `json:"kind"`
+	// Namespace is the object's namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the object's name.
+	Name string `json:"name"`
+	// Action is the type of change (create, update or delete).
+	Action string `json:"action"`
+}
+
+// ConfigPinResponse is the /debug/config/pin response schema.
+type ConfigPinResponse struct {
+	// PinnedID is the ID of the configuration gateways are pinned to. It's nil if gateways are not pinned.
+	PinnedID *uint64 `json:"pinnedID"`
+}
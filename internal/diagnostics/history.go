@@ -0,0 +1,141 @@
+package diagnostics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+)
+
+// DefaultConfigHistorySize is the default number of configurations kept in ConfigHistory.
+const DefaultConfigHistorySize = 10
+
+// ObjectChangeType is the type of change of a Kubernetes object between two configurations.
+type ObjectChangeType string
+
+const (
+	ObjectChangeTypeCreate ObjectChangeType = "create"
+	ObjectChangeTypeUpdate ObjectChangeType = "update"
+	ObjectChangeTypeDelete ObjectChangeType = "delete"
+)
+
+// ObjectChange is a change of a Kubernetes object configuration was translated from.
+type ObjectChange struct {
+	// Kind is the object's kind.
+	Kind string
+	// Namespace is the object's namespace.
+	Namespace string
+	// Name is the object's name.
+	Name string
+	// ChangeType is the type of change.
+	ChangeType ObjectChangeType
+}
+
+// ConfigHistoryEntry is a configuration pushed to gateways.
+type ConfigHistoryEntry struct {
+	// ID identifies the entry. IDs grow with every recorded configuration.
+	ID uint64
+	// Timestamp is the time the configuration was pushed at.
+	Timestamp time.Time
+	// SHAs are the hashes of the configuration reported by gateways.
+	SHAs []string
+	// Fallback tells whether the configuration was pushed to recover from a rejection of another configuration.
+	Fallback bool
+	// ObjectChanges are changes of Kubernetes objects since the previously recorded configuration that triggered
+	// the push. It's limited to MaxObjectChanges, ObjectChangesCount is the total number of changes.
+	ObjectChanges []ObjectChange
+	// ObjectChangesCount is the number of changes of Kubernetes objects.
+	ObjectChangesCount int
+
+	state *kongstate.KongState
+}
+
+// MaxObjectChanges is the maximum number of object changes kept for a single ConfigHistoryEntry.
+const MaxObjectChanges = 100
+
+// ConfigHistory is a bounded history of configurations pushed to gateways. It allows pinning gateways to one of them
+// until the pin is released. It's safe for concurrent use.
+type ConfigHistory struct {
+	lock    sync.RWMutex
+	entries []ConfigHistoryEntry
+	size    int
+	nextID  uint64
+
+	// pinned is the entry gateways are pinned to. It's kept even when it's evicted from entries.
+	pinned *ConfigHistoryEntry
+}
+
+// NewConfigHistory creates a ConfigHistory keeping up to size most recent configurations.
+func NewConfigHistory(size int) *ConfigHistory {
+	return &ConfigHistory{
+		size:   max(size, 1),
+		nextID: 1,
+	}
+}
+
+// Record records a configuration, evicting the oldest one if the history is full. It returns the ID of the entry.
+func (h *ConfigHistory) Record(entry ConfigHistoryEntry, state *kongstate.KongState) uint64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	entry.ID = h.nextID
+	entry.state = state
+	h.nextID++
+	if len(entry.ObjectChanges) > MaxObjectChanges {
+		entry.ObjectChanges = entry.ObjectChanges[:MaxObjectChanges]
+	}
+	if len(h.entries) == h.size {
+		h.entries = h.entries[1:]
+	}
+	h.entries = append(h.entries, entry)
+	return entry.ID
+}
+
+// Entries returns recorded configurations, the most recent one first.
+func (h *ConfigHistory) Entries() []ConfigHistoryEntry {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	entries := make([]ConfigHistoryEntry, 0, len(h.entries))
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		entries = append(entries, h.entries[i])
+	}
+	return entries
+}
+
+// Pin pins gateways to the configuration with the given ID.
+func (h *ConfigHistory) Pin(id uint64) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i := range h.entries {
+		if h.entries[i].ID == id {
+			entry := h.entries[i]
+			h.pinned = &entry
+			return nil
+		}
+	}
+	return fmt.Errorf("no configuration with ID %d in history", id)
+}
+
+// Unpin releases the pin. It returns false if gateways were not pinned.
+func (h *ConfigHistory) Unpin() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	pinned := h.pinned != nil
+	h.pinned = nil
+	return pinned
+}
+
+// Pinned returns the entry gateways are pinned to along with its configuration.
+func (h *ConfigHistory) Pinned() (ConfigHistoryEntry, *kongstate.KongState, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if h.pinned == nil {
+		return ConfigHistoryEntry{}, nil, false
+	}
+	return *h.pinned, h.pinned.state, true
+}
@@ -0,0 +1,59 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
+)
+
+func TestConfigHistory(t *testing.T) {
+	stateWithService := func(name string) *kongstate.KongState {
+		return &kongstate.KongState{
+			Services: []kongstate.Service{{Service: kong.Service{Name: kong.String(name)}}},
+		}
+	}
+	entryIDs := func(h *ConfigHistory) []uint64 {
+		return lo.Map(h.Entries(), func(e ConfigHistoryEntry, _ int) uint64 { return e.ID })
+	}
+
+	h := NewConfigHistory(2)
+	_, _, pinned := h.Pinned()
+	require.False(t, pinned)
+
+	require.Equal(t, uint64(1), h.Record(ConfigHistoryEntry{SHAs: []string{"1"}}, stateWithService("first")))
+	require.Equal(t, uint64(2), h.Record(ConfigHistoryEntry{SHAs: []string{"2"}}, stateWithService("second")))
+	require.Equal(t, []uint64{2, 1}, entryIDs(h), "entries should be returned the most recent first")
+
+	t.Log("Pinning the oldest configuration")
+	require.NoError(t, h.Pin(1))
+	entry, state, pinned := h.Pinned()
+	require.True(t, pinned)
+	require.Equal(t, uint64(1), entry.ID)
+	require.Equal(t, "first", *state.Services[0].Name)
+
+	t.Log("Recording a configuration evicting the pinned one")
+	h.Record(ConfigHistoryEntry{
+		ObjectChanges:      make([]ObjectChange, MaxObjectChanges+1),
+		ObjectChangesCount: MaxObjectChanges + 1,
+	}, stateWithService("third"))
+	require.Equal(t, []uint64{3, 2}, entryIDs(h))
+	require.Len(t, h.Entries()[0].ObjectChanges, MaxObjectChanges, "object changes should be truncated")
+	require.Equal(t, MaxObjectChanges+1, h.Entries()[0].ObjectChangesCount)
+	entry, state, pinned = h.Pinned()
+	require.True(t, pinned, "evicted configuration should stay pinned")
+	require.Equal(t, uint64(1), entry.ID)
+	require.Equal(t, "first", *state.Services[0].Name)
+
+	t.Log("Pinning an evicted configuration")
+	require.ErrorContains(t, h.Pin(1), "no configuration with ID 1 in history")
+
+	t.Log("Releasing the pin")
+	require.True(t, h.Unpin())
+	_, _, pinned = h.Pinned()
+	require.False(t, pinned)
+	require.False(t, h.Unpin())
+}
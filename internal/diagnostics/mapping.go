@@ -2,8 +2,11 @@ package diagnostics
 
 import (
 	"github.com/samber/lo"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 )
 
 // mapFallbackCacheMetadataIntoFallbackResponse maps the generated cache metadata into a FallbackResponse.
@@ -44,5 +47,109 @@ func mapFallbackCacheMetadataIntoFallbackResponse(meta *fallback.GeneratedCacheM
 		BrokenObjects:     brokenObjects,
 		ExcludedObjects:   mapAffectedObjectsMeta(meta.ExcludedObjects),
 		BackfilledObjects: mapAffectedObjectsMeta(meta.BackfilledObjects),
+		ExcludedEntities: lo.Map(meta.ExcludedEntities, func(e fallback.BrokenEntity, _ int) FallbackExcludedEntity {
+			return FallbackExcludedEntity{
+				Type:          e.Type,
+				Name:          e.Name,
+				ForeignKeys:   e.ForeignKeys,
+				CausingObject: e.CausingObject.String(),
+			}
+		}),
+	}
+}
+
+// mapConfigDiffIntoConfigDiffResponse maps the config diff into a ConfigDiffResponse.
+func mapConfigDiffIntoConfigDiffResponse(diff *ConfigDiff) ConfigDiffResponse {
+	if diff == nil {
+		return ConfigDiffResponse{
+			Summary: map[string]ConfigDiffSummary{},
+			Changes: []ConfigDiffEntityChange{},
+		}
+	}
+
+	summary := make(map[string]ConfigDiffSummary)
+	for entityType, counts := range diff.Diff.Counts() {
+		summary[string(entityType)] = ConfigDiffSummary{
+			Create: counts[kongstate.ChangeTypeCreate],
+			Update: counts[kongstate.ChangeTypeUpdate],
+			Delete: counts[kongstate.ChangeTypeDelete],
+		}
+	}
+	return ConfigDiffResponse{
+		Timestamp: &diff.Timestamp,
+		Failed:    diff.Failed,
+		Summary:   summary,
+		Changes: lo.Map(diff.Diff.Changes, func(change kongstate.EntityChange, _ int) ConfigDiffEntityChange {
+			return ConfigDiffEntityChange{
+				Type:   string(change.EntityType),
+				Action: string(change.ChangeType),
+				Name:   change.Name,
+				Fields: change.Fields,
+			}
+		}),
+	}
+}
+
+// mapDryRunResultIntoDryRunResponse maps the dry-run result into a DryRunResponse.
+func mapDryRunResultIntoDryRunResponse(result *DryRunResult) DryRunResponse {
+	if result == nil {
+		return DryRunResponse{
+			Status:              DryRunStatusNotRun,
+			TranslationFailures: []DryRunObjectFailure{},
+			BrokenObjects:       []DryRunObjectFailure{},
+		}
+	}
+
+	mapFailures := func(resourceFailures []failures.ResourceFailure) []DryRunObjectFailure {
+		return lo.Map(resourceFailures, func(f failures.ResourceFailure, _ int) DryRunObjectFailure {
+			return DryRunObjectFailure{
+				Message: f.Message(),
+				Objects: lo.Map(f.CausingObjects(), func(obj client.Object, _ int) string {
+					return fallback.GetObjectHash(obj).String()
+				}),
+			}
+		})
+	}
+	resp := DryRunResponse{
+		Timestamp:           &result.Timestamp,
+		Status:              DryRunStatusValid,
+		TranslationFailures: mapFailures(result.TranslationFailures),
+		BrokenObjects:       mapFailures(result.ValidationFailures),
+	}
+	if result.ValidationErr != nil {
+		resp.Status = DryRunStatusInvalid
+		resp.Error = result.ValidationErr.Error()
+	}
+	return resp
+}
+
+// mapConfigHistoryIntoConfigHistoryResponse maps the config history into a ConfigHistoryResponse.
+func mapConfigHistoryIntoConfigHistoryResponse(history *ConfigHistory) ConfigHistoryResponse {
+	resp := ConfigHistoryResponse{
+		Entries: []ConfigHistoryEntryResponse{},
+	}
+	if history == nil {
+		return resp
+	}
+	if pinned, _, ok := history.Pinned(); ok {
+		resp.PinnedID = lo.ToPtr(pinned.ID)
+	}
+	for _, entry := range history.Entries() {
+		resp.Entries = append(resp.Entries, ConfigHistoryEntryResponse{
+			ID:        entry.ID,
+			Timestamp: entry.Timestamp,
+			SHAs:      lo.Ternary(entry.SHAs != nil, entry.SHAs, []string{}),
+			Fallback:  entry.Fallback,
+			ObjectChanges: lo.Map(entry.ObjectChanges, func(change ObjectChange, _ int) ConfigHistoryObjectChange {
+				return ConfigHistoryObjectChange{
+					Kind:      change.Kind,
+					Namespace: change.Namespace,
+					Name:      change.Name,
+					Action:    string(change.ChangeType),
+				}
+			}),
+			ObjectChangesCount: entry.ObjectChangesCount,
+		})
 	}
+	return resp
 }
@@ -2,16 +2,20 @@ package diagnostics
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-database-reconciler/pkg/file"
+	"github.com/samber/lo"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
@@ -43,6 +47,19 @@ type Server struct {
 
 	currentFallbackCacheMetadata *fallback.GeneratedCacheMetadata
 
+	lastConfigDiff *ConfigDiff
+
+	lastDryRunResult *DryRunResult
+
+	// configPinToken is the bearer token authorizing requests to the configuration history and pinning gateways
+	// to a configuration from history. Pinning is disabled when it's empty.
+	configPinToken string
+
+	// tlsCertFile and tlsKeyFile are paths to the certificate and key the server is served with over HTTPS.
+	// The server is served over plain HTTP when they're empty.
+	tlsCertFile string
+	tlsKeyFile  string
+
 	configLock   *sync.RWMutex
 	fallbackLock *sync.RWMutex
 }
@@ -57,6 +74,19 @@ type ServerConfig struct {
 
 	// DumpSensitiveConfig makes config dumps to include sensitive information.
 	DumpSensitiveConfig bool
+
+	// ConfigHistorySize is the number of configurations pushed to gateways kept in history.
+	ConfigHistorySize int
+
+	// ConfigPinToken is the bearer token authorizing requests to the configuration history and pinning gateways
+	// to a configuration from history. Pinning is disabled when it's empty. It should only be set when the server
+	// is served over HTTPS, so that the token is not sent over the network in plain text.
+	ConfigPinToken string
+
+	// TLSCertFile and TLSKeyFile are paths to the certificate and key to serve the server with over HTTPS.
+	// The server is served over plain HTTP when they're empty.
+	TLSCertFile string
+	TLSKeyFile  string
 }
 
 // NewServer creates a diagnostics server ready to start listening.
@@ -64,6 +94,9 @@ func NewServer(logger logr.Logger, cfg ServerConfig) Server {
 	s := Server{
 		logger:           logger,
 		profilingEnabled: cfg.ProfilingEnabled,
+		configPinToken:   cfg.ConfigPinToken,
+		tlsCertFile:      cfg.TLSCertFile,
+		tlsKeyFile:       cfg.TLSKeyFile,
 		configLock:       &sync.RWMutex{},
 		fallbackLock:     &sync.RWMutex{},
 	}
@@ -73,6 +106,9 @@ func NewServer(logger logr.Logger, cfg ServerConfig) Server {
 			DumpsIncludeSensitive: cfg.DumpSensitiveConfig,
 			Configs:               make(chan ConfigDump, diagnosticConfigBufferDepth),
 			FallbackCacheMetadata: make(chan fallback.GeneratedCacheMetadata, diagnosticConfigBufferDepth),
+			ConfigDiffs:           make(chan ConfigDiff, diagnosticConfigBufferDepth),
+			DryRunResults:         make(chan DryRunResult, diagnosticConfigBufferDepth),
+			ConfigHistory:         NewConfigHistory(lo.Ternary(cfg.ConfigHistorySize > 0, cfg.ConfigHistorySize, DefaultConfigHistorySize)),
 		}
 	}
 
@@ -105,7 +141,12 @@ func (s *Server) Listen(ctx context.Context, port int) error {
 	go s.receiveConfig(ctx)
 
 	go func() {
-		err := httpServer.ListenAndServe()
+		var err error
+		if s.tlsCertFile != "" {
+			err = httpServer.ListenAndServeTLS(s.tlsCertFile, s.tlsKeyFile)
+		} else {
+			err = httpServer.ListenAndServe()
+		}
 		if err != nil {
 			if !errors.Is(err, http.ErrServerClosed) {
 				s.logger.Error(err, "Could not start diagnostics server")
@@ -114,7 +155,7 @@ func (s *Server) Listen(ctx context.Context, port int) error {
 		}
 	}()
 
-	s.logger.Info("Diagnostics server is starting to listen", "addr", port)
+	s.logger.Info("Diagnostics server is starting to listen", "addr", httpServer.Addr, "tls", s.tlsCertFile != "")
 
 	select {
 	case <-ctx.Done():
@@ -133,6 +174,10 @@ func (s *Server) receiveConfig(ctx context.Context) {
 			s.onConfigDump(dump)
 		case meta := <-s.configDumps.FallbackCacheMetadata:
 			s.onFallbackCacheMetadata(meta)
+		case diff := <-s.configDumps.ConfigDiffs:
+			s.onConfigDiff(diff)
+		case result := <-s.configDumps.DryRunResults:
+			s.onDryRunResult(result)
 		case <-ctx.Done():
 			if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
 				s.logger.Error(err, "Shutting down diagnostic config collection: context completed with error")
@@ -174,6 +219,18 @@ func (s *Server) onFallbackCacheMetadata(meta fallback.GeneratedCacheMetadata) {
 	s.currentFallbackCacheMetadata = &meta
 }
 
+func (s *Server) onConfigDiff(diff ConfigDiff) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.lastConfigDiff = &diff
+}
+
+func (s *Server) onDryRunResult(result DryRunResult) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.lastDryRunResult = &result
+}
+
 // installProfilingHandlers adds the Profiling webservice to the given mux.
 func installProfilingHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/pprof", redirectTo("/debug/pprof/"))
@@ -195,6 +252,14 @@ func (s *Server) installConfigDebugHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/config/failed", s.handleLastFailedConfig)
 	mux.HandleFunc("/debug/config/fallback", s.handleCurrentFallback)
 	mux.HandleFunc("/debug/config/raw-error", s.handleLastErrBody)
+	mux.HandleFunc("/debug/config/diff", s.handleLastConfigDiff)
+	mux.HandleFunc("/debug/config/dry-run", s.handleLastDryRunResult)
+	if s.configPinToken != "" {
+		mux.HandleFunc("/debug/config/history", s.withConfigPinToken(s.handleConfigHistory))
+		mux.HandleFunc("/debug/config/pin", s.withConfigPinToken(s.handleConfigPin))
+	} else {
+		mux.HandleFunc("/debug/config/history", s.handleConfigHistory)
+	}
 }
 
 // redirectTo redirects request to a certain destination.
@@ -252,3 +317,86 @@ func (s *Server) handleLastErrBody(rw http.ResponseWriter, _ *http.Request) {
 		rw.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+func (s *Server) handleLastConfigDiff(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	if err := json.NewEncoder(rw).Encode(mapConfigDiffIntoConfigDiffResponse(s.lastConfigDiff)); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleLastDryRunResult(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	if err := json.NewEncoder(rw).Encode(mapDryRunResultIntoDryRunResponse(s.lastDryRunResult)); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleConfigHistory(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(mapConfigHistoryIntoConfigHistoryResponse(s.configDumps.ConfigHistory)); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// handleConfigPin pins gateways to a configuration from history (POST with the `id` query parameter), releases
+// the pin (DELETE) or returns the pinned configuration's ID (GET). Requests have to be authorized with the pin token.
+func (s *Server) handleConfigPin(rw http.ResponseWriter, req *http.Request) {
+	history := s.configDumps.ConfigHistory
+
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		id, err := strconv.ParseUint(req.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			http.Error(rw, "id query parameter has to be a configuration ID from /debug/config/history", http.StatusBadRequest)
+			return
+		}
+		if err := history.Pin(id); err != nil {
+			http.Error(rw, err.Error(), http.StatusNotFound)
+			return
+		}
+		s.logger.Info("Gateways pinned to configuration from history", "id", id)
+	case http.MethodDelete:
+		if history.Unpin() {
+			s.logger.Info("Gateways unpinned from configuration from history")
+		}
+	default:
+		rw.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPost, http.MethodDelete}, ", "))
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var resp ConfigPinResponse
+	if pinned, _, ok := history.Pinned(); ok {
+		resp.PinnedID = lo.ToPtr(pinned.ID)
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(resp); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// withConfigPinToken wraps the handler with a check of the config pin token in the request's Authorization header.
+func (s *Server) withConfigPinToken(handler http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if !s.isConfigPinRequestAuthorized(req) {
+			rw.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(rw, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler(rw, req)
+	}
+}
+
+func (s *Server) isConfigPinRequestAuthorized(req *http.Request) bool {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.configPinToken)) == 1
+}
@@ -2,16 +2,20 @@ package diagnostics
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-database-reconciler/pkg/file"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 	testhelpers "github.com/kong/kubernetes-ingress-controller/v3/test/helpers"
 )
 
@@ -143,3 +147,175 @@ func TestServer_EventsHandling(t *testing.T) {
 		require.Nil(t, s.currentFallbackCacheMetadata, "expected fallback cache metadata to be dropped as it's no more relevant")
 	})
 }
+
+func TestServer_FallbackExcludedEntities(t *testing.T) {
+	s := NewServer(logr.Discard(), ServerConfig{
+		ConfigDumpsEnabled: true,
+	})
+	plugin := fallback.ObjectHash{
+		Group:     "configuration.konghq.com",
+		Kind:      "KongPlugin",
+		Namespace: "default",
+		Name:      "rate-limiting",
+		UID:       "plugin-uid",
+	}
+	s.onFallbackCacheMetadata(fallback.GeneratedCacheMetadata{
+		BrokenObjects: []fallback.ObjectHash{plugin},
+		ExcludedEntities: []fallback.BrokenEntity{
+			{
+				Type:          "plugin",
+				Name:          "rate-limiting",
+				ForeignKeys:   map[string]string{"route": "default.ingress.httpbin..80"},
+				CausingObject: plugin,
+			},
+		},
+	})
+
+	rw := httptest.NewRecorder()
+	s.handleCurrentFallback(rw, httptest.NewRequest(http.MethodGet, "/debug/config/fallback", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	var resp FallbackResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+	require.Equal(t, FallbackStatusTriggered, resp.Status)
+	require.Empty(t, resp.ExcludedObjects)
+	require.Equal(t, []FallbackExcludedEntity{
+		{
+			Type:          "plugin",
+			Name:          "rate-limiting",
+			ForeignKeys:   map[string]string{"route": "default.ingress.httpbin..80"},
+			CausingObject: plugin.String(),
+		},
+	}, resp.ExcludedEntities)
+}
+
+func TestServer_ConfigDiff(t *testing.T) {
+	s := NewServer(logr.Discard(), ServerConfig{
+		ConfigDumpsEnabled: true,
+	})
+
+	t.Run("no diff yet", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		s.handleLastConfigDiff(rw, httptest.NewRequest(http.MethodGet, "/debug/config/diff", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.JSONEq(t, `{"failed":false,"summary":{},"changes":[]}`, rw.Body.String())
+	})
+
+	t.Run("on config diff", func(t *testing.T) {
+		timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		s.onConfigDiff(ConfigDiff{
+			Timestamp: timestamp,
+			Failed:    true,
+			Diff: kongstate.StateDiff{
+				Changes: []kongstate.EntityChange{
+					{EntityType: kongstate.EntityTypeService, ChangeType: kongstate.ChangeTypeUpdate, Name: "svc", Fields: []string{"host"}},
+					{EntityType: kongstate.EntityTypeRoute, ChangeType: kongstate.ChangeTypeDelete, Name: "route"},
+				},
+			},
+		})
+
+		rw := httptest.NewRecorder()
+		s.handleLastConfigDiff(rw, httptest.NewRequest(http.MethodGet, "/debug/config/diff", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var resp ConfigDiffResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Timestamp)
+		require.True(t, timestamp.Equal(*resp.Timestamp))
+		require.True(t, resp.Failed)
+		require.Equal(t, ConfigDiffSummary{Update: 1}, resp.Summary["service"])
+		require.Equal(t, ConfigDiffSummary{Delete: 1}, resp.Summary["route"])
+		require.Equal(t, ConfigDiffSummary{}, resp.Summary["plugin"])
+		require.Equal(t, []ConfigDiffEntityChange{
+			{Type: "service", Action: "update", Name: "svc", Fields: []string{"host"}},
+			{Type: "route", Action: "delete", Name: "route"},
+		}, resp.Changes)
+	})
+}
+
+func TestServer_ConfigHistory(t *testing.T) {
+	const token = "secret-token"
+	s := NewServer(logr.Discard(), ServerConfig{
+		ConfigDumpsEnabled: true,
+		ConfigPinToken:     token,
+	})
+	mux := http.NewServeMux()
+	s.installConfigDebugHandlers(mux)
+	history := s.ConfigDumps().ConfigHistory
+	history.Record(ConfigHistoryEntry{
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		SHAs:      []string{"sha"},
+		ObjectChanges: []ObjectChange{
+			{Kind: "Ingress", Namespace: "default", Name: "ingress", ChangeType: ObjectChangeTypeCreate},
+		},
+		ObjectChangesCount: 1,
+	}, &kongstate.KongState{})
+
+	do := func(method, target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rw := httptest.NewRecorder()
+		mux.ServeHTTP(rw, req)
+		return rw
+	}
+
+	t.Run("history requires the token", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/debug/config/history", "").Code)
+		require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/debug/config/history", "wrong").Code)
+	})
+
+	t.Run("history is served", func(t *testing.T) {
+		rw := do(http.MethodGet, "/debug/config/history", token)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.JSONEq(t, `{
+			"entries": [{
+				"id": 1,
+				"timestamp": "2024-01-01T00:00:00Z",
+				"shas": ["sha"],
+				"fallback": false,
+				"objectChanges": [{"kind": "Ingress", "namespace": "default", "name": "ingress", "action": "create"}],
+				"objectChangesCount": 1
+			}]
+		}`, rw.Body.String())
+	})
+
+	t.Run("pinning requires the token", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/debug/config/pin?id=1", "").Code)
+		require.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/debug/config/pin?id=1", "wrong").Code)
+		_, _, pinned := history.Pinned()
+		require.False(t, pinned)
+	})
+
+	t.Run("pinning unknown configuration fails", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, do(http.MethodPost, "/debug/config/pin?id=2", token).Code)
+		require.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/debug/config/pin", token).Code)
+	})
+
+	t.Run("configuration is pinned and unpinned", func(t *testing.T) {
+		rw := do(http.MethodPost, "/debug/config/pin?id=1", token)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.JSONEq(t, `{"pinnedID": 1}`, rw.Body.String())
+		require.Contains(t, do(http.MethodGet, "/debug/config/history", token).Body.String(), `"pinnedID":1`)
+
+		rw = do(http.MethodDelete, "/debug/config/pin", token)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.JSONEq(t, `{"pinnedID": null}`, rw.Body.String())
+		_, _, pinned := history.Pinned()
+		require.False(t, pinned)
+	})
+
+	t.Run("pinning is disabled without a token", func(t *testing.T) {
+		s := NewServer(logr.Discard(), ServerConfig{ConfigDumpsEnabled: true})
+		mux := http.NewServeMux()
+		s.installConfigDebugHandlers(mux)
+		rw := httptest.NewRecorder()
+		mux.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/debug/config/pin?id=1", nil))
+		require.Equal(t, http.StatusNotFound, rw.Code)
+
+		rw = httptest.NewRecorder()
+		mux.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/debug/config/history", nil))
+		require.Equal(t, http.StatusOK, rw.Code, "history should be served without a token when pinning is disabled")
+	})
+}
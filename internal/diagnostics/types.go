@@ -1,9 +1,13 @@
 package diagnostics
 
 import (
+	"time"
+
 	"github.com/kong/go-database-reconciler/pkg/file"
 
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/fallback"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/kongstate"
 )
 
 // DumpMeta annotates a config dump.
@@ -26,6 +30,30 @@ type ConfigDump struct {
 	RawResponseBody []byte
 }
 
+// ConfigDiff is a difference between the configuration last successfully applied to Kong and a new configuration
+// pushed to Kong.
+type ConfigDiff struct {
+	// Diff contains changes of Kong entities.
+	Diff kongstate.StateDiff
+	// Failed indicates the new configuration was not accepted by Kong, so the changes were not applied.
+	Failed bool
+	// Timestamp is the time the diff was computed at.
+	Timestamp time.Time
+}
+
+// DryRunResult is a result of translating and validating a configuration in dry-run mode.
+type DryRunResult struct {
+	// Timestamp is the time the configuration was validated at.
+	Timestamp time.Time
+	// TranslationFailures are failures that occurred when translating Kubernetes objects into the configuration.
+	TranslationFailures []failures.ResourceFailure
+	// ValidationFailures are failures of Kubernetes objects that Kong entities failing validation were translated
+	// from. These objects would be reported as broken if the configuration was applied.
+	ValidationFailures []failures.ResourceFailure
+	// ValidationErr is the error returned when validating the configuration, if any.
+	ValidationErr error
+}
+
 // ConfigDumpDiagnostic contains settings and channels for receiving diagnostic configuration dumps.
 type ConfigDumpDiagnostic struct {
 	// DumpsIncludeSensitive is true if the configuration dump includes sensitive values, such as certificate private
@@ -35,4 +63,10 @@ type ConfigDumpDiagnostic struct {
 	Configs chan ConfigDump
 	// FallbackCacheMetadata is the channel that receives fallback metadata from the fallback cache generator.
 	FallbackCacheMetadata chan fallback.GeneratedCacheMetadata
+	// ConfigDiffs is the channel that receives differences between the last applied and new configurations.
+	ConfigDiffs chan ConfigDiff
+	// DryRunResults is the channel that receives results of validating configurations in dry-run mode.
+	DryRunResults chan DryRunResult
+	// ConfigHistory is the history of configurations pushed to gateways. Gateways can be pinned to one of them.
+	ConfigHistory *ConfigHistory
 }
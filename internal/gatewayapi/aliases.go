@@ -3,6 +3,7 @@ package gatewayapi
 import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -41,6 +42,7 @@ type (
 	HTTPMethod                = gatewayv1.HTTPMethod
 	HTTPPathMatch             = gatewayv1.HTTPPathMatch
 	HTTPQueryParamMatch       = gatewayv1.HTTPQueryParamMatch
+	HTTPRequestMirrorFilter   = gatewayv1.HTTPRequestMirrorFilter
 	HTTPRequestRedirectFilter = gatewayv1.HTTPRequestRedirectFilter
 	HTTPRoute                 = gatewayv1.HTTPRoute
 	HTTPRouteFilter           = gatewayv1.HTTPRouteFilter
@@ -79,6 +81,7 @@ type (
 	RouteStatus               = gatewayv1.RouteStatus
 	SecretObjectReference     = gatewayv1.SecretObjectReference
 	SectionName               = gatewayv1.SectionName
+	TLSModeType               = gatewayv1.TLSModeType
 	GRPCBackendRef            = gatewayv1.GRPCBackendRef
 	GRPCHeaderMatch           = gatewayv1.GRPCHeaderMatch
 	GRPCHeaderName            = gatewayv1.GRPCHeaderName
@@ -108,6 +111,23 @@ type (
 	UDPRouteRule         = gatewayv1alpha2.UDPRouteRule
 	UDPRouteSpec         = gatewayv1alpha2.UDPRouteSpec
 	UDPRouteStatus       = gatewayv1alpha2.UDPRouteStatus
+
+	LocalPolicyTargetReference                = gatewayv1alpha2.LocalPolicyTargetReference
+	LocalPolicyTargetReferenceWithSectionName = gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+
+	BackendLBPolicy        = gatewayv1alpha2.BackendLBPolicy
+	BackendLBPolicyList    = gatewayv1alpha2.BackendLBPolicyList
+	BackendLBPolicySpec    = gatewayv1alpha2.BackendLBPolicySpec
+	CookieConfig           = gatewayv1.CookieConfig
+	CookieLifetimeType     = gatewayv1.CookieLifetimeType
+	SessionPersistence     = gatewayv1.SessionPersistence
+	SessionPersistenceType = gatewayv1.SessionPersistenceType
+
+	BackendTLSPolicy            = gatewayv1alpha3.BackendTLSPolicy
+	BackendTLSPolicyList        = gatewayv1alpha3.BackendTLSPolicyList
+	BackendTLSPolicySpec        = gatewayv1alpha3.BackendTLSPolicySpec
+	BackendTLSPolicyValidation  = gatewayv1alpha3.BackendTLSPolicyValidation
+	WellKnownCACertificatesType = gatewayv1alpha3.WellKnownCACertificatesType
 )
 
 const (
@@ -168,6 +188,7 @@ const (
 	RouteReasonNotAllowedByListeners      = gatewayv1.RouteReasonNotAllowedByListeners
 	RouteReasonRefNotPermitted            = gatewayv1.RouteReasonRefNotPermitted
 	RouteReasonResolvedRefs               = gatewayv1.RouteReasonResolvedRefs
+	RouteReasonUnsupportedValue           = gatewayv1.RouteReasonUnsupportedValue
 	TCPProtocolType                       = gatewayv1.TCPProtocolType
 	TLSModePassthrough                    = gatewayv1.TLSModePassthrough
 	TLSModeTerminate                      = gatewayv1.TLSModeTerminate
@@ -180,4 +201,11 @@ const (
 	PolicyConditionAccepted = gatewayv1alpha2.PolicyConditionAccepted
 	PolicyReasonAccepted    = gatewayv1alpha2.PolicyReasonAccepted
 	PolicyReasonConflicted  = gatewayv1alpha2.PolicyReasonConflicted
+	PolicyReasonInvalid     = gatewayv1alpha2.PolicyReasonInvalid
+
+	WellKnownCACertificatesSystem = gatewayv1alpha3.WellKnownCACertificatesSystem
+
+	CookieBasedSessionPersistence = gatewayv1.CookieBasedSessionPersistence
+	HeaderBasedSessionPersistence = gatewayv1.HeaderBasedSessionPersistence
+	PermanentCookieLifetimeType   = gatewayv1.PermanentCookieLifetimeType
 )
@@ -6,6 +6,11 @@ import (
 
 const (
 	V1Group = Group(gatewayv1.GroupName)
+
+	// ManagedGatewayFinalizer is set on managed Gateways, i.e. Gateways the controller provisions Kong proxies for,
+	// and on unmanaged Gateways annotated with konghq.com/admin-service to make sure the Admin API endpoints of their
+	// dedicated data-planes are unregistered before the Gateways are gone.
+	ManagedGatewayFinalizer = "konghq.com/managed-gateway-cleanup"
 )
 
 var V1GroupVersion = gatewayv1.GroupVersion.Version
@@ -12,12 +12,19 @@ const (
 	// ValidateKey is the key used to indicate a Secret contains plugin configuration.
 	ValidateKey = "/validate"
 
+	// ManagedGatewayKey is the key used to indicate which Gateway a managed proxy resource belongs to.
+	ManagedGatewayKey = "/managed-gateway"
+
 	// CredentialTypeLabel is the label used to indicate a Secret's credential type.
 	CredentialTypeLabel = LabelPrefix + CredentialKey
 
 	// ValidateLabel is applied to plugins used for plugin configuration to allow the admission webhook to check
 	// updates to them.
 	ValidateLabel = LabelPrefix + ValidateKey
+
+	// ManagedGatewayLabel is applied to Deployments and Services provisioned for managed Gateways.
+	// Its value is the name of the Gateway the resource was provisioned for.
+	ManagedGatewayLabel = LabelPrefix + ManagedGatewayKey
 )
 
 // ValidateType indicates the type of validation applied to a Secret.
@@ -7,7 +7,9 @@ import (
 
 	"github.com/samber/mo"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	cliflag "k8s.io/component-base/cli/flag"
@@ -18,6 +20,8 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/annotations"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/diagnostics"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/konnect"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/license"
 	cfgtypes "github.com/kong/kubernetes-ingress-controller/v3/internal/manager/config/types"
@@ -48,19 +52,28 @@ type Config struct {
 	LogFormat string
 
 	// Kong high-level controller manager configurations
-	KongAdminAPIConfig                adminapi.HTTPClientOpts
-	KongAdminInitializationRetries    uint
-	KongAdminInitializationRetryDelay time.Duration
-	KongAdminToken                    string
-	KongAdminTokenPath                string
-	KongWorkspace                     string
-	AnonymousReports                  bool
-	EnableReverseSync                 bool
-	UseLastValidConfigForFallback     bool
-	SyncPeriod                        time.Duration
-	SkipCACertificates                bool
-	CacheSyncTimeout                  time.Duration
-	GracefulShutdownTimeout           *time.Duration
+	KongAdminAPIConfig                 adminapi.HTTPClientOpts
+	KongAdminInitializationRetries     uint
+	KongAdminInitializationRetryDelay  time.Duration
+	KongAdminToken                     string
+	KongAdminTokenPath                 string
+	KongWorkspace                      string
+	AnonymousReports                   bool
+	EnableReverseSync                  bool
+	ConfigDriftDetectionInterval       time.Duration
+	CredentialTypes                    []string
+	UseLastValidConfigForFallback      bool
+	UseEntityLevelExclusionForFallback bool
+	CompressDBLessConfig               bool
+	LargeDBLessConfigWarningThreshold  int
+	DryRun                             bool
+	DryRunShadowKongAdminURL           string
+	StagedRollout                      sendconfig.StagedRolloutConfig
+	LastValidConfigSecret              OptionalNamespacedName
+	SyncPeriod                         time.Duration
+	SkipCACertificates                 bool
+	CacheSyncTimeout                   time.Duration
+	GracefulShutdownTimeout            *time.Duration
 
 	// Kong Proxy configurations
 	APIServerHost               string
@@ -127,14 +140,28 @@ type Config struct {
 	// KIC can only reconciling the specified Gateway.
 	GatewayToReconcile OptionalNamespacedName
 
+	// Managed Gateways configuration, used when the ManagedGateways feature gate is enabled.
+	ManagedGatewayProxyImage    string
+	ManagedGatewayProxyReplicas int
+	ManagedGatewayServiceType   string
+	ManagedGatewayAdminCertFile string
+	ManagedGatewayAdminKeyFile  string
+
 	// Admission Webhook server config
 	AdmissionServer admission.ServerConfig
+	// AdmissionShadowKongAdminURL is the Admin API URL of a DB-less Kong Gateway used by the admission webhook
+	// to validate objects in the context of the whole configuration. Empty disables such validation.
+	AdmissionShadowKongAdminURL string
 
 	// Diagnostics and performance
-	EnableProfiling      bool
-	EnableConfigDumps    bool
-	DumpSensitiveConfig  bool
-	DiagnosticServerPort int
+	EnableProfiling             bool
+	EnableConfigDumps           bool
+	DumpSensitiveConfig         bool
+	ConfigHistorySize           int
+	ConfigPinTokenFile          string
+	DiagnosticServerTLSCertFile string
+	DiagnosticServerTLSKeyFile  string
+	DiagnosticServerPort        int
 
 	// Feature Gates
 	FeatureGates map[string]bool
@@ -181,9 +208,24 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringVar(&c.KongWorkspace, "kong-workspace", "", "Kong Enterprise workspace to configure. Leave this empty if not using Kong workspaces.")
 	flagSet.BoolVar(&c.AnonymousReports, "anonymous-reports", true, `Send anonymized usage data to help improve Kong.`)
 	flagSet.BoolVar(&c.EnableReverseSync, "enable-reverse-sync", false, `Send configuration to Kong even if the configuration checksum has not changed since previous update.`)
+	flagSet.DurationVar(&c.ConfigDriftDetectionInterval, "config-drift-detection-interval", 0, `Interval of checking whether configuration of DB-less gateways drifted from the configuration pushed to them, e.g. because it was changed through the Admin API or a gateway restarted with different configuration. Drifted gateways get the configuration pushed again. Drift detection is disabled when set to 0. It's not supported for DB-backed gateways.`)
+	flagSet.StringSliceVar(&c.CredentialTypes, "credential-type", nil, `Credential type(s) (name:entity_type) provided by Kong credential plugins, in comma-separated format (or specify this flag multiple times). KongConsumer credential Secrets labeled with the type name are validated against the schema of the entity type fetched from Kong and sent to Kong as entities of that type. Only supported with DB-less Kong Gateways.`)
 	// TODO: When FallbackConfiguration graduates we should remove the feature gate mention from the help text.
 	// https://github.com/Kong/kubernetes-ingress-controller/issues/6170
 	flagSet.BoolVar(&c.UseLastValidConfigForFallback, "use-last-valid-config-for-fallback", false, fmt.Sprintf(`When recovering from config push failures, use the last valid configuration cache to backfill broken objects. It can only be used with the %s feature gate enabled.`, featuregates.FallbackConfiguration))
+	flagSet.BoolVar(&c.UseEntityLevelExclusionForFallback, "use-entity-level-exclusion-for-fallback", false, fmt.Sprintf(`When recovering from config push failures, exclude only broken plugin instances instead of whole broken KongPlugins and KongClusterPlugins along with all objects using them. Please note that objects using them stay configured without the excluded plugins. Only instances of plugins observing traffic or caching responses (e.g. http-log, prometheus or proxy-cache) are excluded on their own, other plugins are always excluded along with objects using them. It can only be used with the %s feature gate enabled.`, featuregates.FallbackConfiguration))
+	flagSet.BoolVar(&c.CompressDBLessConfig, "compress-dbless-config", false, `Send DB-less configuration to Kong compressed with gzip and streamed in chunks instead of as a single uncompressed body. When Kong's Admin API, or any proxy in front of it, rejects gzip-encoded request bodies, configuration is sent to that gateway uncompressed instead.`)
+	flagSet.IntVar(&c.LargeDBLessConfigWarningThreshold, "large-dbless-config-warning-threshold", sendconfig.DefaultLargeDBLessConfigWarningThreshold, `Size of serialized DB-less configuration, in bytes, above which a warning listing namespaces contributing the most entities is logged. Set to 0 to disable the warning.`)
+	flagSet.BoolVar(&c.DryRun, "dry-run", false, `Translate and validate configuration against Kong without applying it, writing Kubernetes objects or their status or emitting Kubernetes events. Leader election is disabled. Results are exposed by the diagnostics server (with --dump-config) and metrics.`)
+	flagSet.StringVar(&c.DryRunShadowKongAdminURL, "dry-run-shadow-kong-admin-url", "", `Admin API URL of a DB-less Kong Gateway dedicated to dry-run validation (its configuration is replaced on every validation). When set, the whole configuration is validated at once, detecting conflicts between entities, instead of validating each entity on its own. Uses the same TLS client configuration and token as --kong-admin-url. It can only be used with --dry-run.`)
+	flagSet.Var(flags.NewValidatedValue(&c.StagedRollout.Canaries, canariesFromFlagValue, flags.WithTypeNameOverride[intstr.IntOrString]("int-or-percent")), "staged-rollout-canaries",
+		`Number (e.g. 1) or percentage (e.g. 10%) of discovered gateways that get new configuration first. The rest of gateways get it only if the canaries stay healthy for --staged-rollout-soak-period. Canaries that fail are rolled back to the last valid configuration. Staged rollout is disabled when not set.`)
+	flagSet.DurationVar(&c.StagedRollout.SoakPeriod, "staged-rollout-soak-period", sendconfig.DefaultStagedRolloutSoakPeriod, `The time canary gateways are health checked before configuration is pushed to the rest of gateways. Used only with --staged-rollout-canaries.`)
+	flagSet.DurationVar(&c.StagedRollout.HealthCheckInterval, "staged-rollout-health-check-interval", sendconfig.DefaultStagedRolloutHealthCheckInterval, `The minimal interval of health checks of canary gateways. Canaries are health checked on configuration syncs, so they're checked at most as often as configuration is synced. Used only with --staged-rollout-canaries.`)
+	flagSet.IntVar(&c.StagedRollout.MaxHealthCheckFailures, "staged-rollout-max-health-check-failures", 0, `Number of failed health checks tolerated per canary gateway during the soak period. Used only with --staged-rollout-canaries.`)
+	flagSet.Float64Var(&c.StagedRollout.MaxErrorRate, "staged-rollout-max-error-rate", 0, `Ratio (between 0 and 1) of requests proxied by a canary gateway during the soak period that may fail with a 5xx status code. Requires the Prometheus plugin with status_code_metrics enabled. Zero disables checking the error rate. Used only with --staged-rollout-canaries.`)
+	flagSet.Var(flags.NewValidatedValue(&c.LastValidConfigSecret, namespacedNameFromFlagValue, nnTypeNameOverride), "last-valid-config-secret",
+		`Secret in "namespace/name" format to persist the last valid configuration in, so that it's available to recover from configuration failures after the controller restarts. Configuration exceeding the size of a single Secret is split into Secrets with an index suffix appended to the name. Writing Secrets is not granted by the controller's ClusterRole, grant it in the Secret's namespace (see the last_valid_config_secret kustomize component).`)
 	// Default has to be explicitly passed to generate the proper docs. See https://github.com/kubernetes-sigs/controller-runtime/blob/f1c5dd3851ce3df8b4b7830d9b6eae6271f6932d/pkg/cache/cache.go#L146-L151.
 	flagSet.DurationVar(&c.SyncPeriod, "sync-period", 10*time.Hour, `Determine the minimum frequency at which watched resources are reconciled. Set to 0 to use default from controller-runtime.`)
 	flagSet.BoolVar(&c.SkipCACertificates, "skip-ca-certificates", false, `Disable syncing CA certificate syncing (for use with multi-workspace environments).`)
@@ -268,6 +310,16 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.BoolVar(&c.GatewayAPIGRPCRouteController, "enable-controller-gwapi-grpcroute", true, "Enable the Gateway API GRPCRoute controller.")
 	flagSet.Var(flags.NewValidatedValue(&c.GatewayToReconcile, namespacedNameFromFlagValue, nnTypeNameOverride), "gateway-to-reconcile",
 		`Gateway namespaced name in "namespace/name" format. Makes KIC reconcile only the specified Gateway.`)
+	flagSet.StringVar(&c.ManagedGatewayProxyImage, "managed-gateway-proxy-image", gateway.DefaultManagedGatewayProxyImage,
+		`Kong Gateway image used for proxies provisioned for managed Gateways. Used only with the ManagedGateways feature gate enabled.`)
+	flagSet.IntVar(&c.ManagedGatewayProxyReplicas, "managed-gateway-proxy-replicas", 1,
+		`Number of replicas of proxies provisioned for managed Gateways. Used only with the ManagedGateways feature gate enabled.`)
+	flagSet.StringVar(&c.ManagedGatewayServiceType, "managed-gateway-service-type", string(corev1.ServiceTypeLoadBalancer),
+		`Type of the Service exposing listeners of managed Gateways. One of: ClusterIP, NodePort, LoadBalancer. Used only with the ManagedGateways feature gate enabled.`)
+	flagSet.StringVar(&c.ManagedGatewayAdminCertFile, "managed-gateway-admin-tls-cert-file", "",
+		`Path to PEM-encoded certificate the Admin API of proxies provisioned for managed Gateways is served with. It has to be trusted by the controller (see --kong-admin-ca-cert). Required with the ManagedGateways feature gate enabled.`)
+	flagSet.StringVar(&c.ManagedGatewayAdminKeyFile, "managed-gateway-admin-tls-key-file", "",
+		`Path to PEM-encoded private key of --managed-gateway-admin-tls-cert-file. Required with the ManagedGateways feature gate enabled.`)
 	flagSet.BoolVar(&c.KongServiceFacadeEnabled, "enable-controller-kong-service-facade", true, "Enable the KongServiceFacade controller.")
 	flagSet.BoolVar(&c.KongVaultEnabled, "enable-controller-kong-vault", true, "Enable the KongVault controller.")
 	flagSet.BoolVar(&c.KongLicenseEnabled, "enable-controller-kong-license", true, "Enable the KongLicense controller.")
@@ -286,11 +338,19 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 		`Admission server PEM certificate value. Mutually exclusive with --admission-webhook-cert-file.`)
 	flagSet.StringVar(&c.AdmissionServer.Key, "admission-webhook-key", "",
 		`Admission server PEM private key value. Mutually exclusive with --admission-webhook-key-file.`)
+	flagSet.StringVar(&c.AdmissionShadowKongAdminURL, "admission-webhook-shadow-kong-admin-url", "",
+		`Admin API URL of a DB-less Kong Gateway dedicated to admission validation (its configuration is replaced on every validation). `+
+			`When set, objects allowed by the admission webhook are additionally validated in the context of the whole configuration translated from the cluster state. `+
+			`Uses the same TLS client configuration and token as --kong-admin-url.`)
 
 	// Diagnostics
 	flagSet.BoolVar(&c.EnableProfiling, "profiling", false, fmt.Sprintf("Enable profiling via web interface host:%v/debug/pprof/.", DiagnosticsPort))
 	flagSet.BoolVar(&c.EnableConfigDumps, "dump-config", false, fmt.Sprintf("Enable config dumps via web interface host:%v/debug/config.", DiagnosticsPort))
 	flagSet.BoolVar(&c.DumpSensitiveConfig, "dump-sensitive-config", false, "Include credentials and TLS secrets in configs exposed with --dump-config flag.")
+	flagSet.IntVar(&c.ConfigHistorySize, "dump-config-history-size", diagnostics.DefaultConfigHistorySize, fmt.Sprintf("Number of configurations pushed to gateways kept in history exposed with --dump-config flag via web interface host:%v/debug/config/history.", DiagnosticsPort))
+	flagSet.StringVar(&c.ConfigPinTokenFile, "dump-config-pin-token-file", "", fmt.Sprintf(`Path to a file with a bearer token authorizing requests to web interface host:%v/debug/config/pin, which pins gateways to a configuration from history until the pin is released, and to host:%v/debug/config/history. Pinning is disabled when not set. Requires --dump-config, --diagnostic-server-tls-cert-file and --diagnostic-server-tls-key-file flags.`, DiagnosticsPort, DiagnosticsPort))
+	flagSet.StringVar(&c.DiagnosticServerTLSCertFile, "diagnostic-server-tls-cert-file", "", `Path to a PEM certificate file to serve the profiling and config dump server over HTTPS with. Requires --diagnostic-server-tls-key-file.`)
+	flagSet.StringVar(&c.DiagnosticServerTLSKeyFile, "diagnostic-server-tls-key-file", "", `Path to a PEM private key file to serve the profiling and config dump server over HTTPS with. Requires --diagnostic-server-tls-cert-file.`)
 	flagSet.IntVar(&c.DiagnosticServerPort, "diagnostic-server-port", DiagnosticsPort, "The port to listen on for the profiling and config dump server.")
 	_ = flagSet.MarkHidden("diagnostic-server-port")
 
@@ -4,12 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/samber/lo"
 	"github.com/samber/mo"
+	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
 	cfgtypes "github.com/kong/kubernetes-ingress-controller/v3/internal/manager/config/types"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/featuregates"
 )
@@ -53,6 +58,24 @@ func dnsStrategyFromFlagValue(flagValue string) (cfgtypes.DNSStrategy, error) {
 	return strategy, nil
 }
 
+func canariesFromFlagValue(flagValue string) (intstr.IntOrString, error) {
+	canaries := intstr.Parse(flagValue)
+	if canaries.Type == intstr.Int {
+		if canaries.IntVal < 0 {
+			return intstr.IntOrString{}, errors.New("number of canaries cannot be negative")
+		}
+		return canaries, nil
+	}
+	percentage, ok := strings.CutSuffix(flagValue, "%")
+	if !ok {
+		return intstr.IntOrString{}, errors.New("the expected format is a number (e.g. 1) or a percentage (e.g. 10%)")
+	}
+	if p, err := strconv.Atoi(percentage); err != nil || p < 0 || p > 100 {
+		return intstr.IntOrString{}, errors.New("percentage has to be a number between 0 and 100")
+	}
+	return canaries, nil
+}
+
 // Validate validates the config. It should be used to validate the config variables' interdependencies.
 // When a single variable is to be validated, *FromFlagValue function should be implemented.
 func (c *Config) Validate() error {
@@ -74,6 +97,30 @@ func (c *Config) Validate() error {
 	if err := c.validateFallbackConfiguration(); err != nil {
 		return fmt.Errorf("invalid fallback config settings: %w", err)
 	}
+	if err := c.validateManagedGateways(); err != nil {
+		return fmt.Errorf("invalid managed gateways config settings: %w", err)
+	}
+	if c.LargeDBLessConfigWarningThreshold < 0 {
+		return errors.New("--large-dbless-config-warning-threshold can't be negative")
+	}
+	if c.ConfigDriftDetectionInterval < 0 {
+		return errors.New("--config-drift-detection-interval can't be negative")
+	}
+	if err := c.validateDryRun(); err != nil {
+		return fmt.Errorf("invalid dry run config settings: %w", err)
+	}
+	if err := c.validateDiagnostics(); err != nil {
+		return fmt.Errorf("invalid diagnostics config settings: %w", err)
+	}
+	if err := c.validateStagedRollout(); err != nil {
+		return fmt.Errorf("invalid staged rollout config settings: %w", err)
+	}
+	if err := c.validateAdmissionShadowKong(); err != nil {
+		return fmt.Errorf("invalid admission webhook config settings: %w", err)
+	}
+	if err := c.validateCredentialTypes(); err != nil {
+		return fmt.Errorf("invalid credential types: %w", err)
+	}
 
 	return nil
 }
@@ -116,6 +163,58 @@ func (c *Config) validateFallbackConfiguration() error {
 			featuregates.FallbackConfiguration,
 		)
 	}
+	if !c.FeatureGates[featuregates.FallbackConfiguration] && c.UseEntityLevelExclusionForFallback {
+		return fmt.Errorf(
+			"--use-entity-level-exclusion-for-fallback or CONTROLLER_USE_ENTITY_LEVEL_EXCLUSION_FOR_FALLBACK can only be used with %s feature gate enabled",
+			featuregates.FallbackConfiguration,
+		)
+	}
+	if c.UseLastValidConfigForFallback && c.UseEntityLevelExclusionForFallback {
+		return errors.New("--use-last-valid-config-for-fallback and --use-entity-level-exclusion-for-fallback are mutually exclusive")
+	}
+	return nil
+}
+
+func (c *Config) validateDryRun() error {
+	if !c.DryRun {
+		if c.DryRunShadowKongAdminURL != "" {
+			return errors.New("--dry-run-shadow-kong-admin-url can only be used with --dry-run")
+		}
+		return nil
+	}
+	// The shadow gateway's configuration is replaced on every validation, it must never serve traffic.
+	if c.DryRunShadowKongAdminURL != "" && lo.Contains(c.KongAdminURLs, c.DryRunShadowKongAdminURL) {
+		return errors.New("--dry-run-shadow-kong-admin-url can't point to one of --kong-admin-url")
+	}
+	if c.Konnect.ConfigSynchronizationEnabled {
+		return errors.New("--dry-run can't be used with --konnect-sync-enabled")
+	}
+	if c.ConfigDriftDetectionInterval > 0 {
+		return errors.New("--dry-run can't be used with --config-drift-detection-interval")
+	}
+	if c.FeatureGates[featuregates.ManagedGateways] {
+		return fmt.Errorf("--dry-run can't be used with %s feature gate enabled", featuregates.ManagedGateways)
+	}
+	if c.LeaderElectionForce == LeaderElectionEnabled {
+		return errors.New("--dry-run can't be used with --force-leader-election=enabled, dry run never takes part in leader election")
+	}
+	return nil
+}
+
+func (c *Config) validateDiagnostics() error {
+	if c.EnableConfigDumps && c.ConfigHistorySize < 1 {
+		return errors.New("--dump-config-history-size has to be positive")
+	}
+	if c.ConfigPinTokenFile != "" && !c.EnableConfigDumps {
+		return errors.New("--dump-config-pin-token-file can only be used with --dump-config")
+	}
+	if (c.DiagnosticServerTLSCertFile == "") != (c.DiagnosticServerTLSKeyFile == "") {
+		return errors.New("--diagnostic-server-tls-cert-file and --diagnostic-server-tls-key-file have to be set together")
+	}
+	// The token would be sent over the network in plain text otherwise.
+	if c.ConfigPinTokenFile != "" && c.DiagnosticServerTLSCertFile == "" {
+		return errors.New("--dump-config-pin-token-file can only be used with --diagnostic-server-tls-cert-file and --diagnostic-server-tls-key-file")
+	}
 	return nil
 }
 
@@ -140,3 +239,86 @@ func validateClientTLS(clientTLS adminapi.TLSClientConfig) error {
 
 	return nil
 }
+
+func (c *Config) validateManagedGateways() error {
+	if !c.FeatureGates[featuregates.ManagedGateways] {
+		return nil
+	}
+	if c.ManagedGatewayProxyImage == "" {
+		return errors.New("--managed-gateway-proxy-image can't be empty")
+	}
+	if c.ManagedGatewayProxyReplicas < 1 {
+		return errors.New("--managed-gateway-proxy-replicas has to be positive")
+	}
+	// Admin APIs of provisioned proxies are reachable from the whole cluster, so they only accept the controller's
+	// client certificate.
+	if c.KongAdminAPIConfig.TLSClient.Cert == "" && c.KongAdminAPIConfig.TLSClient.CertFile == "" {
+		return errors.New("--kong-admin-tls-client-cert or --kong-admin-tls-client-cert-file is required, " +
+			"Admin APIs of proxies provisioned for managed Gateways only accept clients presenting it")
+	}
+	if c.ManagedGatewayAdminCertFile == "" || c.ManagedGatewayAdminKeyFile == "" {
+		return errors.New("--managed-gateway-admin-tls-cert-file and --managed-gateway-admin-tls-key-file are required, " +
+			"Admin APIs of proxies provisioned for managed Gateways are served with them")
+	}
+	// The certificate is verified against the controller's CA in setup, but without a CA configured only
+	// publicly trusted certificates would pass, which is not something the proxies could be served with.
+	if !c.KongAdminAPIConfig.TLSSkipVerify && c.KongAdminAPIConfig.CACert == "" && c.KongAdminAPIConfig.CACertPath == "" {
+		return errors.New("--kong-admin-ca-cert or --kong-admin-ca-cert-file is required to verify " +
+			"Admin APIs of proxies provisioned for managed Gateways")
+	}
+	switch serviceType := corev1.ServiceType(c.ManagedGatewayServiceType); serviceType {
+	case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+		return nil
+	default:
+		return fmt.Errorf("unsupported --managed-gateway-service-type %q, must be one of: %s, %s, %s",
+			serviceType, corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer)
+	}
+}
+
+func (c *Config) validateStagedRollout() error {
+	if !c.StagedRollout.Enabled() {
+		return nil
+	}
+	if c.StagedRollout.SoakPeriod < 0 {
+		return errors.New("--staged-rollout-soak-period can't be negative")
+	}
+	if c.StagedRollout.HealthCheckInterval <= 0 {
+		return errors.New("--staged-rollout-health-check-interval has to be positive")
+	}
+	if c.StagedRollout.MaxHealthCheckFailures < 0 {
+		return errors.New("--staged-rollout-max-health-check-failures can't be negative")
+	}
+	if c.StagedRollout.MaxErrorRate < 0 || c.StagedRollout.MaxErrorRate > 1 {
+		return errors.New("--staged-rollout-max-error-rate has to be between 0 and 1")
+	}
+	return nil
+}
+
+func (c *Config) validateAdmissionShadowKong() error {
+	if c.AdmissionShadowKongAdminURL == "" {
+		return nil
+	}
+	if c.AdmissionServer.ListenAddr == "off" {
+		return errors.New("--admission-webhook-shadow-kong-admin-url can't be used with the admission webhook server disabled")
+	}
+	// The shadow gateway's configuration is replaced on every validation, it must never serve traffic.
+	if lo.Contains(c.KongAdminURLs, c.AdmissionShadowKongAdminURL) {
+		return errors.New("--admission-webhook-shadow-kong-admin-url can't point to one of --kong-admin-url")
+	}
+	return nil
+}
+
+func (c *Config) validateCredentialTypes() error {
+	registry := credentials.NewDefaultRegistry()
+	for _, value := range c.CredentialTypes {
+		t, err := credentials.ParseSchemaBasedType(value)
+		if err != nil {
+			return err
+		}
+		if _, ok := registry.Get(t.Name); ok {
+			return fmt.Errorf("credential type %s is already registered", t.Name)
+		}
+		registry.Register(t)
+	}
+	return nil
+}
@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/samber/mo"
 	"github.com/stretchr/testify/require"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/sendconfig"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/featuregates"
 )
@@ -111,6 +115,34 @@ func TestConfigValidatedVars(t *testing.T) {
 				ExpectedErrorContains: "namespace cannot be empty",
 			},
 		},
+		"--staged-rollout-canaries": {
+			{
+				Input: "2",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.StagedRollout.Canaries
+				},
+				ExpectedValue: intstr.FromInt32(2),
+			},
+			{
+				Input: "25%",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.StagedRollout.Canaries
+				},
+				ExpectedValue: intstr.FromString("25%"),
+			},
+			{
+				Input:                 "-1",
+				ExpectedErrorContains: "number of canaries cannot be negative",
+			},
+			{
+				Input:                 "150%",
+				ExpectedErrorContains: "percentage has to be a number between 0 and 100",
+			},
+			{
+				Input:                 "one",
+				ExpectedErrorContains: "the expected format is a number (e.g. 1) or a percentage (e.g. 10%)",
+			},
+		},
 	}
 
 	for flag, flagTestCases := range testCasesGroupedByFlag {
@@ -320,4 +352,309 @@ func TestConfigValidate(t *testing.T) {
 			require.NoError(t, c.Validate())
 		})
 	})
+
+	t.Run("--use-entity-level-exclusion-for-fallback", func(t *testing.T) {
+		t.Run("enabled without feature gate is rejected", func(t *testing.T) {
+			c := manager.Config{
+				UseEntityLevelExclusionForFallback: true,
+			}
+			require.ErrorContains(t, c.Validate(), "--use-entity-level-exclusion-for-fallback or CONTROLLER_USE_ENTITY_LEVEL_EXCLUSION_FOR_FALLBACK can only be used with FallbackConfiguration feature gate enabled")
+		})
+		t.Run("enabled with feature gate is accepted", func(t *testing.T) {
+			c := manager.Config{
+				UseEntityLevelExclusionForFallback: true,
+				FeatureGates: map[string]bool{
+					featuregates.FallbackConfiguration: true,
+				},
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("enabled with --use-last-valid-config-for-fallback is rejected", func(t *testing.T) {
+			c := manager.Config{
+				UseEntityLevelExclusionForFallback: true,
+				UseLastValidConfigForFallback:      true,
+				FeatureGates: map[string]bool{
+					featuregates.FallbackConfiguration: true,
+				},
+			}
+			require.ErrorContains(t, c.Validate(), "--use-last-valid-config-for-fallback and --use-entity-level-exclusion-for-fallback are mutually exclusive")
+		})
+	})
+
+	t.Run("--dry-run", func(t *testing.T) {
+		t.Run("enabled is accepted", func(t *testing.T) {
+			c := manager.Config{
+				DryRun: true,
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("enabled with managed gateways is rejected", func(t *testing.T) {
+			c := manager.Config{
+				DryRun:                      true,
+				ManagedGatewayServiceType:   "ClusterIP",
+				ManagedGatewayProxyImage:    "kong:3.7",
+				ManagedGatewayProxyReplicas: 1,
+				ManagedGatewayAdminCertFile: "admin.crt",
+				ManagedGatewayAdminKeyFile:  "admin.key",
+				KongAdminAPIConfig: adminapi.HTTPClientOpts{
+					TLSClient: adminapi.TLSClientConfig{Cert: "cert", Key: "key"},
+					CACert:    "ca",
+				},
+				FeatureGates: map[string]bool{
+					featuregates.ManagedGateways: true,
+				},
+			}
+			require.ErrorContains(t, c.Validate(), "--dry-run can't be used with ManagedGateways feature gate enabled")
+		})
+		t.Run("enabled with shadow gateway is accepted", func(t *testing.T) {
+			c := manager.Config{
+				DryRun:                   true,
+				DryRunShadowKongAdminURL: "http://kong-shadow-admin:8001",
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("enabled with forced leader election is rejected", func(t *testing.T) {
+			c := manager.Config{
+				DryRun:              true,
+				LeaderElectionForce: manager.LeaderElectionEnabled,
+			}
+			require.ErrorContains(t, c.Validate(), "--dry-run can't be used with --force-leader-election=enabled")
+		})
+		t.Run("shadow gateway without --dry-run is rejected", func(t *testing.T) {
+			c := manager.Config{
+				DryRunShadowKongAdminURL: "http://kong-shadow-admin:8001",
+			}
+			require.ErrorContains(t, c.Validate(), "--dry-run-shadow-kong-admin-url can only be used with --dry-run")
+		})
+		t.Run("shadow gateway pointing to one of gateways is rejected", func(t *testing.T) {
+			c := manager.Config{
+				DryRun:                   true,
+				KongAdminURLs:            []string{"http://kong-admin:8001"},
+				DryRunShadowKongAdminURL: "http://kong-admin:8001",
+			}
+			require.ErrorContains(t, c.Validate(), "--dry-run-shadow-kong-admin-url can't point to one of --kong-admin-url")
+		})
+	})
+	t.Run("managed gateways", func(t *testing.T) {
+		validConfig := func() manager.Config {
+			return manager.Config{
+				ManagedGatewayServiceType:   "LoadBalancer",
+				ManagedGatewayProxyImage:    "kong:3.7",
+				ManagedGatewayProxyReplicas: 1,
+				ManagedGatewayAdminCertFile: "admin.crt",
+				ManagedGatewayAdminKeyFile:  "admin.key",
+				KongAdminAPIConfig: adminapi.HTTPClientOpts{
+					TLSClient: adminapi.TLSClientConfig{Cert: "cert", Key: "key"},
+					CACert:    "ca",
+				},
+				FeatureGates: map[string]bool{
+					featuregates.ManagedGateways: true,
+				},
+			}
+		}
+		t.Run("valid config is accepted", func(t *testing.T) {
+			c := validConfig()
+			require.NoError(t, c.Validate())
+		})
+		t.Run("empty proxy image is rejected", func(t *testing.T) {
+			c := validConfig()
+			c.ManagedGatewayProxyImage = ""
+			require.ErrorContains(t, c.Validate(), "--managed-gateway-proxy-image can't be empty")
+		})
+		t.Run("no proxy replicas is rejected", func(t *testing.T) {
+			c := validConfig()
+			c.ManagedGatewayProxyReplicas = 0
+			require.ErrorContains(t, c.Validate(), "--managed-gateway-proxy-replicas has to be positive")
+		})
+		t.Run("missing Admin API client certificate is rejected", func(t *testing.T) {
+			c := validConfig()
+			c.KongAdminAPIConfig.TLSClient = adminapi.TLSClientConfig{}
+			require.ErrorContains(t, c.Validate(), "--kong-admin-tls-client-cert or --kong-admin-tls-client-cert-file is required")
+		})
+		t.Run("missing Admin API certificate or key is rejected", func(t *testing.T) {
+			c := validConfig()
+			c.ManagedGatewayAdminKeyFile = ""
+			require.ErrorContains(t, c.Validate(), "--managed-gateway-admin-tls-cert-file and --managed-gateway-admin-tls-key-file are required")
+		})
+		t.Run("missing Admin API CA is rejected", func(t *testing.T) {
+			c := validConfig()
+			c.KongAdminAPIConfig.CACert = ""
+			require.ErrorContains(t, c.Validate(), "--kong-admin-ca-cert or --kong-admin-ca-cert-file is required")
+		})
+		t.Run("missing Admin API CA is accepted with TLS verification skipped", func(t *testing.T) {
+			c := validConfig()
+			c.KongAdminAPIConfig.CACert = ""
+			c.KongAdminAPIConfig.TLSSkipVerify = true
+			require.NoError(t, c.Validate())
+		})
+	})
+	t.Run("--config-drift-detection-interval", func(t *testing.T) {
+		t.Run("positive is accepted", func(t *testing.T) {
+			c := manager.Config{ConfigDriftDetectionInterval: time.Minute}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("negative is rejected", func(t *testing.T) {
+			c := manager.Config{ConfigDriftDetectionInterval: -time.Minute}
+			require.ErrorContains(t, c.Validate(), "--config-drift-detection-interval can't be negative")
+		})
+		t.Run("with --dry-run is rejected", func(t *testing.T) {
+			c := manager.Config{ConfigDriftDetectionInterval: time.Minute, DryRun: true}
+			require.ErrorContains(t, c.Validate(), "--dry-run can't be used with --config-drift-detection-interval")
+		})
+	})
+	t.Run("--large-dbless-config-warning-threshold", func(t *testing.T) {
+		t.Run("0 is accepted", func(t *testing.T) {
+			c := manager.Config{LargeDBLessConfigWarningThreshold: 0}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("negative is rejected", func(t *testing.T) {
+			c := manager.Config{LargeDBLessConfigWarningThreshold: -1}
+			require.ErrorContains(t, c.Validate(), "--large-dbless-config-warning-threshold can't be negative")
+		})
+	})
+	t.Run("--staged-rollout-canaries", func(t *testing.T) {
+		t.Run("enabled with defaults is accepted", func(t *testing.T) {
+			c := manager.Config{
+				StagedRollout: sendconfig.StagedRolloutConfig{
+					Canaries:            intstr.FromString("10%"),
+					SoakPeriod:          sendconfig.DefaultStagedRolloutSoakPeriod,
+					HealthCheckInterval: sendconfig.DefaultStagedRolloutHealthCheckInterval,
+				},
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("enabled with non-positive health check interval is rejected", func(t *testing.T) {
+			c := manager.Config{
+				StagedRollout: sendconfig.StagedRolloutConfig{
+					Canaries:   intstr.FromInt32(1),
+					SoakPeriod: sendconfig.DefaultStagedRolloutSoakPeriod,
+				},
+			}
+			require.ErrorContains(t, c.Validate(), "--staged-rollout-health-check-interval has to be positive")
+		})
+		t.Run("enabled with max error rate above 1 is rejected", func(t *testing.T) {
+			c := manager.Config{
+				StagedRollout: sendconfig.StagedRolloutConfig{
+					Canaries:            intstr.FromInt32(1),
+					SoakPeriod:          sendconfig.DefaultStagedRolloutSoakPeriod,
+					HealthCheckInterval: sendconfig.DefaultStagedRolloutHealthCheckInterval,
+					MaxErrorRate:        1.5,
+				},
+			}
+			require.ErrorContains(t, c.Validate(), "--staged-rollout-max-error-rate has to be between 0 and 1")
+		})
+	})
+	t.Run("--admission-webhook-shadow-kong-admin-url", func(t *testing.T) {
+		t.Run("with admission webhook enabled is accepted", func(t *testing.T) {
+			c := manager.Config{
+				AdmissionServer:             admission.ServerConfig{ListenAddr: ":8080"},
+				KongAdminURLs:               []string{"http://kong-admin:8001"},
+				AdmissionShadowKongAdminURL: "http://kong-shadow-admin:8001",
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("with admission webhook disabled is rejected", func(t *testing.T) {
+			c := manager.Config{
+				AdmissionServer:             admission.ServerConfig{ListenAddr: "off"},
+				AdmissionShadowKongAdminURL: "http://kong-shadow-admin:8001",
+			}
+			require.ErrorContains(t, c.Validate(), "--admission-webhook-shadow-kong-admin-url can't be used with the admission webhook server disabled")
+		})
+		t.Run("pointing to a configured gateway is rejected", func(t *testing.T) {
+			c := manager.Config{
+				AdmissionServer:             admission.ServerConfig{ListenAddr: ":8080"},
+				KongAdminURLs:               []string{"http://kong-admin:8001"},
+				AdmissionShadowKongAdminURL: "http://kong-admin:8001",
+			}
+			require.ErrorContains(t, c.Validate(), "--admission-webhook-shadow-kong-admin-url can't point to one of --kong-admin-url")
+		})
+	})
+	t.Run("--dump-config-pin-token-file", func(t *testing.T) {
+		t.Run("with --dump-config and TLS is accepted", func(t *testing.T) {
+			c := manager.Config{
+				EnableConfigDumps:           true,
+				ConfigHistorySize:           1,
+				ConfigPinTokenFile:          "/etc/kic/pin-token",
+				DiagnosticServerTLSCertFile: "/etc/kic/tls.crt",
+				DiagnosticServerTLSKeyFile:  "/etc/kic/tls.key",
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("without TLS is rejected", func(t *testing.T) {
+			c := manager.Config{
+				EnableConfigDumps:  true,
+				ConfigHistorySize:  1,
+				ConfigPinTokenFile: "/etc/kic/pin-token",
+			}
+			require.ErrorContains(t, c.Validate(), "--dump-config-pin-token-file can only be used with --diagnostic-server-tls-cert-file and --diagnostic-server-tls-key-file")
+		})
+		t.Run("without --dump-config is rejected", func(t *testing.T) {
+			c := manager.Config{
+				ConfigPinTokenFile: "/etc/kic/pin-token",
+			}
+			require.ErrorContains(t, c.Validate(), "--dump-config-pin-token-file can only be used with --dump-config")
+		})
+		t.Run("with non-positive history size is rejected", func(t *testing.T) {
+			c := manager.Config{
+				EnableConfigDumps: true,
+			}
+			require.ErrorContains(t, c.Validate(), "--dump-config-history-size has to be positive")
+		})
+	})
+	t.Run("--diagnostic-server-tls-cert-file", func(t *testing.T) {
+		t.Run("with --diagnostic-server-tls-key-file is accepted", func(t *testing.T) {
+			c := manager.Config{
+				DiagnosticServerTLSCertFile: "/etc/kic/tls.crt",
+				DiagnosticServerTLSKeyFile:  "/etc/kic/tls.key",
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("without --diagnostic-server-tls-key-file is rejected", func(t *testing.T) {
+			c := manager.Config{
+				DiagnosticServerTLSCertFile: "/etc/kic/tls.crt",
+			}
+			require.ErrorContains(t, c.Validate(), "--diagnostic-server-tls-cert-file and --diagnostic-server-tls-key-file have to be set together")
+		})
+		t.Run("--diagnostic-server-tls-key-file alone is rejected", func(t *testing.T) {
+			c := manager.Config{
+				DiagnosticServerTLSKeyFile: "/etc/kic/tls.key",
+			}
+			require.ErrorContains(t, c.Validate(), "--diagnostic-server-tls-cert-file and --diagnostic-server-tls-key-file have to be set together")
+		})
+	})
+	t.Run("--last-valid-config-secret", func(t *testing.T) {
+		nn := k8stypes.NamespacedName{Namespace: "kong", Name: "last-valid-config"}
+		t.Run("alone is accepted", func(t *testing.T) {
+			c := manager.Config{
+				LastValidConfigSecret: mo.Some(nn),
+			}
+			require.NoError(t, c.Validate())
+		})
+	})
+	t.Run("--credential-type", func(t *testing.T) {
+		t.Run("new type is accepted", func(t *testing.T) {
+			c := manager.Config{
+				CredentialTypes: []string{"custom-auth:custom_auth_credentials"},
+			}
+			require.NoError(t, c.Validate())
+		})
+		t.Run("invalid format is rejected", func(t *testing.T) {
+			c := manager.Config{
+				CredentialTypes: []string{"custom_auth_credentials"},
+			}
+			require.ErrorContains(t, c.Validate(), `credential type "custom_auth_credentials" is not in the name:entity_type format`)
+		})
+		t.Run("built-in type is rejected", func(t *testing.T) {
+			c := manager.Config{
+				CredentialTypes: []string{"key-auth:keyauth_credentials"},
+			}
+			require.ErrorContains(t, c.Validate(), "credential type key-auth is already registered")
+		})
+		t.Run("duplicated type is rejected", func(t *testing.T) {
+			c := manager.Config{
+				CredentialTypes: []string{"custom-auth:custom_auth_credentials", "custom-auth:other_credentials"},
+			}
+			require.ErrorContains(t, c.Validate(), "credential type custom-auth is already registered")
+		})
+	})
 }
@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/controllers"
@@ -65,8 +66,11 @@ func setupControllers(
 	kubernetesStatusQueue *status.Queue,
 	c *Config,
 	featureGates featuregates.FeatureGates,
+	expressionRoutes bool,
+	untrustedLua bool,
 	kongAdminAPIEndpointsNotifier configuration.EndpointsNotifier,
 	adminAPIsDiscoverer configuration.AdminAPIsDiscoverer,
+	managedGateways *gateway.ManagedGatewaysConfig,
 ) []ControllerDef {
 	controllers := []ControllerDef{
 		// ---------------------------------------------------------------------------
@@ -222,6 +226,18 @@ func setupControllers(
 				StatusQueue:                kubernetesStatusQueue,
 			},
 		},
+		{
+			Enabled: c.KongConsumerEnabled,
+			Controller: &configuration.KongConsumerCredentialRotationReconciler{
+				Client:                     mgr.GetClient(),
+				Log:                        ctrl.LoggerFrom(ctx).WithName("controllers").WithName("KongConsumerCredentialRotation"),
+				Scheme:                     mgr.GetScheme(),
+				CacheSyncTimeout:           c.CacheSyncTimeout,
+				IngressClassName:           c.IngressClassName,
+				DisableIngressClassLookups: !c.IngressClassNetV1Enabled,
+				UpdateStatus:               c.UpdateStatus,
+			},
+		},
 		{
 			Enabled: c.KongConsumerEnabled,
 			Controller: &configuration.KongV1Beta1KongConsumerGroupReconciler{
@@ -333,6 +349,7 @@ func setupControllers(
 					CacheSyncTimeout:     c.CacheSyncTimeout,
 					ReferenceIndexers:    referenceIndexers,
 					GatewayNN:            controllers.NewOptionalNamespacedName(c.GatewayToReconcile),
+					ManagedGateways:      managedGateways,
 				},
 			},
 		},
@@ -348,13 +365,16 @@ func setupControllers(
 					Resource: "httproutes",
 				}),
 				Controller: &gateway.HTTPRouteReconciler{
-					Client:           mgr.GetClient(),
-					Log:              ctrl.LoggerFrom(ctx).WithName("controllers").WithName("HTTPRoute"),
-					Scheme:           mgr.GetScheme(),
-					DataplaneClient:  dataplaneClient,
-					CacheSyncTimeout: c.CacheSyncTimeout,
-					StatusQueue:      kubernetesStatusQueue,
-					GatewayNN:        controllers.NewOptionalNamespacedName(c.GatewayToReconcile),
+					Client:                 mgr.GetClient(),
+					Log:                    ctrl.LoggerFrom(ctx).WithName("controllers").WithName("HTTPRoute"),
+					Scheme:                 mgr.GetScheme(),
+					DataplaneClient:        dataplaneClient,
+					CacheSyncTimeout:       c.CacheSyncTimeout,
+					StatusQueue:            kubernetesStatusQueue,
+					GatewayNN:              controllers.NewOptionalNamespacedName(c.GatewayToReconcile),
+					ExpressionRoutes:       expressionRoutes,
+					HTTPRouteRequestMirror: featureGates.Enabled(featuregates.HTTPRouteRequestMirror),
+					UntrustedLua:           untrustedLua,
 				},
 			},
 		},
@@ -469,6 +489,49 @@ func setupControllers(
 				},
 			},
 		},
+		{
+			Enabled: featureGates.Enabled(featuregates.GatewayAlphaFeature),
+			Controller: &crds.DynamicCRDController{
+				Manager:          mgr,
+				Log:              ctrl.LoggerFrom(ctx).WithName("controllers").WithName("Dynamic/BackendTLSPolicy"),
+				CacheSyncTimeout: c.CacheSyncTimeout,
+				RequiredCRDs: append(baseGatewayCRDs(), schema.GroupVersionResource{
+					Group:    gatewayv1alpha3.GroupVersion.Group,
+					Version:  gatewayv1alpha3.GroupVersion.Version,
+					Resource: "backendtlspolicies",
+				}),
+				Controller: &gateway.BackendTLSPolicyReconciler{
+					Client:            mgr.GetClient(),
+					Log:               ctrl.LoggerFrom(ctx).WithName("controllers").WithName("BackendTLSPolicy"),
+					Scheme:            mgr.GetScheme(),
+					DataplaneClient:   dataplaneClient,
+					CacheSyncTimeout:  c.CacheSyncTimeout,
+					StatusQueue:       kubernetesStatusQueue,
+					ReferenceIndexers: referenceIndexers,
+				},
+			},
+		},
+		{
+			Enabled: featureGates.Enabled(featuregates.GatewayAlphaFeature),
+			Controller: &crds.DynamicCRDController{
+				Manager:          mgr,
+				Log:              ctrl.LoggerFrom(ctx).WithName("controllers").WithName("Dynamic/BackendLBPolicy"),
+				CacheSyncTimeout: c.CacheSyncTimeout,
+				RequiredCRDs: append(baseGatewayCRDs(), schema.GroupVersionResource{
+					Group:    gatewayv1alpha2.GroupVersion.Group,
+					Version:  gatewayv1alpha2.GroupVersion.Version,
+					Resource: "backendlbpolicies",
+				}),
+				Controller: &gateway.BackendLBPolicyReconciler{
+					Client:           mgr.GetClient(),
+					Log:              ctrl.LoggerFrom(ctx).WithName("controllers").WithName("BackendLBPolicy"),
+					Scheme:           mgr.GetScheme(),
+					DataplaneClient:  dataplaneClient,
+					CacheSyncTimeout: c.CacheSyncTimeout,
+					StatusQueue:      kubernetesStatusQueue,
+				},
+			},
+		},
 	}
 
 	return controllers
@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"context"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WriteDiscardingClient decorates client.Client so that all writes to objects and their subresources are discarded.
+// It's used in dry-run mode to make sure controllers never modify Kubernetes objects (e.g. update their status,
+// annotate Secrets or persist the last valid configuration) while reads still hit the API server.
+type WriteDiscardingClient struct {
+	client.Client
+}
+
+func NewWriteDiscardingClient(c client.Client) WriteDiscardingClient {
+	return WriteDiscardingClient{Client: c}
+}
+
+// Create discards the write.
+func (c WriteDiscardingClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return nil
+}
+
+// Update discards the write.
+func (c WriteDiscardingClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return nil
+}
+
+// Patch discards the write.
+func (c WriteDiscardingClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return nil
+}
+
+// Delete discards the write.
+func (c WriteDiscardingClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return nil
+}
+
+// DeleteAllOf discards the write.
+func (c WriteDiscardingClient) DeleteAllOf(context.Context, client.Object, ...client.DeleteAllOfOption) error {
+	return nil
+}
+
+// Status returns a status writer discarding all writes.
+func (c WriteDiscardingClient) Status() client.SubResourceWriter {
+	return discardingSubResourceWriter{}
+}
+
+// SubResource returns a client for the given subresource reading from the API server and discarding all writes.
+func (c WriteDiscardingClient) SubResource(subResource string) client.SubResourceClient {
+	return discardingSubResourceClient{SubResourceReader: c.Client.SubResource(subResource)}
+}
+
+// discardingSubResourceWriter is a client.SubResourceWriter that discards all writes.
+type discardingSubResourceWriter struct{}
+
+func (discardingSubResourceWriter) Create(context.Context, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (discardingSubResourceWriter) Update(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (discardingSubResourceWriter) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+	return nil
+}
+
+// discardingSubResourceClient is a client.SubResourceClient that reads from the API server and discards all writes.
+type discardingSubResourceClient struct {
+	client.SubResourceReader
+	discardingSubResourceWriter
+}
+
+// newDryRunManagerClient generates a controller-runtime client discarding all writes and wraps it in our override
+// decorator.
+func newDryRunManagerClient(config *rest.Config, options client.Options) (client.Client, error) {
+	base, err := client.New(config, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypeMetaSettingClient(NewWriteDiscardingClient(base)), nil
+}
@@ -0,0 +1,62 @@
+package manager_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager"
+)
+
+func TestWriteDiscardingClient(t *testing.T) {
+	ctx := context.Background()
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"},
+	}
+	base := fake.NewClientBuilder().WithObjects(ingress).WithStatusSubresource(ingress).Build()
+	c := manager.NewWriteDiscardingClient(base)
+
+	getIngress := func() *netv1.Ingress {
+		got := &netv1.Ingress{}
+		require.NoError(t, base.Get(ctx, client.ObjectKeyFromObject(ingress), got))
+		return got
+	}
+	withStatus := func() *netv1.Ingress {
+		updated := getIngress()
+		updated.Status.LoadBalancer.Ingress = []netv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}}
+		return updated
+	}
+
+	t.Run("reads are not discarded", func(t *testing.T) {
+		got := &netv1.Ingress{}
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ingress), got))
+		require.Equal(t, ingress.Name, got.Name)
+	})
+
+	t.Run("status writes are discarded", func(t *testing.T) {
+		require.NoError(t, c.Status().Update(ctx, withStatus()))
+		require.NoError(t, c.SubResource("status").Update(ctx, withStatus()))
+		require.Empty(t, getIngress().Status.LoadBalancer.Ingress)
+	})
+
+	t.Run("object writes are discarded", func(t *testing.T) {
+		updated := getIngress()
+		updated.Labels = map[string]string{"updated": "true"}
+		require.NoError(t, c.Update(ctx, updated))
+		require.NoError(t, c.Patch(ctx, updated, client.MergeFrom(getIngress())))
+		require.Empty(t, getIngress().Labels)
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "default"}}
+		require.NoError(t, c.Create(ctx, secret))
+		require.Error(t, base.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{}))
+
+		require.NoError(t, c.Delete(ctx, getIngress()))
+		getIngress()
+	})
+}
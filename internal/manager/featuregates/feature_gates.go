@@ -39,6 +39,19 @@ const (
 	// https://github.com/Kong/kubernetes-ingress-controller/issues/6124
 	KongCustomEntity = "KongCustomEntity"
 
+	// ManagedGateways is the name of the feature-gate that enables provisioning of a dedicated Kong proxy
+	// (Deployment and Services) for each Gateway whose GatewayClass is not annotated as unmanaged.
+	ManagedGateways = "ManagedGateways"
+
+	// IngressIncrementalTranslation is the name of the feature-gate that enables reusing translation results of Ingresses
+	// not affected by changes since the previous translation instead of translating all Ingresses every time.
+	// Other objects are always translated.
+	IngressIncrementalTranslation = "IngressIncrementalTranslation"
+
+	// HTTPRouteRequestMirror is the name of the feature-gate that enables translating HTTPRoute RequestMirror filters.
+	// Requests are mirrored by generated pre-function plugins, which require Kong's untrusted_lua to be set to on.
+	HTTPRouteRequestMirror = "HTTPRouteRequestMirror"
+
 	// DocsURL provides a link to the documentation for feature gates in the KIC repository.
 	DocsURL = "https://github.com/Kong/kubernetes-ingress-controller/blob/main/FEATURE_GATES.md"
 )
@@ -79,12 +92,15 @@ func (fg FeatureGates) Enabled(feature string) bool {
 // NOTE: if you're adding a new feature gate, it needs to be added here.
 func GetFeatureGatesDefaults() FeatureGates {
 	return map[string]bool{
-		GatewayAlphaFeature:        false,
-		FillIDsFeature:             true,
-		RewriteURIsFeature:         false,
-		KongServiceFacade:          false,
-		SanitizeKonnectConfigDumps: true,
-		FallbackConfiguration:      false,
-		KongCustomEntity:           false,
+		GatewayAlphaFeature:           false,
+		FillIDsFeature:                true,
+		RewriteURIsFeature:            false,
+		KongServiceFacade:             false,
+		SanitizeKonnectConfigDumps:    true,
+		FallbackConfiguration:         false,
+		KongCustomEntity:              false,
+		ManagedGateways:               false,
+		IngressIncrementalTranslation: false,
+		HTTPRouteRequestMirror:        false,
 	}
 }
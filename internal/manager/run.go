@@ -13,6 +13,7 @@ import (
 	"github.com/avast/retry-go/v4"
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -115,16 +116,21 @@ func Run(
 	kongSemVersion := semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
 
 	kongConfig := sendconfig.Config{
-		Version:                       kongSemVersion,
-		InMemory:                      dbMode.IsDBLessMode(),
-		Concurrency:                   c.Concurrency,
-		FilterTags:                    c.FilterTags,
-		SkipCACertificates:            c.SkipCACertificates,
-		EnableReverseSync:             c.EnableReverseSync,
-		ExpressionRoutes:              dpconf.ShouldEnableExpressionRoutes(routerFlavor),
-		SanitizeKonnectConfigDumps:    featureGates.Enabled(featuregates.SanitizeKonnectConfigDumps),
-		FallbackConfiguration:         featureGates.Enabled(featuregates.FallbackConfiguration),
-		UseLastValidConfigForFallback: c.UseLastValidConfigForFallback,
+		Version:                            kongSemVersion,
+		InMemory:                           dbMode.IsDBLessMode(),
+		Concurrency:                        c.Concurrency,
+		FilterTags:                         c.FilterTags,
+		SkipCACertificates:                 c.SkipCACertificates,
+		EnableReverseSync:                  c.EnableReverseSync,
+		ExpressionRoutes:                   dpconf.ShouldEnableExpressionRoutes(routerFlavor),
+		SanitizeKonnectConfigDumps:         featureGates.Enabled(featuregates.SanitizeKonnectConfigDumps),
+		FallbackConfiguration:              featureGates.Enabled(featuregates.FallbackConfiguration),
+		UseLastValidConfigForFallback:      c.UseLastValidConfigForFallback,
+		UseEntityLevelExclusionForFallback: c.UseEntityLevelExclusionForFallback,
+		CompressDBLessConfig:               c.CompressDBLessConfig,
+		LargeDBLessConfigWarningThreshold:  c.LargeDBLessConfigWarningThreshold,
+		DryRun:                             c.DryRun,
+		StagedRollout:                      c.StagedRollout,
 	}
 
 	setupLog.Info("Configuring and building the controller manager")
@@ -144,7 +150,10 @@ func Run(
 
 	setupLog.Info("Initializing Dataplane Client")
 	var eventRecorder record.EventRecorder
-	if c.EmitKubernetesEvents {
+	if c.DryRun {
+		setupLog.Info("Dry run mode enabled, discarding all events")
+		eventRecorder = &record.FakeRecorder{}
+	} else if c.EmitKubernetesEvents {
 		setupLog.Info("Emitting Kubernetes events enabled, creating an event recorder for " + KongClientEventRecorderComponentName)
 		eventRecorder = mgr.GetEventRecorderFor(KongClientEventRecorderComponentName)
 	} else {
@@ -165,16 +174,22 @@ func Run(
 	}
 	clientsManager = clientsManager.WithDBMode(dbMode)
 
-	if c.KongAdminSvc.IsPresent() {
+	// Admin API endpoints of proxies provisioned for managed Gateways are registered dynamically,
+	// so the loop is required with managed Gateways enabled as well.
+	if c.KongAdminSvc.IsPresent() || featureGates.Enabled(featuregates.ManagedGateways) {
 		setupLog.Info("Running AdminAPIClientsManager loop")
 		clientsManager.Run()
 	}
 
+	credentialTypes := setupCredentialTypes(setupLog, c, dbMode)
+
 	translatorFeatureFlags := translator.NewFeatureFlags(
 		featureGates,
 		routerFlavor,
+		dbMode,
 		c.UpdateStatus,
 		kongStartUpConfig.Version.IsKongGatewayEnterprise(),
+		kongStartUpConfig.UntrustedLua,
 	)
 
 	referenceIndexers := ctrlref.NewCacheIndexers(setupLog.WithName("reference-indexers"))
@@ -184,13 +199,24 @@ func Run(
 	if err != nil {
 		return fmt.Errorf("failed to create translator: %w", err)
 	}
+	configTranslator.InjectCredentialTypes(credentialTypes)
 
 	setupLog.Info("Starting Admission Server")
-	if err := setupAdmissionServer(ctx, c, clientsManager, referenceIndexers, mgr.GetClient(), logger, translatorFeatureFlags, storer); err != nil {
+	if err := setupAdmissionServer(
+		ctx, c, clientsManager, referenceIndexers, mgr.GetClient(), logger, translatorFeatureFlags, credentialTypes, storer, cache,
+	); err != nil {
 		return err
 	}
 
 	updateStrategyResolver := sendconfig.NewDefaultUpdateStrategyResolver(kongConfig, logger)
+	if c.DryRunShadowKongAdminURL != "" {
+		shadowClient, err := setupShadowGatewayClient(ctx, c, setupLog, c.DryRunShadowKongAdminURL)
+		if err != nil {
+			return fmt.Errorf("unable to setup dry-run shadow gateway: %w", err)
+		}
+		updateStrategyResolver = updateStrategyResolver.WithDryRunShadowGateway(shadowClient.AdminAPIClient())
+		setupLog.Info("Dry run validates whole configuration", "shadow_gateway", c.DryRunShadowKongAdminURL)
+	}
 	configurationChangeDetector := sendconfig.NewDefaultConfigurationChangeDetector(logger)
 	kongConfigFetcher := configfetcher.NewDefaultKongLastGoodConfigFetcher(translatorFeatureFlags.FillIDs, c.KongWorkspace)
 	fallbackConfigGenerator := fallback.NewGenerator(fallback.NewDefaultCacheGraphProvider(), logger)
@@ -213,6 +239,17 @@ func Run(
 		return fmt.Errorf("failed to initialize kong data-plane client: %w", err)
 	}
 
+	if persister, err := setupLastValidConfigPersister(logger, mgr, c); err != nil {
+		return fmt.Errorf("failed to set up last valid configuration persistence: %w", err)
+	} else if persister != nil {
+		setupLog.Info("Persisting last valid configuration enabled")
+		dataplaneClient.SetLastValidConfigPersister(persister)
+	}
+
+	if err := setupConfigDriftDetection(logger, mgr, dataplaneClient, c, dbMode); err != nil {
+		return fmt.Errorf("failed to set up configuration drift detection: %w", err)
+	}
+
 	setupLog.Info("Initializing Dataplane Synchronizer")
 	synchronizer, err := setupDataplaneSynchronizer(logger, mgr, dataplaneClient, c.ProxySyncSeconds, c.InitCacheSyncDuration)
 	if err != nil {
@@ -234,6 +271,38 @@ func Run(
 		return err
 	}
 
+	var managedGateways *gateway.ManagedGatewaysConfig
+	if featureGates.Enabled(featuregates.ManagedGateways) {
+		adminAPIPortName := "admin-tls"
+		if len(c.KongAdminSvcPortNames) > 0 {
+			adminAPIPortName = c.KongAdminSvcPortNames[0]
+		}
+		// Provisioned proxies run DB-less, so they can't be configured alongside DB-backed gateways.
+		if !dbMode.IsDBLessMode() {
+			return fmt.Errorf("%s feature gate can only be used with DB-less gateways", featuregates.ManagedGateways)
+		}
+		adminAPIClientCA, err := managedGatewayAdminAPIClientCA(c.KongAdminAPIConfig.TLSClient)
+		if err != nil {
+			return fmt.Errorf("failed to determine Admin API client CA for managed gateways: %w", err)
+		}
+		adminAPIServerCert, adminAPIServerKey, err := managedGatewayAdminAPIServerCert(c)
+		if err != nil {
+			return fmt.Errorf("failed to load Admin API certificate for managed gateways: %w", err)
+		}
+		managedGateways = &gateway.ManagedGatewaysConfig{
+			ProxyImage:           c.ManagedGatewayProxyImage,
+			ProxyReplicas:        int32(c.ManagedGatewayProxyReplicas),
+			ServiceType:          corev1.ServiceType(c.ManagedGatewayServiceType),
+			RouterFlavor:         string(routerFlavor),
+			AdminAPIPortName:     adminAPIPortName,
+			AdminAPIClientCACert: adminAPIClientCA,
+			AdminAPIServerCert:   adminAPIServerCert,
+			AdminAPIServerKey:    adminAPIServerKey,
+			AdminAPIsNotifier:    clientsManager,
+			AdminAPIsDiscoverer:  adminAPIsDiscoverer,
+		}
+	}
+
 	setupLog.Info("Starting Enabled Controllers")
 	controllers := setupControllers(
 		ctx,
@@ -245,8 +314,11 @@ func Run(
 		kubernetesStatusQueue,
 		c,
 		featureGates,
+		dpconf.ShouldEnableExpressionRoutes(routerFlavor),
+		kongStartUpConfig.UntrustedLua,
 		clientsManager,
 		adminAPIsDiscoverer,
+		managedGateways,
 	)
 	for _, c := range controllers {
 		if err := c.MaybeSetupWithManager(mgr); err != nil {
@@ -2,9 +2,13 @@ package manager
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -26,14 +30,17 @@ import (
 	ctrllicense "github.com/kong/kubernetes-ingress-controller/v3/controllers/license"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/admission/validation/consumers/credentials"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/clients"
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v3/internal/controllers/reference"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane"
 	dpconf "github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/config"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/configfetcher"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/translator"
 	konnectLicense "github.com/kong/kubernetes-ingress-controller/v3/internal/konnect/license"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/license"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/scheme"
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/manager/utils/kongconfig"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/kubernetes/object/status"
@@ -118,6 +125,11 @@ func setupManagerOptions(ctx context.Context, logger logr.Logger, c *Config, dbm
 		managerOpts.LeaderElectionNamespace = c.LeaderElectionNamespace
 	}
 
+	if c.DryRun {
+		logger.Info("Dry run mode enabled, Kubernetes objects will not be modified")
+		managerOpts.NewClient = newDryRunManagerClient
+	}
+
 	return managerOpts, nil
 }
 
@@ -127,6 +139,12 @@ const (
 )
 
 func leaderElectionEnabled(logger logr.Logger, c *Config, dbmode dpconf.DBMode) bool {
+	// A dry-run instance must never take the lease from the controller actually configuring Kong
+	// (they'd share the same --election-id by default).
+	if c.DryRun {
+		logger.Info("Dry run mode enabled, disabling leader election")
+		return false
+	}
 	if c.LeaderElectionForce == LeaderElectionEnabled {
 		logger.Info("leader election forcibly enabled")
 		return true
@@ -194,7 +212,9 @@ func setupAdmissionServer(
 	managerClient client.Client,
 	logger logr.Logger,
 	translatorFeatures translator.FeatureFlags,
+	credentialTypes *credentials.Registry,
 	storer store.Storer,
+	cache store.CacheStores,
 ) error {
 	admissionLogger := logger.WithName("admission-server")
 
@@ -203,6 +223,16 @@ func setupAdmissionServer(
 		return nil
 	}
 
+	configValidator, err := setupAdmissionConfigValidator(ctx, managerConfig, admissionLogger, translatorFeatures, credentialTypes, cache)
+	if err != nil {
+		return fmt.Errorf("failed to set up admission whole configuration validation: %w", err)
+	}
+
+	warningsProvider, err := admission.NewKongWarningsProvider(managerClient, translatorFeatures)
+	if err != nil {
+		return fmt.Errorf("failed to set up admission warnings: %w", err)
+	}
+
 	adminAPIServicesProvider := admission.NewDefaultAdminAPIServicesProvider(clientsManager)
 	srv, err := admission.MakeTLSServer(ctx, &managerConfig.AdmissionServer, &admission.RequestHandler{
 		Validator: admission.NewKongHTTPValidator(
@@ -211,9 +241,12 @@ func setupAdmissionServer(
 			managerConfig.IngressClassName,
 			adminAPIServicesProvider,
 			translatorFeatures,
+			credentialTypes,
 			storer,
 		),
 		ReferenceIndexers: referenceIndexers,
+		ConfigValidator:   configValidator,
+		WarningsProvider:  warningsProvider,
 		Logger:            admissionLogger,
 	}, admissionLogger)
 	if err != nil {
@@ -226,6 +259,62 @@ func setupAdmissionServer(
 	return nil
 }
 
+// setupAdmissionConfigValidator returns a validator validating objects in the context of the whole configuration
+// against a shadow gateway if --admission-webhook-shadow-kong-admin-url is set. Otherwise, it returns nil.
+func setupAdmissionConfigValidator(
+	ctx context.Context,
+	c *Config,
+	logger logr.Logger,
+	translatorFeatures translator.FeatureFlags,
+	credentialTypes *credentials.Registry,
+	cache store.CacheStores,
+) (admission.ConfigValidator, error) {
+	if c.AdmissionShadowKongAdminURL == "" {
+		return nil, nil
+	}
+
+	shadowClient, err := setupShadowGatewayClient(ctx, c, logger, c.AdmissionShadowKongAdminURL)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := admission.NewShadowGatewayConfigValidator(
+		logger, cache, c.IngressClassName, translatorFeatures, credentialTypes, shadowClient, admission.DefaultShadowGatewayLimits(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Whole configuration validation enabled", "shadow_gateway", c.AdmissionShadowKongAdminURL)
+	return validator, nil
+}
+
+// setupShadowGatewayClient returns a client of a shadow gateway used to validate whole configurations. The shadow
+// gateway has to be DB-less as configuration is validated with its `POST /config` endpoint.
+func setupShadowGatewayClient(ctx context.Context, c *Config, logger logr.Logger, url string) (*adminapi.Client, error) {
+	httpclient, err := adminapi.MakeHTTPClient(&c.KongAdminAPIConfig, c.KongAdminToken)
+	if err != nil {
+		return nil, err
+	}
+	shadowClient, err := adminapi.NewKongClientForWorkspace(ctx, url, "", httpclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shadow gateway client: %w", err)
+	}
+
+	roots, err := kongconfig.GetRoots(ctx, logger, c.KongAdminInitializationRetries, c.KongAdminInitializationRetryDelay,
+		[]*adminapi.Client{shadowClient})
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve shadow gateway root: %w", err)
+	}
+	startUpConfig, err := kongconfig.ValidateRoots(roots, c.SkipCACertificates)
+	if err != nil {
+		return nil, fmt.Errorf("could not validate shadow gateway root configuration: %w", err)
+	}
+	if !startUpConfig.DBMode.IsDBLessMode() {
+		return nil, fmt.Errorf("shadow gateway %s has to run in DB-less mode", url)
+	}
+	return shadowClient, nil
+}
+
 // setupDataplaneAddressFinder returns a default and UDP address finder. These finders return the override addresses if
 // set or the publish service addresses if no overrides are set. If no UDP overrides or UDP publish service are set,
 // the UDP finder will also return the default addresses. If no override or publish service is set, this function
@@ -474,3 +563,146 @@ func setupLicenseGetter(
 
 	return nil, nil
 }
+
+// setupCredentialTypes returns a registry of the credential types supported out of the box extended with the ones
+// configured with --credential-type. Credentials of the latter are rejected with DB-backed gateways, as they're sent
+// to Kong as generic entities.
+func setupCredentialTypes(logger logr.Logger, c *Config, dbMode dpconf.DBMode) *credentials.Registry {
+	if len(c.CredentialTypes) > 0 && !dbMode.IsDBLessMode() {
+		logger.Info("Credential types registered with --credential-type are only supported with DB-less gateways, " +
+			"credentials of these types will be rejected")
+	}
+	registry := credentials.NewDefaultRegistry()
+	for _, value := range c.CredentialTypes {
+		// The value has already been validated by Config.Validate.
+		t, _ := credentials.ParseSchemaBasedType(value)
+		registry.Register(t)
+	}
+	return registry
+}
+
+// setupConfigDriftDetection enables detecting drifts of configuration applied to gateways if it's configured.
+// Gateways backed by a database load configuration from it, so their drifts are not detected.
+func setupConfigDriftDetection(
+	logger logr.Logger,
+	mgr manager.Manager,
+	dataplaneClient *dataplane.KongClient,
+	c *Config,
+	dbMode dpconf.DBMode,
+) error {
+	if c.ConfigDriftDetectionInterval == 0 {
+		return nil
+	}
+	if !dbMode.IsDBLessMode() {
+		logger.Info("Configuration drift detection is not supported for DB-backed gateways, skipping")
+		return nil
+	}
+
+	dataplaneClient.SetConfigDriftDetector(configfetcher.NewDefaultConfigDriftDetector())
+	return mgr.Add(dataplane.NewConfigDriftDetectionLoop(
+		logger.WithName("config-drift-detection"),
+		dataplaneClient,
+		c.ConfigDriftDetectionInterval,
+	))
+}
+
+// setupLastValidConfigPersister sets up a persister of the last valid configuration when it's enabled. Configuration
+// is persisted in the background by a runnable added to the manager. It returns nil when persisting the last valid
+// configuration is disabled.
+func setupLastValidConfigPersister(logger logr.Logger, mgr manager.Manager, c *Config) (configfetcher.LastValidConfigPersister, error) {
+	nn, ok := c.LastValidConfigSecret.Get()
+	if !ok {
+		return nil, nil
+	}
+	kubeClient, err := c.GetKubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	persister := configfetcher.NewAsyncLastValidConfigPersister(
+		logger.WithName("last-valid-config-persister"),
+		configfetcher.NewKubernetesLastValidConfigPersister(kubeClient, nn),
+		configfetcher.DefaultLastValidConfigPersistInterval,
+	)
+	if err := mgr.Add(persister); err != nil {
+		return nil, fmt.Errorf("failed to add last valid configuration persister to the manager: %w", err)
+	}
+	return persister, nil
+}
+
+// managedGatewayAdminAPIClientCA returns the PEM-encoded CA certificate Admin APIs of proxies provisioned for managed
+// Gateways verify client certificates with. It's the last certificate of the controller's Admin API client
+// certificate bundle, i.e. the client certificate itself when it's self-signed or the top certificate of its chain.
+func managedGatewayAdminAPIClientCA(clientTLS adminapi.TLSClientConfig) (string, error) {
+	certPEM := []byte(clientTLS.Cert)
+	if clientTLS.CertFile != "" {
+		var err error
+		if certPEM, err = os.ReadFile(clientTLS.CertFile); err != nil {
+			return "", fmt.Errorf("failed to read Admin API client certificate file: %w", err)
+		}
+	}
+
+	var ca *pem.Block
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			ca = block
+		}
+	}
+	if ca == nil {
+		return "", errors.New("no certificate found in Admin API client certificate")
+	}
+	return string(pem.EncodeToMemory(ca)), nil
+}
+
+// managedGatewayAdminAPIServerCert returns the PEM-encoded certificate and key Admin APIs of proxies provisioned for
+// managed Gateways are served with. Unless the controller skips verification of Admin API certificates, the
+// certificate is verified against the controller's Admin API CA, so that the controller is able to talk to the proxies.
+func managedGatewayAdminAPIServerCert(c *Config) (cert string, key string, err error) {
+	certPEM, err := os.ReadFile(c.ManagedGatewayAdminCertFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read managed gateway Admin API certificate file: %w", err)
+	}
+	keyPEM, err := os.ReadFile(c.ManagedGatewayAdminKeyFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read managed gateway Admin API key file: %w", err)
+	}
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid managed gateway Admin API certificate: %w", err)
+	}
+	if c.KongAdminAPIConfig.TLSSkipVerify {
+		return string(certPEM), string(keyPEM), nil
+	}
+
+	caPEM := []byte(c.KongAdminAPIConfig.CACert)
+	if c.KongAdminAPIConfig.CACertPath != "" {
+		if caPEM, err = os.ReadFile(c.KongAdminAPIConfig.CACertPath); err != nil {
+			return "", "", fmt.Errorf("failed to read Admin API CA certificate file: %w", err)
+		}
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return "", "", errors.New("no certificate found in Admin API CA certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range keyPair.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid managed gateway Admin API certificate chain: %w", err)
+		}
+		intermediates.AddCert(intermediate)
+	}
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid managed gateway Admin API certificate: %w", err)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		// Without a server name set, Admin APIs are verified against their discovered addresses which
+		// are not known upfront, so only the certificate chain is verified.
+		DNSName: c.KongAdminAPIConfig.TLSServerName,
+	}); err != nil {
+		return "", "", fmt.Errorf("managed gateway Admin API certificate is not trusted by the controller: %w", err)
+	}
+	return string(certPEM), string(keyPEM), nil
+}
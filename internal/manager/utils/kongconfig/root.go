@@ -23,6 +23,8 @@ type KongStartUpOptions struct {
 	DBMode       dpconf.DBMode
 	RouterFlavor dpconf.RouterFlavor
 	Version      kong.Version
+	// UntrustedLua is true when all Kong instances are configured with untrusted_lua set to on.
+	UntrustedLua bool
 }
 
 // ValidateRoots checks if all provided kong roots are the same given that we
@@ -57,10 +59,15 @@ func ValidateRoots(roots []Root, skipCACerts bool) (*KongStartUpOptions, error)
 		return nil, err
 	}
 
+	// Unlike the fields above, untrusted_lua is allowed to differ between instances, so it's considered
+	// enabled only when it's enabled in all of them.
+	untrustedLua := lo.EveryBy(roots, UntrustedLuaFromRoot)
+
 	return &KongStartUpOptions{
 		DBMode:       dbMode,
 		RouterFlavor: routerFlavor,
 		Version:      kongVersion,
+		UntrustedLua: untrustedLua,
 	}, nil
 }
 
@@ -113,6 +120,17 @@ func RouterFlavorFromRoot(r Root) (dpconf.RouterFlavor, error) {
 	return dpconf.RouterFlavor(routerFlavorStr), nil
 }
 
+// UntrustedLuaFromRoot returns whether Kong allows running arbitrary Lua code in plugins like pre-function,
+// i.e. whether untrusted_lua is set to on. The sandbox mode doesn't allow the code the controller generates.
+func UntrustedLuaFromRoot(r Root) bool {
+	rootConfig, err := extractConfigurationFromRoot(r)
+	if err != nil {
+		return false
+	}
+	untrustedLua, _ := rootConfig["untrusted_lua"].(string)
+	return untrustedLua == "on"
+}
+
 func KongVersionFromRoot(r Root) (kong.Version, error) {
 	v := kong.VersionFromInfo(r)
 	kv, err := kong.ParseSemanticVersion(v)
@@ -49,6 +49,49 @@ func TestValidateRoots(t *testing.T) {
 	}
 }
 
+func TestValidateRoots_UntrustedLua(t *testing.T) {
+	rootWithUntrustedLua := func(t *testing.T, value string) Root {
+		var root Root
+		require.NoError(t, json.Unmarshal([]byte(dblessConfigJSON3_4_1), &root))
+		root["configuration"].(map[string]any)["untrusted_lua"] = value
+		return root
+	}
+
+	testCases := []struct {
+		name     string
+		values   []string
+		expected bool
+	}{
+		{
+			name:     "sandbox",
+			values:   []string{"sandbox"},
+			expected: false,
+		},
+		{
+			name:     "on in all instances",
+			values:   []string{"on", "on"},
+			expected: true,
+		},
+		{
+			name:     "on in some instances",
+			values:   []string{"on", "off"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var roots []Root
+			for _, value := range tc.values {
+				roots = append(roots, rootWithUntrustedLua(t, value))
+			}
+			kongOptions, err := ValidateRoots(roots, false)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, kongOptions.UntrustedLua)
+		})
+	}
+}
+
 const dblessConfigJSON3_4_1 = `
 {
 	"node_id": "69d063c5-761b-4bab-a426-c89da49a9409",
@@ -23,6 +23,9 @@ type CtrlFuncMetrics struct {
 	TranslationBrokenResources prometheus.Gauge
 	ConfigPushDuration         *prometheus.HistogramVec
 	ConfigPushSuccessTime      *prometheus.GaugeVec
+	ConfigPushPayloadSize      *prometheus.GaugeVec
+	ConfigSerializationTime    prometheus.Histogram
+	ConfigDriftCount           *prometheus.CounterVec
 
 	// Fallback config push metrics.
 	FallbackTranslationCount           *prometheus.CounterVec
@@ -34,6 +37,14 @@ type CtrlFuncMetrics struct {
 	FallbackCacheGeneratingDuration    *prometheus.HistogramVec
 	ProcessedConfigSnapshotCacheHit    prometheus.Counter
 	ProcessedConfigSnapshotCacheMiss   prometheus.Counter
+
+	// Config diff metrics.
+	ConfigDiffEntityCount *prometheus.GaugeVec
+
+	// Dry run metrics.
+	DryRunValidationCount           *prometheus.CounterVec
+	DryRunValidationBrokenResources prometheus.Gauge
+	DryRunValidationSuccessTime     prometheus.Gauge
 }
 
 const (
@@ -53,6 +64,8 @@ const (
 	ProtocolDBLess Protocol = "db-less"
 	// ProtocolDeck indicates that configuration was sent to Kong using the DB mode protocol (deck sync).
 	ProtocolDeck Protocol = "deck"
+	// ProtocolDryRun indicates that configuration was only validated against Kong's schemas without being applied.
+	ProtocolDryRun Protocol = "dry-run"
 
 	// ProtocolKey defines the key of the metric label indicating which protocol KIC used to configure Kong.
 	ProtocolKey string = "protocol"
@@ -72,6 +85,25 @@ const (
 	FailureReasonKey string = "failure_reason"
 )
 
+const (
+	// EntityTypeKey defines the key of the metric label indicating the type of Kong entity.
+	EntityTypeKey string = "entity_type"
+
+	// ChangeTypeKey defines the key of the metric label indicating the type of change (create, update or delete).
+	ChangeTypeKey string = "change_type"
+)
+
+const (
+	// EncodingKey defines the key of the metric label indicating the content encoding of a configuration payload.
+	EncodingKey string = "encoding"
+
+	// EncodingIdentity indicates that a configuration payload was not compressed.
+	EncodingIdentity string = "identity"
+
+	// EncodingGzip indicates that a configuration payload was compressed with gzip.
+	EncodingGzip string = "gzip"
+)
+
 const (
 	// DataplaneKey defines the name of the metric label indicating which dataplane this time series is relevant for.
 	DataplaneKey string = "dataplane"
@@ -85,6 +117,9 @@ const (
 	MetricNameTranslationCount           = "ingress_controller_translation_count"
 	MetricNameTranslationBrokenResources = "ingress_controller_translation_broken_resource_count"
 	MetricNameConfigPushDuration         = "ingress_controller_configuration_push_duration_milliseconds"
+	MetricNameConfigPushPayloadSize      = "ingress_controller_configuration_push_payload_size_bytes"
+	MetricNameConfigSerializationTime    = "ingress_controller_configuration_serialization_duration_milliseconds"
+	MetricNameConfigDriftCount           = "ingress_controller_configuration_drift_count"
 )
 
 // Fallback config push metrics names.
@@ -100,6 +135,18 @@ const (
 	MetricNameProcessedConfigSnapshotCacheMiss   = "ingress_controller_processed_config_snapshot_cache_miss"
 )
 
+// Config diff metrics names.
+const (
+	MetricNameConfigDiffEntityCount = "ingress_controller_configuration_diff_entity_count"
+)
+
+// Dry run metrics names.
+const (
+	MetricNameDryRunValidationCount           = "ingress_controller_dry_run_validation_count"
+	MetricNameDryRunValidationBrokenResources = "ingress_controller_dry_run_validation_broken_resource_count"
+	MetricNameDryRunValidationSuccessTime     = "ingress_controller_dry_run_validation_last_successful"
+)
+
 var _lock sync.Mutex
 
 func NewCtrlFuncMetrics() *CtrlFuncMetrics {
@@ -189,6 +236,38 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		[]string{DataplaneKey},
 	)
 
+	controllerMetrics.ConfigPushPayloadSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigPushPayloadSize,
+			Help: fmt.Sprintf("The size of the most recent DB-less configuration payload sent to Kong, in bytes. "+
+				"`%s` describes the dataplane that was the target of the configuration push. "+
+				"`%s` describes whether the size is of the serialized configuration (`%s`) or of the payload "+
+				"compressed with gzip (`%s`), which is only recorded when compression is enabled.",
+				DataplaneKey, EncodingKey, EncodingIdentity, EncodingGzip,
+			),
+		},
+		[]string{DataplaneKey, EncodingKey},
+	)
+
+	controllerMetrics.ConfigSerializationTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigSerializationTime,
+			Help: "How long it took to serialize DB-less configuration before sending it to Kong, in milliseconds.",
+		},
+	)
+
+	controllerMetrics.ConfigDriftCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigDriftCount,
+			Help: fmt.Sprintf(
+				"Count of detected drifts of configuration applied to Kong from the configuration pushed to it. "+
+					"`%s` describes the dataplane whose configuration drifted.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
 	controllerMetrics.FallbackTranslationCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: MetricNameFallbackTranslationCount,
@@ -295,6 +374,50 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		},
 	)
 
+	controllerMetrics.ConfigDiffEntityCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigDiffEntityCount,
+			Help: fmt.Sprintf("The number of Kong entities changed by the most recent configuration compared to "+
+				"the last configuration successfully pushed to Kong. `%s` describes the type of Kong entity. "+
+				"`%s` describes the type of change (`create`, `update` or `delete`). "+
+				"`%s` describes whether Kong accepted the configuration (`%s`) or not (`%s`).",
+				EntityTypeKey, ChangeTypeKey,
+				SuccessKey, SuccessTrue, SuccessFalse,
+			),
+		},
+		[]string{EntityTypeKey, ChangeTypeKey, SuccessKey},
+	)
+
+	controllerMetrics.DryRunValidationCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameDryRunValidationCount,
+			Help: fmt.Sprintf(
+				"Count of validations of configuration in dry-run mode. "+
+					"`%s` describes whether the configuration passed validation (`%s`) or not (`%s`). "+
+					"`%s` is populated in case of `%s=\"%s\"` and describes the reason of failure "+
+					"(one of `%s`, `%s`, `%s`).",
+				SuccessKey, SuccessTrue, SuccessFalse,
+				FailureReasonKey, SuccessKey, SuccessFalse,
+				FailureReasonConflict, FailureReasonNetwork, FailureReasonOther,
+			),
+		},
+		[]string{SuccessKey, FailureReasonKey},
+	)
+
+	controllerMetrics.DryRunValidationBrokenResources = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: MetricNameDryRunValidationBrokenResources,
+			Help: "The number of resources that failed the most recent validation of configuration in dry-run mode.",
+		},
+	)
+
+	controllerMetrics.DryRunValidationSuccessTime = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: MetricNameDryRunValidationSuccessTime,
+			Help: "The time of the last configuration that passed validation in dry-run mode.",
+		},
+	)
+
 	allMetrics := []prometheus.Collector{
 		controllerMetrics.ConfigPushCount,
 		controllerMetrics.ConfigPushBrokenResources,
@@ -302,6 +425,9 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		controllerMetrics.TranslationBrokenResources,
 		controllerMetrics.ConfigPushDuration,
 		controllerMetrics.ConfigPushSuccessTime,
+		controllerMetrics.ConfigPushPayloadSize,
+		controllerMetrics.ConfigSerializationTime,
+		controllerMetrics.ConfigDriftCount,
 		controllerMetrics.FallbackTranslationBrokenResources,
 		controllerMetrics.FallbackTranslationCount,
 		controllerMetrics.FallbackConfigPushCount,
@@ -311,6 +437,10 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		controllerMetrics.FallbackCacheGeneratingDuration,
 		controllerMetrics.ProcessedConfigSnapshotCacheHit,
 		controllerMetrics.ProcessedConfigSnapshotCacheMiss,
+		controllerMetrics.ConfigDiffEntityCount,
+		controllerMetrics.DryRunValidationCount,
+		controllerMetrics.DryRunValidationBrokenResources,
+		controllerMetrics.DryRunValidationSuccessTime,
 	}
 	for _, m := range allMetrics {
 		metrics.Registry.Unregister(m)
@@ -337,6 +467,27 @@ func (c *CtrlFuncMetrics) RecordPushFailure(p Protocol, d time.Duration, datapla
 	c.recordPushBrokenResources(count, dpOpt)
 }
 
+// RecordPushPayload records the size of a DB-less configuration payload and the time it took to serialize it.
+// compressedSize is recorded only when it's positive.
+func (c *CtrlFuncMetrics) RecordPushPayload(dataplane string, size, compressedSize int, serializationTime time.Duration) {
+	c.ConfigPushPayloadSize.With(prometheus.Labels{
+		DataplaneKey: dataplane,
+		EncodingKey:  EncodingIdentity,
+	}).Set(float64(size))
+	if compressedSize > 0 {
+		c.ConfigPushPayloadSize.With(prometheus.Labels{
+			DataplaneKey: dataplane,
+			EncodingKey:  EncodingGzip,
+		}).Set(float64(compressedSize))
+	}
+	c.ConfigSerializationTime.Observe(float64(serializationTime.Milliseconds()))
+}
+
+// RecordConfigDrift records a drift of configuration applied to a dataplane.
+func (c *CtrlFuncMetrics) RecordConfigDrift(dataplane string) {
+	c.ConfigDriftCount.With(prometheus.Labels{DataplaneKey: dataplane}).Inc()
+}
+
 // RecordTranslationSuccess records a successful configuration translation.
 func (c *CtrlFuncMetrics) RecordTranslationSuccess() {
 	c.TranslationCount.With(prometheus.Labels{
@@ -380,6 +531,45 @@ func (c *CtrlFuncMetrics) RecordProcessedConfigSnapshotCacheMiss() {
 	c.ProcessedConfigSnapshotCacheMiss.Inc()
 }
 
+// RecordConfigDiffEntityCount records the number of Kong entities of the given type changed by the most recent
+// configuration with the given type of change. The count recorded for the opposite result of the push is dropped,
+// so that only the most recent configuration is reported.
+func (c *CtrlFuncMetrics) RecordConfigDiffEntityCount(entityType, changeType string, success bool, count int) {
+	successLabel, otherSuccessLabel := SuccessTrue, SuccessFalse
+	if !success {
+		successLabel, otherSuccessLabel = SuccessFalse, SuccessTrue
+	}
+	c.ConfigDiffEntityCount.Delete(prometheus.Labels{
+		EntityTypeKey: entityType,
+		ChangeTypeKey: changeType,
+		SuccessKey:    otherSuccessLabel,
+	})
+	c.ConfigDiffEntityCount.With(prometheus.Labels{
+		EntityTypeKey: entityType,
+		ChangeTypeKey: changeType,
+		SuccessKey:    successLabel,
+	}).Set(float64(count))
+}
+
+// RecordDryRunSuccess records a configuration that passed validation in dry-run mode.
+func (c *CtrlFuncMetrics) RecordDryRunSuccess() {
+	c.DryRunValidationCount.With(prometheus.Labels{
+		SuccessKey:       SuccessTrue,
+		FailureReasonKey: "",
+	}).Inc()
+	c.DryRunValidationBrokenResources.Set(0)
+	c.DryRunValidationSuccessTime.SetToCurrentTime()
+}
+
+// RecordDryRunFailure records a configuration that failed validation in dry-run mode.
+func (c *CtrlFuncMetrics) RecordDryRunFailure(brokenResourcesCount int, err error) {
+	c.DryRunValidationCount.With(prometheus.Labels{
+		SuccessKey:       SuccessFalse,
+		FailureReasonKey: pushFailureReason(err),
+	}).Inc()
+	c.DryRunValidationBrokenResources.Set(float64(brokenResourcesCount))
+}
+
 // RecordFallbackTranslationBrokenResources records the number of fallback resources failing translation.
 func (c *CtrlFuncMetrics) RecordFallbackTranslationBrokenResources(count int) {
 	c.FallbackTranslationBrokenResources.Set(float64(count))
@@ -10,6 +10,7 @@ import (
 
 	deckutils "github.com/kong/go-database-reconciler/pkg/utils"
 	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/internal/dataplane/deckerrors"
@@ -38,6 +39,27 @@ func TestRecordPush(t *testing.T) {
 	})
 }
 
+func TestRecordPushPayload(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	t.Run("recording uncompressed payload works", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			m.RecordPushPayload("https://10.0.0.1:8080", 1024, 0, time.Millisecond)
+		})
+	})
+	t.Run("recording compressed payload works", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			m.RecordPushPayload("https://10.0.0.1:8080", 1024, 128, time.Millisecond)
+		})
+	})
+}
+
+func TestRecordConfigDrift(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordConfigDrift("https://10.0.0.1:8080")
+	})
+}
+
 func TestRecordTranslation(t *testing.T) {
 	m := NewCtrlFuncMetrics()
 	t.Run("recording translation success works", func(t *testing.T) {
@@ -54,6 +76,18 @@ func TestRecordTranslation(t *testing.T) {
 	})
 }
 
+func TestRecordConfigDiffEntityCount(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordConfigDiffEntityCount("service", "create", true, 3)
+		m.RecordConfigDiffEntityCount("service", "create", true, 0)
+	})
+
+	m.RecordConfigDiffEntityCount("service", "create", false, 2)
+	require.Equal(t, 1, testutil.CollectAndCount(m.ConfigDiffEntityCount), "count of the successful push should be dropped")
+	require.Equal(t, float64(2), testutil.ToFloat64(m.ConfigDiffEntityCount.WithLabelValues("service", "create", SuccessFalse)))
+}
+
 func TestPushFailureReason(t *testing.T) {
 	apiConflictErr := kong.NewAPIError(http.StatusConflict, "conflict api error")
 	networkErr := net.UnknownNetworkError("network error")
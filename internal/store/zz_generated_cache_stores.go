@@ -27,6 +27,7 @@ type CacheStores struct {
 	IngressClassV1                 cache.Store
 	Service                        cache.Store
 	Secret                         cache.Store
+	ConfigMap                      cache.Store
 	EndpointSlice                  cache.Store
 	HTTPRoute                      cache.Store
 	UDPRoute                       cache.Store
@@ -35,6 +36,8 @@ type CacheStores struct {
 	GRPCRoute                      cache.Store
 	ReferenceGrant                 cache.Store
 	Gateway                        cache.Store
+	BackendTLSPolicy               cache.Store
+	BackendLBPolicy                cache.Store
 	Plugin                         cache.Store
 	ClusterPlugin                  cache.Store
 	Consumer                       cache.Store
@@ -58,6 +61,7 @@ func NewCacheStores() CacheStores {
 		IngressClassV1:                 cache.NewStore(clusterWideKeyFunc),
 		Service:                        cache.NewStore(namespacedKeyFunc),
 		Secret:                         cache.NewStore(namespacedKeyFunc),
+		ConfigMap:                      cache.NewStore(namespacedKeyFunc),
 		EndpointSlice:                  cache.NewStore(namespacedKeyFunc),
 		HTTPRoute:                      cache.NewStore(namespacedKeyFunc),
 		UDPRoute:                       cache.NewStore(namespacedKeyFunc),
@@ -66,6 +70,8 @@ func NewCacheStores() CacheStores {
 		GRPCRoute:                      cache.NewStore(namespacedKeyFunc),
 		ReferenceGrant:                 cache.NewStore(namespacedKeyFunc),
 		Gateway:                        cache.NewStore(namespacedKeyFunc),
+		BackendTLSPolicy:               cache.NewStore(namespacedKeyFunc),
+		BackendLBPolicy:                cache.NewStore(namespacedKeyFunc),
 		Plugin:                         cache.NewStore(namespacedKeyFunc),
 		ClusterPlugin:                  cache.NewStore(clusterWideKeyFunc),
 		Consumer:                       cache.NewStore(namespacedKeyFunc),
@@ -97,6 +103,8 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 		return c.Service.Get(obj)
 	case *corev1.Secret:
 		return c.Secret.Get(obj)
+	case *corev1.ConfigMap:
+		return c.ConfigMap.Get(obj)
 	case *discoveryv1.EndpointSlice:
 		return c.EndpointSlice.Get(obj)
 	case *gatewayapi.HTTPRoute:
@@ -113,6 +121,10 @@ func (c CacheStores) Get(obj runtime.Object) (item interface{}, exists bool, err
 		return c.ReferenceGrant.Get(obj)
 	case *gatewayapi.Gateway:
 		return c.Gateway.Get(obj)
+	case *gatewayapi.BackendTLSPolicy:
+		return c.BackendTLSPolicy.Get(obj)
+	case *gatewayapi.BackendLBPolicy:
+		return c.BackendLBPolicy.Get(obj)
 	case *kongv1.KongPlugin:
 		return c.Plugin.Get(obj)
 	case *kongv1.KongClusterPlugin:
@@ -156,6 +168,8 @@ func (c CacheStores) Add(obj runtime.Object) error {
 		return c.Service.Add(obj)
 	case *corev1.Secret:
 		return c.Secret.Add(obj)
+	case *corev1.ConfigMap:
+		return c.ConfigMap.Add(obj)
 	case *discoveryv1.EndpointSlice:
 		return c.EndpointSlice.Add(obj)
 	case *gatewayapi.HTTPRoute:
@@ -172,6 +186,10 @@ func (c CacheStores) Add(obj runtime.Object) error {
 		return c.ReferenceGrant.Add(obj)
 	case *gatewayapi.Gateway:
 		return c.Gateway.Add(obj)
+	case *gatewayapi.BackendTLSPolicy:
+		return c.BackendTLSPolicy.Add(obj)
+	case *gatewayapi.BackendLBPolicy:
+		return c.BackendLBPolicy.Add(obj)
 	case *kongv1.KongPlugin:
 		return c.Plugin.Add(obj)
 	case *kongv1.KongClusterPlugin:
@@ -215,6 +233,8 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 		return c.Service.Delete(obj)
 	case *corev1.Secret:
 		return c.Secret.Delete(obj)
+	case *corev1.ConfigMap:
+		return c.ConfigMap.Delete(obj)
 	case *discoveryv1.EndpointSlice:
 		return c.EndpointSlice.Delete(obj)
 	case *gatewayapi.HTTPRoute:
@@ -231,6 +251,10 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 		return c.ReferenceGrant.Delete(obj)
 	case *gatewayapi.Gateway:
 		return c.Gateway.Delete(obj)
+	case *gatewayapi.BackendTLSPolicy:
+		return c.BackendTLSPolicy.Delete(obj)
+	case *gatewayapi.BackendLBPolicy:
+		return c.BackendLBPolicy.Delete(obj)
 	case *kongv1.KongPlugin:
 		return c.Plugin.Delete(obj)
 	case *kongv1.KongClusterPlugin:
@@ -266,6 +290,7 @@ func (c CacheStores) ListAllStores() []cache.Store {
 		c.IngressClassV1,
 		c.Service,
 		c.Secret,
+		c.ConfigMap,
 		c.EndpointSlice,
 		c.HTTPRoute,
 		c.UDPRoute,
@@ -274,6 +299,8 @@ func (c CacheStores) ListAllStores() []cache.Store {
 		c.GRPCRoute,
 		c.ReferenceGrant,
 		c.Gateway,
+		c.BackendTLSPolicy,
+		c.BackendLBPolicy,
 		c.Plugin,
 		c.ClusterPlugin,
 		c.Consumer,
@@ -296,6 +323,7 @@ func (c CacheStores) SupportedTypes() []client.Object {
 		&netv1.IngressClass{},
 		&corev1.Service{},
 		&corev1.Secret{},
+		&corev1.ConfigMap{},
 		&discoveryv1.EndpointSlice{},
 		&gatewayapi.HTTPRoute{},
 		&gatewayapi.UDPRoute{},
@@ -304,6 +332,8 @@ func (c CacheStores) SupportedTypes() []client.Object {
 		&gatewayapi.GRPCRoute{},
 		&gatewayapi.ReferenceGrant{},
 		&gatewayapi.Gateway{},
+		&gatewayapi.BackendTLSPolicy{},
+		&gatewayapi.BackendLBPolicy{},
 		&kongv1.KongPlugin{},
 		&kongv1.KongClusterPlugin{},
 		&kongv1.KongConsumer{},
@@ -45,6 +45,11 @@ func TestCacheStores(t *testing.T) {
 			objectToStore: &corev1.Secret{},
 		},
 
+		{
+			name:          "ConfigMap",
+			objectToStore: &corev1.ConfigMap{},
+		},
+
 		{
 			name:          "EndpointSlice",
 			objectToStore: &discoveryv1.EndpointSlice{},
@@ -85,6 +90,16 @@ func TestCacheStores(t *testing.T) {
 			objectToStore: &gatewayapi.Gateway{},
 		},
 
+		{
+			name:          "BackendTLSPolicy",
+			objectToStore: &gatewayapi.BackendTLSPolicy{},
+		},
+
+		{
+			name:          "BackendLBPolicy",
+			objectToStore: &gatewayapi.BackendLBPolicy{},
+		},
+
 		{
 			name:          "KongPlugin",
 			objectToStore: &kongv1.KongPlugin{},
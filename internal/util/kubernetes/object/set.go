@@ -12,6 +12,7 @@ type (
 type objectConfigurationStatus struct {
 	generation int64
 	succeeded  bool
+	warnings   []string
 }
 
 type ConfigurationStatus string
@@ -53,23 +54,29 @@ func (s *ConfigurationStatusSet) Insert(obj client.Object, succeeded bool) {
 	}
 }
 
-func (s *ConfigurationStatusSet) Get(obj client.Object) ConfigurationStatus {
-	if s.store == nil {
-		return ConfigurationStatusUnknown
-	}
-
-	objGVK := gvk(obj.GetObjectKind().GroupVersionKind().String())
-	nsName := k8stypes.NamespacedName{
-		Namespace: obj.GetNamespace(),
-		Name:      obj.GetName(),
+// AddWarning attaches a warning to an object already present in the set. Warnings describe
+// differences between the requested and the actual configuration of successfully configured objects.
+func (s *ConfigurationStatusSet) AddWarning(obj client.Object, warning string) {
+	status, ok := s.lookup(obj)
+	if !ok {
+		return
 	}
+	status.warnings = append(status.warnings, warning)
+	s.store[gvk(obj.GetObjectKind().GroupVersionKind().String())][objectNamespacedName(obj)] = status
+}
 
-	gvkMap, ok := s.store[objGVK]
-	if !ok {
-		return ConfigurationStatusUnknown
+// GetWarnings returns warnings attached to an object which has been successfully configured
+// in its current generation.
+func (s *ConfigurationStatusSet) GetWarnings(obj client.Object) []string {
+	if s.Get(obj) != ConfigurationStatusSucceeded {
+		return nil
 	}
+	status, _ := s.lookup(obj)
+	return status.warnings
+}
 
-	status, ok := gvkMap[nsName]
+func (s *ConfigurationStatusSet) Get(obj client.Object) ConfigurationStatus {
+	status, ok := s.lookup(obj)
 	if !ok {
 		return ConfigurationStatusUnknown
 	}
@@ -86,3 +93,24 @@ func (s *ConfigurationStatusSet) Get(obj client.Object) ConfigurationStatus {
 
 	return ConfigurationStatusSucceeded
 }
+
+func (s *ConfigurationStatusSet) lookup(obj client.Object) (objectConfigurationStatus, bool) {
+	if s.store == nil {
+		return objectConfigurationStatus{}, false
+	}
+
+	gvkMap, ok := s.store[gvk(obj.GetObjectKind().GroupVersionKind().String())]
+	if !ok {
+		return objectConfigurationStatus{}, false
+	}
+
+	status, ok := gvkMap[objectNamespacedName(obj)]
+	return status, ok
+}
+
+func objectNamespacedName(obj client.Object) k8stypes.NamespacedName {
+	return k8stypes.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
@@ -75,12 +75,23 @@ func TestObjectConfigurationStatusSet(t *testing.T) {
 	require.Equal(t, ConfigurationStatusFailed, set.Get(ing2))
 	require.Equal(t, ConfigurationStatusSucceeded, set.Get(ing3))
 	require.Equal(t, ConfigurationStatusSucceeded, set.Get(tcp))
+
+	t.Log("verifying warnings are attached only to objects present in the set")
+	set.AddWarning(ing3, "some warning")
+	set.AddWarning(ing2, "ignored warning")
+	require.Equal(t, []string{"some warning"}, set.GetWarnings(ing3))
+	require.Empty(t, set.GetWarnings(ing1))
+	require.Empty(t, set.GetWarnings(ing2), "failed objects should not report warnings")
+	require.Equal(t, ConfigurationStatusSucceeded, set.Get(ing3))
+
 	t.Log("updating generation of some objects")
 	ing1.Generation = 2
 	require.Equal(t, ConfigurationStatusUnknown, set.Get(ing1))
 	require.Equal(t, ConfigurationStatusFailed, set.Get(ing2))
 	require.Equal(t, ConfigurationStatusSucceeded, set.Get(ing3))
 	require.Equal(t, ConfigurationStatusSucceeded, set.Get(tcp))
+	ing3.Generation = 2
+	require.Empty(t, set.GetWarnings(ing3), "warnings of outdated generations should not be reported")
 }
 
 // -----------------------------------------------------------------------------
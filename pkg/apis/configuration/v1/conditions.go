@@ -51,4 +51,36 @@ const (
 
 	// ReasonPending is used with the ConditionProgrammed when the status is "Unknown".
 	ReasonPending ConditionReason = "Pending"
+
+	// ConditionCredentialRotation indicates whether credentials of the object are being rotated to
+	// successors declared by their Secrets with the konghq.com/credential-successor annotation.
+	//
+	// Resources that support this condition are:
+	//
+	// * KongConsumer
+	//
+	// It's only present on objects referencing credential Secrets that declare a successor.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "RotationInProgress"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "RotationCompleted"
+	// * "RotationInvalid"
+	//
+	ConditionCredentialRotation ConditionType = "CredentialRotation"
+
+	// ReasonRotationInProgress is used with the ConditionCredentialRotation condition when both
+	// the rotated credentials and their successors are attached until the grace period expires.
+	ReasonRotationInProgress ConditionReason = "RotationInProgress"
+
+	// ReasonRotationCompleted is used with the ConditionCredentialRotation condition when the grace
+	// period of all rotations has expired and only the successors are attached.
+	ReasonRotationCompleted ConditionReason = "RotationCompleted"
+
+	// ReasonRotationInvalid is used with the ConditionCredentialRotation condition when a rotation
+	// is declared with invalid annotations or the successor Secret doesn't exist.
+	ReasonRotationInvalid ConditionReason = "RotationInvalid"
 )
@@ -73,12 +73,34 @@ type KongConsumerStatus struct {
 	// Known condition types are:
 	//
 	// * "Programmed"
+	// * "CredentialRotation"
 	//
 	// +listType=map
 	// +listMapKey=type
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:default={{type: "Programmed", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CredentialRotations record progress of rotations of credential Secrets of the KongConsumer
+	// to successors declared with the konghq.com/credential-successor annotation.
+	//
+	// +listType=map
+	// +listMapKey=secret
+	// +optional
+	CredentialRotations []CredentialRotationStatus `json:"credentialRotations,omitempty"`
+}
+
+// CredentialRotationStatus records progress of a rotation of a credential Secret to its successor.
+type CredentialRotationStatus struct {
+	// Secret is the name of the rotated credential Secret.
+	Secret string `json:"secret"`
+
+	// Successor is the name of the Secret the credential is rotated to.
+	Successor string `json:"successor"`
+
+	// StartedAt is when the controller first observed the rotation with an existing successor.
+	// Both credentials are attached until the grace period elapses from this time.
+	StartedAt metav1.Time `json:"startedAt"`
 }
 
 func init() {
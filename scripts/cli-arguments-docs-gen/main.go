@@ -75,7 +75,7 @@ func getTypeForHuman(flag *pflag.Flag) string {
 	case "mapStringBool":
 		return "list of string=bool"
 	// The below are types that are human readable out-of-the-box, in case of missing one extend the list.
-	case "bool", "string", "int", "uint", "duration", "dns-strategy", "namespaced-name":
+	case "bool", "string", "int", "uint", "duration", "dns-strategy", "namespaced-name", "int-or-percent":
 		return typ
 	default:
 		panic(fmt.Sprintf("unknown type %q", typ))
@@ -22,8 +22,6 @@ import (
 )
 
 var skippedTestsForTraditionalRoutes = []string{
-	// core conformance
-	tests.HTTPRouteHeaderMatching.ShortName,
 	// NOTE: Skipping tests.GRPCRouteHeaderMatching.ShortName and
 	// tests.GRPCExactMethodMatching.ShortName since they are flaky on CI.
 	// TODO: https://github.com/Kong/kubernetes-ingress-controller/issues/6144
@@ -38,6 +36,19 @@ var skippedTestsForExpressionRoutes = []string{
 	tests.GRPCRouteListenerHostnameMatching.ShortName,
 }
 
+// NOTE: HTTPRoute request timeouts are not claimed with any router flavor. Kong services only have connect, read
+// and write timeouts, so timeouts.request is approximated with them and by limiting retries, which doesn't enforce
+// a deadline for the whole request.
+//
+// NOTE: HTTPRoute request mirroring is not claimed with any router flavor. RequestMirror filters are only translated
+// with the off-by-default HTTPRouteRequestMirror feature gate enabled and Kong Gateway configured with
+// untrusted_lua set to on, which the default configuration doesn't do.
+//
+// NOTE: HTTPRoute query parameter and method matching are not claimed with the traditional router:
+//   - Query parameter matches can't be expressed with traditional routes and HTTPRoutes using them are rejected.
+//   - Method matches are translated, but the traditional router prefers routes matching on more fields or more
+//     headers over Gateway API precedence, which is only enforced (by rejecting conflicting matches) within a single
+//     HTTPRoute, not across HTTPRoutes attached to the same listener.
 var traditionalRoutesSupportedFeatures = []features.SupportedFeature{
 	// core features
 	features.SupportGateway,
@@ -47,11 +58,18 @@ var traditionalRoutesSupportedFeatures = []features.SupportedFeature{
 	features.SupportHTTPRouteResponseHeaderModification,
 	features.SupportHTTPRoutePathRewrite,
 	features.SupportHTTPRouteHostRewrite,
-	// TODO: https://github.com/Kong/kubernetes-ingress-controller/issues/5868
-	// Temporarily disabled and tracking through the following issue.
-	// suite.SupportHTTPRouteBackendTimeout,
+	features.SupportHTTPRouteBackendTimeout,
 }
 
+// NOTE: TLSRoute, TCPRoute and UDPRoute are translated to Kong stream routes (also with the expressions router),
+// but their features and the GATEWAY-TLS profile are not claimed:
+//   - GATEWAY-TLS conformance tests require a TLS passthrough listener on port 443 of the Gateway, while the Kong
+//     proxy deployed for conformance tests already terminates HTTPS there. Kong can't serve both on the same port.
+//   - The UDPRoute conformance test sends DNS queries to UDP port 5300 of the Gateway, which the Kong proxy deployed
+//     for conformance tests neither listens on nor exposes through its LoadBalancer Service.
+//   - The Gateway API version in use defines no TCPRoute feature nor conformance tests for it.
+//
+// L4 routes are covered by integration tests instead.
 var expressionRoutesSupportedFeatures = []features.SupportedFeature{
 	// core features
 	features.SupportGateway,
@@ -63,9 +81,7 @@ var expressionRoutesSupportedFeatures = []features.SupportedFeature{
 	features.SupportHTTPRouteResponseHeaderModification,
 	features.SupportHTTPRoutePathRewrite,
 	features.SupportHTTPRouteHostRewrite,
-	// TODO: https://github.com/Kong/kubernetes-ingress-controller/issues/5868
-	// Temporarily disabled and tracking through the following issue.
-	// features.SupportHTTPRouteBackendTimeout,
+	features.SupportHTTPRouteBackendTimeout,
 }
 
 func TestGatewayConformance(t *testing.T) {
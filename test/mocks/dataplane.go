@@ -16,6 +16,8 @@ type Dataplane struct {
 	// https://github.com/Kong/kubernetes-ingress-controller/issues/3793
 	// which requires the status to be reported for route objects.
 	ObjectsStatuses map[string]map[string]k8sobj.ConfigurationStatus
+	// Mapping namespace to name to configuration warnings.
+	ObjectsWarnings map[string]map[string][]string
 }
 
 func (d Dataplane) UpdateObject(_ client.Object) error {
@@ -43,6 +45,10 @@ func (d Dataplane) KubernetesObjectConfigurationStatus(obj client.Object) k8sobj
 	return d.ObjectsStatuses[obj.GetNamespace()][obj.GetName()]
 }
 
+func (d Dataplane) KubernetesObjectConfigurationWarnings(obj client.Object) []string {
+	return d.ObjectsWarnings[obj.GetNamespace()][obj.GetName()]
+}
+
 func (d Dataplane) KubernetesObjectIsConfigured(obj client.Object) bool {
 	return d.ObjectsStatuses[obj.GetNamespace()][obj.GetName()] == k8sobj.ConfigurationStatusSucceeded
 }